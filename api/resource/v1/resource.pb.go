@@ -0,0 +1,4935 @@
+// The gRPC API of the resource server. It mirrors the REST API under /api/v1
+// and is served by the same process on the port set by server.grpc_port.
+//
+// Regenerate the Go code with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v29.3.0
+// source: api/resource/v1/resource.proto
+
+package resourcev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDefinitionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDefinitionsRequest) Reset() {
+	*x = ListDefinitionsRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDefinitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDefinitionsRequest) ProtoMessage() {}
+
+func (x *ListDefinitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDefinitionsRequest.ProtoReflect.Descriptor instead.
+func (*ListDefinitionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{0}
+}
+
+type GetDefinitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDefinitionRequest) Reset() {
+	*x = GetDefinitionRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDefinitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDefinitionRequest) ProtoMessage() {}
+
+func (x *GetDefinitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDefinitionRequest.ProtoReflect.Descriptor instead.
+func (*GetDefinitionRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDefinitionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PathDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Parent        string                 `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Deprecated    bool                   `protobuf:"varint,6,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	AllowedScopes []string               `protobuf:"bytes,7,rep,name=allowed_scopes,json=allowedScopes,proto3" json:"allowed_scopes,omitempty"`
+	Parameters    []*PathParameter       `protobuf:"bytes,8,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Providers     []string               `protobuf:"bytes,9,rep,name=providers,proto3" json:"providers,omitempty"`
+	// Patterns are keyed by provider
+	Patterns               map[string]*PathPatterns `protobuf:"bytes,10,rep,name=patterns,proto3" json:"patterns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DefaultStorageMetadata *StorageDefaults         `protobuf:"bytes,11,opt,name=default_storage_metadata,json=defaultStorageMetadata,proto3" json:"default_storage_metadata,omitempty"`
+	Children               []*PathDefinition        `protobuf:"bytes,12,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *PathDefinition) Reset() {
+	*x = PathDefinition{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathDefinition) ProtoMessage() {}
+
+func (x *PathDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathDefinition.ProtoReflect.Descriptor instead.
+func (*PathDefinition) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PathDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PathDefinition) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *PathDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PathDefinition) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *PathDefinition) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PathDefinition) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *PathDefinition) GetAllowedScopes() []string {
+	if x != nil {
+		return x.AllowedScopes
+	}
+	return nil
+}
+
+func (x *PathDefinition) GetParameters() []*PathParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *PathDefinition) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *PathDefinition) GetPatterns() map[string]*PathPatterns {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *PathDefinition) GetDefaultStorageMetadata() *StorageDefaults {
+	if x != nil {
+		return x.DefaultStorageMetadata
+	}
+	return nil
+}
+
+func (x *PathDefinition) GetChildren() []*PathDefinition {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type PathParameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Required      bool                   `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	Rules         []string               `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	Enum          []string               `protobuf:"bytes,4,rep,name=enum,proto3" json:"enum,omitempty"`
+	Pattern       string                 `protobuf:"bytes,5,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	MinLength     *int32                 `protobuf:"varint,6,opt,name=min_length,json=minLength,proto3,oneof" json:"min_length,omitempty"`
+	MaxLength     *int32                 `protobuf:"varint,7,opt,name=max_length,json=maxLength,proto3,oneof" json:"max_length,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	DefaultValue  string                 `protobuf:"bytes,9,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathParameter) Reset() {
+	*x = PathParameter{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathParameter) ProtoMessage() {}
+
+func (x *PathParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathParameter.ProtoReflect.Descriptor instead.
+func (*PathParameter) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PathParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PathParameter) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *PathParameter) GetRules() []string {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *PathParameter) GetEnum() []string {
+	if x != nil {
+		return x.Enum
+	}
+	return nil
+}
+
+func (x *PathParameter) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *PathParameter) GetMinLength() int32 {
+	if x != nil && x.MinLength != nil {
+		return *x.MinLength
+	}
+	return 0
+}
+
+func (x *PathParameter) GetMaxLength() int32 {
+	if x != nil && x.MaxLength != nil {
+		return *x.MaxLength
+	}
+	return 0
+}
+
+func (x *PathParameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PathParameter) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+type PathPatterns struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UrlType string                 `protobuf:"bytes,1,opt,name=url_type,json=urlType,proto3" json:"url_type,omitempty"`
+	// Patterns are keyed by scope
+	Patterns      map[string]string `protobuf:"bytes,2,rep,name=patterns,proto3" json:"patterns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathPatterns) Reset() {
+	*x = PathPatterns{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathPatterns) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathPatterns) ProtoMessage() {}
+
+func (x *PathPatterns) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathPatterns.ProtoReflect.Descriptor instead.
+func (*PathPatterns) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PathPatterns) GetUrlType() string {
+	if x != nil {
+		return x.UrlType
+	}
+	return ""
+}
+
+func (x *PathPatterns) GetPatterns() map[string]string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+type StorageDefaults struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CacheControl      *CacheControlDefaults  `protobuf:"bytes,1,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	RequiredChecksums []string               `protobuf:"bytes,2,rep,name=required_checksums,json=requiredChecksums,proto3" json:"required_checksums,omitempty"`
+	CustomHeaders     map[string]string      `protobuf:"bytes,3,rep,name=custom_headers,json=customHeaders,proto3" json:"custom_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StorageDefaults) Reset() {
+	*x = StorageDefaults{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageDefaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageDefaults) ProtoMessage() {}
+
+func (x *StorageDefaults) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageDefaults.ProtoReflect.Descriptor instead.
+func (*StorageDefaults) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StorageDefaults) GetCacheControl() *CacheControlDefaults {
+	if x != nil {
+		return x.CacheControl
+	}
+	return nil
+}
+
+func (x *StorageDefaults) GetRequiredChecksums() []string {
+	if x != nil {
+		return x.RequiredChecksums
+	}
+	return nil
+}
+
+func (x *StorageDefaults) GetCustomHeaders() map[string]string {
+	if x != nil {
+		return x.CustomHeaders
+	}
+	return nil
+}
+
+type CacheControlDefaults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxAge        int64                  `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	AllowPublic   bool                   `protobuf:"varint,2,opt,name=allow_public,json=allowPublic,proto3" json:"allow_public,omitempty"`
+	Default       string                 `protobuf:"bytes,3,opt,name=default,proto3" json:"default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheControlDefaults) Reset() {
+	*x = CacheControlDefaults{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheControlDefaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheControlDefaults) ProtoMessage() {}
+
+func (x *CacheControlDefaults) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheControlDefaults.ProtoReflect.Descriptor instead.
+func (*CacheControlDefaults) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CacheControlDefaults) GetMaxAge() int64 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *CacheControlDefaults) GetAllowPublic() bool {
+	if x != nil {
+		return x.AllowPublic
+	}
+	return false
+}
+
+func (x *CacheControlDefaults) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+type ResolveDefinitionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Parameters map[string]string      `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Scope is G, A or CA; global when empty
+	Scope         string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	ScopeValue    int32  `protobuf:"varint,4,opt,name=scope_value,json=scopeValue,proto3" json:"scope_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDefinitionRequest) Reset() {
+	*x = ResolveDefinitionRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDefinitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDefinitionRequest) ProtoMessage() {}
+
+func (x *ResolveDefinitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDefinitionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDefinitionRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResolveDefinitionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolveDefinitionRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ResolveDefinitionRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ResolveDefinitionRequest) GetScopeValue() int32 {
+	if x != nil {
+		return x.ScopeValue
+	}
+	return 0
+}
+
+type ResolveDefinitionResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Definition    string                  `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	Scope         string                  `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	ScopeValue    int32                   `protobuf:"varint,3,opt,name=scope_value,json=scopeValue,proto3" json:"scope_value,omitempty"`
+	Providers     []*ResolvedProviderPath `protobuf:"bytes,4,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDefinitionResponse) Reset() {
+	*x = ResolveDefinitionResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDefinitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDefinitionResponse) ProtoMessage() {}
+
+func (x *ResolveDefinitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDefinitionResponse.ProtoReflect.Descriptor instead.
+func (*ResolveDefinitionResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResolveDefinitionResponse) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *ResolveDefinitionResponse) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ResolveDefinitionResponse) GetScopeValue() int32 {
+	if x != nil {
+		return x.ScopeValue
+	}
+	return 0
+}
+
+func (x *ResolveDefinitionResponse) GetProviders() []*ResolvedProviderPath {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type ResolvedProviderPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	UrlType       string                 `protobuf:"bytes,3,opt,name=url_type,json=urlType,proto3" json:"url_type,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Errors        map[string]string      `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Valid         bool                   `protobuf:"varint,6,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedProviderPath) Reset() {
+	*x = ResolvedProviderPath{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedProviderPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedProviderPath) ProtoMessage() {}
+
+func (x *ResolvedProviderPath) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedProviderPath.ProtoReflect.Descriptor instead.
+func (*ResolvedProviderPath) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResolvedProviderPath) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ResolvedProviderPath) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ResolvedProviderPath) GetUrlType() string {
+	if x != nil {
+		return x.UrlType
+	}
+	return ""
+}
+
+func (x *ResolvedProviderPath) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ResolvedProviderPath) GetErrors() map[string]string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ResolvedProviderPath) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type ListProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersRequest) Reset() {
+	*x = ListProvidersRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersRequest) ProtoMessage() {}
+
+func (x *ListProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{10}
+}
+
+type GetProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderRequest) Reset() {
+	*x = GetProviderRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderRequest) ProtoMessage() {}
+
+func (x *GetProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProviderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Provider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capabilities  *ProviderCapabilities  `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Provider) Reset() {
+	*x = Provider{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provider) ProtoMessage() {}
+
+func (x *Provider) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provider.ProtoReflect.Descriptor instead.
+func (*Provider) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Provider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Provider) GetCapabilities() *ProviderCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type ProviderCapabilities struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	SupportsRead               bool                   `protobuf:"varint,1,opt,name=supports_read,json=supportsRead,proto3" json:"supports_read,omitempty"`
+	SupportsWrite              bool                   `protobuf:"varint,2,opt,name=supports_write,json=supportsWrite,proto3" json:"supports_write,omitempty"`
+	SupportsDelete             bool                   `protobuf:"varint,3,opt,name=supports_delete,json=supportsDelete,proto3" json:"supports_delete,omitempty"`
+	SupportsListing            bool                   `protobuf:"varint,4,opt,name=supports_listing,json=supportsListing,proto3" json:"supports_listing,omitempty"`
+	SupportsMetadata           bool                   `protobuf:"varint,5,opt,name=supports_metadata,json=supportsMetadata,proto3" json:"supports_metadata,omitempty"`
+	SupportsMultipart          bool                   `protobuf:"varint,6,opt,name=supports_multipart,json=supportsMultipart,proto3" json:"supports_multipart,omitempty"`
+	SupportsResumableUploads   bool                   `protobuf:"varint,7,opt,name=supports_resumable_uploads,json=supportsResumableUploads,proto3" json:"supports_resumable_uploads,omitempty"`
+	SupportsSignedUrls         bool                   `protobuf:"varint,8,opt,name=supports_signed_urls,json=supportsSignedUrls,proto3" json:"supports_signed_urls,omitempty"`
+	SupportsChecksumAlgorithms []string               `protobuf:"bytes,9,rep,name=supports_checksum_algorithms,json=supportsChecksumAlgorithms,proto3" json:"supports_checksum_algorithms,omitempty"`
+	MaxUploadSize              int64                  `protobuf:"varint,10,opt,name=max_upload_size,json=maxUploadSize,proto3" json:"max_upload_size,omitempty"`
+	MaxExpiry                  *durationpb.Duration   `protobuf:"bytes,11,opt,name=max_expiry,json=maxExpiry,proto3" json:"max_expiry,omitempty"`
+	MinExpiry                  *durationpb.Duration   `protobuf:"bytes,12,opt,name=min_expiry,json=minExpiry,proto3" json:"min_expiry,omitempty"`
+	Multipart                  *MultipartCapabilities `protobuf:"bytes,13,opt,name=multipart,proto3" json:"multipart,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ProviderCapabilities) Reset() {
+	*x = ProviderCapabilities{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderCapabilities) ProtoMessage() {}
+
+func (x *ProviderCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderCapabilities.ProtoReflect.Descriptor instead.
+func (*ProviderCapabilities) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProviderCapabilities) GetSupportsRead() bool {
+	if x != nil {
+		return x.SupportsRead
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsWrite() bool {
+	if x != nil {
+		return x.SupportsWrite
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsDelete() bool {
+	if x != nil {
+		return x.SupportsDelete
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsListing() bool {
+	if x != nil {
+		return x.SupportsListing
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsMetadata() bool {
+	if x != nil {
+		return x.SupportsMetadata
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsMultipart() bool {
+	if x != nil {
+		return x.SupportsMultipart
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsResumableUploads() bool {
+	if x != nil {
+		return x.SupportsResumableUploads
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsSignedUrls() bool {
+	if x != nil {
+		return x.SupportsSignedUrls
+	}
+	return false
+}
+
+func (x *ProviderCapabilities) GetSupportsChecksumAlgorithms() []string {
+	if x != nil {
+		return x.SupportsChecksumAlgorithms
+	}
+	return nil
+}
+
+func (x *ProviderCapabilities) GetMaxUploadSize() int64 {
+	if x != nil {
+		return x.MaxUploadSize
+	}
+	return 0
+}
+
+func (x *ProviderCapabilities) GetMaxExpiry() *durationpb.Duration {
+	if x != nil {
+		return x.MaxExpiry
+	}
+	return nil
+}
+
+func (x *ProviderCapabilities) GetMinExpiry() *durationpb.Duration {
+	if x != nil {
+		return x.MinExpiry
+	}
+	return nil
+}
+
+func (x *ProviderCapabilities) GetMultipart() *MultipartCapabilities {
+	if x != nil {
+		return x.Multipart
+	}
+	return nil
+}
+
+type MultipartCapabilities struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinPartSize   int64                  `protobuf:"varint,1,opt,name=min_part_size,json=minPartSize,proto3" json:"min_part_size,omitempty"`
+	MaxPartSize   int64                  `protobuf:"varint,2,opt,name=max_part_size,json=maxPartSize,proto3" json:"max_part_size,omitempty"`
+	MaxParts      int32                  `protobuf:"varint,3,opt,name=max_parts,json=maxParts,proto3" json:"max_parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultipartCapabilities) Reset() {
+	*x = MultipartCapabilities{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultipartCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultipartCapabilities) ProtoMessage() {}
+
+func (x *MultipartCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultipartCapabilities.ProtoReflect.Descriptor instead.
+func (*MultipartCapabilities) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MultipartCapabilities) GetMinPartSize() int64 {
+	if x != nil {
+		return x.MinPartSize
+	}
+	return 0
+}
+
+func (x *MultipartCapabilities) GetMaxPartSize() int64 {
+	if x != nil {
+		return x.MaxPartSize
+	}
+	return 0
+}
+
+func (x *MultipartCapabilities) GetMaxParts() int32 {
+	if x != nil {
+		return x.MaxParts
+	}
+	return 0
+}
+
+type ListFilesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Provider          string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Definition        string                 `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	MaxKeys           int32                  `protobuf:"varint,3,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
+	ContinuationToken string                 `protobuf:"bytes,4,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+	Prefix            string                 `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Delimiter         string                 `protobuf:"bytes,6,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Scope             string                 `protobuf:"bytes,7,opt,name=scope,proto3" json:"scope,omitempty"`
+	ScopeValue        int32                  `protobuf:"varint,8,opt,name=scope_value,json=scopeValue,proto3" json:"scope_value,omitempty"`
+	Parameters        map[string]string      `protobuf:"bytes,9,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ModifiedAfter     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=modified_after,json=modifiedAfter,proto3" json:"modified_after,omitempty"`
+	ModifiedBefore    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=modified_before,json=modifiedBefore,proto3" json:"modified_before,omitempty"`
+	MinSize           *int64                 `protobuf:"varint,12,opt,name=min_size,json=minSize,proto3,oneof" json:"min_size,omitempty"`
+	MaxSize           *int64                 `protobuf:"varint,13,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	ContentType       string                 `protobuf:"bytes,14,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	IncludeMetadata   bool                   `protobuf:"varint,15,opt,name=include_metadata,json=includeMetadata,proto3" json:"include_metadata,omitempty"`
+	// Sort is key, size or last_modified; it orders the files of each page
+	Sort string `protobuf:"bytes,16,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Order is asc or desc
+	Order string `protobuf:"bytes,17,opt,name=order,proto3" json:"order,omitempty"`
+	// MaxPages stops the stream after this many pages; zero lists every page
+	MaxPages      int32 `protobuf:"varint,18,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesRequest) Reset() {
+	*x = ListFilesRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRequest) ProtoMessage() {}
+
+func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFilesRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetMaxKeys() int32 {
+	if x != nil {
+		return x.MaxKeys
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetScopeValue() int32 {
+	if x != nil {
+		return x.ScopeValue
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetModifiedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAfter
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetModifiedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedBefore
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetMinSize() int64 {
+	if x != nil && x.MinSize != nil {
+		return *x.MinSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetMaxSize() int64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetIncludeMetadata() bool {
+	if x != nil {
+		return x.IncludeMetadata
+	}
+	return false
+}
+
+func (x *ListFilesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+type FileListPage struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Files             []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	CommonPrefixes    []string               `protobuf:"bytes,2,rep,name=common_prefixes,json=commonPrefixes,proto3" json:"common_prefixes,omitempty"`
+	ContinuationToken string                 `protobuf:"bytes,3,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+	IsTruncated       bool                   `protobuf:"varint,4,opt,name=is_truncated,json=isTruncated,proto3" json:"is_truncated,omitempty"`
+	MaxKeys           int32                  `protobuf:"varint,5,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FileListPage) Reset() {
+	*x = FileListPage{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileListPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileListPage) ProtoMessage() {}
+
+func (x *FileListPage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileListPage.ProtoReflect.Descriptor instead.
+func (*FileListPage) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FileListPage) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *FileListPage) GetCommonPrefixes() []string {
+	if x != nil {
+		return x.CommonPrefixes
+	}
+	return nil
+}
+
+func (x *FileListPage) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *FileListPage) GetIsTruncated() bool {
+	if x != nil {
+		return x.IsTruncated
+	}
+	return false
+}
+
+func (x *FileListPage) GetMaxKeys() int32 {
+	if x != nil {
+		return x.MaxKeys
+	}
+	return 0
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Etag          string                 `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	LastModified  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileInfo) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *FileInfo) GetLastModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastModified
+	}
+	return nil
+}
+
+func (x *FileInfo) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GenerateUploadURLRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Provider   string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Definition string                 `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	Parameters map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Scope      string                 `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ScopeValue int32                  `protobuf:"varint,5,opt,name=scope_value,json=scopeValue,proto3" json:"scope_value,omitempty"`
+	Expiry     *durationpb.Duration   `protobuf:"bytes,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Metadata   *UploadMetadata        `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// ContentLength and Checksum declare the file to be uploaded so the
+	// definition's upload policy can be signed into the URL
+	ContentLength int64     `protobuf:"varint,8,opt,name=content_length,json=contentLength,proto3" json:"content_length,omitempty"`
+	Checksum      *Checksum `protobuf:"bytes,9,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateUploadURLRequest) Reset() {
+	*x = GenerateUploadURLRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateUploadURLRequest) ProtoMessage() {}
+
+func (x *GenerateUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GenerateUploadURLRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLRequest) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *GenerateUploadURLRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLRequest) GetScopeValue() int32 {
+	if x != nil {
+		return x.ScopeValue
+	}
+	return 0
+}
+
+func (x *GenerateUploadURLRequest) GetExpiry() *durationpb.Duration {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *GenerateUploadURLRequest) GetMetadata() *UploadMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GenerateUploadURLRequest) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+func (x *GenerateUploadURLRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+type UploadMetadata struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ContentType        string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ContentEncoding    string                 `protobuf:"bytes,2,opt,name=content_encoding,json=contentEncoding,proto3" json:"content_encoding,omitempty"`
+	ContentLanguage    string                 `protobuf:"bytes,3,opt,name=content_language,json=contentLanguage,proto3" json:"content_language,omitempty"`
+	ContentDisposition string                 `protobuf:"bytes,4,opt,name=content_disposition,json=contentDisposition,proto3" json:"content_disposition,omitempty"`
+	CacheControl       string                 `protobuf:"bytes,5,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	StorageClass       string                 `protobuf:"bytes,6,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	Acl                string                 `protobuf:"bytes,7,opt,name=acl,proto3" json:"acl,omitempty"`
+	CustomHeaders      map[string]string      `protobuf:"bytes,8,rep,name=custom_headers,json=customHeaders,proto3" json:"custom_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UploadMetadata) Reset() {
+	*x = UploadMetadata{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMetadata) ProtoMessage() {}
+
+func (x *UploadMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMetadata.ProtoReflect.Descriptor instead.
+func (*UploadMetadata) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UploadMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetContentEncoding() string {
+	if x != nil {
+		return x.ContentEncoding
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetContentLanguage() string {
+	if x != nil {
+		return x.ContentLanguage
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetContentDisposition() string {
+	if x != nil {
+		return x.ContentDisposition
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetCacheControl() string {
+	if x != nil {
+		return x.CacheControl
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetAcl() string {
+	if x != nil {
+		return x.Acl
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetCustomHeaders() map[string]string {
+	if x != nil {
+		return x.CustomHeaders
+	}
+	return nil
+}
+
+type Checksum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Checksum) Reset() {
+	*x = Checksum{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checksum) ProtoMessage() {}
+
+func (x *Checksum) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checksum.ProtoReflect.Descriptor instead.
+func (*Checksum) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Checksum) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Checksum) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GenerateDownloadURLRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	FilePath string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Expiry   *durationpb.Duration   `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// ResponseHeaders override headers of the download response, such as
+	// Content-Disposition
+	ResponseHeaders map[string]string `protobuf:"bytes,4,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerateDownloadURLRequest) Reset() {
+	*x = GenerateDownloadURLRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDownloadURLRequest) ProtoMessage() {}
+
+func (x *GenerateDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GenerateDownloadURLRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GenerateDownloadURLRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *GenerateDownloadURLRequest) GetExpiry() *durationpb.Duration {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *GenerateDownloadURLRequest) GetResponseHeaders() map[string]string {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+type SignedURL struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Url                string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Method             string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Headers            map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpiresAt          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ResolvedPath       string                 `protobuf:"bytes,5,opt,name=resolved_path,json=resolvedPath,proto3" json:"resolved_path,omitempty"`
+	ResolvedParameters map[string]string      `protobuf:"bytes,6,rep,name=resolved_parameters,json=resolvedParameters,proto3" json:"resolved_parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Constraints        *UploadConstraints     `protobuf:"bytes,7,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SignedURL) Reset() {
+	*x = SignedURL{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedURL) ProtoMessage() {}
+
+func (x *SignedURL) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedURL.ProtoReflect.Descriptor instead.
+func (*SignedURL) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SignedURL) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SignedURL) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SignedURL) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *SignedURL) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SignedURL) GetResolvedPath() string {
+	if x != nil {
+		return x.ResolvedPath
+	}
+	return ""
+}
+
+func (x *SignedURL) GetResolvedParameters() map[string]string {
+	if x != nil {
+		return x.ResolvedParameters
+	}
+	return nil
+}
+
+func (x *SignedURL) GetConstraints() *UploadConstraints {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+type UploadConstraints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxSize       int64                  `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Checksum      *Checksum              `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadConstraints) Reset() {
+	*x = UploadConstraints{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadConstraints) ProtoMessage() {}
+
+func (x *UploadConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadConstraints.ProtoReflect.Descriptor instead.
+func (*UploadConstraints) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UploadConstraints) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *UploadConstraints) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadConstraints) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *UploadConstraints) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+type InitMultipartUploadRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	Provider       string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Scope          string                 `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	ScopeValue     int32                  `protobuf:"varint,4,opt,name=scope_value,json=scopeValue,proto3" json:"scope_value,omitempty"`
+	Parameters     map[string]string      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata       *UploadMetadata        `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InitMultipartUploadRequest) Reset() {
+	*x = InitMultipartUploadRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitMultipartUploadRequest) ProtoMessage() {}
+
+func (x *InitMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitMultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*InitMultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InitMultipartUploadRequest) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadRequest) GetScopeValue() int32 {
+	if x != nil {
+		return x.ScopeValue
+	}
+	return 0
+}
+
+func (x *InitMultipartUploadRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *InitMultipartUploadRequest) GetMetadata() *UploadMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type InitMultipartUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	MaxPartSize   int64                  `protobuf:"varint,4,opt,name=max_part_size,json=maxPartSize,proto3" json:"max_part_size,omitempty"`
+	MinPartSize   int64                  `protobuf:"varint,5,opt,name=min_part_size,json=minPartSize,proto3" json:"min_part_size,omitempty"`
+	MaxParts      int32                  `protobuf:"varint,6,opt,name=max_parts,json=maxParts,proto3" json:"max_parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitMultipartUploadResponse) Reset() {
+	*x = InitMultipartUploadResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitMultipartUploadResponse) ProtoMessage() {}
+
+func (x *InitMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitMultipartUploadResponse.ProtoReflect.Descriptor instead.
+func (*InitMultipartUploadResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InitMultipartUploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *InitMultipartUploadResponse) GetMaxPartSize() int64 {
+	if x != nil {
+		return x.MaxPartSize
+	}
+	return 0
+}
+
+func (x *InitMultipartUploadResponse) GetMinPartSize() int64 {
+	if x != nil {
+		return x.MinPartSize
+	}
+	return 0
+}
+
+func (x *InitMultipartUploadResponse) GetMaxParts() int32 {
+	if x != nil {
+		return x.MaxParts
+	}
+	return 0
+}
+
+type GetMultipartURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	UploadId      string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Parts         []*PartRequest         `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMultipartURLsRequest) Reset() {
+	*x = GetMultipartURLsRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipartURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipartURLsRequest) ProtoMessage() {}
+
+func (x *GetMultipartURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipartURLsRequest.ProtoReflect.Descriptor instead.
+func (*GetMultipartURLsRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetMultipartURLsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetMultipartURLsRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *GetMultipartURLsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetMultipartURLsRequest) GetParts() []*PartRequest {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+type PartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Checksum      *Checksum              `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartRequest) Reset() {
+	*x = PartRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartRequest) ProtoMessage() {}
+
+func (x *PartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartRequest.ProtoReflect.Descriptor instead.
+func (*PartRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PartRequest) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *PartRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+type GetMultipartURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartUrls      []*MultipartPartURL    `protobuf:"bytes,1,rep,name=part_urls,json=partUrls,proto3" json:"part_urls,omitempty"`
+	CompleteUrl   *SignedURL             `protobuf:"bytes,2,opt,name=complete_url,json=completeUrl,proto3" json:"complete_url,omitempty"`
+	AbortUrl      *SignedURL             `protobuf:"bytes,3,opt,name=abort_url,json=abortUrl,proto3" json:"abort_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMultipartURLsResponse) Reset() {
+	*x = GetMultipartURLsResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipartURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipartURLsResponse) ProtoMessage() {}
+
+func (x *GetMultipartURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipartURLsResponse.ProtoReflect.Descriptor instead.
+func (*GetMultipartURLsResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetMultipartURLsResponse) GetPartUrls() []*MultipartPartURL {
+	if x != nil {
+		return x.PartUrls
+	}
+	return nil
+}
+
+func (x *GetMultipartURLsResponse) GetCompleteUrl() *SignedURL {
+	if x != nil {
+		return x.CompleteUrl
+	}
+	return nil
+}
+
+func (x *GetMultipartURLsResponse) GetAbortUrl() *SignedURL {
+	if x != nil {
+		return x.AbortUrl
+	}
+	return nil
+}
+
+type MultipartPartURL struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Url           *SignedURL             `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultipartPartURL) Reset() {
+	*x = MultipartPartURL{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultipartPartURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultipartPartURL) ProtoMessage() {}
+
+func (x *MultipartPartURL) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultipartPartURL.ProtoReflect.Descriptor instead.
+func (*MultipartPartURL) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MultipartPartURL) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *MultipartPartURL) GetUrl() *SignedURL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type ListUploadsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Status       string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ResourceType string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	Provider     string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Offset       int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Limit stops the stream after this many uploads; zero lists every upload
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUploadsRequest) Reset() {
+	*x = ListUploadsRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUploadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUploadsRequest) ProtoMessage() {}
+
+func (x *ListUploadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUploadsRequest.ProtoReflect.Descriptor instead.
+func (*ListUploadsRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUploadsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUploadsRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ListUploadsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ListUploadsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListUploadsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadRequest) Reset() {
+	*x = GetUploadRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadRequest) ProtoMessage() {}
+
+func (x *GetUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetUploadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Upload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ResourceType   string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId     string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	ResourceField  string                 `protobuf:"bytes,4,opt,name=resource_field,json=resourceField,proto3" json:"resource_field,omitempty"`
+	UploadType     string                 `protobuf:"bytes,5,opt,name=upload_type,json=uploadType,proto3" json:"upload_type,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Error          string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	PathDefinition string                 `protobuf:"bytes,8,opt,name=path_definition,json=pathDefinition,proto3" json:"path_definition,omitempty"`
+	PathParameters *structpb.Struct       `protobuf:"bytes,9,opt,name=path_parameters,json=pathParameters,proto3" json:"path_parameters,omitempty"`
+	Provider       string                 `protobuf:"bytes,10,opt,name=provider,proto3" json:"provider,omitempty"`
+	StorageKey     string                 `protobuf:"bytes,11,opt,name=storage_key,json=storageKey,proto3" json:"storage_key,omitempty"`
+	Size           *int64                 `protobuf:"varint,12,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	MultipartId    string                 `protobuf:"bytes,13,opt,name=multipart_id,json=multipartId,proto3" json:"multipart_id,omitempty"`
+	TotalParts     *int32                 `protobuf:"varint,14,opt,name=total_parts,json=totalParts,proto3,oneof" json:"total_parts,omitempty"`
+	UploadedParts  *int32                 `protobuf:"varint,15,opt,name=uploaded_parts,json=uploadedParts,proto3,oneof" json:"uploaded_parts,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Upload) Reset() {
+	*x = Upload{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upload) ProtoMessage() {}
+
+func (x *Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upload.ProtoReflect.Descriptor instead.
+func (*Upload) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Upload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Upload) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *Upload) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *Upload) GetResourceField() string {
+	if x != nil {
+		return x.ResourceField
+	}
+	return ""
+}
+
+func (x *Upload) GetUploadType() string {
+	if x != nil {
+		return x.UploadType
+	}
+	return ""
+}
+
+func (x *Upload) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Upload) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Upload) GetPathDefinition() string {
+	if x != nil {
+		return x.PathDefinition
+	}
+	return ""
+}
+
+func (x *Upload) GetPathParameters() *structpb.Struct {
+	if x != nil {
+		return x.PathParameters
+	}
+	return nil
+}
+
+func (x *Upload) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Upload) GetStorageKey() string {
+	if x != nil {
+		return x.StorageKey
+	}
+	return ""
+}
+
+func (x *Upload) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Upload) GetMultipartId() string {
+	if x != nil {
+		return x.MultipartId
+	}
+	return ""
+}
+
+func (x *Upload) GetTotalParts() int32 {
+	if x != nil && x.TotalParts != nil {
+		return *x.TotalParts
+	}
+	return 0
+}
+
+func (x *Upload) GetUploadedParts() int32 {
+	if x != nil && x.UploadedParts != nil {
+		return *x.UploadedParts
+	}
+	return 0
+}
+
+func (x *Upload) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Upload) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Upload) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Upload) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type AbortUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadRequest) ProtoMessage() {}
+
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AbortUploadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AbortUploadRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Upload *Upload                `protobuf:"bytes,1,opt,name=upload,proto3" json:"upload,omitempty"`
+	// ProviderAborted is set when the provider's multipart upload was aborted too
+	ProviderAborted bool `protobuf:"varint,2,opt,name=provider_aborted,json=providerAborted,proto3" json:"provider_aborted,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AbortUploadResponse) GetUpload() *Upload {
+	if x != nil {
+		return x.Upload
+	}
+	return nil
+}
+
+func (x *AbortUploadResponse) GetProviderAborted() bool {
+	if x != nil {
+		return x.ProviderAborted
+	}
+	return false
+}
+
+type RunJanitorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunJanitorRequest) Reset() {
+	*x = RunJanitorRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunJanitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJanitorRequest) ProtoMessage() {}
+
+func (x *RunJanitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJanitorRequest.ProtoReflect.Descriptor instead.
+func (*RunJanitorRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RunJanitorRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RunJanitorRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type JanitorResult struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	DryRun        bool                    `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Scanned       int32                   `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Aborted       int32                   `protobuf:"varint,3,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	Failed        int32                   `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Uploads       []*JanitorUploadOutcome `protobuf:"bytes,5,rep,name=uploads,proto3" json:"uploads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JanitorResult) Reset() {
+	*x = JanitorResult{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JanitorResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JanitorResult) ProtoMessage() {}
+
+func (x *JanitorResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JanitorResult.ProtoReflect.Descriptor instead.
+func (*JanitorResult) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *JanitorResult) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *JanitorResult) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *JanitorResult) GetAborted() int32 {
+	if x != nil {
+		return x.Aborted
+	}
+	return 0
+}
+
+func (x *JanitorResult) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *JanitorResult) GetUploads() []*JanitorUploadOutcome {
+	if x != nil {
+		return x.Uploads
+	}
+	return nil
+}
+
+type JanitorUploadOutcome struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider        string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	StorageKey      string                 `protobuf:"bytes,3,opt,name=storage_key,json=storageKey,proto3" json:"storage_key,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Aborted         bool                   `protobuf:"varint,5,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	ProviderAborted bool                   `protobuf:"varint,6,opt,name=provider_aborted,json=providerAborted,proto3" json:"provider_aborted,omitempty"`
+	Error           string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *JanitorUploadOutcome) Reset() {
+	*x = JanitorUploadOutcome{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JanitorUploadOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JanitorUploadOutcome) ProtoMessage() {}
+
+func (x *JanitorUploadOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JanitorUploadOutcome.ProtoReflect.Descriptor instead.
+func (*JanitorUploadOutcome) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *JanitorUploadOutcome) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JanitorUploadOutcome) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *JanitorUploadOutcome) GetStorageKey() string {
+	if x != nil {
+		return x.StorageKey
+	}
+	return ""
+}
+
+func (x *JanitorUploadOutcome) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *JanitorUploadOutcome) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+func (x *JanitorUploadOutcome) GetProviderAborted() bool {
+	if x != nil {
+		return x.ProviderAborted
+	}
+	return false
+}
+
+func (x *JanitorUploadOutcome) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListAchievementsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IncludeInactive lists unpublished achievements too
+	IncludeInactive bool  `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	Offset          int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Limit stops the stream after this many achievements; zero lists every one
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAchievementsRequest) Reset() {
+	*x = ListAchievementsRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAchievementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAchievementsRequest) ProtoMessage() {}
+
+func (x *ListAchievementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAchievementsRequest.ProtoReflect.Descriptor instead.
+func (*ListAchievementsRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListAchievementsRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+func (x *ListAchievementsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAchievementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PreviewAchievementsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At defaults to now
+	At            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewAchievementsRequest) Reset() {
+	*x = PreviewAchievementsRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAchievementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAchievementsRequest) ProtoMessage() {}
+
+func (x *PreviewAchievementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAchievementsRequest.ProtoReflect.Descriptor instead.
+func (*PreviewAchievementsRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PreviewAchievementsRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *PreviewAchievementsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PreviewAchievementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetAchievementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAchievementRequest) Reset() {
+	*x = GetAchievementRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAchievementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAchievementRequest) ProtoMessage() {}
+
+func (x *GetAchievementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAchievementRequest.ProtoReflect.Descriptor instead.
+func (*GetAchievementRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetAchievementRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Achievement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Points        int32                  `protobuf:"varint,5,opt,name=points,proto3" json:"points,omitempty"`
+	IconUrl       string                 `protobuf:"bytes,6,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	BannerUrl     string                 `protobuf:"bytes,7,opt,name=banner_url,json=bannerUrl,proto3" json:"banner_url,omitempty"`
+	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Achievement) Reset() {
+	*x = Achievement{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Achievement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Achievement) ProtoMessage() {}
+
+func (x *Achievement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Achievement.ProtoReflect.Descriptor instead.
+func (*Achievement) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Achievement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Achievement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Achievement) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Achievement) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Achievement) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *Achievement) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *Achievement) GetBannerUrl() string {
+	if x != nil {
+		return x.BannerUrl
+	}
+	return ""
+}
+
+func (x *Achievement) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Achievement) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Achievement) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *Achievement) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Achievement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Achievement) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateAchievementRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Category    string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Points      int32                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	IconFormat  string                 `protobuf:"bytes,5,opt,name=icon_format,json=iconFormat,proto3" json:"icon_format,omitempty"`
+	// Provider defaults to r2
+	Provider      string                 `protobuf:"bytes,6,opt,name=provider,proto3" json:"provider,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAchievementRequest) Reset() {
+	*x = CreateAchievementRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAchievementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAchievementRequest) ProtoMessage() {}
+
+func (x *CreateAchievementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAchievementRequest.ProtoReflect.Descriptor instead.
+func (*CreateAchievementRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateAchievementRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAchievementRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateAchievementRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateAchievementRequest) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *CreateAchievementRequest) GetIconFormat() string {
+	if x != nil {
+		return x.IconFormat
+	}
+	return ""
+}
+
+func (x *CreateAchievementRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CreateAchievementRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreateAchievementRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+type CreateAchievementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Points        int32                  `protobuf:"varint,5,opt,name=points,proto3" json:"points,omitempty"`
+	IconUrl       string                 `protobuf:"bytes,6,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	Upload        *UploadInfo            `protobuf:"bytes,7,opt,name=upload,proto3" json:"upload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAchievementResponse) Reset() {
+	*x = CreateAchievementResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAchievementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAchievementResponse) ProtoMessage() {}
+
+func (x *CreateAchievementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAchievementResponse.ProtoReflect.Descriptor instead.
+func (*CreateAchievementResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateAchievementResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateAchievementResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAchievementResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateAchievementResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateAchievementResponse) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *CreateAchievementResponse) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *CreateAchievementResponse) GetUpload() *UploadInfo {
+	if x != nil {
+		return x.Upload
+	}
+	return nil
+}
+
+type UploadInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	UploadUrl     string                 `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadInfo) Reset() {
+	*x = UploadInfo{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadInfo) ProtoMessage() {}
+
+func (x *UploadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadInfo.ProtoReflect.Descriptor instead.
+func (*UploadInfo) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UploadInfo) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadInfo) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *UploadInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type DeleteAchievementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAchievementRequest) Reset() {
+	*x = DeleteAchievementRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAchievementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAchievementRequest) ProtoMessage() {}
+
+func (x *DeleteAchievementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAchievementRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAchievementRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteAchievementRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAchievementHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAchievementHistoryRequest) Reset() {
+	*x = GetAchievementHistoryRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAchievementHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAchievementHistoryRequest) ProtoMessage() {}
+
+func (x *GetAchievementHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAchievementHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAchievementHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetAchievementHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetAchievementHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetAchievementHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AchievementRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	Previous      *Achievement           `protobuf:"bytes,5,opt,name=previous,proto3" json:"previous,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AchievementRevision) Reset() {
+	*x = AchievementRevision{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AchievementRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AchievementRevision) ProtoMessage() {}
+
+func (x *AchievementRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AchievementRevision.ProtoReflect.Descriptor instead.
+func (*AchievementRevision) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AchievementRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *AchievementRevision) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AchievementRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AchievementRevision) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AchievementRevision) GetPrevious() *Achievement {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *AchievementRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Old           *structpb.Value        `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           *structpb.Value        `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOld() *structpb.Value {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *FieldChange) GetNew() *structpb.Value {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+type RevertAchievementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Revision      int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertAchievementRequest) Reset() {
+	*x = RevertAchievementRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertAchievementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertAchievementRequest) ProtoMessage() {}
+
+func (x *RevertAchievementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertAchievementRequest.ProtoReflect.Descriptor instead.
+func (*RevertAchievementRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RevertAchievementRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevertAchievementRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type RevertAchievementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Achievement   *Achievement           `protobuf:"bytes,1,opt,name=achievement,proto3" json:"achievement,omitempty"`
+	Revision      int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	IconRestored  bool                   `protobuf:"varint,3,opt,name=icon_restored,json=iconRestored,proto3" json:"icon_restored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertAchievementResponse) Reset() {
+	*x = RevertAchievementResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertAchievementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertAchievementResponse) ProtoMessage() {}
+
+func (x *RevertAchievementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertAchievementResponse.ProtoReflect.Descriptor instead.
+func (*RevertAchievementResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RevertAchievementResponse) GetAchievement() *Achievement {
+	if x != nil {
+		return x.Achievement
+	}
+	return nil
+}
+
+func (x *RevertAchievementResponse) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RevertAchievementResponse) GetIconRestored() bool {
+	if x != nil {
+		return x.IconRestored
+	}
+	return false
+}
+
+type UpdateAchievementIconRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAchievementIconRequest) Reset() {
+	*x = UpdateAchievementIconRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAchievementIconRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAchievementIconRequest) ProtoMessage() {}
+
+func (x *UpdateAchievementIconRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAchievementIconRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAchievementIconRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UpdateAchievementIconRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type UpdateAchievementIconResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	UploadUrl     string                 `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	NewIconUrl    string                 `protobuf:"bytes,4,opt,name=new_icon_url,json=newIconUrl,proto3" json:"new_icon_url,omitempty"`
+	Constraints   *UploadConstraints     `protobuf:"bytes,5,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAchievementIconResponse) Reset() {
+	*x = UpdateAchievementIconResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAchievementIconResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAchievementIconResponse) ProtoMessage() {}
+
+func (x *UpdateAchievementIconResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAchievementIconResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAchievementIconResponse) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *UpdateAchievementIconResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UpdateAchievementIconResponse) GetNewIconUrl() string {
+	if x != nil {
+		return x.NewIconUrl
+	}
+	return ""
+}
+
+func (x *UpdateAchievementIconResponse) GetConstraints() *UploadConstraints {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+type UpdateAchievementScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAchievementScheduleRequest) Reset() {
+	*x = UpdateAchievementScheduleRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAchievementScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAchievementScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateAchievementScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAchievementScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAchievementScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UpdateAchievementScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAchievementScheduleRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *UpdateAchievementScheduleRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+type ConfirmUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMsg      string                 `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	FileSize      int64                  `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Etags         []*PartETag            `protobuf:"bytes,6,rep,name=etags,proto3" json:"etags,omitempty"`
+	VerifyExists  bool                   `protobuf:"varint,7,opt,name=verify_exists,json=verifyExists,proto3" json:"verify_exists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmUploadRequest) Reset() {
+	*x = ConfirmUploadRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmUploadRequest) ProtoMessage() {}
+
+func (x *ConfirmUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmUploadRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmUploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ConfirmUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmUploadRequest) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *ConfirmUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetEtags() []*PartETag {
+	if x != nil {
+		return x.Etags
+	}
+	return nil
+}
+
+func (x *ConfirmUploadRequest) GetVerifyExists() bool {
+	if x != nil {
+		return x.VerifyExists
+	}
+	return false
+}
+
+type PartETag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Part          int32                  `protobuf:"varint,1,opt,name=part,proto3" json:"part,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartETag) Reset() {
+	*x = PartETag{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartETag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartETag) ProtoMessage() {}
+
+func (x *PartETag) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartETag.ProtoReflect.Descriptor instead.
+func (*PartETag) Descriptor() ([]byte, []int) {
+	return file_api_resource_v1_resource_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PartETag) GetPart() int32 {
+	if x != nil {
+		return x.Part
+	}
+	return 0
+}
+
+func (x *PartETag) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *PartETag) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+var File_api_resource_v1_resource_proto protoreflect.FileDescriptor
+
+const file_api_resource_v1_resource_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/resource/v1/resource.proto\x12\vresource.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x18\n" +
+	"\x16ListDefinitionsRequest\"*\n" +
+	"\x14GetDefinitionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xec\x04\n" +
+	"\x0ePathDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06parent\x18\x04 \x01(\tR\x06parent\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x06 \x01(\bR\n" +
+	"deprecated\x12%\n" +
+	"\x0eallowed_scopes\x18\a \x03(\tR\rallowedScopes\x12:\n" +
+	"\n" +
+	"parameters\x18\b \x03(\v2\x1a.resource.v1.PathParameterR\n" +
+	"parameters\x12\x1c\n" +
+	"\tproviders\x18\t \x03(\tR\tproviders\x12E\n" +
+	"\bpatterns\x18\n" +
+	" \x03(\v2).resource.v1.PathDefinition.PatternsEntryR\bpatterns\x12V\n" +
+	"\x18default_storage_metadata\x18\v \x01(\v2\x1c.resource.v1.StorageDefaultsR\x16defaultStorageMetadata\x127\n" +
+	"\bchildren\x18\f \x03(\v2\x1b.resource.v1.PathDefinitionR\bchildren\x1aV\n" +
+	"\rPatternsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.resource.v1.PathPatternsR\x05value:\x028\x01\"\xb0\x02\n" +
+	"\rPathParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\x12\x14\n" +
+	"\x05rules\x18\x03 \x03(\tR\x05rules\x12\x12\n" +
+	"\x04enum\x18\x04 \x03(\tR\x04enum\x12\x18\n" +
+	"\apattern\x18\x05 \x01(\tR\apattern\x12\"\n" +
+	"\n" +
+	"min_length\x18\x06 \x01(\x05H\x00R\tminLength\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_length\x18\a \x01(\x05H\x01R\tmaxLength\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12#\n" +
+	"\rdefault_value\x18\t \x01(\tR\fdefaultValueB\r\n" +
+	"\v_min_lengthB\r\n" +
+	"\v_max_length\"\xab\x01\n" +
+	"\fPathPatterns\x12\x19\n" +
+	"\burl_type\x18\x01 \x01(\tR\aurlType\x12C\n" +
+	"\bpatterns\x18\x02 \x03(\v2'.resource.v1.PathPatterns.PatternsEntryR\bpatterns\x1a;\n" +
+	"\rPatternsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x02\n" +
+	"\x0fStorageDefaults\x12F\n" +
+	"\rcache_control\x18\x01 \x01(\v2!.resource.v1.CacheControlDefaultsR\fcacheControl\x12-\n" +
+	"\x12required_checksums\x18\x02 \x03(\tR\x11requiredChecksums\x12V\n" +
+	"\x0ecustom_headers\x18\x03 \x03(\v2/.resource.v1.StorageDefaults.CustomHeadersEntryR\rcustomHeaders\x1a@\n" +
+	"\x12CustomHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
+	"\x14CacheControlDefaults\x12\x17\n" +
+	"\amax_age\x18\x01 \x01(\x03R\x06maxAge\x12!\n" +
+	"\fallow_public\x18\x02 \x01(\bR\vallowPublic\x12\x18\n" +
+	"\adefault\x18\x03 \x01(\tR\adefault\"\xfb\x01\n" +
+	"\x18ResolveDefinitionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12U\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\v25.resource.v1.ResolveDefinitionRequest.ParametersEntryR\n" +
+	"parameters\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x1f\n" +
+	"\vscope_value\x18\x04 \x01(\x05R\n" +
+	"scopeValue\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\x19ResolveDefinitionResponse\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x01 \x01(\tR\n" +
+	"definition\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x1f\n" +
+	"\vscope_value\x18\x03 \x01(\x05R\n" +
+	"scopeValue\x12?\n" +
+	"\tproviders\x18\x04 \x03(\v2!.resource.v1.ResolvedProviderPathR\tproviders\"\x8b\x03\n" +
+	"\x14ResolvedProviderPath\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x19\n" +
+	"\burl_type\x18\x03 \x01(\tR\aurlType\x12Q\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\v21.resource.v1.ResolvedProviderPath.ParametersEntryR\n" +
+	"parameters\x12E\n" +
+	"\x06errors\x18\x05 \x03(\v2-.resource.v1.ResolvedProviderPath.ErrorsEntryR\x06errors\x12\x14\n" +
+	"\x05valid\x18\x06 \x01(\bR\x05valid\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x16\n" +
+	"\x14ListProvidersRequest\"(\n" +
+	"\x12GetProviderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"e\n" +
+	"\bProvider\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12E\n" +
+	"\fcapabilities\x18\x02 \x01(\v2!.resource.v1.ProviderCapabilitiesR\fcapabilities\"\xa2\x05\n" +
+	"\x14ProviderCapabilities\x12#\n" +
+	"\rsupports_read\x18\x01 \x01(\bR\fsupportsRead\x12%\n" +
+	"\x0esupports_write\x18\x02 \x01(\bR\rsupportsWrite\x12'\n" +
+	"\x0fsupports_delete\x18\x03 \x01(\bR\x0esupportsDelete\x12)\n" +
+	"\x10supports_listing\x18\x04 \x01(\bR\x0fsupportsListing\x12+\n" +
+	"\x11supports_metadata\x18\x05 \x01(\bR\x10supportsMetadata\x12-\n" +
+	"\x12supports_multipart\x18\x06 \x01(\bR\x11supportsMultipart\x12<\n" +
+	"\x1asupports_resumable_uploads\x18\a \x01(\bR\x18supportsResumableUploads\x120\n" +
+	"\x14supports_signed_urls\x18\b \x01(\bR\x12supportsSignedUrls\x12@\n" +
+	"\x1csupports_checksum_algorithms\x18\t \x03(\tR\x1asupportsChecksumAlgorithms\x12&\n" +
+	"\x0fmax_upload_size\x18\n" +
+	" \x01(\x03R\rmaxUploadSize\x128\n" +
+	"\n" +
+	"max_expiry\x18\v \x01(\v2\x19.google.protobuf.DurationR\tmaxExpiry\x128\n" +
+	"\n" +
+	"min_expiry\x18\f \x01(\v2\x19.google.protobuf.DurationR\tminExpiry\x12@\n" +
+	"\tmultipart\x18\r \x01(\v2\".resource.v1.MultipartCapabilitiesR\tmultipart\"|\n" +
+	"\x15MultipartCapabilities\x12\"\n" +
+	"\rmin_part_size\x18\x01 \x01(\x03R\vminPartSize\x12\"\n" +
+	"\rmax_part_size\x18\x02 \x01(\x03R\vmaxPartSize\x12\x1b\n" +
+	"\tmax_parts\x18\x03 \x01(\x05R\bmaxParts\"\x8a\x06\n" +
+	"\x10ListFilesRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x02 \x01(\tR\n" +
+	"definition\x12\x19\n" +
+	"\bmax_keys\x18\x03 \x01(\x05R\amaxKeys\x12-\n" +
+	"\x12continuation_token\x18\x04 \x01(\tR\x11continuationToken\x12\x16\n" +
+	"\x06prefix\x18\x05 \x01(\tR\x06prefix\x12\x1c\n" +
+	"\tdelimiter\x18\x06 \x01(\tR\tdelimiter\x12\x14\n" +
+	"\x05scope\x18\a \x01(\tR\x05scope\x12\x1f\n" +
+	"\vscope_value\x18\b \x01(\x05R\n" +
+	"scopeValue\x12M\n" +
+	"\n" +
+	"parameters\x18\t \x03(\v2-.resource.v1.ListFilesRequest.ParametersEntryR\n" +
+	"parameters\x12A\n" +
+	"\x0emodified_after\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rmodifiedAfter\x12C\n" +
+	"\x0fmodified_before\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0emodifiedBefore\x12\x1e\n" +
+	"\bmin_size\x18\f \x01(\x03H\x00R\aminSize\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\r \x01(\x03H\x01R\amaxSize\x88\x01\x01\x12!\n" +
+	"\fcontent_type\x18\x0e \x01(\tR\vcontentType\x12)\n" +
+	"\x10include_metadata\x18\x0f \x01(\bR\x0fincludeMetadata\x12\x12\n" +
+	"\x04sort\x18\x10 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x11 \x01(\tR\x05order\x12\x1b\n" +
+	"\tmax_pages\x18\x12 \x01(\x05R\bmaxPages\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_min_sizeB\v\n" +
+	"\t_max_size\"\xd1\x01\n" +
+	"\fFileListPage\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.resource.v1.FileInfoR\x05files\x12'\n" +
+	"\x0fcommon_prefixes\x18\x02 \x03(\tR\x0ecommonPrefixes\x12-\n" +
+	"\x12continuation_token\x18\x03 \x01(\tR\x11continuationToken\x12!\n" +
+	"\fis_truncated\x18\x04 \x01(\bR\visTruncated\x12\x19\n" +
+	"\bmax_keys\x18\x05 \x01(\x05R\amaxKeys\"\xdd\x01\n" +
+	"\bFileInfo\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etag\x12?\n" +
+	"\rlast_modified\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastModified\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xe9\x03\n" +
+	"\x18GenerateUploadURLRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x02 \x01(\tR\n" +
+	"definition\x12U\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v25.resource.v1.GenerateUploadURLRequest.ParametersEntryR\n" +
+	"parameters\x12\x14\n" +
+	"\x05scope\x18\x04 \x01(\tR\x05scope\x12\x1f\n" +
+	"\vscope_value\x18\x05 \x01(\x05R\n" +
+	"scopeValue\x121\n" +
+	"\x06expiry\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06expiry\x127\n" +
+	"\bmetadata\x18\a \x01(\v2\x1b.resource.v1.UploadMetadataR\bmetadata\x12%\n" +
+	"\x0econtent_length\x18\b \x01(\x03R\rcontentLength\x121\n" +
+	"\bchecksum\x18\t \x01(\v2\x15.resource.v1.ChecksumR\bchecksum\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x03\n" +
+	"\x0eUploadMetadata\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12)\n" +
+	"\x10content_encoding\x18\x02 \x01(\tR\x0fcontentEncoding\x12)\n" +
+	"\x10content_language\x18\x03 \x01(\tR\x0fcontentLanguage\x12/\n" +
+	"\x13content_disposition\x18\x04 \x01(\tR\x12contentDisposition\x12#\n" +
+	"\rcache_control\x18\x05 \x01(\tR\fcacheControl\x12#\n" +
+	"\rstorage_class\x18\x06 \x01(\tR\fstorageClass\x12\x10\n" +
+	"\x03acl\x18\a \x01(\tR\x03acl\x12U\n" +
+	"\x0ecustom_headers\x18\b \x03(\v2..resource.v1.UploadMetadata.CustomHeadersEntryR\rcustomHeaders\x1a@\n" +
+	"\x12CustomHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\bChecksum\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xb5\x02\n" +
+	"\x1aGenerateDownloadURLRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1b\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x121\n" +
+	"\x06expiry\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06expiry\x12g\n" +
+	"\x10response_headers\x18\x04 \x03(\v2<.resource.v1.GenerateDownloadURLRequest.ResponseHeadersEntryR\x0fresponseHeaders\x1aB\n" +
+	"\x14ResponseHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfa\x03\n" +
+	"\tSignedURL\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12=\n" +
+	"\aheaders\x18\x03 \x03(\v2#.resource.v1.SignedURL.HeadersEntryR\aheaders\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12#\n" +
+	"\rresolved_path\x18\x05 \x01(\tR\fresolvedPath\x12_\n" +
+	"\x13resolved_parameters\x18\x06 \x03(\v2..resource.v1.SignedURL.ResolvedParametersEntryR\x12resolvedParameters\x12@\n" +
+	"\vconstraints\x18\a \x01(\v2\x1e.resource.v1.UploadConstraintsR\vconstraints\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aE\n" +
+	"\x17ResolvedParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x11UploadConstraints\x12\x19\n" +
+	"\bmax_size\x18\x01 \x01(\x03R\amaxSize\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x121\n" +
+	"\bchecksum\x18\x03 \x01(\v2\x15.resource.v1.ChecksumR\bchecksum\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\"\xe9\x02\n" +
+	"\x1aInitMultipartUploadRequest\x12'\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tR\x0edefinitionName\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x1f\n" +
+	"\vscope_value\x18\x04 \x01(\x05R\n" +
+	"scopeValue\x12W\n" +
+	"\n" +
+	"parameters\x18\x05 \x03(\v27.resource.v1.InitMultipartUploadRequest.ParametersEntryR\n" +
+	"parameters\x127\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x1b.resource.v1.UploadMetadataR\bmetadata\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcf\x01\n" +
+	"\x1bInitMultipartUploadResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\"\n" +
+	"\rmax_part_size\x18\x04 \x01(\x03R\vmaxPartSize\x12\"\n" +
+	"\rmin_part_size\x18\x05 \x01(\x03R\vminPartSize\x12\x1b\n" +
+	"\tmax_parts\x18\x06 \x01(\x05R\bmaxParts\"\x96\x01\n" +
+	"\x17GetMultipartURLsRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\tupload_id\x18\x02 \x01(\tR\buploadId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12.\n" +
+	"\x05parts\x18\x04 \x03(\v2\x18.resource.v1.PartRequestR\x05parts\"a\n" +
+	"\vPartRequest\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x121\n" +
+	"\bchecksum\x18\x02 \x01(\v2\x15.resource.v1.ChecksumR\bchecksum\"\xc6\x01\n" +
+	"\x18GetMultipartURLsResponse\x12:\n" +
+	"\tpart_urls\x18\x01 \x03(\v2\x1d.resource.v1.MultipartPartURLR\bpartUrls\x129\n" +
+	"\fcomplete_url\x18\x02 \x01(\v2\x16.resource.v1.SignedURLR\vcompleteUrl\x123\n" +
+	"\tabort_url\x18\x03 \x01(\v2\x16.resource.v1.SignedURLR\babortUrl\"]\n" +
+	"\x10MultipartPartURL\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12(\n" +
+	"\x03url\x18\x02 \x01(\v2\x16.resource.v1.SignedURLR\x03url\"\x9b\x01\n" +
+	"\x12ListUploadsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\"\n" +
+	"\x10GetUploadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa6\x06\n" +
+	"\x06Upload\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\tR\n" +
+	"resourceId\x12%\n" +
+	"\x0eresource_field\x18\x04 \x01(\tR\rresourceField\x12\x1f\n" +
+	"\vupload_type\x18\x05 \x01(\tR\n" +
+	"uploadType\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12'\n" +
+	"\x0fpath_definition\x18\b \x01(\tR\x0epathDefinition\x12@\n" +
+	"\x0fpath_parameters\x18\t \x01(\v2\x17.google.protobuf.StructR\x0epathParameters\x12\x1a\n" +
+	"\bprovider\x18\n" +
+	" \x01(\tR\bprovider\x12\x1f\n" +
+	"\vstorage_key\x18\v \x01(\tR\n" +
+	"storageKey\x12\x17\n" +
+	"\x04size\x18\f \x01(\x03H\x00R\x04size\x88\x01\x01\x12!\n" +
+	"\fmultipart_id\x18\r \x01(\tR\vmultipartId\x12$\n" +
+	"\vtotal_parts\x18\x0e \x01(\x05H\x01R\n" +
+	"totalParts\x88\x01\x01\x12*\n" +
+	"\x0euploaded_parts\x18\x0f \x01(\x05H\x02R\ruploadedParts\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAtB\a\n" +
+	"\x05_sizeB\x0e\n" +
+	"\f_total_partsB\x11\n" +
+	"\x0f_uploaded_parts\"<\n" +
+	"\x12AbortUploadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"m\n" +
+	"\x13AbortUploadResponse\x12+\n" +
+	"\x06upload\x18\x01 \x01(\v2\x13.resource.v1.UploadR\x06upload\x12)\n" +
+	"\x10provider_aborted\x18\x02 \x01(\bR\x0fproviderAborted\"B\n" +
+	"\x11RunJanitorRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xb1\x01\n" +
+	"\rJanitorResult\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned\x12\x18\n" +
+	"\aaborted\x18\x03 \x01(\x05R\aaborted\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12;\n" +
+	"\auploads\x18\x05 \x03(\v2!.resource.v1.JanitorUploadOutcomeR\auploads\"\xf9\x01\n" +
+	"\x14JanitorUploadOutcome\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x1f\n" +
+	"\vstorage_key\x18\x03 \x01(\tR\n" +
+	"storageKey\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aaborted\x18\x05 \x01(\bR\aaborted\x12)\n" +
+	"\x10provider_aborted\x18\x06 \x01(\bR\x0fproviderAborted\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"r\n" +
+	"\x17ListAchievementsRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"v\n" +
+	"\x1aPreviewAchievementsRequest\x12*\n" +
+	"\x02at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"'\n" +
+	"\x15GetAchievementRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x83\x04\n" +
+	"\vAchievement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06points\x18\x05 \x01(\x05R\x06points\x12\x19\n" +
+	"\bicon_url\x18\x06 \x01(\tR\aiconUrl\x12\x1d\n" +
+	"\n" +
+	"banner_url\x18\a \x01(\tR\tbannerUrl\x12\x1b\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"publish_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x123\n" +
+	"\bmetadata\x18\v \x01(\v2\x17.google.protobuf.StructR\bmetadata\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbb\x02\n" +
+	"\x18CreateAchievementRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x05R\x06points\x12\x1f\n" +
+	"\vicon_format\x18\x05 \x01(\tR\n" +
+	"iconFormat\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\x129\n" +
+	"\n" +
+	"publish_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\"\xe1\x01\n" +
+	"\x19CreateAchievementResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06points\x18\x05 \x01(\x05R\x06points\x12\x19\n" +
+	"\bicon_url\x18\x06 \x01(\tR\aiconUrl\x12/\n" +
+	"\x06upload\x18\a \x01(\v2\x17.resource.v1.UploadInfoR\x06upload\"\x83\x01\n" +
+	"\n" +
+	"UploadInfo\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"*\n" +
+	"\x18DeleteAchievementRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\\\n" +
+	"\x1cGetAchievementHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x84\x02\n" +
+	"\x13AchievementRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x122\n" +
+	"\achanges\x18\x04 \x03(\v2\x18.resource.v1.FieldChangeR\achanges\x124\n" +
+	"\bprevious\x18\x05 \x01(\v2\x18.resource.v1.AchievementR\bprevious\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"w\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12(\n" +
+	"\x03old\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x03old\x12(\n" +
+	"\x03new\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x03new\"F\n" +
+	"\x18RevertAchievementRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\"\x98\x01\n" +
+	"\x19RevertAchievementResponse\x12:\n" +
+	"\vachievement\x18\x01 \x01(\v2\x18.resource.v1.AchievementR\vachievement\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x12#\n" +
+	"\ricon_restored\x18\x03 \x01(\bR\ficonRestored\"v\n" +
+	"\x1cUpdateAchievementIconRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"\xfa\x01\n" +
+	"\x1dUpdateAchievementIconResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12 \n" +
+	"\fnew_icon_url\x18\x04 \x01(\tR\n" +
+	"newIconUrl\x12@\n" +
+	"\vconstraints\x18\x05 \x01(\v2\x1e.resource.v1.UploadConstraintsR\vconstraints\"\xac\x01\n" +
+	" UpdateAchievementScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"publish_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\"\xfc\x01\n" +
+	"\x14ConfirmUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1b\n" +
+	"\terror_msg\x18\x03 \x01(\tR\berrorMsg\x12\x1b\n" +
+	"\tfile_size\x18\x04 \x01(\x03R\bfileSize\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12+\n" +
+	"\x05etags\x18\x06 \x03(\v2\x15.resource.v1.PartETagR\x05etags\x12#\n" +
+	"\rverify_exists\x18\a \x01(\bR\fverifyExists\"F\n" +
+	"\bPartETag\x12\x12\n" +
+	"\x04part\x18\x01 \x01(\x05R\x04part\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size2\x9f\x02\n" +
+	"\x11DefinitionService\x12U\n" +
+	"\x0fListDefinitions\x12#.resource.v1.ListDefinitionsRequest\x1a\x1b.resource.v1.PathDefinition0\x01\x12O\n" +
+	"\rGetDefinition\x12!.resource.v1.GetDefinitionRequest\x1a\x1b.resource.v1.PathDefinition\x12b\n" +
+	"\x11ResolveDefinition\x12%.resource.v1.ResolveDefinitionRequest\x1a&.resource.v1.ResolveDefinitionResponse2\xa5\x01\n" +
+	"\x0fProviderService\x12K\n" +
+	"\rListProviders\x12!.resource.v1.ListProvidersRequest\x1a\x15.resource.v1.Provider0\x01\x12E\n" +
+	"\vGetProvider\x12\x1f.resource.v1.GetProviderRequest\x1a\x15.resource.v1.Provider2\x82\x02\n" +
+	"\vFileService\x12G\n" +
+	"\tListFiles\x12\x1d.resource.v1.ListFilesRequest\x1a\x19.resource.v1.FileListPage0\x01\x12R\n" +
+	"\x11GenerateUploadURL\x12%.resource.v1.GenerateUploadURLRequest\x1a\x16.resource.v1.SignedURL\x12V\n" +
+	"\x13GenerateDownloadURL\x12'.resource.v1.GenerateDownloadURLRequest\x1a\x16.resource.v1.SignedURL2\xdd\x01\n" +
+	"\x10MultipartService\x12h\n" +
+	"\x13InitMultipartUpload\x12'.resource.v1.InitMultipartUploadRequest\x1a(.resource.v1.InitMultipartUploadResponse\x12_\n" +
+	"\x10GetMultipartURLs\x12$.resource.v1.GetMultipartURLsRequest\x1a%.resource.v1.GetMultipartURLsResponse2\xb3\x02\n" +
+	"\rUploadService\x12E\n" +
+	"\vListUploads\x12\x1f.resource.v1.ListUploadsRequest\x1a\x13.resource.v1.Upload0\x01\x12?\n" +
+	"\tGetUpload\x12\x1d.resource.v1.GetUploadRequest\x1a\x13.resource.v1.Upload\x12P\n" +
+	"\vAbortUpload\x12\x1f.resource.v1.AbortUploadRequest\x1a .resource.v1.AbortUploadResponse\x12H\n" +
+	"\n" +
+	"RunJanitor\x12\x1e.resource.v1.RunJanitorRequest\x1a\x1a.resource.v1.JanitorResult2\xbc\a\n" +
+	"\x12AchievementService\x12T\n" +
+	"\x10ListAchievements\x12$.resource.v1.ListAchievementsRequest\x1a\x18.resource.v1.Achievement0\x01\x12Z\n" +
+	"\x13PreviewAchievements\x12'.resource.v1.PreviewAchievementsRequest\x1a\x18.resource.v1.Achievement0\x01\x12N\n" +
+	"\x0eGetAchievement\x12\".resource.v1.GetAchievementRequest\x1a\x18.resource.v1.Achievement\x12b\n" +
+	"\x11CreateAchievement\x12%.resource.v1.CreateAchievementRequest\x1a&.resource.v1.CreateAchievementResponse\x12R\n" +
+	"\x11DeleteAchievement\x12%.resource.v1.DeleteAchievementRequest\x1a\x16.google.protobuf.Empty\x12f\n" +
+	"\x15GetAchievementHistory\x12).resource.v1.GetAchievementHistoryRequest\x1a .resource.v1.AchievementRevision0\x01\x12b\n" +
+	"\x11RevertAchievement\x12%.resource.v1.RevertAchievementRequest\x1a&.resource.v1.RevertAchievementResponse\x12n\n" +
+	"\x15UpdateAchievementIcon\x12).resource.v1.UpdateAchievementIconRequest\x1a*.resource.v1.UpdateAchievementIconResponse\x12d\n" +
+	"\x19UpdateAchievementSchedule\x12-.resource.v1.UpdateAchievementScheduleRequest\x1a\x18.resource.v1.Achievement\x12J\n" +
+	"\rConfirmUpload\x12!.resource.v1.ConfirmUploadRequest\x1a\x16.google.protobuf.EmptyBBZ@github.com/anh-nguyen/resource-server/api/resource/v1;resourcev1b\x06proto3"
+
+var (
+	file_api_resource_v1_resource_proto_rawDescOnce sync.Once
+	file_api_resource_v1_resource_proto_rawDescData []byte
+)
+
+func file_api_resource_v1_resource_proto_rawDescGZIP() []byte {
+	file_api_resource_v1_resource_proto_rawDescOnce.Do(func() {
+		file_api_resource_v1_resource_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_resource_v1_resource_proto_rawDesc), len(file_api_resource_v1_resource_proto_rawDesc)))
+	})
+	return file_api_resource_v1_resource_proto_rawDescData
+}
+
+var file_api_resource_v1_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
+var file_api_resource_v1_resource_proto_goTypes = []any{
+	(*ListDefinitionsRequest)(nil),           // 0: resource.v1.ListDefinitionsRequest
+	(*GetDefinitionRequest)(nil),             // 1: resource.v1.GetDefinitionRequest
+	(*PathDefinition)(nil),                   // 2: resource.v1.PathDefinition
+	(*PathParameter)(nil),                    // 3: resource.v1.PathParameter
+	(*PathPatterns)(nil),                     // 4: resource.v1.PathPatterns
+	(*StorageDefaults)(nil),                  // 5: resource.v1.StorageDefaults
+	(*CacheControlDefaults)(nil),             // 6: resource.v1.CacheControlDefaults
+	(*ResolveDefinitionRequest)(nil),         // 7: resource.v1.ResolveDefinitionRequest
+	(*ResolveDefinitionResponse)(nil),        // 8: resource.v1.ResolveDefinitionResponse
+	(*ResolvedProviderPath)(nil),             // 9: resource.v1.ResolvedProviderPath
+	(*ListProvidersRequest)(nil),             // 10: resource.v1.ListProvidersRequest
+	(*GetProviderRequest)(nil),               // 11: resource.v1.GetProviderRequest
+	(*Provider)(nil),                         // 12: resource.v1.Provider
+	(*ProviderCapabilities)(nil),             // 13: resource.v1.ProviderCapabilities
+	(*MultipartCapabilities)(nil),            // 14: resource.v1.MultipartCapabilities
+	(*ListFilesRequest)(nil),                 // 15: resource.v1.ListFilesRequest
+	(*FileListPage)(nil),                     // 16: resource.v1.FileListPage
+	(*FileInfo)(nil),                         // 17: resource.v1.FileInfo
+	(*GenerateUploadURLRequest)(nil),         // 18: resource.v1.GenerateUploadURLRequest
+	(*UploadMetadata)(nil),                   // 19: resource.v1.UploadMetadata
+	(*Checksum)(nil),                         // 20: resource.v1.Checksum
+	(*GenerateDownloadURLRequest)(nil),       // 21: resource.v1.GenerateDownloadURLRequest
+	(*SignedURL)(nil),                        // 22: resource.v1.SignedURL
+	(*UploadConstraints)(nil),                // 23: resource.v1.UploadConstraints
+	(*InitMultipartUploadRequest)(nil),       // 24: resource.v1.InitMultipartUploadRequest
+	(*InitMultipartUploadResponse)(nil),      // 25: resource.v1.InitMultipartUploadResponse
+	(*GetMultipartURLsRequest)(nil),          // 26: resource.v1.GetMultipartURLsRequest
+	(*PartRequest)(nil),                      // 27: resource.v1.PartRequest
+	(*GetMultipartURLsResponse)(nil),         // 28: resource.v1.GetMultipartURLsResponse
+	(*MultipartPartURL)(nil),                 // 29: resource.v1.MultipartPartURL
+	(*ListUploadsRequest)(nil),               // 30: resource.v1.ListUploadsRequest
+	(*GetUploadRequest)(nil),                 // 31: resource.v1.GetUploadRequest
+	(*Upload)(nil),                           // 32: resource.v1.Upload
+	(*AbortUploadRequest)(nil),               // 33: resource.v1.AbortUploadRequest
+	(*AbortUploadResponse)(nil),              // 34: resource.v1.AbortUploadResponse
+	(*RunJanitorRequest)(nil),                // 35: resource.v1.RunJanitorRequest
+	(*JanitorResult)(nil),                    // 36: resource.v1.JanitorResult
+	(*JanitorUploadOutcome)(nil),             // 37: resource.v1.JanitorUploadOutcome
+	(*ListAchievementsRequest)(nil),          // 38: resource.v1.ListAchievementsRequest
+	(*PreviewAchievementsRequest)(nil),       // 39: resource.v1.PreviewAchievementsRequest
+	(*GetAchievementRequest)(nil),            // 40: resource.v1.GetAchievementRequest
+	(*Achievement)(nil),                      // 41: resource.v1.Achievement
+	(*CreateAchievementRequest)(nil),         // 42: resource.v1.CreateAchievementRequest
+	(*CreateAchievementResponse)(nil),        // 43: resource.v1.CreateAchievementResponse
+	(*UploadInfo)(nil),                       // 44: resource.v1.UploadInfo
+	(*DeleteAchievementRequest)(nil),         // 45: resource.v1.DeleteAchievementRequest
+	(*GetAchievementHistoryRequest)(nil),     // 46: resource.v1.GetAchievementHistoryRequest
+	(*AchievementRevision)(nil),              // 47: resource.v1.AchievementRevision
+	(*FieldChange)(nil),                      // 48: resource.v1.FieldChange
+	(*RevertAchievementRequest)(nil),         // 49: resource.v1.RevertAchievementRequest
+	(*RevertAchievementResponse)(nil),        // 50: resource.v1.RevertAchievementResponse
+	(*UpdateAchievementIconRequest)(nil),     // 51: resource.v1.UpdateAchievementIconRequest
+	(*UpdateAchievementIconResponse)(nil),    // 52: resource.v1.UpdateAchievementIconResponse
+	(*UpdateAchievementScheduleRequest)(nil), // 53: resource.v1.UpdateAchievementScheduleRequest
+	(*ConfirmUploadRequest)(nil),             // 54: resource.v1.ConfirmUploadRequest
+	(*PartETag)(nil),                         // 55: resource.v1.PartETag
+	nil,                                      // 56: resource.v1.PathDefinition.PatternsEntry
+	nil,                                      // 57: resource.v1.PathPatterns.PatternsEntry
+	nil,                                      // 58: resource.v1.StorageDefaults.CustomHeadersEntry
+	nil,                                      // 59: resource.v1.ResolveDefinitionRequest.ParametersEntry
+	nil,                                      // 60: resource.v1.ResolvedProviderPath.ParametersEntry
+	nil,                                      // 61: resource.v1.ResolvedProviderPath.ErrorsEntry
+	nil,                                      // 62: resource.v1.ListFilesRequest.ParametersEntry
+	nil,                                      // 63: resource.v1.GenerateUploadURLRequest.ParametersEntry
+	nil,                                      // 64: resource.v1.UploadMetadata.CustomHeadersEntry
+	nil,                                      // 65: resource.v1.GenerateDownloadURLRequest.ResponseHeadersEntry
+	nil,                                      // 66: resource.v1.SignedURL.HeadersEntry
+	nil,                                      // 67: resource.v1.SignedURL.ResolvedParametersEntry
+	nil,                                      // 68: resource.v1.InitMultipartUploadRequest.ParametersEntry
+	(*durationpb.Duration)(nil),              // 69: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),            // 70: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                  // 71: google.protobuf.Struct
+	(*structpb.Value)(nil),                   // 72: google.protobuf.Value
+	(*emptypb.Empty)(nil),                    // 73: google.protobuf.Empty
+}
+var file_api_resource_v1_resource_proto_depIdxs = []int32{
+	3,  // 0: resource.v1.PathDefinition.parameters:type_name -> resource.v1.PathParameter
+	56, // 1: resource.v1.PathDefinition.patterns:type_name -> resource.v1.PathDefinition.PatternsEntry
+	5,  // 2: resource.v1.PathDefinition.default_storage_metadata:type_name -> resource.v1.StorageDefaults
+	2,  // 3: resource.v1.PathDefinition.children:type_name -> resource.v1.PathDefinition
+	57, // 4: resource.v1.PathPatterns.patterns:type_name -> resource.v1.PathPatterns.PatternsEntry
+	6,  // 5: resource.v1.StorageDefaults.cache_control:type_name -> resource.v1.CacheControlDefaults
+	58, // 6: resource.v1.StorageDefaults.custom_headers:type_name -> resource.v1.StorageDefaults.CustomHeadersEntry
+	59, // 7: resource.v1.ResolveDefinitionRequest.parameters:type_name -> resource.v1.ResolveDefinitionRequest.ParametersEntry
+	9,  // 8: resource.v1.ResolveDefinitionResponse.providers:type_name -> resource.v1.ResolvedProviderPath
+	60, // 9: resource.v1.ResolvedProviderPath.parameters:type_name -> resource.v1.ResolvedProviderPath.ParametersEntry
+	61, // 10: resource.v1.ResolvedProviderPath.errors:type_name -> resource.v1.ResolvedProviderPath.ErrorsEntry
+	13, // 11: resource.v1.Provider.capabilities:type_name -> resource.v1.ProviderCapabilities
+	69, // 12: resource.v1.ProviderCapabilities.max_expiry:type_name -> google.protobuf.Duration
+	69, // 13: resource.v1.ProviderCapabilities.min_expiry:type_name -> google.protobuf.Duration
+	14, // 14: resource.v1.ProviderCapabilities.multipart:type_name -> resource.v1.MultipartCapabilities
+	62, // 15: resource.v1.ListFilesRequest.parameters:type_name -> resource.v1.ListFilesRequest.ParametersEntry
+	70, // 16: resource.v1.ListFilesRequest.modified_after:type_name -> google.protobuf.Timestamp
+	70, // 17: resource.v1.ListFilesRequest.modified_before:type_name -> google.protobuf.Timestamp
+	17, // 18: resource.v1.FileListPage.files:type_name -> resource.v1.FileInfo
+	70, // 19: resource.v1.FileInfo.last_modified:type_name -> google.protobuf.Timestamp
+	71, // 20: resource.v1.FileInfo.metadata:type_name -> google.protobuf.Struct
+	63, // 21: resource.v1.GenerateUploadURLRequest.parameters:type_name -> resource.v1.GenerateUploadURLRequest.ParametersEntry
+	69, // 22: resource.v1.GenerateUploadURLRequest.expiry:type_name -> google.protobuf.Duration
+	19, // 23: resource.v1.GenerateUploadURLRequest.metadata:type_name -> resource.v1.UploadMetadata
+	20, // 24: resource.v1.GenerateUploadURLRequest.checksum:type_name -> resource.v1.Checksum
+	64, // 25: resource.v1.UploadMetadata.custom_headers:type_name -> resource.v1.UploadMetadata.CustomHeadersEntry
+	69, // 26: resource.v1.GenerateDownloadURLRequest.expiry:type_name -> google.protobuf.Duration
+	65, // 27: resource.v1.GenerateDownloadURLRequest.response_headers:type_name -> resource.v1.GenerateDownloadURLRequest.ResponseHeadersEntry
+	66, // 28: resource.v1.SignedURL.headers:type_name -> resource.v1.SignedURL.HeadersEntry
+	70, // 29: resource.v1.SignedURL.expires_at:type_name -> google.protobuf.Timestamp
+	67, // 30: resource.v1.SignedURL.resolved_parameters:type_name -> resource.v1.SignedURL.ResolvedParametersEntry
+	23, // 31: resource.v1.SignedURL.constraints:type_name -> resource.v1.UploadConstraints
+	20, // 32: resource.v1.UploadConstraints.checksum:type_name -> resource.v1.Checksum
+	68, // 33: resource.v1.InitMultipartUploadRequest.parameters:type_name -> resource.v1.InitMultipartUploadRequest.ParametersEntry
+	19, // 34: resource.v1.InitMultipartUploadRequest.metadata:type_name -> resource.v1.UploadMetadata
+	27, // 35: resource.v1.GetMultipartURLsRequest.parts:type_name -> resource.v1.PartRequest
+	20, // 36: resource.v1.PartRequest.checksum:type_name -> resource.v1.Checksum
+	29, // 37: resource.v1.GetMultipartURLsResponse.part_urls:type_name -> resource.v1.MultipartPartURL
+	22, // 38: resource.v1.GetMultipartURLsResponse.complete_url:type_name -> resource.v1.SignedURL
+	22, // 39: resource.v1.GetMultipartURLsResponse.abort_url:type_name -> resource.v1.SignedURL
+	22, // 40: resource.v1.MultipartPartURL.url:type_name -> resource.v1.SignedURL
+	71, // 41: resource.v1.Upload.path_parameters:type_name -> google.protobuf.Struct
+	70, // 42: resource.v1.Upload.created_at:type_name -> google.protobuf.Timestamp
+	70, // 43: resource.v1.Upload.updated_at:type_name -> google.protobuf.Timestamp
+	70, // 44: resource.v1.Upload.completed_at:type_name -> google.protobuf.Timestamp
+	70, // 45: resource.v1.Upload.expires_at:type_name -> google.protobuf.Timestamp
+	32, // 46: resource.v1.AbortUploadResponse.upload:type_name -> resource.v1.Upload
+	37, // 47: resource.v1.JanitorResult.uploads:type_name -> resource.v1.JanitorUploadOutcome
+	70, // 48: resource.v1.JanitorUploadOutcome.expires_at:type_name -> google.protobuf.Timestamp
+	70, // 49: resource.v1.PreviewAchievementsRequest.at:type_name -> google.protobuf.Timestamp
+	70, // 50: resource.v1.Achievement.publish_at:type_name -> google.protobuf.Timestamp
+	70, // 51: resource.v1.Achievement.unpublish_at:type_name -> google.protobuf.Timestamp
+	71, // 52: resource.v1.Achievement.metadata:type_name -> google.protobuf.Struct
+	70, // 53: resource.v1.Achievement.created_at:type_name -> google.protobuf.Timestamp
+	70, // 54: resource.v1.Achievement.updated_at:type_name -> google.protobuf.Timestamp
+	70, // 55: resource.v1.CreateAchievementRequest.publish_at:type_name -> google.protobuf.Timestamp
+	70, // 56: resource.v1.CreateAchievementRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	44, // 57: resource.v1.CreateAchievementResponse.upload:type_name -> resource.v1.UploadInfo
+	70, // 58: resource.v1.UploadInfo.expires_at:type_name -> google.protobuf.Timestamp
+	48, // 59: resource.v1.AchievementRevision.changes:type_name -> resource.v1.FieldChange
+	41, // 60: resource.v1.AchievementRevision.previous:type_name -> resource.v1.Achievement
+	70, // 61: resource.v1.AchievementRevision.created_at:type_name -> google.protobuf.Timestamp
+	72, // 62: resource.v1.FieldChange.old:type_name -> google.protobuf.Value
+	72, // 63: resource.v1.FieldChange.new:type_name -> google.protobuf.Value
+	41, // 64: resource.v1.RevertAchievementResponse.achievement:type_name -> resource.v1.Achievement
+	70, // 65: resource.v1.UpdateAchievementIconResponse.expires_at:type_name -> google.protobuf.Timestamp
+	23, // 66: resource.v1.UpdateAchievementIconResponse.constraints:type_name -> resource.v1.UploadConstraints
+	70, // 67: resource.v1.UpdateAchievementScheduleRequest.publish_at:type_name -> google.protobuf.Timestamp
+	70, // 68: resource.v1.UpdateAchievementScheduleRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	55, // 69: resource.v1.ConfirmUploadRequest.etags:type_name -> resource.v1.PartETag
+	4,  // 70: resource.v1.PathDefinition.PatternsEntry.value:type_name -> resource.v1.PathPatterns
+	0,  // 71: resource.v1.DefinitionService.ListDefinitions:input_type -> resource.v1.ListDefinitionsRequest
+	1,  // 72: resource.v1.DefinitionService.GetDefinition:input_type -> resource.v1.GetDefinitionRequest
+	7,  // 73: resource.v1.DefinitionService.ResolveDefinition:input_type -> resource.v1.ResolveDefinitionRequest
+	10, // 74: resource.v1.ProviderService.ListProviders:input_type -> resource.v1.ListProvidersRequest
+	11, // 75: resource.v1.ProviderService.GetProvider:input_type -> resource.v1.GetProviderRequest
+	15, // 76: resource.v1.FileService.ListFiles:input_type -> resource.v1.ListFilesRequest
+	18, // 77: resource.v1.FileService.GenerateUploadURL:input_type -> resource.v1.GenerateUploadURLRequest
+	21, // 78: resource.v1.FileService.GenerateDownloadURL:input_type -> resource.v1.GenerateDownloadURLRequest
+	24, // 79: resource.v1.MultipartService.InitMultipartUpload:input_type -> resource.v1.InitMultipartUploadRequest
+	26, // 80: resource.v1.MultipartService.GetMultipartURLs:input_type -> resource.v1.GetMultipartURLsRequest
+	30, // 81: resource.v1.UploadService.ListUploads:input_type -> resource.v1.ListUploadsRequest
+	31, // 82: resource.v1.UploadService.GetUpload:input_type -> resource.v1.GetUploadRequest
+	33, // 83: resource.v1.UploadService.AbortUpload:input_type -> resource.v1.AbortUploadRequest
+	35, // 84: resource.v1.UploadService.RunJanitor:input_type -> resource.v1.RunJanitorRequest
+	38, // 85: resource.v1.AchievementService.ListAchievements:input_type -> resource.v1.ListAchievementsRequest
+	39, // 86: resource.v1.AchievementService.PreviewAchievements:input_type -> resource.v1.PreviewAchievementsRequest
+	40, // 87: resource.v1.AchievementService.GetAchievement:input_type -> resource.v1.GetAchievementRequest
+	42, // 88: resource.v1.AchievementService.CreateAchievement:input_type -> resource.v1.CreateAchievementRequest
+	45, // 89: resource.v1.AchievementService.DeleteAchievement:input_type -> resource.v1.DeleteAchievementRequest
+	46, // 90: resource.v1.AchievementService.GetAchievementHistory:input_type -> resource.v1.GetAchievementHistoryRequest
+	49, // 91: resource.v1.AchievementService.RevertAchievement:input_type -> resource.v1.RevertAchievementRequest
+	51, // 92: resource.v1.AchievementService.UpdateAchievementIcon:input_type -> resource.v1.UpdateAchievementIconRequest
+	53, // 93: resource.v1.AchievementService.UpdateAchievementSchedule:input_type -> resource.v1.UpdateAchievementScheduleRequest
+	54, // 94: resource.v1.AchievementService.ConfirmUpload:input_type -> resource.v1.ConfirmUploadRequest
+	2,  // 95: resource.v1.DefinitionService.ListDefinitions:output_type -> resource.v1.PathDefinition
+	2,  // 96: resource.v1.DefinitionService.GetDefinition:output_type -> resource.v1.PathDefinition
+	8,  // 97: resource.v1.DefinitionService.ResolveDefinition:output_type -> resource.v1.ResolveDefinitionResponse
+	12, // 98: resource.v1.ProviderService.ListProviders:output_type -> resource.v1.Provider
+	12, // 99: resource.v1.ProviderService.GetProvider:output_type -> resource.v1.Provider
+	16, // 100: resource.v1.FileService.ListFiles:output_type -> resource.v1.FileListPage
+	22, // 101: resource.v1.FileService.GenerateUploadURL:output_type -> resource.v1.SignedURL
+	22, // 102: resource.v1.FileService.GenerateDownloadURL:output_type -> resource.v1.SignedURL
+	25, // 103: resource.v1.MultipartService.InitMultipartUpload:output_type -> resource.v1.InitMultipartUploadResponse
+	28, // 104: resource.v1.MultipartService.GetMultipartURLs:output_type -> resource.v1.GetMultipartURLsResponse
+	32, // 105: resource.v1.UploadService.ListUploads:output_type -> resource.v1.Upload
+	32, // 106: resource.v1.UploadService.GetUpload:output_type -> resource.v1.Upload
+	34, // 107: resource.v1.UploadService.AbortUpload:output_type -> resource.v1.AbortUploadResponse
+	36, // 108: resource.v1.UploadService.RunJanitor:output_type -> resource.v1.JanitorResult
+	41, // 109: resource.v1.AchievementService.ListAchievements:output_type -> resource.v1.Achievement
+	41, // 110: resource.v1.AchievementService.PreviewAchievements:output_type -> resource.v1.Achievement
+	41, // 111: resource.v1.AchievementService.GetAchievement:output_type -> resource.v1.Achievement
+	43, // 112: resource.v1.AchievementService.CreateAchievement:output_type -> resource.v1.CreateAchievementResponse
+	73, // 113: resource.v1.AchievementService.DeleteAchievement:output_type -> google.protobuf.Empty
+	47, // 114: resource.v1.AchievementService.GetAchievementHistory:output_type -> resource.v1.AchievementRevision
+	50, // 115: resource.v1.AchievementService.RevertAchievement:output_type -> resource.v1.RevertAchievementResponse
+	52, // 116: resource.v1.AchievementService.UpdateAchievementIcon:output_type -> resource.v1.UpdateAchievementIconResponse
+	41, // 117: resource.v1.AchievementService.UpdateAchievementSchedule:output_type -> resource.v1.Achievement
+	73, // 118: resource.v1.AchievementService.ConfirmUpload:output_type -> google.protobuf.Empty
+	95, // [95:119] is the sub-list for method output_type
+	71, // [71:95] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
+}
+
+func init() { file_api_resource_v1_resource_proto_init() }
+func file_api_resource_v1_resource_proto_init() {
+	if File_api_resource_v1_resource_proto != nil {
+		return
+	}
+	file_api_resource_v1_resource_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_resource_v1_resource_proto_msgTypes[15].OneofWrappers = []any{}
+	file_api_resource_v1_resource_proto_msgTypes[32].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_resource_v1_resource_proto_rawDesc), len(file_api_resource_v1_resource_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   69,
+			NumExtensions: 0,
+			NumServices:   6,
+		},
+		GoTypes:           file_api_resource_v1_resource_proto_goTypes,
+		DependencyIndexes: file_api_resource_v1_resource_proto_depIdxs,
+		MessageInfos:      file_api_resource_v1_resource_proto_msgTypes,
+	}.Build()
+	File_api_resource_v1_resource_proto = out.File
+	file_api_resource_v1_resource_proto_goTypes = nil
+	file_api_resource_v1_resource_proto_depIdxs = nil
+}
@@ -0,0 +1,1385 @@
+// The gRPC API of the resource server. It mirrors the REST API under /api/v1
+// and is served by the same process on the port set by server.grpc_port.
+//
+// Regenerate the Go code with `make proto` after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v29.3.0
+// source: api/resource/v1/resource.proto
+
+package resourcev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DefinitionService_ListDefinitions_FullMethodName   = "/resource.v1.DefinitionService/ListDefinitions"
+	DefinitionService_GetDefinition_FullMethodName     = "/resource.v1.DefinitionService/GetDefinition"
+	DefinitionService_ResolveDefinition_FullMethodName = "/resource.v1.DefinitionService/ResolveDefinition"
+)
+
+// DefinitionServiceClient is the client API for DefinitionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DefinitionService describes the resource path definitions
+type DefinitionServiceClient interface {
+	// ListDefinitions streams the root definitions, each with its children
+	ListDefinitions(ctx context.Context, in *ListDefinitionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathDefinition], error)
+	GetDefinition(ctx context.Context, in *GetDefinitionRequest, opts ...grpc.CallOption) (*PathDefinition, error)
+	// ResolveDefinition resolves a definition's paths without touching storage
+	ResolveDefinition(ctx context.Context, in *ResolveDefinitionRequest, opts ...grpc.CallOption) (*ResolveDefinitionResponse, error)
+}
+
+type definitionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDefinitionServiceClient(cc grpc.ClientConnInterface) DefinitionServiceClient {
+	return &definitionServiceClient{cc}
+}
+
+func (c *definitionServiceClient) ListDefinitions(ctx context.Context, in *ListDefinitionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PathDefinition], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DefinitionService_ServiceDesc.Streams[0], DefinitionService_ListDefinitions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDefinitionsRequest, PathDefinition]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DefinitionService_ListDefinitionsClient = grpc.ServerStreamingClient[PathDefinition]
+
+func (c *definitionServiceClient) GetDefinition(ctx context.Context, in *GetDefinitionRequest, opts ...grpc.CallOption) (*PathDefinition, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathDefinition)
+	err := c.cc.Invoke(ctx, DefinitionService_GetDefinition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *definitionServiceClient) ResolveDefinition(ctx context.Context, in *ResolveDefinitionRequest, opts ...grpc.CallOption) (*ResolveDefinitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveDefinitionResponse)
+	err := c.cc.Invoke(ctx, DefinitionService_ResolveDefinition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DefinitionServiceServer is the server API for DefinitionService service.
+// All implementations must embed UnimplementedDefinitionServiceServer
+// for forward compatibility.
+//
+// DefinitionService describes the resource path definitions
+type DefinitionServiceServer interface {
+	// ListDefinitions streams the root definitions, each with its children
+	ListDefinitions(*ListDefinitionsRequest, grpc.ServerStreamingServer[PathDefinition]) error
+	GetDefinition(context.Context, *GetDefinitionRequest) (*PathDefinition, error)
+	// ResolveDefinition resolves a definition's paths without touching storage
+	ResolveDefinition(context.Context, *ResolveDefinitionRequest) (*ResolveDefinitionResponse, error)
+	mustEmbedUnimplementedDefinitionServiceServer()
+}
+
+// UnimplementedDefinitionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDefinitionServiceServer struct{}
+
+func (UnimplementedDefinitionServiceServer) ListDefinitions(*ListDefinitionsRequest, grpc.ServerStreamingServer[PathDefinition]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDefinitions not implemented")
+}
+func (UnimplementedDefinitionServiceServer) GetDefinition(context.Context, *GetDefinitionRequest) (*PathDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefinition not implemented")
+}
+func (UnimplementedDefinitionServiceServer) ResolveDefinition(context.Context, *ResolveDefinitionRequest) (*ResolveDefinitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDefinition not implemented")
+}
+func (UnimplementedDefinitionServiceServer) mustEmbedUnimplementedDefinitionServiceServer() {}
+func (UnimplementedDefinitionServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDefinitionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DefinitionServiceServer will
+// result in compilation errors.
+type UnsafeDefinitionServiceServer interface {
+	mustEmbedUnimplementedDefinitionServiceServer()
+}
+
+func RegisterDefinitionServiceServer(s grpc.ServiceRegistrar, srv DefinitionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDefinitionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DefinitionService_ServiceDesc, srv)
+}
+
+func _DefinitionService_ListDefinitions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDefinitionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DefinitionServiceServer).ListDefinitions(m, &grpc.GenericServerStream[ListDefinitionsRequest, PathDefinition]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DefinitionService_ListDefinitionsServer = grpc.ServerStreamingServer[PathDefinition]
+
+func _DefinitionService_GetDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DefinitionServiceServer).GetDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DefinitionService_GetDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DefinitionServiceServer).GetDefinition(ctx, req.(*GetDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DefinitionService_ResolveDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DefinitionServiceServer).ResolveDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DefinitionService_ResolveDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DefinitionServiceServer).ResolveDefinition(ctx, req.(*ResolveDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DefinitionService_ServiceDesc is the grpc.ServiceDesc for DefinitionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DefinitionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.DefinitionService",
+	HandlerType: (*DefinitionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDefinition",
+			Handler:    _DefinitionService_GetDefinition_Handler,
+		},
+		{
+			MethodName: "ResolveDefinition",
+			Handler:    _DefinitionService_ResolveDefinition_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListDefinitions",
+			Handler:       _DefinitionService_ListDefinitions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/resource/v1/resource.proto",
+}
+
+const (
+	ProviderService_ListProviders_FullMethodName = "/resource.v1.ProviderService/ListProviders"
+	ProviderService_GetProvider_FullMethodName   = "/resource.v1.ProviderService/GetProvider"
+)
+
+// ProviderServiceClient is the client API for ProviderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProviderService describes the configured storage providers
+type ProviderServiceClient interface {
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Provider], error)
+	GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*Provider, error)
+}
+
+type providerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProviderServiceClient(cc grpc.ClientConnInterface) ProviderServiceClient {
+	return &providerServiceClient{cc}
+}
+
+func (c *providerServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Provider], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProviderService_ServiceDesc.Streams[0], ProviderService_ListProviders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListProvidersRequest, Provider]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProviderService_ListProvidersClient = grpc.ServerStreamingClient[Provider]
+
+func (c *providerServiceClient) GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*Provider, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, ProviderService_GetProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProviderServiceServer is the server API for ProviderService service.
+// All implementations must embed UnimplementedProviderServiceServer
+// for forward compatibility.
+//
+// ProviderService describes the configured storage providers
+type ProviderServiceServer interface {
+	ListProviders(*ListProvidersRequest, grpc.ServerStreamingServer[Provider]) error
+	GetProvider(context.Context, *GetProviderRequest) (*Provider, error)
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+// UnimplementedProviderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProviderServiceServer struct{}
+
+func (UnimplementedProviderServiceServer) ListProviders(*ListProvidersRequest, grpc.ServerStreamingServer[Provider]) error {
+	return status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedProviderServiceServer) GetProvider(context.Context, *GetProviderRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvider not implemented")
+}
+func (UnimplementedProviderServiceServer) mustEmbedUnimplementedProviderServiceServer() {}
+func (UnimplementedProviderServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeProviderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProviderServiceServer will
+// result in compilation errors.
+type UnsafeProviderServiceServer interface {
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+func RegisterProviderServiceServer(s grpc.ServiceRegistrar, srv ProviderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProviderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProviderService_ServiceDesc, srv)
+}
+
+func _ProviderService_ListProviders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProvidersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProviderServiceServer).ListProviders(m, &grpc.GenericServerStream[ListProvidersRequest, Provider]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProviderService_ListProvidersServer = grpc.ServerStreamingServer[Provider]
+
+func _ProviderService_GetProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).GetProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_GetProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).GetProvider(ctx, req.(*GetProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProviderService_ServiceDesc is the grpc.ServiceDesc for ProviderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProviderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.ProviderService",
+	HandlerType: (*ProviderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProvider",
+			Handler:    _ProviderService_GetProvider_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListProviders",
+			Handler:       _ProviderService_ListProviders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/resource/v1/resource.proto",
+}
+
+const (
+	FileService_ListFiles_FullMethodName           = "/resource.v1.FileService/ListFiles"
+	FileService_GenerateUploadURL_FullMethodName   = "/resource.v1.FileService/GenerateUploadURL"
+	FileService_GenerateDownloadURL_FullMethodName = "/resource.v1.FileService/GenerateDownloadURL"
+)
+
+// FileServiceClient is the client API for FileService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FileService lists files and signs URLs for transferring them
+type FileServiceClient interface {
+	// ListFiles streams one message per page of the listing, following
+	// continuation tokens until the listing or max_pages is exhausted
+	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileListPage], error)
+	GenerateUploadURL(ctx context.Context, in *GenerateUploadURLRequest, opts ...grpc.CallOption) (*SignedURL, error)
+	GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*SignedURL, error)
+}
+
+type fileServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFileServiceClient(cc grpc.ClientConnInterface) FileServiceClient {
+	return &fileServiceClient{cc}
+}
+
+func (c *fileServiceClient) ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileListPage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[0], FileService_ListFiles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListFilesRequest, FileListPage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ListFilesClient = grpc.ServerStreamingClient[FileListPage]
+
+func (c *fileServiceClient) GenerateUploadURL(ctx context.Context, in *GenerateUploadURLRequest, opts ...grpc.CallOption) (*SignedURL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignedURL)
+	err := c.cc.Invoke(ctx, FileService_GenerateUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*SignedURL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignedURL)
+	err := c.cc.Invoke(ctx, FileService_GenerateDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FileServiceServer is the server API for FileService service.
+// All implementations must embed UnimplementedFileServiceServer
+// for forward compatibility.
+//
+// FileService lists files and signs URLs for transferring them
+type FileServiceServer interface {
+	// ListFiles streams one message per page of the listing, following
+	// continuation tokens until the listing or max_pages is exhausted
+	ListFiles(*ListFilesRequest, grpc.ServerStreamingServer[FileListPage]) error
+	GenerateUploadURL(context.Context, *GenerateUploadURLRequest) (*SignedURL, error)
+	GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*SignedURL, error)
+	mustEmbedUnimplementedFileServiceServer()
+}
+
+// UnimplementedFileServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFileServiceServer struct{}
+
+func (UnimplementedFileServiceServer) ListFiles(*ListFilesRequest, grpc.ServerStreamingServer[FileListPage]) error {
+	return status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
+}
+func (UnimplementedFileServiceServer) GenerateUploadURL(context.Context, *GenerateUploadURLRequest) (*SignedURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateUploadURL not implemented")
+}
+func (UnimplementedFileServiceServer) GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*SignedURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateDownloadURL not implemented")
+}
+func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
+func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FileServiceServer will
+// result in compilation errors.
+type UnsafeFileServiceServer interface {
+	mustEmbedUnimplementedFileServiceServer()
+}
+
+func RegisterFileServiceServer(s grpc.ServiceRegistrar, srv FileServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFileServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FileService_ServiceDesc, srv)
+}
+
+func _FileService_ListFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListFilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).ListFiles(m, &grpc.GenericServerStream[ListFilesRequest, FileListPage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ListFilesServer = grpc.ServerStreamingServer[FileListPage]
+
+func _FileService_GenerateUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GenerateUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GenerateUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GenerateUploadURL(ctx, req.(*GenerateUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GenerateDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GenerateDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GenerateDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GenerateDownloadURL(ctx, req.(*GenerateDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FileService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.FileService",
+	HandlerType: (*FileServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateUploadURL",
+			Handler:    _FileService_GenerateUploadURL_Handler,
+		},
+		{
+			MethodName: "GenerateDownloadURL",
+			Handler:    _FileService_GenerateDownloadURL_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListFiles",
+			Handler:       _FileService_ListFiles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/resource/v1/resource.proto",
+}
+
+const (
+	MultipartService_InitMultipartUpload_FullMethodName = "/resource.v1.MultipartService/InitMultipartUpload"
+	MultipartService_GetMultipartURLs_FullMethodName    = "/resource.v1.MultipartService/GetMultipartURLs"
+)
+
+// MultipartServiceClient is the client API for MultipartService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MultipartService drives multipart uploads made directly to a provider
+type MultipartServiceClient interface {
+	InitMultipartUpload(ctx context.Context, in *InitMultipartUploadRequest, opts ...grpc.CallOption) (*InitMultipartUploadResponse, error)
+	GetMultipartURLs(ctx context.Context, in *GetMultipartURLsRequest, opts ...grpc.CallOption) (*GetMultipartURLsResponse, error)
+}
+
+type multipartServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMultipartServiceClient(cc grpc.ClientConnInterface) MultipartServiceClient {
+	return &multipartServiceClient{cc}
+}
+
+func (c *multipartServiceClient) InitMultipartUpload(ctx context.Context, in *InitMultipartUploadRequest, opts ...grpc.CallOption) (*InitMultipartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitMultipartUploadResponse)
+	err := c.cc.Invoke(ctx, MultipartService_InitMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *multipartServiceClient) GetMultipartURLs(ctx context.Context, in *GetMultipartURLsRequest, opts ...grpc.CallOption) (*GetMultipartURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMultipartURLsResponse)
+	err := c.cc.Invoke(ctx, MultipartService_GetMultipartURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MultipartServiceServer is the server API for MultipartService service.
+// All implementations must embed UnimplementedMultipartServiceServer
+// for forward compatibility.
+//
+// MultipartService drives multipart uploads made directly to a provider
+type MultipartServiceServer interface {
+	InitMultipartUpload(context.Context, *InitMultipartUploadRequest) (*InitMultipartUploadResponse, error)
+	GetMultipartURLs(context.Context, *GetMultipartURLsRequest) (*GetMultipartURLsResponse, error)
+	mustEmbedUnimplementedMultipartServiceServer()
+}
+
+// UnimplementedMultipartServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMultipartServiceServer struct{}
+
+func (UnimplementedMultipartServiceServer) InitMultipartUpload(context.Context, *InitMultipartUploadRequest) (*InitMultipartUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitMultipartUpload not implemented")
+}
+func (UnimplementedMultipartServiceServer) GetMultipartURLs(context.Context, *GetMultipartURLsRequest) (*GetMultipartURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMultipartURLs not implemented")
+}
+func (UnimplementedMultipartServiceServer) mustEmbedUnimplementedMultipartServiceServer() {}
+func (UnimplementedMultipartServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeMultipartServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MultipartServiceServer will
+// result in compilation errors.
+type UnsafeMultipartServiceServer interface {
+	mustEmbedUnimplementedMultipartServiceServer()
+}
+
+func RegisterMultipartServiceServer(s grpc.ServiceRegistrar, srv MultipartServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMultipartServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MultipartService_ServiceDesc, srv)
+}
+
+func _MultipartService_InitMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitMultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MultipartServiceServer).InitMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MultipartService_InitMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MultipartServiceServer).InitMultipartUpload(ctx, req.(*InitMultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MultipartService_GetMultipartURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMultipartURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MultipartServiceServer).GetMultipartURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MultipartService_GetMultipartURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MultipartServiceServer).GetMultipartURLs(ctx, req.(*GetMultipartURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MultipartService_ServiceDesc is the grpc.ServiceDesc for MultipartService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MultipartService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.MultipartService",
+	HandlerType: (*MultipartServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InitMultipartUpload",
+			Handler:    _MultipartService_InitMultipartUpload_Handler,
+		},
+		{
+			MethodName: "GetMultipartURLs",
+			Handler:    _MultipartService_GetMultipartURLs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/resource/v1/resource.proto",
+}
+
+const (
+	UploadService_ListUploads_FullMethodName = "/resource.v1.UploadService/ListUploads"
+	UploadService_GetUpload_FullMethodName   = "/resource.v1.UploadService/GetUpload"
+	UploadService_AbortUpload_FullMethodName = "/resource.v1.UploadService/AbortUpload"
+	UploadService_RunJanitor_FullMethodName  = "/resource.v1.UploadService/RunJanitor"
+)
+
+// UploadServiceClient is the client API for UploadService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UploadService inspects and aborts tracked uploads
+type UploadServiceClient interface {
+	ListUploads(ctx context.Context, in *ListUploadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Upload], error)
+	GetUpload(ctx context.Context, in *GetUploadRequest, opts ...grpc.CallOption) (*Upload, error)
+	AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error)
+	// RunJanitor aborts expired uploads once, as the background janitor does
+	RunJanitor(ctx context.Context, in *RunJanitorRequest, opts ...grpc.CallOption) (*JanitorResult, error)
+}
+
+type uploadServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUploadServiceClient(cc grpc.ClientConnInterface) UploadServiceClient {
+	return &uploadServiceClient{cc}
+}
+
+func (c *uploadServiceClient) ListUploads(ctx context.Context, in *ListUploadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Upload], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UploadService_ServiceDesc.Streams[0], UploadService_ListUploads_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListUploadsRequest, Upload]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UploadService_ListUploadsClient = grpc.ServerStreamingClient[Upload]
+
+func (c *uploadServiceClient) GetUpload(ctx context.Context, in *GetUploadRequest, opts ...grpc.CallOption) (*Upload, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Upload)
+	err := c.cc.Invoke(ctx, UploadService_GetUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadServiceClient) AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortUploadResponse)
+	err := c.cc.Invoke(ctx, UploadService_AbortUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadServiceClient) RunJanitor(ctx context.Context, in *RunJanitorRequest, opts ...grpc.CallOption) (*JanitorResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JanitorResult)
+	err := c.cc.Invoke(ctx, UploadService_RunJanitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadServiceServer is the server API for UploadService service.
+// All implementations must embed UnimplementedUploadServiceServer
+// for forward compatibility.
+//
+// UploadService inspects and aborts tracked uploads
+type UploadServiceServer interface {
+	ListUploads(*ListUploadsRequest, grpc.ServerStreamingServer[Upload]) error
+	GetUpload(context.Context, *GetUploadRequest) (*Upload, error)
+	AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error)
+	// RunJanitor aborts expired uploads once, as the background janitor does
+	RunJanitor(context.Context, *RunJanitorRequest) (*JanitorResult, error)
+	mustEmbedUnimplementedUploadServiceServer()
+}
+
+// UnimplementedUploadServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUploadServiceServer struct{}
+
+func (UnimplementedUploadServiceServer) ListUploads(*ListUploadsRequest, grpc.ServerStreamingServer[Upload]) error {
+	return status.Errorf(codes.Unimplemented, "method ListUploads not implemented")
+}
+func (UnimplementedUploadServiceServer) GetUpload(context.Context, *GetUploadRequest) (*Upload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpload not implemented")
+}
+func (UnimplementedUploadServiceServer) AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
+func (UnimplementedUploadServiceServer) RunJanitor(context.Context, *RunJanitorRequest) (*JanitorResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJanitor not implemented")
+}
+func (UnimplementedUploadServiceServer) mustEmbedUnimplementedUploadServiceServer() {}
+func (UnimplementedUploadServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeUploadServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UploadServiceServer will
+// result in compilation errors.
+type UnsafeUploadServiceServer interface {
+	mustEmbedUnimplementedUploadServiceServer()
+}
+
+func RegisterUploadServiceServer(s grpc.ServiceRegistrar, srv UploadServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUploadServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UploadService_ServiceDesc, srv)
+}
+
+func _UploadService_ListUploads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUploadsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UploadServiceServer).ListUploads(m, &grpc.GenericServerStream[ListUploadsRequest, Upload]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UploadService_ListUploadsServer = grpc.ServerStreamingServer[Upload]
+
+func _UploadService_GetUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServiceServer).GetUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UploadService_GetUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServiceServer).GetUpload(ctx, req.(*GetUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UploadService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UploadService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServiceServer).AbortUpload(ctx, req.(*AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UploadService_RunJanitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJanitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServiceServer).RunJanitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UploadService_RunJanitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServiceServer).RunJanitor(ctx, req.(*RunJanitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UploadService_ServiceDesc is the grpc.ServiceDesc for UploadService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UploadService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.UploadService",
+	HandlerType: (*UploadServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUpload",
+			Handler:    _UploadService_GetUpload_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _UploadService_AbortUpload_Handler,
+		},
+		{
+			MethodName: "RunJanitor",
+			Handler:    _UploadService_RunJanitor_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUploads",
+			Handler:       _UploadService_ListUploads_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/resource/v1/resource.proto",
+}
+
+const (
+	AchievementService_ListAchievements_FullMethodName          = "/resource.v1.AchievementService/ListAchievements"
+	AchievementService_PreviewAchievements_FullMethodName       = "/resource.v1.AchievementService/PreviewAchievements"
+	AchievementService_GetAchievement_FullMethodName            = "/resource.v1.AchievementService/GetAchievement"
+	AchievementService_CreateAchievement_FullMethodName         = "/resource.v1.AchievementService/CreateAchievement"
+	AchievementService_DeleteAchievement_FullMethodName         = "/resource.v1.AchievementService/DeleteAchievement"
+	AchievementService_GetAchievementHistory_FullMethodName     = "/resource.v1.AchievementService/GetAchievementHistory"
+	AchievementService_RevertAchievement_FullMethodName         = "/resource.v1.AchievementService/RevertAchievement"
+	AchievementService_UpdateAchievementIcon_FullMethodName     = "/resource.v1.AchievementService/UpdateAchievementIcon"
+	AchievementService_UpdateAchievementSchedule_FullMethodName = "/resource.v1.AchievementService/UpdateAchievementSchedule"
+	AchievementService_ConfirmUpload_FullMethodName             = "/resource.v1.AchievementService/ConfirmUpload"
+)
+
+// AchievementServiceClient is the client API for AchievementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AchievementService manages achievements and their icons
+type AchievementServiceClient interface {
+	ListAchievements(ctx context.Context, in *ListAchievementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Achievement], error)
+	// PreviewAchievements streams the achievements active at a given time
+	PreviewAchievements(ctx context.Context, in *PreviewAchievementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Achievement], error)
+	GetAchievement(ctx context.Context, in *GetAchievementRequest, opts ...grpc.CallOption) (*Achievement, error)
+	CreateAchievement(ctx context.Context, in *CreateAchievementRequest, opts ...grpc.CallOption) (*CreateAchievementResponse, error)
+	DeleteAchievement(ctx context.Context, in *DeleteAchievementRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetAchievementHistory streams an achievement's revisions, newest first
+	GetAchievementHistory(ctx context.Context, in *GetAchievementHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AchievementRevision], error)
+	RevertAchievement(ctx context.Context, in *RevertAchievementRequest, opts ...grpc.CallOption) (*RevertAchievementResponse, error)
+	UpdateAchievementIcon(ctx context.Context, in *UpdateAchievementIconRequest, opts ...grpc.CallOption) (*UpdateAchievementIconResponse, error)
+	UpdateAchievementSchedule(ctx context.Context, in *UpdateAchievementScheduleRequest, opts ...grpc.CallOption) (*Achievement, error)
+	ConfirmUpload(ctx context.Context, in *ConfirmUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type achievementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAchievementServiceClient(cc grpc.ClientConnInterface) AchievementServiceClient {
+	return &achievementServiceClient{cc}
+}
+
+func (c *achievementServiceClient) ListAchievements(ctx context.Context, in *ListAchievementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Achievement], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AchievementService_ServiceDesc.Streams[0], AchievementService_ListAchievements_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAchievementsRequest, Achievement]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_ListAchievementsClient = grpc.ServerStreamingClient[Achievement]
+
+func (c *achievementServiceClient) PreviewAchievements(ctx context.Context, in *PreviewAchievementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Achievement], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AchievementService_ServiceDesc.Streams[1], AchievementService_PreviewAchievements_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PreviewAchievementsRequest, Achievement]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_PreviewAchievementsClient = grpc.ServerStreamingClient[Achievement]
+
+func (c *achievementServiceClient) GetAchievement(ctx context.Context, in *GetAchievementRequest, opts ...grpc.CallOption) (*Achievement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Achievement)
+	err := c.cc.Invoke(ctx, AchievementService_GetAchievement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) CreateAchievement(ctx context.Context, in *CreateAchievementRequest, opts ...grpc.CallOption) (*CreateAchievementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAchievementResponse)
+	err := c.cc.Invoke(ctx, AchievementService_CreateAchievement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) DeleteAchievement(ctx context.Context, in *DeleteAchievementRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AchievementService_DeleteAchievement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) GetAchievementHistory(ctx context.Context, in *GetAchievementHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AchievementRevision], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AchievementService_ServiceDesc.Streams[2], AchievementService_GetAchievementHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAchievementHistoryRequest, AchievementRevision]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_GetAchievementHistoryClient = grpc.ServerStreamingClient[AchievementRevision]
+
+func (c *achievementServiceClient) RevertAchievement(ctx context.Context, in *RevertAchievementRequest, opts ...grpc.CallOption) (*RevertAchievementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertAchievementResponse)
+	err := c.cc.Invoke(ctx, AchievementService_RevertAchievement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) UpdateAchievementIcon(ctx context.Context, in *UpdateAchievementIconRequest, opts ...grpc.CallOption) (*UpdateAchievementIconResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAchievementIconResponse)
+	err := c.cc.Invoke(ctx, AchievementService_UpdateAchievementIcon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) UpdateAchievementSchedule(ctx context.Context, in *UpdateAchievementScheduleRequest, opts ...grpc.CallOption) (*Achievement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Achievement)
+	err := c.cc.Invoke(ctx, AchievementService_UpdateAchievementSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *achievementServiceClient) ConfirmUpload(ctx context.Context, in *ConfirmUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AchievementService_ConfirmUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AchievementServiceServer is the server API for AchievementService service.
+// All implementations must embed UnimplementedAchievementServiceServer
+// for forward compatibility.
+//
+// AchievementService manages achievements and their icons
+type AchievementServiceServer interface {
+	ListAchievements(*ListAchievementsRequest, grpc.ServerStreamingServer[Achievement]) error
+	// PreviewAchievements streams the achievements active at a given time
+	PreviewAchievements(*PreviewAchievementsRequest, grpc.ServerStreamingServer[Achievement]) error
+	GetAchievement(context.Context, *GetAchievementRequest) (*Achievement, error)
+	CreateAchievement(context.Context, *CreateAchievementRequest) (*CreateAchievementResponse, error)
+	DeleteAchievement(context.Context, *DeleteAchievementRequest) (*emptypb.Empty, error)
+	// GetAchievementHistory streams an achievement's revisions, newest first
+	GetAchievementHistory(*GetAchievementHistoryRequest, grpc.ServerStreamingServer[AchievementRevision]) error
+	RevertAchievement(context.Context, *RevertAchievementRequest) (*RevertAchievementResponse, error)
+	UpdateAchievementIcon(context.Context, *UpdateAchievementIconRequest) (*UpdateAchievementIconResponse, error)
+	UpdateAchievementSchedule(context.Context, *UpdateAchievementScheduleRequest) (*Achievement, error)
+	ConfirmUpload(context.Context, *ConfirmUploadRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAchievementServiceServer()
+}
+
+// UnimplementedAchievementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAchievementServiceServer struct{}
+
+func (UnimplementedAchievementServiceServer) ListAchievements(*ListAchievementsRequest, grpc.ServerStreamingServer[Achievement]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAchievements not implemented")
+}
+func (UnimplementedAchievementServiceServer) PreviewAchievements(*PreviewAchievementsRequest, grpc.ServerStreamingServer[Achievement]) error {
+	return status.Errorf(codes.Unimplemented, "method PreviewAchievements not implemented")
+}
+func (UnimplementedAchievementServiceServer) GetAchievement(context.Context, *GetAchievementRequest) (*Achievement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAchievement not implemented")
+}
+func (UnimplementedAchievementServiceServer) CreateAchievement(context.Context, *CreateAchievementRequest) (*CreateAchievementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAchievement not implemented")
+}
+func (UnimplementedAchievementServiceServer) DeleteAchievement(context.Context, *DeleteAchievementRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAchievement not implemented")
+}
+func (UnimplementedAchievementServiceServer) GetAchievementHistory(*GetAchievementHistoryRequest, grpc.ServerStreamingServer[AchievementRevision]) error {
+	return status.Errorf(codes.Unimplemented, "method GetAchievementHistory not implemented")
+}
+func (UnimplementedAchievementServiceServer) RevertAchievement(context.Context, *RevertAchievementRequest) (*RevertAchievementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertAchievement not implemented")
+}
+func (UnimplementedAchievementServiceServer) UpdateAchievementIcon(context.Context, *UpdateAchievementIconRequest) (*UpdateAchievementIconResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAchievementIcon not implemented")
+}
+func (UnimplementedAchievementServiceServer) UpdateAchievementSchedule(context.Context, *UpdateAchievementScheduleRequest) (*Achievement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAchievementSchedule not implemented")
+}
+func (UnimplementedAchievementServiceServer) ConfirmUpload(context.Context, *ConfirmUploadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmUpload not implemented")
+}
+func (UnimplementedAchievementServiceServer) mustEmbedUnimplementedAchievementServiceServer() {}
+func (UnimplementedAchievementServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeAchievementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AchievementServiceServer will
+// result in compilation errors.
+type UnsafeAchievementServiceServer interface {
+	mustEmbedUnimplementedAchievementServiceServer()
+}
+
+func RegisterAchievementServiceServer(s grpc.ServiceRegistrar, srv AchievementServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAchievementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AchievementService_ServiceDesc, srv)
+}
+
+func _AchievementService_ListAchievements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAchievementsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AchievementServiceServer).ListAchievements(m, &grpc.GenericServerStream[ListAchievementsRequest, Achievement]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_ListAchievementsServer = grpc.ServerStreamingServer[Achievement]
+
+func _AchievementService_PreviewAchievements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PreviewAchievementsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AchievementServiceServer).PreviewAchievements(m, &grpc.GenericServerStream[PreviewAchievementsRequest, Achievement]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_PreviewAchievementsServer = grpc.ServerStreamingServer[Achievement]
+
+func _AchievementService_GetAchievement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAchievementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).GetAchievement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_GetAchievement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).GetAchievement(ctx, req.(*GetAchievementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_CreateAchievement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAchievementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).CreateAchievement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_CreateAchievement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).CreateAchievement(ctx, req.(*CreateAchievementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_DeleteAchievement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAchievementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).DeleteAchievement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_DeleteAchievement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).DeleteAchievement(ctx, req.(*DeleteAchievementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_GetAchievementHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAchievementHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AchievementServiceServer).GetAchievementHistory(m, &grpc.GenericServerStream[GetAchievementHistoryRequest, AchievementRevision]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AchievementService_GetAchievementHistoryServer = grpc.ServerStreamingServer[AchievementRevision]
+
+func _AchievementService_RevertAchievement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertAchievementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).RevertAchievement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_RevertAchievement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).RevertAchievement(ctx, req.(*RevertAchievementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_UpdateAchievementIcon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAchievementIconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).UpdateAchievementIcon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_UpdateAchievementIcon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).UpdateAchievementIcon(ctx, req.(*UpdateAchievementIconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_UpdateAchievementSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAchievementScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).UpdateAchievementSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_UpdateAchievementSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).UpdateAchievementSchedule(ctx, req.(*UpdateAchievementScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AchievementService_ConfirmUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AchievementServiceServer).ConfirmUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AchievementService_ConfirmUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AchievementServiceServer).ConfirmUpload(ctx, req.(*ConfirmUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AchievementService_ServiceDesc is the grpc.ServiceDesc for AchievementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AchievementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.v1.AchievementService",
+	HandlerType: (*AchievementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAchievement",
+			Handler:    _AchievementService_GetAchievement_Handler,
+		},
+		{
+			MethodName: "CreateAchievement",
+			Handler:    _AchievementService_CreateAchievement_Handler,
+		},
+		{
+			MethodName: "DeleteAchievement",
+			Handler:    _AchievementService_DeleteAchievement_Handler,
+		},
+		{
+			MethodName: "RevertAchievement",
+			Handler:    _AchievementService_RevertAchievement_Handler,
+		},
+		{
+			MethodName: "UpdateAchievementIcon",
+			Handler:    _AchievementService_UpdateAchievementIcon_Handler,
+		},
+		{
+			MethodName: "UpdateAchievementSchedule",
+			Handler:    _AchievementService_UpdateAchievementSchedule_Handler,
+		},
+		{
+			MethodName: "ConfirmUpload",
+			Handler:    _AchievementService_ConfirmUpload_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAchievements",
+			Handler:       _AchievementService_ListAchievements_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PreviewAchievements",
+			Handler:       _AchievementService_PreviewAchievements_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAchievementHistory",
+			Handler:       _AchievementService_GetAchievementHistory_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/resource/v1/resource.proto",
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anh-nguyen/resource-server/pkg/client"
+)
+
+func init() {
+	register("definitions list", "", listDefinitions)
+	register("definitions get", "<name>", getDefinition)
+	register("definitions resolve", "[-scope S] [-scope-value N] [-param name=value ...] <name>", resolveDefinition)
+	register("providers list", "", listProviders)
+	register("providers get", "<name>", getProvider)
+}
+
+func listDefinitions(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("definitions list", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	definitions, err := env.client.ListDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	var walk func(definitions []*client.PathDefinition)
+	walk = func(definitions []*client.PathDefinition) {
+		for _, definition := range definitions {
+			rows = append(rows, definitionRow(definition))
+			walk(definition.Children)
+		}
+	}
+	for i := range definitions {
+		walk([]*client.PathDefinition{&definitions[i]})
+	}
+
+	header := []string{"NAME", "PARENT", "SCOPES", "PROVIDERS", "VERSION", "DEPRECATED"}
+	return env.out.print(definitions, header, rows)
+}
+
+func definitionRow(definition *client.PathDefinition) []string {
+	version := "-"
+	if definition.Version > 0 {
+		version = strconv.Itoa(definition.Version)
+	}
+	return []string{
+		definition.Name,
+		orDash(definition.Parent),
+		orDash(strings.Join(definition.AllowedScopes, ",")),
+		orDash(strings.Join(definition.Providers, ",")),
+		version,
+		strconv.FormatBool(definition.Deprecated),
+	}
+}
+
+func getDefinition(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("definitions get", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	definition, err := env.client.GetDefinition(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if env.out.json {
+		return env.out.print(definition, nil, nil)
+	}
+
+	fields := [][2]string{
+		{"Name", definition.Name},
+		{"Display name", orDash(definition.DisplayName)},
+		{"Description", orDash(definition.Description)},
+		{"Parent", orDash(definition.Parent)},
+		{"Scopes", orDash(strings.Join(definition.AllowedScopes, ","))},
+		{"Providers", orDash(strings.Join(definition.Providers, ","))},
+	}
+	if err := env.out.printFields(definition, fields); err != nil {
+		return err
+	}
+
+	if len(definition.Parameters) > 0 {
+		env.out.w.Write([]byte("\n"))
+		var rows [][]string
+		for _, param := range definition.Parameters {
+			rows = append(rows, []string{
+				param.Name,
+				strconv.FormatBool(param.Required),
+				orDash(param.DefaultValue),
+				orDash(strings.Join(param.Enum, ",")),
+				orDash(param.Description),
+			})
+		}
+		if err := env.out.print(nil, []string{"PARAMETER", "REQUIRED", "DEFAULT", "ENUM", "DESCRIPTION"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(definition.Patterns) > 0 {
+		env.out.w.Write([]byte("\n"))
+		var rows [][]string
+		for provider, patterns := range definition.Patterns {
+			for scope, pattern := range patterns.Patterns {
+				rows = append(rows, []string{provider, scope, patterns.URLType, pattern})
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i][0]+rows[i][1] < rows[j][0]+rows[j][1]
+		})
+		return env.out.print(nil, []string{"PROVIDER", "SCOPE", "URL TYPE", "PATTERN"}, rows)
+	}
+	return nil
+}
+
+func resolveDefinition(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("definitions resolve", flag.ContinueOnError)
+	scope, scopeValue, params := scopeFlags(flags)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	resolved, err := env.client.ResolveDefinition(ctx, flags.Arg(0), &client.ResolvePathRequest{
+		Parameters: params,
+		Scope:      *scope,
+		ScopeValue: int16(*scopeValue),
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, path := range resolved.Providers {
+		var problems []string
+		for name, problem := range path.Errors {
+			problems = append(problems, name+": "+problem)
+		}
+		sort.Strings(problems)
+		rows = append(rows, []string{
+			path.Provider,
+			orDash(path.Path),
+			path.URLType,
+			strconv.FormatBool(path.Valid),
+			orDash(strings.Join(problems, "; ")),
+		})
+	}
+	return env.out.print(resolved, []string{"PROVIDER", "PATH", "URL TYPE", "VALID", "ERRORS"}, rows)
+}
+
+func listProviders(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("providers list", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	providers, err := env.client.ListProviders(ctx)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, provider := range providers {
+		rows = append(rows, providerRow(&provider))
+	}
+	header := []string{"NAME", "READ", "WRITE", "DELETE", "LISTING", "MULTIPART", "MAX UPLOAD"}
+	return env.out.print(providers, header, rows)
+}
+
+func providerRow(provider *client.Provider) []string {
+	caps := provider.Capabilities
+	maxUpload := "-"
+	if caps.MaxUploadSize > 0 {
+		maxUpload = formatBytes(caps.MaxUploadSize)
+	}
+	return []string{
+		provider.Name,
+		strconv.FormatBool(caps.SupportsRead),
+		strconv.FormatBool(caps.SupportsWrite),
+		strconv.FormatBool(caps.SupportsDelete),
+		strconv.FormatBool(caps.SupportsListing),
+		strconv.FormatBool(caps.SupportsMultipart),
+		maxUpload,
+	}
+}
+
+func getProvider(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("providers get", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	provider, err := env.client.GetProvider(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	caps := provider.Capabilities
+	fields := [][2]string{
+		{"Name", provider.Name},
+		{"Read", strconv.FormatBool(caps.SupportsRead)},
+		{"Write", strconv.FormatBool(caps.SupportsWrite)},
+		{"Delete", strconv.FormatBool(caps.SupportsDelete)},
+		{"Listing", strconv.FormatBool(caps.SupportsListing)},
+		{"Metadata", strconv.FormatBool(caps.SupportsMetadata)},
+		{"Signed URLs", strconv.FormatBool(caps.SupportsSignedURLs)},
+		{"Multipart", strconv.FormatBool(caps.SupportsMultipart)},
+		{"Checksums", orDash(strings.Join(caps.SupportsChecksumAlgorithms, ","))},
+		{"Max upload", providerRow(provider)[6]},
+	}
+	if caps.Multipart != nil {
+		fields = append(fields,
+			[2]string{"Min part size", formatBytes(caps.Multipart.MinPartSize)},
+			[2]string{"Max part size", formatBytes(caps.Multipart.MaxPartSize)},
+			[2]string{"Max parts", strconv.Itoa(caps.Multipart.MaxParts)},
+		)
+	}
+	return env.out.printFields(provider, fields)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/anh-nguyen/resource-server/pkg/client"
+)
+
+func init() {
+	register("files list", "[-prefix P] [-delimiter D] [-max-keys N] [-all] [-scope S] [-param name=value ...] <provider> <definition>", listFiles)
+	register("files upload", "[-multipart] [-content-type T] [-resource-id ID] [-scope S] [-param name=value ...] <provider> <definition> <file|->", uploadFile)
+	register("files download", "[-o file|-] [-range bytes=a-b] <provider> <path>", downloadFile)
+	register("files delete", "<provider> <path>", deleteFile)
+}
+
+func listFiles(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("files list", flag.ContinueOnError)
+	scope, scopeValue, params := scopeFlags(flags)
+	prefix := flags.String("prefix", "", "Key prefix relative to the definition's path")
+	delimiter := flags.String("delimiter", "", "Group keys sharing a prefix up to the delimiter")
+	maxKeys := flags.Int("max-keys", 0, "Maximum keys per page")
+	all := flags.Bool("all", false, "Follow continuation tokens to list every page")
+	if err := parseFlags(flags, args, 2); err != nil {
+		return err
+	}
+
+	opts := &client.ListFilesOptions{
+		MaxKeys:    *maxKeys,
+		Prefix:     *prefix,
+		Delimiter:  *delimiter,
+		Scope:      *scope,
+		ScopeValue: int16(*scopeValue),
+		Parameters: params,
+	}
+
+	result := &client.FileList{}
+	for {
+		page, err := env.client.ListFiles(ctx, flags.Arg(0), flags.Arg(1), opts)
+		if err != nil {
+			return err
+		}
+		result.Files = append(result.Files, page.Files...)
+		result.CommonPrefixes = append(result.CommonPrefixes, page.CommonPrefixes...)
+		result.ContinuationToken = page.ContinuationToken
+		result.IsTruncated = page.IsTruncated
+		result.MaxKeys = page.MaxKeys
+
+		if !*all || !page.IsTruncated || page.ContinuationToken == "" {
+			break
+		}
+		opts.ContinuationToken = page.ContinuationToken
+	}
+
+	var rows [][]string
+	for _, prefix := range result.CommonPrefixes {
+		rows = append(rows, []string{prefix, "-", "-", "-"})
+	}
+	for _, file := range result.Files {
+		rows = append(rows, []string{file.Key, formatBytes(file.Size), orDash(file.ContentType), formatTime(file.LastModified)})
+	}
+	if err := env.out.print(result, []string{"KEY", "SIZE", "CONTENT TYPE", "LAST MODIFIED"}, rows); err != nil {
+		return err
+	}
+
+	if !env.out.json && result.IsTruncated {
+		fmt.Fprintf(env.stderr, "More files follow; continue with -all or the token %s\n", result.ContinuationToken)
+	}
+	return nil
+}
+
+func uploadFile(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("files upload", flag.ContinueOnError)
+	scope, scopeValue, params := scopeFlags(flags)
+	multipart := flags.Bool("multipart", false, "Upload parts directly to the provider instead of streaming through the server")
+	contentType := flags.String("content-type", "", "Content type of the file")
+	resourceID := flags.String("resource-id", "", "Resource ID recorded with the upload")
+	partSize := flags.Int64("part-size", 0, "Multipart part size in bytes; defaults to the provider's limits")
+	concurrency := flags.Int("concurrency", 0, "Multipart parts uploaded at once")
+	if err := parseFlags(flags, args, 3); err != nil {
+		return err
+	}
+	provider, definition, name := flags.Arg(0), flags.Arg(1), flags.Arg(2)
+
+	var body io.Reader = os.Stdin
+	var size int64
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		body, size = file, info.Size()
+	}
+
+	var bar *progress
+	if !env.quiet {
+		bar = newProgress(env.stderr, "Uploading", size)
+		body = bar.reader(body)
+	}
+
+	var result any
+	var fields [][2]string
+	if *multipart {
+		uploaded, err := env.client.UploadMultipart(ctx, &client.MultipartInitRequest{
+			DefinitionName: definition,
+			Provider:       provider,
+			Scope:          *scope,
+			ScopeValue:     int16(*scopeValue),
+			ParamResolver:  params,
+		}, body, &client.MultipartOptions{
+			Size:        size,
+			PartSize:    *partSize,
+			Concurrency: *concurrency,
+		})
+		if bar != nil {
+			bar.finish()
+		}
+		if err != nil {
+			return err
+		}
+
+		result = uploaded
+		fields = [][2]string{
+			{"Upload ID", uploaded.UploadID},
+			{"Provider", uploaded.Provider},
+			{"Path", uploaded.Path},
+			{"Size", formatBytes(uploaded.Size)},
+			{"Parts", strconv.Itoa(len(uploaded.Parts))},
+		}
+	} else {
+		uploaded, err := env.client.UploadContent(ctx, provider, definition, body, &client.ContentUploadOptions{
+			Scope:         *scope,
+			ScopeValue:    int16(*scopeValue),
+			ResourceID:    *resourceID,
+			Parameters:    params,
+			ContentType:   *contentType,
+			ContentLength: size,
+		})
+		if bar != nil {
+			bar.finish()
+		}
+		if err != nil {
+			return err
+		}
+
+		result = uploaded
+		fields = [][2]string{
+			{"Upload ID", uploaded.UploadID},
+			{"Provider", uploaded.Provider},
+			{"Path", uploaded.Path},
+			{"Size", formatBytes(uploaded.Size)},
+			{"Content type", orDash(uploaded.ContentType)},
+			{"Upload type", uploaded.UploadType},
+			{"Parts", strconv.Itoa(uploaded.Parts)},
+		}
+	}
+
+	return env.out.printFields(result, fields)
+}
+
+func downloadFile(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("files download", flag.ContinueOnError)
+	output := flags.String("o", "", "Output file, or - for stdout; defaults to the file's base name")
+	byteRange := flags.String("range", "", "Byte range to download, e.g. bytes=0-1023")
+	if err := parseFlags(flags, args, 2); err != nil {
+		return err
+	}
+	provider, filePath := flags.Arg(0), flags.Arg(1)
+
+	target := *output
+	if target == "" {
+		target = path.Base(filePath)
+	}
+
+	content, err := env.client.DownloadContent(ctx, provider, filePath, &client.ContentDownloadOptions{Range: *byteRange})
+	if err != nil {
+		return err
+	}
+	defer content.Body.Close()
+
+	var w io.Writer = os.Stdout
+	if target != "-" {
+		file, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var body io.Reader = content.Body
+	var bar *progress
+	if !env.quiet {
+		bar = newProgress(env.stderr, "Downloading", content.ContentLength)
+		body = bar.reader(body)
+	}
+	written, err := io.Copy(w, body)
+	if bar != nil {
+		bar.finish()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Content written to stdout is not mixed with a summary
+	if target == "-" {
+		return nil
+	}
+
+	summary := map[string]any{
+		"path":        filePath,
+		"file":        target,
+		"size":        written,
+		"contentType": content.ContentType,
+		"etag":        content.ETag,
+	}
+	return env.out.printFields(summary, [][2]string{
+		{"Path", filePath},
+		{"File", target},
+		{"Size", formatBytes(written)},
+		{"Content type", orDash(content.ContentType)},
+		{"ETag", orDash(content.ETag)},
+	})
+}
+
+func deleteFile(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("files delete", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 2); err != nil {
+		return err
+	}
+	provider, filePath := flags.Arg(0), flags.Arg(1)
+
+	if err := env.client.DeleteFile(ctx, provider, filePath); err != nil {
+		return err
+	}
+
+	result := map[string]any{"provider": provider, "path": filePath, "deleted": true}
+	return env.out.printFields(result, [][2]string{
+		{"Provider", provider},
+		{"Deleted", filePath},
+	})
+}
@@ -0,0 +1,224 @@
+// Command resctl is an operator tool for the resource server. It talks to the
+// server's HTTP API, so it needs no database or provider credentials.
+//
+// Usage:
+//
+//	resctl [flags] <group> <command> [command flags] [args]
+//
+// Run resctl -h for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/pkg/client"
+)
+
+// command is a resctl subcommand, registered under "<group> <name>"
+type command struct {
+	usage string
+	run   func(ctx context.Context, env *env, args []string) error
+}
+
+var commands = map[string]command{}
+
+func register(name, usage string, run func(ctx context.Context, env *env, args []string) error) {
+	commands[name] = command{usage: usage, run: run}
+}
+
+// env is shared by every command
+type env struct {
+	client *client.Client
+	out    *printer
+	stderr io.Writer
+	quiet  bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("resctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	server := flags.String("server", envOr("RESCTL_SERVER", "http://localhost:8081"), "Resource server base URL (env RESCTL_SERVER)")
+	principal := flags.String("principal", envOr("RESCTL_PRINCIPAL", ""), "Principal recorded as the actor of changes (env RESCTL_PRINCIPAL)")
+	token := flags.String("token", envOr("RESCTL_TOKEN", ""), "Bearer token for the admin API (env RESCTL_TOKEN)")
+	output := flags.String("output", "table", "Output format: table or json")
+	timeout := flags.Duration("timeout", 0, "Overall timeout of the command, e.g. 5m; zero waits until done")
+	quiet := flags.Bool("quiet", false, "Do not report transfer progress")
+	flags.Usage = func() { usage(flags) }
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() < 2 {
+		usage(flags)
+		return 2
+	}
+
+	name := flags.Arg(0) + " " + flags.Arg(1)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "resctl: unknown command %q\n\n", name)
+		usage(flags)
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *output)
+	if err != nil {
+		fmt.Fprintf(stderr, "resctl: %v\n", err)
+		return 2
+	}
+
+	opts := []client.Option{
+		// Transfers may take longer than the client's default timeout;
+		// -timeout bounds the whole command instead
+		client.WithHTTPClient(&http.Client{}),
+	}
+	if *principal != "" {
+		opts = append(opts, client.WithPrincipal(*principal))
+	}
+	if *token != "" {
+		opts = append(opts, client.WithHeader("Authorization", "Bearer "+*token))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	e := &env{
+		client: client.New(*server, opts...),
+		out:    out,
+		stderr: stderr,
+		quiet:  *quiet,
+	}
+	if err := cmd.run(ctx, e, flags.Args()[2:]); err != nil {
+		var usageErr *usageError
+		if !errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "resctl %s: %v\n", name, err)
+			return 1
+		}
+
+		help := errors.Is(usageErr.err, flag.ErrHelp)
+		if !help {
+			fmt.Fprintf(stderr, "resctl %s: %v\n", name, usageErr.err)
+		}
+		fmt.Fprintf(stderr, "usage: resctl %s %s\n", name, cmd.usage)
+		if usageErr.flags != nil {
+			usageErr.flags.SetOutput(stderr)
+			usageErr.flags.PrintDefaults()
+		}
+		if help {
+			return 0
+		}
+		return 2
+	}
+	return 0
+}
+
+func usage(flags *flag.FlagSet) {
+	w := flags.Output()
+	fmt.Fprintf(w, "Usage: resctl [flags] <group> <command> [command flags] [args]\n\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-20s %s\n", name, commands[name].usage)
+	}
+
+	fmt.Fprintf(w, "\nFlags:\n")
+	flags.PrintDefaults()
+}
+
+// usageError is a command invoked with invalid arguments, or with -h. The
+// command's flags are printed with its usage.
+type usageError struct {
+	err   error
+	flags *flag.FlagSet
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+// parseFlags parses the flags of a command and checks its positional
+// argument count
+func parseFlags(flags *flag.FlagSet, args []string, positional int) error {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return &usageError{err: err, flags: flags}
+	}
+	if flags.NArg() != positional {
+		return &usageError{
+			err:   fmt.Errorf("expected %d argument(s), got %d", positional, flags.NArg()),
+			flags: flags,
+		}
+	}
+	return nil
+}
+
+// paramsFlag collects repeated -param name=value flags
+type paramsFlag map[string]string
+
+func (p paramsFlag) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, value := range p {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (p paramsFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("parameter %q is not name=value", value)
+	}
+	p[name] = val
+	return nil
+}
+
+// scopeFlags registers the flags resolving a definition's path
+func scopeFlags(flags *flag.FlagSet) (scope *string, scopeValue *int, params paramsFlag) {
+	params = paramsFlag{}
+	scope = flags.String("scope", "", "Path scope: G, A or CA")
+	scopeValue = flags.Int("scope-value", 0, "Scope value for app scopes")
+	flags.Var(params, "param", "Path parameter as name=value; repeatable")
+	return scope, scopeValue, params
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
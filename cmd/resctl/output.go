@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// printer writes command results as an aligned table or as JSON
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table":
+		return &printer{w: w}, nil
+	case "json":
+		return &printer{w: w, json: true}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, expected table or json", format)
+}
+
+// print writes v as JSON, or the header and rows as a table
+func (p *printer) print(v any, header []string, rows [][]string) error {
+	if p.json {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printFields writes v as JSON, or the fields as a two-column table
+func (p *printer) printFields(v any, fields [][2]string) error {
+	if p.json {
+		return p.print(v, nil, nil)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
+
+// progress reports the bytes transferred through it on w, at most a few
+// times a second. A total of zero or less reports bytes without percentage.
+type progress struct {
+	w     io.Writer
+	label string
+	total int64
+
+	mu       sync.Mutex
+	done     int64
+	reported time.Time
+}
+
+func newProgress(w io.Writer, label string, total int64) *progress {
+	return &progress{w: w, label: label, total: total}
+}
+
+func (p *progress) add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+	if time.Since(p.reported) >= 200*time.Millisecond {
+		p.report()
+	}
+}
+
+// finish reports the final count and ends the progress line
+func (p *progress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.report()
+	fmt.Fprintln(p.w)
+}
+
+func (p *progress) report() {
+	p.reported = time.Now()
+	if p.total > 0 {
+		fmt.Fprintf(p.w, "\r%s %s / %s (%.0f%%)", p.label, formatBytes(p.done), formatBytes(p.total), float64(p.done)*100/float64(p.total))
+		return
+	}
+	fmt.Fprintf(p.w, "\r%s %s", p.label, formatBytes(p.done))
+}
+
+// reader wraps r, counting the bytes read from it
+func (p *progress) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, progress: p}
+}
+
+type progressReader struct {
+	r        io.Reader
+	progress *progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.progress.add(int64(n))
+	return n, err
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
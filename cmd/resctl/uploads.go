@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/anh-nguyen/resource-server/pkg/client"
+)
+
+func init() {
+	register("uploads list", "[-status S] [-resource-type T] [-provider P] [-page N] [-page-size N]", listUploads)
+	register("uploads get", "<upload-id>", getUpload)
+	register("uploads abort", "[-reason R] <upload-id>", abortUpload)
+	register("janitor run", "[-dry-run] [-limit N]", runJanitor)
+}
+
+func listUploads(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("uploads list", flag.ContinueOnError)
+	status := flags.String("status", "", "Only uploads with this status, e.g. pending or failed")
+	resourceType := flags.String("resource-type", "", "Only uploads of this resource type, e.g. achievement")
+	provider := flags.String("provider", "", "Only uploads to this provider")
+	page := flags.Int("page", 1, "Page number")
+	pageSize := flags.Int("page-size", 20, "Uploads per page, at most 100")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	uploads, err := env.client.ListUploads(ctx, &client.ListUploadsOptions{
+		PageOptions:  client.PageOptions{Page: *page, PageSize: *pageSize},
+		Status:       *status,
+		ResourceType: *resourceType,
+		Provider:     *provider,
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, upload := range uploads.Uploads {
+		rows = append(rows, []string{
+			upload.ID,
+			upload.ResourceType,
+			upload.UploadType,
+			upload.Status,
+			upload.Provider,
+			upload.StorageKey,
+			uploadParts(upload),
+			formatTime(upload.CreatedAt),
+			formatTime(upload.ExpiresAt),
+		})
+	}
+	header := []string{"ID", "RESOURCE", "TYPE", "STATUS", "PROVIDER", "KEY", "PARTS", "CREATED", "EXPIRES"}
+	return env.out.print(uploads, header, rows)
+}
+
+func uploadParts(upload *client.Upload) string {
+	if upload.TotalParts == nil {
+		return "-"
+	}
+	uploaded := 0
+	if upload.UploadedParts != nil {
+		uploaded = *upload.UploadedParts
+	}
+	return fmt.Sprintf("%d/%d", uploaded, *upload.TotalParts)
+}
+
+func uploadFields(upload *client.Upload) [][2]string {
+	size := "-"
+	if upload.Size != nil {
+		size = formatBytes(*upload.Size)
+	}
+	completed := "-"
+	if upload.CompletedAt != nil {
+		completed = formatTime(*upload.CompletedAt)
+	}
+
+	return [][2]string{
+		{"ID", upload.ID},
+		{"Resource", upload.ResourceType + " " + upload.ResourceID},
+		{"Field", orDash(upload.ResourceField)},
+		{"Type", upload.UploadType},
+		{"Status", upload.Status},
+		{"Error", orDash(upload.Error)},
+		{"Definition", upload.PathDefinition},
+		{"Provider", upload.Provider},
+		{"Key", upload.StorageKey},
+		{"Size", size},
+		{"Multipart ID", orDash(upload.MultipartID)},
+		{"Parts", uploadParts(upload)},
+		{"Created", formatTime(upload.CreatedAt)},
+		{"Updated", formatTime(upload.UpdatedAt)},
+		{"Completed", completed},
+		{"Expires", formatTime(upload.ExpiresAt)},
+	}
+}
+
+func getUpload(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("uploads get", flag.ContinueOnError)
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	upload, err := env.client.GetUpload(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return env.out.printFields(upload, uploadFields(upload))
+}
+
+func abortUpload(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("uploads abort", flag.ContinueOnError)
+	reason := flags.String("reason", "", "Reason recorded with the aborted upload")
+	if err := parseFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	result, err := env.client.AbortUpload(ctx, flags.Arg(0), *reason)
+	if err != nil {
+		return err
+	}
+
+	fields := append(uploadFields(result.Upload), [2]string{"Provider aborted", strconv.FormatBool(result.ProviderAborted)})
+	return env.out.printFields(result, fields)
+}
+
+func runJanitor(ctx context.Context, env *env, args []string) error {
+	flags := flag.NewFlagSet("janitor run", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Report expired uploads without aborting them")
+	limit := flags.Int("limit", 0, "Maximum expired uploads to handle; defaults to the server's limit")
+	if err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	result, err := env.client.RunUploadJanitor(ctx, &client.RunJanitorRequest{
+		Limit:  *limit,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, outcome := range result.Uploads {
+		rows = append(rows, []string{
+			outcome.ID,
+			outcome.Provider,
+			outcome.StorageKey,
+			formatTime(outcome.ExpiresAt),
+			strconv.FormatBool(outcome.Aborted),
+			strconv.FormatBool(outcome.ProviderAborted),
+			orDash(outcome.Error),
+		})
+	}
+	header := []string{"ID", "PROVIDER", "KEY", "EXPIRED", "ABORTED", "PROVIDER ABORTED", "ERROR"}
+	if err := env.out.print(result, header, rows); err != nil {
+		return err
+	}
+
+	if !env.out.json {
+		fmt.Fprintf(env.stderr, "%d expired, %d aborted, %d failed\n", result.Scanned, result.Aborted, result.Failed)
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d upload(s) could not be aborted", result.Failed)
+	}
+	return nil
+}
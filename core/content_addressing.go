@@ -0,0 +1,49 @@
+package core
+
+import (
+	"path"
+	"strings"
+
+	"avironactive.com/resource/resolver"
+)
+
+// blobDir is the directory below a parent definition's path holding the
+// content-addressed blobs of its children
+const blobDir = ".blobs"
+
+// ContentAddressing opts a definition into deduplicated storage. Confirmed
+// uploads are moved to a key derived from their SHA256 checksum and the
+// logical path is mapped to that blob, so identical files are stored once.
+type ContentAddressing struct {
+	// PathDepth is the number of path segments the definition adds below its
+	// parent's path. Blobs are shared by every file below the parent path.
+	PathDepth int
+}
+
+// AllContentAddressing returns the content addressing of the built-in
+// definitions, keyed by definition name. Definitions left out store their
+// files at their logical paths.
+func AllContentAddressing() map[resolver.DefinitionName]*ContentAddressing {
+	return map[resolver.DefinitionName]*ContentAddressing{
+		AchievementPathName: {PathDepth: 1},
+		WorkoutPathName:     {PathDepth: 2},
+	}
+}
+
+// BlobPath returns the path of the blob holding the contents with the given
+// hex SHA256 digest, for a file stored at logicalPath
+func (c *ContentAddressing) BlobPath(logicalPath, checksumHex string) string {
+	root := strings.TrimSuffix(logicalPath, "/")
+	for range c.PathDepth {
+		root = path.Dir(root)
+	}
+
+	checksumHex = strings.ToLower(checksumHex)
+	return path.Join(root, blobDir, checksumHex[:2], checksumHex)
+}
+
+// IsBlobPath reports whether key is a content-addressed blob rather than a
+// file at its logical path
+func IsBlobPath(key string) bool {
+	return strings.Contains("/"+key, "/"+blobDir+"/")
+}
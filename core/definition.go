@@ -9,7 +9,6 @@ import (
 	"avironactive.com/resource/provider"
 	"avironactive.com/resource/resolver"
 	"avironactive.com/resource/upload"
-	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,6 +19,15 @@ func AllDefinitions() []*resolver.Definition {
 	}
 }
 
+// AllUploadPolicies returns the upload policies of the built-in definitions,
+// keyed by definition name. Definitions without one accept any upload.
+func AllUploadPolicies() map[resolver.DefinitionName]*UploadPolicy {
+	return map[resolver.DefinitionName]*UploadPolicy{
+		AchievementPathName: AchievementUploadPolicy,
+		WorkoutPathName:     WorkoutUploadPolicy,
+	}
+}
+
 var (
 	AchievementsPathName = resolver.DefinitionName("achievements")
 	AchievementPathName  = resolver.DefinitionName("achievement")
@@ -71,12 +79,23 @@ var (
 			},
 		},
 		Parameters: []*resolver.ParameterDefinition{
-			{Name: "achievement_id", Rules: []validation.Rule{validation.Required}, Description: "Achievement identifier"},
-			{Name: "format", DefaultValue: "png", Rules: []validation.Rule{validation.Required}, Description: "Image format (png, jpg, svg)"},
+			{Name: "achievement_id", Rules: mustRules(achievementParameters["achievement_id"]), Description: "Achievement identifier"},
+			{Name: "format", DefaultValue: "png", Rules: mustRules(achievementParameters["format"]), Description: "Image format (png, jpg, svg, webp)"},
 			{Name: "app", Description: "Application name (bike, rower) - required for app scope"},
 		},
 	})
 
+	achievementParameters = map[resolver.ParameterName]ParameterConstraints{
+		"achievement_id": {Required: true},
+		"format":         {Required: true, Enum: []string{"png", "jpg", "svg", "webp"}},
+	}
+
+	AchievementUploadPolicy = &UploadPolicy{
+		MaxSize:      2 << 20, // 2 MiB
+		ContentTypes: []string{"image/png", "image/jpeg", "image/svg+xml", "image/webp"},
+		BindClientIP: true,
+	}
+
 	WorkoutsPath = (&resolver.Definition{
 		Name:          WorkoutsPathName,
 		DisplayName:   "Workout Resources",
@@ -133,11 +152,28 @@ var (
 			},
 		},
 		Parameters: []*resolver.ParameterDefinition{
-			{Name: "workout_id", Rules: []validation.Rule{validation.Required}, Description: "Workout identifier"},
-			{Name: "format", DefaultValue: "json", Rules: []validation.Rule{validation.Required}, Description: "File format (erg, zwo, etc)"},
+			{Name: "workout_id", Rules: mustRules(workoutParameters["workout_id"]), Description: "Workout identifier"},
+			{Name: "format", DefaultValue: "json", Rules: mustRules(workoutParameters["format"]), Description: "File format (erg, mrc, zwo, json)"},
 			{Name: "user_id", DefaultValue: "anonymous", Description: "User ID or 'anonymous' for public workouts"},
 		},
 	})
+
+	workoutParameters = map[resolver.ParameterName]ParameterConstraints{
+		"workout_id": {Required: true},
+		"format":     {Required: true, Enum: []string{"erg", "mrc", "zwo", "json"}},
+	}
+
+	WorkoutUploadPolicy = &UploadPolicy{
+		MaxSize:          10 << 20, // 10 MiB
+		ContentTypes:     []string{"text/plain", "application/xml", "application/json"},
+		RequiredChecksum: metadata.ChecksumAlgorithmSHA256,
+	}
+)
+
+// Values the fallback parameter resolver provides when a path leaves them unset
+const (
+	DefaultEnv     = "dev"
+	DefaultVersion = "v1.0.0"
 )
 
 func NewResourceManager(ctx context.Context, pgxConn *pgxpool.Pool) (resource.ResourceManager, error) {
@@ -147,8 +183,8 @@ func NewResourceManager(ctx context.Context, pgxConn *pgxpool.Pool) (resource.Re
 		resource.WithFallbackParameterResolver(resolver.DefaultFallbackParameterResolver(
 			AllClientAppNames(),
 			AllAppNames(),
-			"dev",
-			"v1.0.0",
+			DefaultEnv,
+			DefaultVersion,
 		)),
 		resource.WithUploadRepository(upload.NewRepository(pgxConn)),
 	)
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+
+	"avironactive.com/resource/resolver"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ParameterConstraints describes what a path parameter accepts. Definitions
+// keep them beside their parameters and build the parameters' validation
+// rules from them, so the constraints can be described to clients as they
+// are enforced.
+type ParameterConstraints struct {
+	Required  bool
+	Enum      []string
+	Pattern   string
+	MinLength int
+	MaxLength int
+}
+
+// Rules builds the validation rules enforcing the constraints
+func (c ParameterConstraints) Rules() ([]validation.Rule, error) {
+	var rules []validation.Rule
+	if c.Required {
+		rules = append(rules, validation.Required)
+	}
+	if len(c.Enum) > 0 {
+		values := make([]any, len(c.Enum))
+		for i, value := range c.Enum {
+			values[i] = value
+		}
+		rules = append(rules, validation.In(values...))
+	}
+	if c.Pattern != "" {
+		re, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rules = append(rules, validation.Match(re))
+	}
+	if c.MinLength > 0 || c.MaxLength > 0 {
+		if c.MaxLength > 0 && c.MinLength > c.MaxLength {
+			return nil, fmt.Errorf("minLength exceeds maxLength")
+		}
+		rules = append(rules, validation.Length(c.MinLength, c.MaxLength))
+	}
+	return rules, nil
+}
+
+// RuleNames names the rules Rules builds, in the same order
+func (c ParameterConstraints) RuleNames() []string {
+	var names []string
+	if c.Required {
+		names = append(names, "required")
+	}
+	if len(c.Enum) > 0 {
+		names = append(names, "in")
+	}
+	if c.Pattern != "" {
+		names = append(names, "match")
+	}
+	if c.MinLength > 0 || c.MaxLength > 0 {
+		names = append(names, "length")
+	}
+	return names
+}
+
+// mustRules builds the rules of the built-in definitions' parameters
+func mustRules(c ParameterConstraints) []validation.Rule {
+	rules, err := c.Rules()
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// AllParameterConstraints returns the constraints of the built-in
+// definitions' parameters, keyed by definition and parameter name.
+// Parameters left out accept any value.
+func AllParameterConstraints() map[resolver.DefinitionName]map[resolver.ParameterName]ParameterConstraints {
+	return map[resolver.DefinitionName]map[resolver.ParameterName]ParameterConstraints{
+		AchievementPathName: achievementParameters,
+		WorkoutPathName:     workoutParameters,
+	}
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"mime"
+	"strings"
+
+	"avironactive.com/resource/metadata"
+)
+
+// UploadPolicy constrains uploads to a definition. The constraints are signed
+// into upload URLs so the storage edge rejects uploads that violate them, and
+// checked by the server for uploads it writes itself.
+type UploadPolicy struct {
+	// MaxSize is the largest accepted object in bytes
+	MaxSize int64
+	// ContentTypes lists the accepted MIME types; empty accepts any
+	ContentTypes []string
+	// RequiredChecksum must be declared by the client and is verified on upload
+	RequiredChecksum metadata.ChecksumAlgorithm
+	// BindClientIP restricts CDN upload URLs to the requesting client's IP
+	BindClientIP bool
+}
+
+// AllowsContentType reports whether the policy accepts the MIME type.
+// Parameters such as charset are ignored.
+func (p *UploadPolicy) AllowsContentType(contentType string) bool {
+	if len(p.ContentTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range p.ContentTypes {
+		if strings.EqualFold(allowed, mediaType) {
+			return true
+		}
+	}
+	return false
+}
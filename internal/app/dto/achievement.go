@@ -8,12 +8,15 @@ import (
 )
 
 type CreateAchievementRequest struct {
-	Name        string `json:"name" validate:"required,max=255"`
-	Description string `json:"description" validate:"max=1000"`
-	Category    string `json:"category" validate:"omitempty,max=50"`
-	Points      int    `json:"points" validate:"min=0,max=10000"`
-	IconFormat  string `json:"iconFormat" validate:"omitempty,oneof=png jpg svg webp"`
-	Provider    string `json:"provider" validate:"omitempty,oneof=cdn gcs r2"`
+	Name        string     `json:"name" validate:"required,max=255"`
+	Description string     `json:"description" validate:"max=1000"`
+	Category    string     `json:"category" validate:"omitempty,max=50"`
+	Points      int        `json:"points" validate:"min=0,max=10000"`
+	IconFormat  string     `json:"iconFormat" validate:"omitempty,oneof=png jpg svg webp"`
+	Provider    string     `json:"provider" validate:"omitempty,oneof=cdn gcs r2"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	Actor       string     `json:"-"`
 }
 
 type CreateAchievementResponse struct {
@@ -41,6 +44,8 @@ type AchievementResponse struct {
 	IconURL     string                 `json:"iconUrl,omitempty"`
 	BannerURL   string                 `json:"bannerUrl,omitempty"`
 	IsActive    bool                   `json:"isActive"`
+	PublishAt   *time.Time             `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time             `json:"unpublishAt,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt   time.Time              `json:"createdAt"`
 	UpdatedAt   time.Time              `json:"updatedAt"`
@@ -50,6 +55,16 @@ type UpdateIconRequest struct {
 	AchievementID string `json:"achievement_id" validate:"required,uuid"`
 	Format        string `json:"format" validate:"required,oneof=png jpg svg webp"`
 	Provider      string `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Size          int64  `json:"size,omitempty" validate:"omitempty,min=0"`
+	Actor         string `json:"-"`
+	ClientIP      string `json:"-"`
+}
+
+type UpdateScheduleRequest struct {
+	AchievementID string     `json:"achievement_id" validate:"required,uuid"`
+	PublishAt     *time.Time `json:"publishAt"`
+	UnpublishAt   *time.Time `json:"unpublishAt"`
+	Actor         string     `json:"-"`
 }
 
 type UpdateIconResponse struct {
@@ -57,6 +72,8 @@ type UpdateIconResponse struct {
 	UploadURL  string `json:"upload_url"`
 	ExpiresAt  int64  `json:"expires_at"`
 	NewIconURL string `json:"new_iconUrl"`
+	// Constraints are the upload policy conditions signed into UploadURL
+	Constraints *UploadConstraintsResponse `json:"constraints,omitempty"`
 }
 
 type PartETag struct {
@@ -86,6 +103,11 @@ type InitMultipartRequest struct {
 	FileSize    int64  `json:"file_size" validate:"required,min=5242880"`
 }
 
+// PartURLsRequest asks for signed URLs for the parts of a multipart upload
+type PartURLsRequest struct {
+	PartCount int `json:"part_count" validate:"required,min=1,max=10000"`
+}
+
 type InitMultipartResponse struct {
 	AchievementID     string `json:"achievement_id"`
 	UploadID          string `json:"upload_id"`
@@ -105,8 +127,56 @@ func NewAchievementResponse(achievement *entity.Achievement) *AchievementRespons
 		Category:    achievement.Category,
 		Points:      achievement.Points,
 		IsActive:    achievement.IsActive,
+		PublishAt:   achievement.PublishAt,
+		UnpublishAt: achievement.UnpublishAt,
 		Metadata:    achievement.Metadata,
 		CreatedAt:   achievement.CreatedAt,
 		UpdatedAt:   achievement.UpdatedAt,
 	}
 }
+
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type AchievementRevisionResponse struct {
+	Revision  int                   `json:"revision"`
+	Action    string                `json:"action"`
+	Actor     string                `json:"actor"`
+	Changes   []FieldChangeResponse `json:"changes"`
+	Previous  *AchievementResponse  `json:"previous,omitempty"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+type RevertAchievementResponse struct {
+	Achievement  *AchievementResponse `json:"achievement"`
+	Revision     int                  `json:"revision"`
+	IconRestored bool                 `json:"iconRestored"`
+}
+
+func NewAchievementRevisionResponse(revision *entity.AchievementRevision) *AchievementRevisionResponse {
+	changes := make([]FieldChangeResponse, 0, len(revision.Changes))
+	for _, change := range revision.Changes {
+		changes = append(changes, FieldChangeResponse{
+			Field: change.Field,
+			Old:   change.Old,
+			New:   change.New,
+		})
+	}
+
+	var previous *AchievementResponse
+	if revision.Previous != nil {
+		previous = NewAchievementResponse(revision.Previous)
+	}
+
+	return &AchievementRevisionResponse{
+		Revision:  revision.Revision,
+		Action:    string(revision.Action),
+		Actor:     revision.Actor,
+		Changes:   changes,
+		Previous:  previous,
+		CreatedAt: revision.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// CreateDefinitionRequest registers a runtime resource definition
+type CreateDefinitionRequest struct {
+	Name string `json:"name" validate:"required,max=128"`
+	entity.DefinitionSpec
+}
+
+// UpdateDefinitionRequest replaces a runtime definition's spec. Version, when
+// set, must match the current version. Changes to the paths of existing
+// objects are rejected unless Migrate is set.
+type UpdateDefinitionRequest struct {
+	Version int  `json:"version,omitempty" validate:"omitempty,min=1"`
+	Migrate bool `json:"migrate,omitempty"`
+	entity.DefinitionSpec
+}
+
+// ResourceDefinitionResponse represents a runtime definition in API responses
+type ResourceDefinitionResponse struct {
+	Name      string                `json:"name"`
+	Version   int                   `json:"version"`
+	Status    string                `json:"status"`
+	Spec      entity.DefinitionSpec `json:"spec"`
+	CreatedBy string                `json:"createdBy"`
+	UpdatedBy string                `json:"updatedBy"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// DefinitionVersionResponse represents one version of a runtime definition
+type DefinitionVersionResponse struct {
+	Version   int                   `json:"version"`
+	Status    string                `json:"status"`
+	Migrated  bool                  `json:"migrated"`
+	Spec      entity.DefinitionSpec `json:"spec"`
+	CreatedBy string                `json:"createdBy"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+func NewResourceDefinitionResponse(def *entity.ResourceDefinition) *ResourceDefinitionResponse {
+	return &ResourceDefinitionResponse{
+		Name:      def.Name,
+		Version:   def.Version,
+		Status:    def.Status,
+		Spec:      def.Spec,
+		CreatedBy: def.CreatedBy,
+		UpdatedBy: def.UpdatedBy,
+		CreatedAt: def.CreatedAt,
+		UpdatedAt: def.UpdatedAt,
+	}
+}
+
+func NewDefinitionVersionResponse(version *entity.ResourceDefinitionVersion) *DefinitionVersionResponse {
+	return &DefinitionVersionResponse{
+		Version:   version.Version,
+		Status:    version.Status,
+		Migrated:  version.Migrated,
+		Spec:      version.Spec,
+		CreatedBy: version.CreatedBy,
+		CreatedAt: version.CreatedAt,
+	}
+}
@@ -123,12 +123,7 @@ func NewErrorResponse(code, message, details string) APIResponse {
 func NewFileListResponseFromProvider(result *provider.ListObjectsResult, maxKeys int) *FileListResponse {
 	files := make([]FileInfo, 0, len(result.Objects))
 	for _, obj := range result.Objects {
-		files = append(files, FileInfo{
-			Key:          obj.Key,
-			Size:         obj.Size,
-			ETag:         obj.ETag,
-			LastModified: *obj.LastModified,
-		})
+		files = append(files, NewFileInfoFromProvider(obj))
 	}
 
 	var nextContinuationToken string
@@ -144,6 +139,41 @@ func NewFileListResponseFromProvider(result *provider.ListObjectsResult, maxKeys
 	}
 }
 
+// NewFileInfoFromProvider creates FileInfo from a provider ObjectInfo
+func NewFileInfoFromProvider(obj provider.ObjectInfo) FileInfo {
+	info := FileInfo{
+		Key:  obj.Key,
+		Size: obj.Size,
+		ETag: obj.ETag,
+	}
+	if obj.LastModified != nil {
+		info.LastModified = *obj.LastModified
+	}
+	return info
+}
+
+// NewFileInfoMetadata collects the object headers and custom metadata shown
+// for a file in list responses
+func NewFileInfoMetadata(m *provider.ObjectMetadata) map[string]any {
+	info := map[string]any{}
+	for key, value := range map[string]string{
+		"cacheControl":       m.CacheControl,
+		"contentEncoding":    m.ContentEncoding,
+		"contentDisposition": m.ContentDisposition,
+		"contentLanguage":    m.ContentLanguage,
+		"storageClass":       string(m.StorageClass),
+		"acl":                string(m.ACL),
+	} {
+		if value != "" {
+			info[key] = value
+		}
+	}
+	if len(m.Metadata) > 0 {
+		info["custom"] = m.Metadata
+	}
+	return info
+}
+
 // NewSignedURLResponseFromProvider creates SignedURLResponse from provider SignedURL
 func NewSignedURLResponseFromProvider(resolvedResource *resolver.ResolvedResource) *SignedURLResponse {
 	return &SignedURLResponse{
@@ -155,6 +185,27 @@ func NewSignedURLResponseFromProvider(resolvedResource *resolver.ResolvedResourc
 	}
 }
 
+// NewUploadConstraintsResponse converts signed upload constraints, returning
+// nil for unconstrained uploads
+func NewUploadConstraintsResponse(constraints *provider.UploadConstraints) *UploadConstraintsResponse {
+	if constraints == nil {
+		return nil
+	}
+
+	response := &UploadConstraintsResponse{
+		MaxSize:     constraints.MaxSize,
+		ContentType: constraints.ContentType,
+		ClientIP:    constraints.ClientIP,
+	}
+	if constraints.Checksum != nil {
+		response.Checksum = &ChecksumInfo{
+			Algorithm: string(constraints.Checksum.Algorithm),
+			Value:     constraints.Checksum.Value,
+		}
+	}
+	return response
+}
+
 func NewSignedURLResponse(objectURL *provider.ObjectURL) *SignedURLResponse {
 	return &SignedURLResponse{
 		URL:       objectURL.URL,
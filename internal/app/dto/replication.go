@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+type CreateReplicationJobRequest struct {
+	Definition     string            `json:"definition" validate:"required,alphanum,max=128"`
+	Scope          string            `json:"scope" validate:"omitempty,oneof=G A CA"`
+	ScopeValue     int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+	Parameters     map[string]string `json:"parameters" validate:"dive,keys,alphanum,endkeys,max=256"`
+	SourceProvider string            `json:"sourceProvider" validate:"required,oneof=cdn gcs r2"`
+	TargetProvider string            `json:"targetProvider" validate:"required,oneof=cdn gcs r2,nefield=SourceProvider"`
+	Concurrency    int               `json:"concurrency,omitempty" validate:"omitempty,min=1,max=32"`
+}
+
+type ReplicationJobResponse struct {
+	ID             string                     `json:"id"`
+	Definition     string                     `json:"definition"`
+	Scope          string                     `json:"scope"`
+	ScopeValue     int16                      `json:"scopeValue,omitempty"`
+	Parameters     map[string]string          `json:"parameters,omitempty"`
+	SourceProvider string                     `json:"sourceProvider"`
+	TargetProvider string                     `json:"targetProvider"`
+	Concurrency    int                        `json:"concurrency"`
+	Status         string                     `json:"status"`
+	Summary        *ReplicationSummary        `json:"summary"`
+	Error          string                     `json:"error,omitempty"`
+	FailedItems    []*ReplicationItemResponse `json:"failedItems,omitempty"`
+	CreatedAt      time.Time                  `json:"createdAt"`
+	UpdatedAt      time.Time                  `json:"updatedAt"`
+	StartedAt      *time.Time                 `json:"startedAt,omitempty"`
+	CompletedAt    *time.Time                 `json:"completedAt,omitempty"`
+}
+
+type ReplicationSummary struct {
+	Copied  int `json:"copied"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+type ReplicationItemResponse struct {
+	SourceKey      string    `json:"sourceKey"`
+	TargetKey      string    `json:"targetKey"`
+	Status         string    `json:"status"`
+	Size           int64     `json:"size"`
+	ChecksumSHA256 string    `json:"checksumSha256,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func NewReplicationJobResponse(job *entity.ReplicationJob) *ReplicationJobResponse {
+	return &ReplicationJobResponse{
+		ID:             job.ID.String(),
+		Definition:     job.Definition,
+		Scope:          job.Scope,
+		ScopeValue:     job.ScopeValue,
+		Parameters:     job.Parameters,
+		SourceProvider: job.SourceProvider,
+		TargetProvider: job.TargetProvider,
+		Concurrency:    job.Concurrency,
+		Status:         job.Status,
+		Summary: &ReplicationSummary{
+			Copied:  job.CopiedCount,
+			Skipped: job.SkippedCount,
+			Failed:  job.FailedCount,
+		},
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
+
+func NewReplicationItemResponse(item *entity.ReplicationItem) *ReplicationItemResponse {
+	return &ReplicationItemResponse{
+		SourceKey:      item.SourceKey,
+		TargetKey:      item.TargetKey,
+		Status:         item.Status,
+		Size:           item.Size,
+		ChecksumSHA256: item.ChecksumSHA256,
+		Error:          item.Error,
+		UpdatedAt:      item.UpdatedAt,
+	}
+}
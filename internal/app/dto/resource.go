@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"net/http"
+	"strings"
 	"time"
 
 	"avironactive.com/resource/metadata"
@@ -10,22 +12,90 @@ import (
 
 // PathDefinitionResponse represents a resource path definition in API responses
 type PathDefinitionResponse struct {
-	Name          string                  `json:"name"`
-	DisplayName   string                  `json:"displayName"`
-	Description   string                  `json:"description"`
-	AllowedScopes []string                `json:"allowedScopes"`
-	Parameters    []PathParameterResponse `json:"parameters"`
-	Providers     []string                `json:"providers"`
-}
-
-// PathParameterResponse represents a path parameter in API responses
+	Name                   string                          `json:"name"`
+	DisplayName            string                          `json:"displayName"`
+	Description            string                          `json:"description"`
+	Parent                 string                          `json:"parent,omitempty"`
+	Version                int                             `json:"version,omitempty"`
+	Deprecated             bool                            `json:"deprecated,omitempty"`
+	AllowedScopes          []string                        `json:"allowedScopes"`
+	Parameters             []PathParameterResponse         `json:"parameters"`
+	Providers              []string                        `json:"providers"`
+	Patterns               map[string]PathPatternsResponse `json:"patterns"`
+	DefaultStorageMetadata *StorageDefaultsResponse        `json:"defaultStorageMetadata,omitempty"`
+	Children               []*PathDefinitionResponse       `json:"children,omitempty"`
+}
+
+// PathParameterResponse represents a path parameter in API responses. Rules
+// lists the names of its validation rules; the constraints they carry are
+// rendered into the remaining fields.
 type PathParameterResponse struct {
 	Name         string   `json:"name"`
+	Required     bool     `json:"required"`
 	Rules        []string `json:"rules,omitempty"`
+	Enum         []string `json:"enum,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinLength    *int     `json:"minLength,omitempty"`
+	MaxLength    *int     `json:"maxLength,omitempty"`
 	Description  string   `json:"description,omitempty"`
 	DefaultValue string   `json:"defaultValue,omitempty"`
 }
 
+// PathPatternsResponse represents a definition's path patterns on one
+// provider, keyed by scope
+type PathPatternsResponse struct {
+	URLType  string            `json:"urlType"`
+	Patterns map[string]string `json:"patterns"`
+}
+
+// StorageDefaultsResponse represents the storage metadata applied to a
+// definition's uploads by default
+type StorageDefaultsResponse struct {
+	CacheControl      *CacheControlDefaults        `json:"cacheControl,omitempty"`
+	RequiredChecksums []metadata.ChecksumAlgorithm `json:"requiredChecksums,omitempty"`
+	CustomHeaders     map[string]string            `json:"customHeaders,omitempty"`
+}
+
+// CacheControlDefaults represents a definition's default cache control
+type CacheControlDefaults struct {
+	MaxAge      int64  `json:"maxAge"`
+	AllowPublic bool   `json:"allowPublic"`
+	Default     string `json:"default,omitempty"`
+}
+
+// ResolvePathRequest represents a dry-run resolution of a definition's paths
+type ResolvePathRequest struct {
+	Parameters map[string]string `json:"parameters" validate:"dive,keys,max=64,endkeys,max=256"`
+	Scope      string            `json:"scope" validate:"omitempty,oneof=G A CA"`
+	ScopeValue int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+}
+
+// ResolveScope returns the scope to resolve, global when none is given
+func (r ResolvePathRequest) ResolveScope() resolver.ScopeType {
+	return parseScope(r.Scope)
+}
+
+// ResolvePathResponse represents the paths a definition resolves to on each
+// of its providers
+type ResolvePathResponse struct {
+	Definition string                 `json:"definition"`
+	Scope      string                 `json:"scope"`
+	ScopeValue int16                  `json:"scopeValue,omitempty"`
+	Providers  []ResolvedProviderPath `json:"providers"`
+}
+
+// ResolvedProviderPath represents a definition's path on one provider. Path
+// keeps the placeholders of parameters without a value; Errors holds the
+// validation error of each invalid parameter.
+type ResolvedProviderPath struct {
+	Provider   string            `json:"provider"`
+	Path       string            `json:"path"`
+	URLType    string            `json:"urlType"`
+	Parameters map[string]string `json:"parameters"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	Valid      bool              `json:"valid"`
+}
+
 // ProviderResponse represents a storage provider in API responses
 type ProviderResponse struct {
 	Name         string               `json:"name"`
@@ -92,9 +162,11 @@ func NewProviderCapabilities(caps *provider.Capabilities) *ProviderCapabilities
 	}
 }
 
-// FileListResponse represents a paginated list of files
+// FileListResponse represents a paginated list of files. With a delimiter,
+// keys below the next delimiter are rolled up into CommonPrefixes.
 type FileListResponse struct {
 	Files             []FileInfo `json:"files"`
+	CommonPrefixes    []string   `json:"commonPrefixes,omitempty"`
 	ContinuationToken string     `json:"continuationToken,omitempty"`
 	IsTruncated       bool       `json:"isTruncated"`
 	MaxKeys           int        `json:"maxKeys"`
@@ -117,6 +189,10 @@ type UploadRequest struct {
 	ScopeValue int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
 	Expiry     string            `json:"expiry,omitempty" validate:"omitempty,duration"`
 	Metadata   *UploadMetadata   `json:"metadata,omitempty" validate:"omitempty"`
+	// ContentLength and Checksum declare the file to be uploaded so the
+	// definition's upload policy can be signed into the URL
+	ContentLength int64         `json:"contentLength,omitempty" validate:"omitempty,min=0"`
+	Checksum      *ChecksumInfo `json:"checksum,omitempty"`
 }
 
 func (r UploadRequest) To() *resolver.DefinitionUploadOptions {
@@ -131,6 +207,12 @@ func (r UploadRequest) To() *resolver.DefinitionUploadOptions {
 	if r.Scope != "" {
 		opts = opts.WithScope(scope, r.ScopeValue)
 	}
+	if expiry, err := time.ParseDuration(r.Expiry); err == nil {
+		opts = opts.WithExpiry(expiry)
+	}
+	if r.Metadata != nil {
+		opts = opts.WithMetadata(r.Metadata.ToRequestHeaders())
+	}
 	return opts
 }
 
@@ -187,24 +269,51 @@ type MultipartUploadRequest struct {
 }
 
 // DownloadRequest represents a request to generate download URL
+// ResponseHeaders override headers of the download response; keys are header
+// names such as Content-Disposition, optionally in the response-content-disposition form
 type DownloadRequest struct {
 	Expiry          string            `json:"expiry,omitempty" validate:"omitempty,duration"`
-	ResponseHeaders map[string]string `json:"responseHeaders,omitempty" validate:"omitempty,dive,keys,max=64,endkeys,max=512"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty" validate:"omitempty,dive,keys,responseheader,endkeys,max=512"`
 }
 
 func (r *DownloadRequest) To() *resolver.DownloadOptions {
 	opts := &resolver.DownloadOptions{}
-	if r.Expiry != "" {
-		// Parse expiry duration and set on options
-		// This would need to be implemented based on your duration parsing logic
+	if expiry, err := time.ParseDuration(r.Expiry); err == nil {
+		opts = opts.WithExpiry(expiry)
 	}
 	if len(r.ResponseHeaders) > 0 {
-		// Set response headers on options
-		// This would need to be implemented based on your options structure
+		headers := make(map[string]string, len(r.ResponseHeaders))
+		for key, value := range r.ResponseHeaders {
+			if name, ok := ResponseHeaderName(key); ok {
+				headers[name] = value
+			}
+		}
+		opts = opts.WithResponseHeaders(headers)
 	}
 	return opts
 }
 
+// responseHeaderOverrides are the response headers a signed download URL may override
+var responseHeaderOverrides = map[string]bool{
+	"Cache-Control":       true,
+	"Content-Disposition": true,
+	"Content-Encoding":    true,
+	"Content-Language":    true,
+	"Content-Type":        true,
+	"Expires":             true,
+}
+
+// ResponseHeaderName returns the canonical header name for a response
+// override key, accepting the response-content-disposition form. The flag
+// reports whether the header may be overridden.
+func ResponseHeaderName(key string) (string, bool) {
+	if strings.HasPrefix(strings.ToLower(key), "response-") {
+		key = key[len("response-"):]
+	}
+	name := http.CanonicalHeaderKey(key)
+	return name, responseHeaderOverrides[name]
+}
+
 // SignedURLResponse represents a signed URL response
 type SignedURLResponse struct {
 	URL                string            `json:"url"`
@@ -213,6 +322,16 @@ type SignedURLResponse struct {
 	ExpiresAt          time.Time         `json:"expiresAt"`
 	ResolvedPath       string            `json:"resolvedPath,omitempty"`
 	ResolvedParameters map[string]string `json:"resolvedParameters,omitempty"`
+	// Constraints are the upload policy conditions signed into the URL
+	Constraints *UploadConstraintsResponse `json:"constraints,omitempty"`
+}
+
+// UploadConstraintsResponse describes the conditions an upload URL enforces
+type UploadConstraintsResponse struct {
+	MaxSize     int64         `json:"maxSize,omitempty"`
+	ContentType string        `json:"contentType,omitempty"`
+	Checksum    *ChecksumInfo `json:"checksum,omitempty"`
+	ClientIP    string        `json:"clientIp,omitempty"`
 }
 
 // MultipartUploadResponse represents a multipart upload response
@@ -406,11 +525,82 @@ type MultipartURLsResponse struct {
 
 // ListFilesRequest represents a request to list files with pagination
 type ListFilesRequest struct {
-	Provider          string `json:"provider" validate:"required,oneof=cdn gcs r2"`
-	Definition        string `json:"definition" validate:"required,alphanum,max=128"`
-	MaxKeys           int32  `json:"maxKeys,omitempty" validate:"omitempty,min=1,max=1000"`
-	ContinuationToken string `json:"continuationToken,omitempty"`
-	Prefix            string `json:"prefix,omitempty" validate:"omitempty,max=256"`
+	Provider          string            `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Definition        string            `json:"definition" validate:"required,alphanum,max=128"`
+	MaxKeys           int32             `json:"maxKeys,omitempty" validate:"omitempty,min=1,max=1000"`
+	ContinuationToken string            `json:"continuationToken,omitempty"`
+	Prefix            string            `json:"prefix,omitempty" validate:"omitempty,max=256"`
+	Options           *ListFilesOptions `json:"options,omitempty"`
+}
+
+// Sort keys for file listings
+const (
+	ListSortKey          = "key"
+	ListSortSize         = "size"
+	ListSortLastModified = "last_modified"
+)
+
+// ListFilesOptions refines a file listing. Scope and Parameters resolve the
+// definition's path so Prefix is relative to it. Filters and sorting apply to
+// the files of each returned page. Delimiter rolls keys up into common
+// prefixes page by page; a prefix is returned once across continuation pages.
+type ListFilesOptions struct {
+	Delimiter       string            `json:"delimiter,omitempty" validate:"omitempty,max=8"`
+	Scope           string            `json:"scope,omitempty" validate:"omitempty,oneof=G A CA"`
+	ScopeValue      int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+	Parameters      map[string]string `json:"parameters,omitempty" validate:"dive,keys,max=64,endkeys,max=256"`
+	ModifiedAfter   *time.Time        `json:"modifiedAfter,omitempty"`
+	ModifiedBefore  *time.Time        `json:"modifiedBefore,omitempty"`
+	MinSize         *int64            `json:"minSize,omitempty" validate:"omitempty,min=0"`
+	MaxSize         *int64            `json:"maxSize,omitempty" validate:"omitempty,min=0"`
+	ContentType     string            `json:"contentType,omitempty" validate:"omitempty,max=128"`
+	IncludeMetadata bool              `json:"includeMetadata,omitempty"`
+	Sort            string            `json:"sort,omitempty" validate:"omitempty,oneof=key size last_modified"`
+	Order           string            `json:"order,omitempty" validate:"omitempty,oneof=asc desc"`
+}
+
+// Scoped reports whether the listing resolves the definition path itself
+func (o *ListFilesOptions) Scoped() bool {
+	return o.Scope != "" || len(o.Parameters) > 0
+}
+
+// NeedsMetadata reports whether every listed file needs a metadata lookup
+func (o *ListFilesOptions) NeedsMetadata() bool {
+	return o.IncludeMetadata || o.ContentType != ""
+}
+
+// MatchesObject applies the size and last-modified filters
+func (o *ListFilesOptions) MatchesObject(obj provider.ObjectInfo) bool {
+	if o.MinSize != nil && obj.Size < *o.MinSize {
+		return false
+	}
+	if o.MaxSize != nil && obj.Size > *o.MaxSize {
+		return false
+	}
+	if o.ModifiedAfter != nil || o.ModifiedBefore != nil {
+		if obj.LastModified == nil {
+			return false
+		}
+		if o.ModifiedAfter != nil && obj.LastModified.Before(*o.ModifiedAfter) {
+			return false
+		}
+		if o.ModifiedBefore != nil && obj.LastModified.After(*o.ModifiedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesContentType applies the content type filter. A trailing "/*"
+// matches any subtype.
+func (o *ListFilesOptions) MatchesContentType(contentType string) bool {
+	if o.ContentType == "" {
+		return true
+	}
+	if mediaType, ok := strings.CutSuffix(o.ContentType, "/*"); ok {
+		return strings.HasPrefix(contentType, mediaType+"/")
+	}
+	return strings.EqualFold(o.ContentType, contentType)
 }
 
 // GenerateUploadURLRequest represents a request to generate an upload URL
@@ -418,6 +608,39 @@ type GenerateUploadURLRequest struct {
 	Provider   string         `json:"provider" validate:"required,oneof=cdn gcs r2"`
 	Definition string         `json:"definition" validate:"required,alphanum,max=128"`
 	Upload     *UploadRequest `json:"upload" validate:"required"`
+	ClientIP   string         `json:"-"`
+}
+
+// Upload types reported for content uploaded through the server
+const (
+	ContentUploadSimple    = "simple"
+	ContentUploadMultipart = "multipart"
+)
+
+// ContentUploadRequest represents a file streamed through the server to the
+// provider. ContentLength is -1 when the client did not declare it.
+type ContentUploadRequest struct {
+	Provider      string         `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Definition    string         `json:"definition" validate:"required,alphanum,max=128"`
+	ResourceID    string         `json:"resourceId,omitempty" validate:"omitempty,max=255"`
+	ContentType   string         `json:"contentType,omitempty" validate:"omitempty,max=128"`
+	ContentLength int64          `json:"contentLength"`
+	Upload        *UploadRequest `json:"upload" validate:"required"`
+}
+
+// ContentUploadResponse describes a completed upload streamed through the server
+type ContentUploadResponse struct {
+	UploadID    string         `json:"uploadId"`
+	Provider    string         `json:"provider"`
+	Path        string         `json:"path"`
+	Size        int64          `json:"size"`
+	ContentType string         `json:"contentType,omitempty"`
+	UploadType  string         `json:"uploadType"`
+	Parts       int            `json:"parts"`
+	Checksums   []ChecksumInfo `json:"checksums"`
+	// BlobKey is the content-addressed blob holding the file when the
+	// definition deduplicates its files
+	BlobKey string `json:"blobKey,omitempty"`
 }
 
 // GenerateDownloadURLRequest represents a request to generate a download URL
@@ -427,6 +650,21 @@ type GenerateDownloadURLRequest struct {
 	Download *DownloadRequest `json:"download,omitempty"`
 }
 
+// ContentDownloadRequest represents a download streamed through the server.
+// The header fields carry the client's Range and conditional request headers.
+type ContentDownloadRequest struct {
+	Provider        string `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	FilePath        string `json:"filePath" validate:"required,max=512"`
+	Range           string `json:"range,omitempty"`
+	IfRange         string `json:"ifRange,omitempty"`
+	IfNoneMatch     string `json:"ifNoneMatch,omitempty"`
+	IfModifiedSince string `json:"ifModifiedSince,omitempty"`
+	Disposition     string `json:"disposition,omitempty" validate:"omitempty,oneof=inline attachment"`
+	FileName        string `json:"fileName,omitempty" validate:"omitempty,max=256"`
+	// SkipBody is set for HEAD requests, which only need the headers
+	SkipBody bool `json:"-"`
+}
+
 // DeleteFileRequest represents a request to delete a file
 type DeleteFileRequest struct {
 	Provider string `json:"provider" validate:"required,oneof=cdn gcs r2"`
@@ -445,3 +683,177 @@ type UpdateFileMetadataRequest struct {
 	FilePath string                 `json:"filePath" validate:"required,max=512"`
 	Metadata *MetadataUpdateRequest `json:"metadata" validate:"required"`
 }
+
+// CopyRequest represents a request to copy or move a file. The destination is
+// either a raw path or a path definition resolved with parameters.
+type CopyRequest struct {
+	DestinationPath string              `json:"destinationPath,omitempty" validate:"required_without=Destination,excluded_with=Destination,max=512"`
+	Destination     *DestinationRequest `json:"destination,omitempty" validate:"omitempty"`
+	Overwrite       bool                `json:"overwrite,omitempty"`
+}
+
+// DestinationRequest identifies a destination by path definition
+type DestinationRequest struct {
+	Definition string            `json:"definition" validate:"required,alphanum,max=128"`
+	Parameters map[string]string `json:"parameters" validate:"dive,keys,alphanum,endkeys,max=256"`
+	Scope      string            `json:"scope" validate:"omitempty,oneof=G A CA"`
+	ScopeValue int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+}
+
+// To converts the destination to definition upload options
+func (r *DestinationRequest) To() *resolver.DefinitionUploadOptions {
+	return UploadRequest{
+		Parameters: r.Parameters,
+		Scope:      r.Scope,
+		ScopeValue: r.ScopeValue,
+	}.To()
+}
+
+// CopyFileRequest represents a request to copy or move a file
+type CopyFileRequest struct {
+	Provider   string       `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	SourcePath string       `json:"sourcePath" validate:"required,max=512"`
+	Copy       *CopyRequest `json:"copy" validate:"required"`
+	Move       bool         `json:"move"`
+	Actor      string       `json:"-"`
+}
+
+// Copy methods reported in CopyFileResponse
+const (
+	CopyMethodNative = "native"
+	CopyMethodStream = "stream"
+	// CopyMethodReference moves a content-addressed file by remapping its
+	// path, without touching the shared blob
+	CopyMethodReference = "reference"
+)
+
+// CopyFileResponse represents the result of a copy or move
+type CopyFileResponse struct {
+	Provider          string        `json:"provider"`
+	SourcePath        string        `json:"sourcePath"`
+	DestinationPath   string        `json:"destinationPath"`
+	Method            string        `json:"method"`
+	Moved             bool          `json:"moved"`
+	UpdatedReferences int64         `json:"updatedReferences"`
+	Metadata          *FileMetadata `json:"metadata,omitempty"`
+}
+
+// MaxBatchItems bounds how many files a single batch request affects
+const MaxBatchItems = 1000
+
+// BatchTargetRequest selects files for a batch operation, either as explicit
+// paths or as every file under a prefix within a definition
+type BatchTargetRequest struct {
+	Paths      []string `json:"paths,omitempty" validate:"required_without=Definition,excluded_with=Definition,max=1000,dive,required,max=512"`
+	Definition string   `json:"definition,omitempty" validate:"omitempty,alphanum,max=128"`
+	Prefix     string   `json:"prefix,omitempty" validate:"excluded_without=Definition,max=256"`
+}
+
+// BatchMetadataRequest represents a request to update metadata on many files
+type BatchMetadataRequest struct {
+	BatchTargetRequest
+	Metadata *MetadataUpdateRequest `json:"metadata" validate:"required"`
+}
+
+// BatchDeleteRequest represents a request to delete many files
+type BatchDeleteRequest struct {
+	Provider string              `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Target   *BatchTargetRequest `json:"target" validate:"required"`
+	DryRun   bool                `json:"dryRun"`
+}
+
+// BatchUpdateMetadataRequest represents a request to update metadata on many files
+type BatchUpdateMetadataRequest struct {
+	Provider string                `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Batch    *BatchMetadataRequest `json:"batch" validate:"required"`
+	DryRun   bool                  `json:"dryRun"`
+}
+
+// Batch item statuses
+const (
+	BatchStatusDeleted = "deleted"
+	BatchStatusUpdated = "updated"
+	BatchStatusMatched = "matched"
+	BatchStatusFailed  = "failed"
+)
+
+// BatchResponse reports the outcome for every file in a batch. Truncated is
+// set when a prefix matched more than MaxBatchItems files; repeat the request
+// to process the rest.
+type BatchResponse struct {
+	Provider  string            `json:"provider"`
+	DryRun    bool              `json:"dryRun"`
+	Truncated bool              `json:"truncated"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult is the outcome for a single file
+type BatchItemResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NewBatchResponse tallies the item results
+func NewBatchResponse(providerName string, dryRun, truncated bool, results []BatchItemResult) *BatchResponse {
+	response := &BatchResponse{
+		Provider:  providerName,
+		DryRun:    dryRun,
+		Truncated: truncated,
+		Total:     len(results),
+		Results:   results,
+	}
+	for _, result := range results {
+		if result.Status == BatchStatusFailed {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	return response
+}
+
+// Bundle archive formats
+const (
+	BundleFormatZip   = "zip"
+	BundleFormatTarGz = "tar.gz"
+)
+
+// MaxBundleItems bounds how many files a single bundle contains
+const MaxBundleItems = 1000
+
+// BundleRequest selects files to download as one archive, either as explicit
+// paths or as every file under the definition's resolved path
+type BundleRequest struct {
+	Provider   string            `json:"provider" validate:"required,oneof=cdn gcs r2"`
+	Definition string            `json:"definition" validate:"required,alphanum,max=128"`
+	Paths      []string          `json:"paths,omitempty" validate:"excluded_with=Scope Parameters Prefix,max=1000,dive,required,max=512"`
+	Scope      string            `json:"scope,omitempty" validate:"omitempty,oneof=G A CA"`
+	ScopeValue int16             `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+	Parameters map[string]string `json:"parameters,omitempty" validate:"dive,keys,max=64,endkeys,max=256"`
+	Prefix     string            `json:"prefix,omitempty" validate:"max=256"`
+	Format     string            `json:"format,omitempty" validate:"omitempty,oneof=zip tar.gz"`
+	// Cache stores the generated archive as its own object keyed by the
+	// bundle hash, and serves later identical bundles from it
+	Cache bool `json:"cache,omitempty"`
+}
+
+// BundleManifest is written as manifest.json at the root of every bundle
+type BundleManifest struct {
+	Hash  string                `json:"hash"`
+	Files []BundleManifestEntry `json:"files"`
+}
+
+// BundleManifestEntry describes one archived file. Name is its path within
+// the archive and Key its path in storage.
+type BundleManifestEntry struct {
+	Name        string         `json:"name"`
+	Key         string         `json:"key"`
+	Size        int64          `json:"size"`
+	ContentType string         `json:"contentType,omitempty"`
+	ETag        string         `json:"etag,omitempty"`
+	Checksums   []ChecksumInfo `json:"checksums,omitempty"`
+}
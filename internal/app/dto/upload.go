@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+type AbortUploadRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+type RunJanitorRequest struct {
+	// Limit caps the number of expired uploads aborted in one run
+	Limit int `json:"limit,omitempty" validate:"omitempty,min=1,max=10000"`
+	// DryRun reports the expired uploads without aborting them
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type UploadResponse struct {
+	ID             string         `json:"id"`
+	ResourceType   string         `json:"resourceType"`
+	ResourceID     string         `json:"resourceId"`
+	ResourceField  string         `json:"resourceField,omitempty"`
+	UploadType     string         `json:"uploadType"`
+	Status         string         `json:"status"`
+	Error          string         `json:"error,omitempty"`
+	PathDefinition string         `json:"pathDefinition"`
+	PathParameters map[string]any `json:"pathParameters,omitempty"`
+	Provider       string         `json:"provider"`
+	StorageKey     string         `json:"storageKey"`
+	Size           *int64         `json:"size,omitempty"`
+	MultipartID    string         `json:"multipartId,omitempty"`
+	TotalParts     *int           `json:"totalParts,omitempty"`
+	UploadedParts  *int           `json:"uploadedParts,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	CompletedAt    *time.Time     `json:"completedAt,omitempty"`
+	ExpiresAt      time.Time      `json:"expiresAt"`
+}
+
+type AbortUploadResponse struct {
+	Upload *UploadResponse `json:"upload"`
+	// ProviderAborted is set when the provider's multipart upload was aborted
+	// too; otherwise its parts are left to the bucket's lifecycle rules
+	ProviderAborted bool `json:"providerAborted"`
+}
+
+type JanitorResultResponse struct {
+	DryRun  bool                    `json:"dryRun"`
+	Scanned int                     `json:"scanned"`
+	Aborted int                     `json:"aborted"`
+	Failed  int                     `json:"failed"`
+	Uploads []*JanitorUploadOutcome `json:"uploads"`
+}
+
+type JanitorUploadOutcome struct {
+	ID              string    `json:"id"`
+	Provider        string    `json:"provider"`
+	StorageKey      string    `json:"storageKey"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	Aborted         bool      `json:"aborted"`
+	ProviderAborted bool      `json:"providerAborted"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func NewUploadResponse(upload *entity.TrackedUpload) *UploadResponse {
+	return &UploadResponse{
+		ID:             upload.ID.String(),
+		ResourceType:   upload.ResourceType,
+		ResourceID:     upload.ResourceID,
+		ResourceField:  upload.ResourceField,
+		UploadType:     upload.UploadType,
+		Status:         upload.Status,
+		Error:          upload.Error,
+		PathDefinition: upload.PathDefinition,
+		PathParameters: upload.PathParameters,
+		Provider:       upload.StorageProvider,
+		StorageKey:     upload.StorageKey,
+		Size:           upload.StorageSize,
+		MultipartID:    upload.MultipartID,
+		TotalParts:     upload.TotalParts,
+		UploadedParts:  upload.UploadedParts,
+		CreatedAt:      upload.CreateTime,
+		UpdatedAt:      upload.UpdateTime,
+		CompletedAt:    upload.CompletedTime,
+		ExpiresAt:      upload.ExpiresTime,
+	}
+}
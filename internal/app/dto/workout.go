@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+type CreateWorkoutRequest struct {
+	UserID         string `json:"userId" validate:"required,max=255"`
+	Name           string `json:"name" validate:"required,max=255"`
+	Description    string `json:"description" validate:"max=1000"`
+	Format         string `json:"format" validate:"required,oneof=erg mrc zwo json"`
+	ChecksumSHA256 string `json:"checksumSha256" validate:"required,len=64,hexadecimal"`
+	Provider       string `json:"provider" validate:"omitempty,oneof=cdn r2"`
+	Scope          string `json:"scope" validate:"omitempty,oneof=G A"`
+	ScopeValue     int16  `json:"scopeValue,omitempty" validate:"omitempty,min=1"`
+}
+
+type ReplaceWorkoutFileRequest struct {
+	WorkoutID      string `json:"workout_id" validate:"required,uuid"`
+	Format         string `json:"format" validate:"required,oneof=erg mrc zwo json"`
+	ChecksumSHA256 string `json:"checksumSha256" validate:"required,len=64,hexadecimal"`
+}
+
+type WorkoutDownloadRequest struct {
+	WorkoutID string  `json:"workout_id" validate:"required,uuid"`
+	Format    string  `json:"format" validate:"omitempty,oneof=erg mrc zwo json"`
+	FTP       float64 `json:"ftp" validate:"omitempty,gt=0,lte=2000"`
+}
+
+type WorkoutResponse struct {
+	ID             string                 `json:"id"`
+	UserID         string                 `json:"userId"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Format         string                 `json:"format"`
+	Provider       string                 `json:"provider"`
+	FileSize       int64                  `json:"fileSize,omitempty"`
+	ChecksumSHA256 string                 `json:"checksumSha256"`
+	DownloadURL    string                 `json:"downloadUrl,omitempty"`
+	ExpiresAt      *time.Time             `json:"expiresAt,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Summary        *WorkoutSummary        `json:"summary,omitempty"`
+	ConfirmedAt    *time.Time             `json:"confirmedAt,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+}
+
+type WorkoutUploadResponse struct {
+	Workout *WorkoutResponse `json:"workout"`
+	Upload  *UploadInfo      `json:"upload"`
+}
+
+type WorkoutDownloadResponse struct {
+	WorkoutID    string     `json:"workoutId"`
+	Format       string     `json:"format"`
+	SourceFormat string     `json:"sourceFormat"`
+	Converted    bool       `json:"converted"`
+	Cached       bool       `json:"cached"`
+	CacheKey     string     `json:"cacheKey,omitempty"`
+	DownloadURL  string     `json:"downloadUrl"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+type WorkoutSummary struct {
+	DurationSeconds int     `json:"durationSeconds"`
+	IntervalCount   int     `json:"intervalCount"`
+	PowerUnit       string  `json:"powerUnit"`
+	PowerMin        float64 `json:"powerMin"`
+	PowerMax        float64 `json:"powerMax"`
+	StrokeRateMin   int     `json:"strokeRateMin,omitempty"`
+	StrokeRateMax   int     `json:"strokeRateMax,omitempty"`
+}
+
+// WorkoutPathOptions builds the resolver options for the workout file path
+func WorkoutPathOptions(workout *entity.Workout) *resolver.DefinitionDownloadOptions {
+	opts := (&resolver.DefinitionDownloadOptions{}).
+		WithProvider(provider.ProviderName(workout.Provider)).
+		WithValues(map[resolver.ParameterName]string{
+			"user_id":    workout.UserID,
+			"workout_id": workout.ID.String(),
+			"format":     workout.Format,
+		})
+
+	if workout.Scope != "" {
+		opts = opts.WithScope(parseScope(workout.Scope), workout.ScopeValue)
+	}
+	return opts
+}
+
+// WorkoutUploadOptions builds the resolver options for writing the workout file
+func WorkoutUploadOptions(workout *entity.Workout) *resolver.DefinitionUploadOptions {
+	opts := (&resolver.DefinitionUploadOptions{}).
+		WithProvider(provider.ProviderName(workout.Provider)).
+		WithValues(map[resolver.ParameterName]string{
+			"user_id":    workout.UserID,
+			"workout_id": workout.ID.String(),
+			"format":     workout.Format,
+		})
+
+	if workout.Scope != "" {
+		opts = opts.WithScope(parseScope(workout.Scope), workout.ScopeValue)
+	}
+	return opts
+}
+
+func NewWorkoutResponse(workout *entity.Workout) *WorkoutResponse {
+	response := &WorkoutResponse{
+		ID:             workout.ID.String(),
+		UserID:         workout.UserID,
+		Name:           workout.Name,
+		Description:    workout.Description,
+		Format:         workout.Format,
+		Provider:       workout.Provider,
+		FileSize:       workout.FileSize,
+		ChecksumSHA256: workout.ChecksumSHA256,
+		Metadata:       workout.Metadata,
+		ConfirmedAt:    workout.ConfirmedAt,
+		CreatedAt:      workout.CreatedAt,
+		UpdatedAt:      workout.UpdatedAt,
+	}
+
+	// Summaries only exist once a file has been validated
+	if workout.IsConfirmed() {
+		summary := WorkoutSummary(workout.Summary)
+		response.Summary = &summary
+	}
+
+	return response
+}
@@ -1,7 +1,9 @@
 package usecases
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"avironactive.com/common/context"
@@ -11,25 +13,51 @@ import (
 	"avironactive.com/resource/upload"
 	"github.com/google/uuid"
 
+	"github.com/anh-nguyen/resource-server/core"
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
 	"github.com/anh-nguyen/resource-server/internal/domain/entity"
 	"github.com/anh-nguyen/resource-server/internal/domain/repository"
 )
 
+// pruneBatchSize bounds the released icons deleted per run
+const pruneBatchSize = 100
+
+// maxUpdateAttempts bounds how often a change is made again after losing a
+// race with another change to the same achievement
+const maxUpdateAttempts = 3
+
+var (
+	// ErrInvalidAchievementID is returned for achievement IDs that are not UUIDs
+	ErrInvalidAchievementID = domainerr.New(domainerr.Validation, "INVALID_ID", "invalid achievement ID")
+	// ErrRevisionNotRevertible is returned when reverting a revision that
+	// recorded no previous state, such as the one creating the achievement
+	ErrRevisionNotRevertible = domainerr.New(domainerr.PreconditionFailed, "REVISION_NOT_REVERTIBLE", "revision has no previous state to revert to")
+)
+
 type AchievementUseCase struct {
 	achievementRepo repository.AchievementRepository
+	revisionRepo    repository.AchievementRevisionRepository
 	uploadManager   upload.UploadManager
 	resourceManager resource.ResourceManager
+	contentStore    *ContentStore
+	registry        *DefinitionRegistry
 }
 
 func NewAchievementUseCase(
 	achievementRepo repository.AchievementRepository,
+	revisionRepo repository.AchievementRevisionRepository,
 	resourceManager resource.ResourceManager,
+	contentStore *ContentStore,
+	registry *DefinitionRegistry,
 ) *AchievementUseCase {
 	return &AchievementUseCase{
 		achievementRepo: achievementRepo,
+		revisionRepo:    revisionRepo,
 		uploadManager:   resourceManager.UploadManager(),
 		resourceManager: resourceManager,
+		contentStore:    contentStore,
+		registry:        registry,
 	}
 }
 
@@ -37,6 +65,9 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 	achievement := entity.NewAchievement(req.Name, req.Description)
 	achievement.Category = req.Category
 	achievement.Points = req.Points
+	if req.PublishAt != nil || req.UnpublishAt != nil {
+		achievement.SetPublishWindow(req.PublishAt, req.UnpublishAt)
+	}
 
 	var uploadResponse *dto.UploadInfo
 	var iconURL string
@@ -52,7 +83,7 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 
 		pathResult, err := uc.resourceManager.DefinitionResolver().ResolveDownloadURL(
 			ctx,
-			resolver.DefinitionName("achievement"),
+			core.AchievementPathName,
 			opts,
 		)
 		if err != nil {
@@ -61,9 +92,11 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 
 		iconPath := pathResult.ResolvedPath.Path
 		achievement.SetIconPath(iconPath)
+		achievement.IconProvider = req.Provider
 
 		iconURL = pathResult.ObjectURL.URL
-		if err := uc.achievementRepo.Create(ctx.Context(), achievement); err != nil {
+		revision := newRevision(entity.RevisionActionCreate, nil, achievement, req.Provider, req.Actor)
+		if err := uc.achievementRepo.Create(ctx.Context(), achievement, revision); err != nil {
 			return nil, fmt.Errorf("failed to create achievement: %w", err)
 		}
 
@@ -84,6 +117,16 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 		}
 		uploadOpts.WithPathParameters(pathParams)
 
+		constraints, err := uploadConstraints(uc.registry.UploadPolicy(core.AchievementPathName), provider.ProviderName(req.Provider), declaredUpload{
+			ContentType: iconContentTypes[req.IconFormat],
+		})
+		if err != nil {
+			return nil, err
+		}
+		if constraints != nil {
+			uploadOpts.WithConstraints(constraints)
+		}
+
 		uploadRecord, err := uc.uploadManager.InitiateUpload(ctx, uploadOpts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initiate upload: %w", err)
@@ -100,7 +143,8 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 			ExpiresAt: uploadRecord.ExpiresTime.Unix(),
 		}
 	} else {
-		if err := uc.achievementRepo.Create(ctx.Context(), achievement); err != nil {
+		revision := newRevision(entity.RevisionActionCreate, nil, achievement, "", req.Actor)
+		if err := uc.achievementRepo.Create(ctx.Context(), achievement, revision); err != nil {
 			return nil, fmt.Errorf("failed to create achievement: %w", err)
 		}
 	}
@@ -119,7 +163,7 @@ func (uc *AchievementUseCase) CreateAchievement(ctx context.Context, req *dto.Cr
 func (uc *AchievementUseCase) GetAchievement(ctx context.Context, id string) (*dto.AchievementResponse, error) {
 	achievementID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid achievement ID: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
 	}
 
 	achievement, err := uc.achievementRepo.GetByID(ctx.Context(), achievementID)
@@ -127,40 +171,24 @@ func (uc *AchievementUseCase) GetAchievement(ctx context.Context, id string) (*d
 		return nil, fmt.Errorf("failed to get achievement: %w", err)
 	}
 
-	response := dto.NewAchievementResponse(achievement)
-	if achievement.IconPath != "" {
-		resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.IconPath, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		response.IconURL = resolved.ObjectURL.URL
-	}
-	if achievement.BannerPath != "" {
-		resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.BannerPath, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		response.BannerURL = resolved.ObjectURL.URL
-	}
-
-	return response, nil
+	return uc.toAchievementResponse(ctx, achievement)
 }
 
 func (uc *AchievementUseCase) UpdateAchievementIcon(ctx context.Context, req *dto.UpdateIconRequest) (*dto.UpdateIconResponse, error) {
 	achievementID, err := uuid.Parse(req.AchievementID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid achievement ID: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
 	}
 
-	achievement, err := uc.achievementRepo.GetByID(ctx.Context(), achievementID)
+	constraints, err := uploadConstraints(uc.registry.UploadPolicy(core.AchievementPathName), provider.ProviderName(req.Provider), declaredUpload{
+		ContentType: iconContentTypes[req.Format],
+		Size:        req.Size,
+		ClientIP:    req.ClientIP,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get achievement: %w", err)
+		return nil, err
 	}
 
-	oldIconPath := achievement.IconPath
-
 	opts := (&resolver.DefinitionDownloadOptions{}).
 		WithProvider(provider.ProviderName(req.Provider)).
 		WithScope(resolver.ScopeGlobal, 0).
@@ -171,7 +199,7 @@ func (uc *AchievementUseCase) UpdateAchievementIcon(ctx context.Context, req *dt
 
 	pathResult, err := uc.resourceManager.DefinitionResolver().ResolveDownloadURL(
 		ctx,
-		resolver.DefinitionName("achievement"),
+		core.AchievementPathName,
 		opts,
 	)
 	if err != nil {
@@ -183,64 +211,45 @@ func (uc *AchievementUseCase) UpdateAchievementIcon(ctx context.Context, req *dt
 	}
 
 	newIconPath := pathResult.ResolvedPath.Path
-	var uploadRecord *upload.Upload
 
-	if newIconPath != oldIconPath {
+	// The old icon object is retained so the previous revision can be
+	// reverted, until PruneReleasedIcons deletes it
+	_, _, err = uc.updateAchievement(ctx, achievementID, req.Provider, req.Actor, func(achievement *entity.Achievement) bool {
+		if achievement.IconPath == newIconPath && achievement.IconProvider == req.Provider {
+			return false
+		}
 		achievement.IconPath = newIconPath
+		achievement.IconProvider = req.Provider
 		achievement.UpdatedAt = time.Now()
-		if err := uc.achievementRepo.Update(ctx.Context(), achievement); err != nil {
-			return nil, fmt.Errorf("failed to update achievement: %w", err)
-		}
-
-		uploadOpts := &upload.UploadOptions{
-			ResourceType:     "achievement",
-			ResourceID:       achievementID.String(),
-			ResourceField:    "icon_path",
-			ResourceValue:    newIconPath,
-			ResourceProvider: upload.ResourceProvider(req.Provider),
-			UploadType:       upload.UploadTypeSimple,
-			PathDefinition:   "achievement",
-			StorageProvider:  upload.ResourceProvider(req.Provider),
-		}
-
-		pathParams := map[string]string{
-			"achievement_id": achievementID.String(),
-			"format":         req.Format,
-		}
-		uploadOpts.WithPathParameters(pathParams)
-
-		var err error
-		uploadRecord, err = uc.uploadManager.InitiateUpload(ctx, uploadOpts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initiate upload: %w", err)
-		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if oldIconPath != "" && oldIconPath != newIconPath {
-			go uc.cleanupOldFile(ctx, oldIconPath, req.Provider)
-		}
-	} else {
-		uploadOpts := &upload.UploadOptions{
-			ResourceType:     "achievement",
-			ResourceID:       achievementID.String(),
-			ResourceField:    "icon_path",
-			ResourceValue:    newIconPath,
-			ResourceProvider: upload.ResourceProvider(req.Provider),
-			UploadType:       upload.UploadTypeSimple,
-			PathDefinition:   "achievement",
-			StorageProvider:  upload.ResourceProvider(req.Provider),
-		}
+	uploadOpts := &upload.UploadOptions{
+		ResourceType:     "achievement",
+		ResourceID:       achievementID.String(),
+		ResourceField:    "icon_path",
+		ResourceValue:    newIconPath,
+		ResourceProvider: upload.ResourceProvider(req.Provider),
+		UploadType:       upload.UploadTypeSimple,
+		PathDefinition:   "achievement",
+		StorageProvider:  upload.ResourceProvider(req.Provider),
+	}
 
-		pathParams := map[string]string{
-			"achievement_id": achievementID.String(),
-			"format":         req.Format,
-		}
-		uploadOpts.WithPathParameters(pathParams)
+	pathParams := map[string]string{
+		"achievement_id": achievementID.String(),
+		"format":         req.Format,
+	}
+	uploadOpts.WithPathParameters(pathParams)
+	if constraints != nil {
+		uploadOpts.WithConstraints(constraints)
+	}
 
-		var err error
-		uploadRecord, err = uc.uploadManager.InitiateUpload(ctx, uploadOpts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initiate upload: %w", err)
-		}
+	uploadRecord, err := uc.uploadManager.InitiateUpload(ctx, uploadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate upload: %w", err)
 	}
 
 	signedURL, err := uc.uploadManager.GetSimpleUploadURL(ctx, uploadRecord)
@@ -254,43 +263,46 @@ func (uc *AchievementUseCase) UpdateAchievementIcon(ctx context.Context, req *dt
 	}
 
 	return &dto.UpdateIconResponse{
-		UploadID:   uploadRecord.ID.String(),
-		UploadURL:  signedURL.URL,
-		ExpiresAt:  uploadRecord.ExpiresTime.Unix(),
-		NewIconURL: newIconResolved.ResolvedPath.Path,
+		UploadID:    uploadRecord.ID.String(),
+		UploadURL:   signedURL.URL,
+		ExpiresAt:   uploadRecord.ExpiresTime.Unix(),
+		NewIconURL:  newIconResolved.ResolvedPath.Path,
+		Constraints: dto.NewUploadConstraintsResponse(constraints),
 	}, nil
 }
 
+// iconContentTypes maps icon formats to the content type pinned in their
+// upload URLs
+var iconContentTypes = map[string]string{
+	"png":  "image/png",
+	"jpg":  "image/jpeg",
+	"svg":  "image/svg+xml",
+	"webp": "image/webp",
+}
+
+// ConfirmUpload confirms an icon upload. Confirmed icons are moved to their
+// content-addressed blob, so icons identical to a stored one are not kept twice.
 func (uc *AchievementUseCase) ConfirmUpload(ctx context.Context, req *dto.ConfirmUploadRequest) error {
 	uploadID, err := uuid.Parse(req.UploadID)
 	if err != nil {
-		return fmt.Errorf("invalid upload ID: %w", err)
+		return fmt.Errorf("%w: %v", ErrInvalidUploadID, err)
 	}
 
-	confirmation := &upload.UploadConfirmation{
-		Success:  req.Success,
-		Error:    req.ErrorMsg,
-		FileSize: req.FileSize,
-		Metadata: req.Metadata,
+	confirmation := newUploadConfirmation(req)
+	if err := uc.uploadManager.ConfirmUpload(ctx, upload.UploadID(uploadID), confirmation); err != nil {
+		return err
+	}
+	if !confirmation.Success {
+		return nil
 	}
 
-	if req.ETags != nil {
-		if etags, ok := req.ETags.([]dto.PartETag); ok {
-			uploadETags := make([]upload.PartETag, len(etags))
-			for i, etag := range etags {
-				uploadETags[i] = upload.PartETag{
-					Part: etag.Part,
-					ETag: etag.ETag,
-					Size: etag.Size,
-				}
-			}
-			confirmation.ETags = uploadETags
-		} else if etag, ok := req.ETags.(string); ok {
-			confirmation.ETags = []upload.PartETag{{Part: 1, ETag: etag}}
-		}
+	record, err := uc.uploadManager.GetUpload(ctx, upload.UploadID(uploadID))
+	if err != nil {
+		return fmt.Errorf("failed to get upload: %w", err)
 	}
 
-	return uc.uploadManager.ConfirmUpload(ctx, upload.UploadID(uploadID), confirmation)
+	_, err = uc.contentStore.Deduplicate(ctx, record.PathDefinition, provider.ProviderName(record.StorageProvider), record.ResourceValue)
+	return err
 }
 
 func (uc *AchievementUseCase) ListAchievements(ctx context.Context, offset, limit int) ([]*dto.AchievementResponse, error) {
@@ -299,64 +311,325 @@ func (uc *AchievementUseCase) ListAchievements(ctx context.Context, offset, limi
 		return nil, fmt.Errorf("failed to list achievements: %w", err)
 	}
 
+	return uc.toAchievementResponses(ctx, achievements)
+}
+
+func (uc *AchievementUseCase) ListActiveAchievements(ctx context.Context, offset, limit int) ([]*dto.AchievementResponse, error) {
+	achievements, err := uc.achievementRepo.ListActive(ctx.Context(), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active achievements: %w", err)
+	}
+
+	return uc.toAchievementResponses(ctx, achievements)
+}
+
+// PreviewActiveAchievements lists the achievements that will be active at the
+// given time according to their publishing windows.
+func (uc *AchievementUseCase) PreviewActiveAchievements(ctx context.Context, at time.Time, offset, limit int) ([]*dto.AchievementResponse, error) {
+	achievements, err := uc.achievementRepo.ListActiveAt(ctx.Context(), at, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview active achievements: %w", err)
+	}
+
+	return uc.toAchievementResponses(ctx, achievements)
+}
+
+func (uc *AchievementUseCase) UpdateAchievementSchedule(ctx context.Context, req *dto.UpdateScheduleRequest) (*dto.AchievementResponse, error) {
+	achievementID, err := uuid.Parse(req.AchievementID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
+	}
+
+	_, _, err = uc.updateAchievement(ctx, achievementID, "", req.Actor, func(achievement *entity.Achievement) bool {
+		achievement.SetPublishWindow(req.PublishAt, req.UnpublishAt)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetAchievement(ctx, req.AchievementID)
+}
+
+// ApplyPublicationWindows flips is_active on every scheduled achievement whose
+// window opened or closed by the given time and returns the resulting events.
+// Each achievement is checked again as stored, so one changed since it was
+// listed is only flipped if its current window calls for it.
+func (uc *AchievementUseCase) ApplyPublicationWindows(ctx context.Context, at time.Time) ([]entity.AchievementPublicationEvent, error) {
+	pending, err := uc.achievementRepo.ListPendingPublication(ctx.Context(), at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending publications: %w", err)
+	}
+
+	events := make([]entity.AchievementPublicationEvent, 0, len(pending))
+	for _, listed := range pending {
+		achievement, changed, err := uc.updateAchievement(ctx, listed.ID, "", schedulerActor, func(achievement *entity.Achievement) bool {
+			active := achievement.ActiveAt(at)
+			if active == achievement.IsActive {
+				return false
+			}
+			achievement.IsActive = active
+			achievement.UpdatedAt = time.Now()
+			return true
+		})
+		if errors.Is(err, repository.ErrAchievementNotFound) {
+			continue
+		}
+		if err != nil {
+			return events, fmt.Errorf("failed to update achievement %s: %w", listed.ID, err)
+		}
+		if !changed {
+			continue
+		}
+
+		eventType := entity.PublicationEventUnpublished
+		if achievement.IsActive {
+			eventType = entity.PublicationEventPublished
+		}
+		events = append(events, entity.AchievementPublicationEvent{
+			AchievementID: achievement.ID,
+			Type:          eventType,
+			OccurredAt:    at,
+		})
+	}
+
+	return events, nil
+}
+
+func (uc *AchievementUseCase) toAchievementResponses(ctx context.Context, achievements []*entity.Achievement) ([]*dto.AchievementResponse, error) {
 	result := make([]*dto.AchievementResponse, len(achievements))
 	for i, achievement := range achievements {
-		result[i] = dto.NewAchievementResponse(achievement)
-		if achievement.IconPath != "" {
-			resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.IconPath, nil)
-			if err != nil {
-				return nil, err
-			}
+		response, err := uc.toAchievementResponse(ctx, achievement)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = response
+	}
 
-			result[i].IconURL = resolved.ObjectURL.URL
+	return result, nil
+}
+
+// toAchievementResponse signs the achievement's icon and banner URLs. Icons
+// of the content-addressed achievement definition are signed for their blob.
+func (uc *AchievementUseCase) toAchievementResponse(ctx context.Context, achievement *entity.Achievement) (*dto.AchievementResponse, error) {
+	response := dto.NewAchievementResponse(achievement)
+	if achievement.IconPath != "" {
+		iconPath, err := uc.contentStore.Resolve(ctx, provider.ProviderName(achievement.IconProvider), achievement.IconPath)
+		if err != nil {
+			return nil, err
 		}
-		if achievement.BannerPath != "" {
-			resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.BannerPath, nil)
-			if err != nil {
-				return nil, err
-			}
 
-			result[i].BannerURL = resolved.ObjectURL.URL
+		resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, iconPath, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response.IconURL = resolved.ObjectURL.URL
+	}
+	if achievement.BannerPath != "" {
+		resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.BannerPath, nil)
+		if err != nil {
+			return nil, err
 		}
+
+		response.BannerURL = resolved.ObjectURL.URL
+	}
+
+	return response, nil
+}
+
+func (uc *AchievementUseCase) DeleteAchievement(ctx context.Context, id, actor string) error {
+	achievementID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
+	}
+
+	achievement, err := uc.achievementRepo.GetByID(ctx.Context(), achievementID)
+	if err != nil {
+		return fmt.Errorf("failed to get achievement: %w", err)
+	}
+
+	revision := newRevision(entity.RevisionActionDelete, achievement, nil, achievement.IconProvider, actor)
+	if err := uc.achievementRepo.Delete(ctx.Context(), achievementID, revision); err != nil {
+		return fmt.Errorf("failed to delete achievement: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *AchievementUseCase) GetAchievementHistory(ctx context.Context, id string, offset, limit int) ([]*dto.AchievementRevisionResponse, error) {
+	achievementID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
+	}
+
+	revisions, err := uc.revisionRepo.ListByAchievement(ctx.Context(), achievementID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievement revisions: %w", err)
+	}
+
+	result := make([]*dto.AchievementRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		result[i] = dto.NewAchievementRevisionResponse(revision)
 	}
 
 	return result, nil
 }
 
-func (uc *AchievementUseCase) ListActiveAchievements(ctx context.Context, offset, limit int) ([]*dto.AchievementResponse, error) {
-	achievements, err := uc.achievementRepo.ListActive(ctx.Context(), offset, limit)
+// RevertAchievement restores the achievement to the state it had before the
+// given revision was applied. Deleted achievements are re-created. The icon
+// path is only restored when the previous icon object still exists in storage.
+func (uc *AchievementUseCase) RevertAchievement(ctx context.Context, id string, revisionNumber int, actor string) (*dto.RevertAchievementResponse, error) {
+	achievementID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list active achievements: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAchievementID, err)
 	}
 
-	result := make([]*dto.AchievementResponse, len(achievements))
-	for i, achievement := range achievements {
-		result[i] = dto.NewAchievementResponse(achievement)
-		if achievement.IconPath != "" {
-			resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.IconPath, nil)
-			if err != nil {
-				return nil, err
-			}
+	revision, err := uc.revisionRepo.GetByRevision(ctx.Context(), achievementID, revisionNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+	if revision.Previous == nil {
+		return nil, fmt.Errorf("%w: revision %d", ErrRevisionNotRevertible, revisionNumber)
+	}
 
-			result[i].IconURL = resolved.ObjectURL.URL
+	latest, err := uc.revisionRepo.ListByAchievement(ctx.Context(), achievementID, 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest revision: %w", err)
+	}
+	deleted := len(latest) > 0 && latest[0].Action == entity.RevisionActionDelete
+
+	var current *entity.Achievement
+	if !deleted {
+		current, err = uc.achievementRepo.GetByID(ctx.Context(), achievementID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get achievement: %w", err)
 		}
-		if achievement.BannerPath != "" {
-			resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, achievement.BannerPath, nil)
-			if err != nil {
-				return nil, err
-			}
+	}
+
+	restored := *revision.Previous
+	restored.UpdatedAt = time.Now()
+	if restored.IconPath != "" && restored.IconProvider == "" {
+		// Snapshots taken before achievements recorded their icon provider
+		restored.IconProvider = revision.IconProvider
+	}
 
-			result[i].BannerURL = resolved.ObjectURL.URL
+	// Icons released longer than the retention period ago may be pruned
+	iconRestored := true
+	if current == nil || restored.IconPath != current.IconPath {
+		iconRestored = uc.iconRetained(ctx, restored.IconProvider, restored.IconPath)
+		if !iconRestored && current != nil {
+			restored.IconPath = current.IconPath
+			restored.IconProvider = current.IconProvider
+		} else if !iconRestored {
+			restored.IconPath = ""
+			restored.IconProvider = ""
 		}
 	}
 
-	return result, nil
+	restoredRevision := newRevision(entity.RevisionActionRevert, current, &restored, restored.IconProvider, actor)
+	if deleted {
+		err = uc.achievementRepo.Create(ctx.Context(), &restored, restoredRevision)
+	} else {
+		err = uc.achievementRepo.Update(ctx.Context(), &restored, restoredRevision)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore achievement: %w", err)
+	}
+
+	response, err := uc.GetAchievement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RevertAchievementResponse{
+		Achievement:  response,
+		Revision:     revisionNumber,
+		IconRestored: iconRestored,
+	}, nil
 }
 
-func (uc *AchievementUseCase) cleanupOldFile(ctx context.Context, path, providerName string) {
-	err := uc.resourceManager.DeleteObject(ctx, provider.ProviderName(providerName), path)
+// updateAchievement applies change to the achievement as stored and writes it
+// with a revision recording the change. When another change is written
+// between the read and the write, the achievement is read and changed again.
+// change reports whether there is anything to write; the stored achievement is
+// returned either way.
+func (uc *AchievementUseCase) updateAchievement(ctx context.Context, id uuid.UUID, iconProvider, actor string, change func(achievement *entity.Achievement) bool) (*entity.Achievement, bool, error) {
+	for attempt := 1; ; attempt++ {
+		achievement, err := uc.achievementRepo.GetByID(ctx.Context(), id)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get achievement: %w", err)
+		}
+
+		previous := *achievement
+		if !change(achievement) {
+			return achievement, false, nil
+		}
+
+		revision := newRevision(entity.RevisionActionUpdate, &previous, achievement, iconProvider, actor)
+		err = uc.achievementRepo.Update(ctx.Context(), achievement, revision)
+		if errors.Is(err, repository.ErrAchievementModified) && attempt < maxUpdateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to update achievement: %w", err)
+		}
+		return achievement, true, nil
+	}
+}
+
+// iconRetained reports whether the icon object at path, or the blob it is
+// mapped to, still exists.
+func (uc *AchievementUseCase) iconRetained(ctx context.Context, providerName, path string) bool {
+	if path == "" {
+		return true
+	}
+	if providerName == "" {
+		return false
+	}
+
+	storedPath, err := uc.contentStore.Resolve(ctx, provider.ProviderName(providerName), path)
 	if err != nil {
-		// Log error but don't fail the main operation
-		fmt.Printf("Failed to delete old file %s: %v\n", path, err)
+		return false
 	}
+
+	_, err = uc.resourceManager.GetObjectMetadata(ctx, provider.ProviderName(providerName), storedPath)
+	return err == nil
+}
+
+// PruneReleasedIcons deletes the icons that revisions replaced or deleted
+// more than retention ago and that are no longer used. Reverting those
+// revisions afterwards keeps the achievement's current icon. A failure to
+// delete one icon is logged and does not stop the run.
+func (uc *AchievementUseCase) PruneReleasedIcons(ctx context.Context, retention time.Duration) (int, error) {
+	icons, err := uc.revisionRepo.ListReleasedIcons(ctx.Context(), time.Now().Add(-retention), pruneBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list released icons: %w", err)
+	}
+
+	pruned := 0
+	for _, icon := range icons {
+		if ctx.Context().Err() != nil {
+			break
+		}
+
+		if err := uc.contentStore.Delete(ctx, provider.ProviderName(icon.Provider), icon.Path); err != nil {
+			log.Printf("Failed to prune icon %s of achievement %s: %v", icon.Path, icon.AchievementID, err)
+			continue
+		}
+		if err := uc.revisionRepo.MarkIconPruned(ctx.Context(), icon); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+
+	return pruned, nil
+}
+
+// newRevision records a change to an achievement; iconProvider is the
+// provider of the icon the change leaves the achievement with
+func newRevision(action entity.RevisionAction, previous, current *entity.Achievement, iconProvider, actor string) *entity.AchievementRevision {
+	revision := entity.NewAchievementRevision(action, previous, current, actor)
+	revision.IconProvider = iconProvider
+	return revision
 }
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+// schedulerActor is recorded in achievement history for automatic changes.
+const schedulerActor = "scheduler"
+
+// schedulerLock is held by the instance running the scheduler, so replicas
+// do not apply the same windows or emit the same events twice
+const schedulerLock = "achievement-scheduler"
+
+// PublicationListener receives events when achievement publishing windows open or close
+type PublicationListener func(event entity.AchievementPublicationEvent)
+
+// AchievementScheduler periodically applies achievement publishing windows
+// and prunes the icons released longer than the retention period ago
+type AchievementScheduler struct {
+	useCase       *AchievementUseCase
+	locker        repository.Locker
+	interval      time.Duration
+	iconRetention time.Duration
+	listeners     []PublicationListener
+
+	mu     sync.Mutex
+	stop   chan struct{}
+	doneWg sync.WaitGroup
+}
+
+// NewAchievementScheduler creates a new achievement scheduler
+func NewAchievementScheduler(useCase *AchievementUseCase, locker repository.Locker, interval, iconRetention time.Duration) *AchievementScheduler {
+	return &AchievementScheduler{
+		useCase:       useCase,
+		locker:        locker,
+		interval:      interval,
+		iconRetention: iconRetention,
+	}
+}
+
+// OnPublication registers a listener for publication events
+func (s *AchievementScheduler) OnPublication(listener PublicationListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// Start runs the scheduler in the background until Stop is called
+func (s *AchievementScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	s.doneWg.Add(1)
+	go func(stop <-chan struct{}) {
+		defer s.doneWg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.RunOnce(ctx); err != nil {
+				log.Printf("Achievement scheduler run failed: %v", err)
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-ctx.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(s.stop)
+}
+
+// Stop halts the background loop and waits for the current run to finish
+func (s *AchievementScheduler) Stop() {
+	s.mu.Lock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	s.mu.Unlock()
+
+	s.doneWg.Wait()
+}
+
+// RunOnce applies publishing windows as of now, notifies listeners and
+// prunes released icons. It does nothing while another instance runs.
+func (s *AchievementScheduler) RunOnce(ctx context.Context) ([]entity.AchievementPublicationEvent, error) {
+	var events []entity.AchievementPublicationEvent
+	_, err := s.locker.TryRun(ctx.Context(), schedulerLock, func() error {
+		var err error
+		events, err = s.run(ctx)
+		return err
+	})
+	return events, err
+}
+
+func (s *AchievementScheduler) run(ctx context.Context) ([]entity.AchievementPublicationEvent, error) {
+	events, err := s.useCase.ApplyPublicationWindows(ctx, time.Now())
+	for _, event := range events {
+		for _, listener := range s.listeners {
+			listener(event)
+		}
+	}
+	if err != nil {
+		return events, err
+	}
+
+	if _, err := s.useCase.PruneReleasedIcons(ctx, s.iconRetention); err != nil {
+		return events, err
+	}
+	return events, nil
+}
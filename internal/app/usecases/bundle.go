@@ -0,0 +1,566 @@
+package usecases
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+var (
+	// ErrEmptyBundle is returned when a bundle selects no files
+	ErrEmptyBundle = domainerr.New(domainerr.NotFound, "EMPTY_BUNDLE", "bundle selects no files")
+	// ErrBundleTooLarge is returned when a bundle selects more than
+	// dto.MaxBundleItems files
+	ErrBundleTooLarge = domainerr.New(domainerr.Validation, "BUNDLE_TOO_LARGE", "bundle selects too many files")
+	// ErrInvalidBundlePath is returned when a bundle selects a path outside
+	// its definition, a path stored for the server's own use, or a path
+	// that would escape the archive
+	ErrInvalidBundlePath = domainerr.New(domainerr.Validation, "INVALID_BUNDLE_PATH", "invalid bundle path")
+)
+
+// bundleManifestName is the archive entry holding the bundle manifest
+const bundleManifestName = "manifest.json"
+
+// bundleCacheDir is the directory below a definition's top-level path
+// holding cached bundles
+const bundleCacheDir = ".bundles"
+
+// bundleCachePruneLimit bounds the cached bundles examined for expiry each
+// time a bundle is cached
+const bundleCachePruneLimit int32 = 100
+
+// isInternalPath reports whether key is stored by the server for its own
+// use, a content-addressed blob or a cached bundle, rather than a file
+func isInternalPath(key string) bool {
+	return core.IsBlobPath(key) || strings.Contains("/"+key, "/"+bundleCacheDir+"/")
+}
+
+// Bundle is an archive of many files streamed through the server. Size is -1
+// when the archive is built while streaming.
+type Bundle struct {
+	Hash   string
+	Format string
+	Cached bool
+	Size   int64
+	Body   io.ReadCloser
+}
+
+// ContentType returns the media type of the archive
+func (b *Bundle) ContentType() string {
+	if b.Format == dto.BundleFormatTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// FileName names the archive after the definition and its hash
+func (b *Bundle) FileName(definition string) string {
+	return fmt.Sprintf("%s-%s.%s", definition, b.Hash[:12], b.Format)
+}
+
+// bundleFile is a selected file with the fields its archive entry needs
+type bundleFile struct {
+	dto.BundleManifestEntry
+	Modified time.Time
+	// StoredPath holds the contents, which is the blob of a content-addressed file
+	StoredPath string
+}
+
+// DownloadBundle archives the selected files with a manifest.json of their
+// keys, sizes and checksums. The archive is built while streaming. With
+// caching, an archive stored for the same hash within the cache TTL is served
+// instead, and a new one is stored before it is served. Archives are cached
+// below the path of the definition's top-level definition for the request's
+// scope, so they stay within the environment and scope of their files;
+// bundles whose scope does not resolve that path are not cached.
+func (uc *FileOperationsUseCase) DownloadBundle(ctx context.Context, req *dto.BundleRequest) (*Bundle, error) {
+	providerName := provider.ProviderName(req.Provider)
+
+	files, err := uc.bundleFiles(ctx, providerName, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Hash:   bundleHash(files),
+		Format: req.Format,
+		Size:   -1,
+	}
+	if bundle.Format == "" {
+		bundle.Format = dto.BundleFormatZip
+	}
+
+	if !req.Cache {
+		bundle.Body = uc.streamBundle(ctx, providerName, bundle, files)
+		return bundle, nil
+	}
+
+	cacheDir, err := uc.bundleCacheDir(ctx, providerName, req)
+	if err != nil {
+		log.Printf("Not caching bundle %s: %v", bundle.Hash, err)
+		bundle.Body = uc.streamBundle(ctx, providerName, bundle, files)
+		return bundle, nil
+	}
+
+	cachePath := path.Join(cacheDir, bundle.Hash+"."+bundle.Format)
+	if cached, err := uc.manager.GetObjectMetadata(ctx, providerName, cachePath); err == nil && !uc.bundleExpired(cached) {
+		body, err := uc.objects.open(ctx, providerName, cachePath, nil)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Cached = true
+		bundle.Size = cached.Size
+		bundle.Body = body
+		return bundle, nil
+	}
+
+	prov, err := uc.manager.GetProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+	writer, ok := prov.(objectWriter)
+	if !ok {
+		// Nowhere to store the archive, so build it while streaming
+		bundle.Body = uc.streamBundle(ctx, providerName, bundle, files)
+		return bundle, nil
+	}
+
+	body, size, err := uc.cacheBundle(ctx, providerName, writer, cachePath, bundle, files)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Size = size
+	bundle.Body = body
+
+	uc.pruneBundleCache(ctx, providerName, req.Definition, cacheDir)
+
+	return bundle, nil
+}
+
+// bundleCacheDir resolves the directory cached bundles of the request are
+// stored in. Child definitions cache below their parent's path, which
+// depends on the scope alone.
+func (uc *FileOperationsUseCase) bundleCacheDir(ctx context.Context, providerName provider.ProviderName, req *dto.BundleRequest) (string, error) {
+	definition := req.Definition
+	parameters := req.Parameters
+	if parent := parentDefinition(uc.manager, resolver.DefinitionName(definition)); parent != nil {
+		definition = string(parent.Name)
+		parameters = nil
+	}
+
+	root, err := resolveDefinitionRoot(ctx, uc.manager, definition, providerName, req.Scope, req.ScopeValue, parameters)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve bundle cache path: %w", err)
+	}
+	return path.Join(root, bundleCacheDir), nil
+}
+
+// bundleExpired reports whether a cached bundle is older than the cache TTL
+func (uc *FileOperationsUseCase) bundleExpired(cached *provider.ObjectMetadata) bool {
+	return uc.bundleCacheTTL > 0 && cached.LastModified != nil && time.Since(*cached.LastModified) > uc.bundleCacheTTL
+}
+
+// pruneBundleCache deletes expired bundles from the cache directory. Bundle
+// hashes change with their files, so replaced bundles are never read again
+// and are only removed here. Failures are logged; the next cached bundle
+// retries them.
+func (uc *FileOperationsUseCase) pruneBundleCache(ctx context.Context, providerName provider.ProviderName, definition, cacheDir string) {
+	if uc.bundleCacheTTL <= 0 {
+		return
+	}
+
+	prefix := cacheDir + "/"
+	maxKeys := bundleCachePruneLimit
+	result, err := uc.manager.ListObjects(ctx, providerName, definition, &provider.ListObjectsOptions{
+		MaxKeys: &maxKeys,
+		Prefix:  &prefix,
+	})
+	if err != nil {
+		log.Printf("Failed to list cached bundles in %s: %v", cacheDir, err)
+		return
+	}
+
+	for _, object := range result.Objects {
+		if object.LastModified == nil || time.Since(*object.LastModified) <= uc.bundleCacheTTL {
+			continue
+		}
+		if err := uc.manager.DeleteObject(ctx, providerName, object.Key); err != nil {
+			log.Printf("Failed to delete expired bundle %s: %v", object.Key, err)
+		}
+	}
+}
+
+// bundleFiles returns the selected files ordered by archive name. Explicit
+// paths are named by their key and must lie within the definition; listed
+// files are named by their path below the definition's resolved path.
+func (uc *FileOperationsUseCase) bundleFiles(ctx context.Context, providerName provider.ProviderName, req *dto.BundleRequest) ([]bundleFile, error) {
+	var root string
+	keys := req.Paths
+	if len(keys) == 0 {
+		var err error
+		root, keys, err = uc.listBundleKeys(ctx, providerName, req)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := uc.checkBundlePaths(providerName, req.Definition, keys); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(keys))
+	files := make([]bundleFile, 0, len(keys))
+	for _, key := range keys {
+		name, err := bundleEntryName(key, root)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		files = append(files, bundleFile{BundleManifestEntry: dto.BundleManifestEntry{Name: name, Key: key}})
+	}
+	if len(files) == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	errs := make([]error, len(files))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, batchConcurrency)
+	for i := range files {
+		if err := ctx.Context().Err(); err != nil {
+			errs[i] = err
+			break
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			storedPath, err := uc.contentStore.Resolve(ctx, providerName, files[i].Key)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			files[i].StoredPath = storedPath
+
+			objectMetadata, err := uc.manager.GetObjectMetadata(ctx, providerName, storedPath)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get file metadata for %s: %w", files[i].Key, err)
+				return
+			}
+
+			files[i].Size = objectMetadata.Size
+			files[i].ContentType = objectMetadata.ContentType
+			files[i].ETag = objectMetadata.ETag
+			for _, checksum := range objectMetadata.Checksums {
+				files[i].Checksums = append(files[i].Checksums, dto.ChecksumInfo{Algorithm: string(checksum.Algorithm), Value: checksum.Value})
+			}
+			if objectMetadata.LastModified != nil {
+				files[i].Modified = *objectMetadata.LastModified
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(files, func(a, b bundleFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return files, nil
+}
+
+// checkBundlePaths rejects explicit paths outside the definition and paths
+// stored for the server's own use
+func (uc *FileOperationsUseCase) checkBundlePaths(providerName provider.ProviderName, definition string, keys []string) error {
+	def, err := uc.manager.GetDefinition(resolver.DefinitionName(definition))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDefinitionNotFound, err)
+	}
+
+	for _, key := range keys {
+		if isInternalPath(key) {
+			return fmt.Errorf("%w: %s is managed by the server", ErrInvalidBundlePath, key)
+		}
+		if !definitionCovers(uc.manager, def, providerName, key) {
+			return fmt.Errorf("%w: %s is outside definition %s", ErrInvalidBundlePath, key, definition)
+		}
+	}
+	return nil
+}
+
+// bundleEntryName names the file at key within the archive, relative to
+// root when one is given. Names never climb out of the archive.
+func bundleEntryName(key, root string) (string, error) {
+	if slices.Contains(strings.Split(key, "/"), "..") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidBundlePath, key)
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if root != "" {
+		name = strings.TrimPrefix(name, root+"/")
+	}
+	return name, nil
+}
+
+// listBundleKeys lists the files under the prefix, resolved below the
+// definition's path when a scope or parameters are given. It returns the
+// root archive names are relative to.
+func (uc *FileOperationsUseCase) listBundleKeys(ctx context.Context, providerName provider.ProviderName, req *dto.BundleRequest) (string, []string, error) {
+	var root string
+	prefix := req.Prefix
+	if req.Scope != "" || len(req.Parameters) > 0 {
+		var err error
+		root, err = resolveDefinitionRoot(ctx, uc.manager, req.Definition, providerName, req.Scope, req.ScopeValue, req.Parameters)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to resolve definition path: %w", err)
+		}
+		prefix = root + "/" + strings.TrimPrefix(req.Prefix, "/")
+	}
+
+	var (
+		keys  []string
+		token string
+	)
+	for {
+		maxKeys := int32(dto.MaxBundleItems + 1 - len(keys))
+		opts := &provider.ListObjectsOptions{
+			MaxKeys: &maxKeys,
+			Prefix:  &prefix,
+		}
+		if token != "" {
+			opts.ContinuationToken = &token
+		}
+
+		result, err := uc.manager.ListObjects(ctx, providerName, req.Definition, opts)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range result.Objects {
+			if !isInternalPath(object.Key) {
+				keys = append(keys, object.Key)
+			}
+		}
+		if len(keys) > dto.MaxBundleItems {
+			return "", nil, fmt.Errorf("%w: more than %d files under %s", ErrBundleTooLarge, dto.MaxBundleItems, prefix)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == nil {
+			break
+		}
+		token = *result.NextContinuationToken
+	}
+
+	// Content-addressed files live in blobs, so only their references list them
+	referenced, err := uc.contentStore.ListPaths(ctx, providerName, req.Definition, prefix, dto.MaxBundleItems+1-len(keys))
+	if err != nil {
+		return "", nil, err
+	}
+	keys = append(keys, referenced...)
+	if len(keys) > dto.MaxBundleItems {
+		return "", nil, fmt.Errorf("%w: more than %d files under %s", ErrBundleTooLarge, dto.MaxBundleItems, prefix)
+	}
+
+	return root, keys, nil
+}
+
+// bundleHash identifies the bundle by the names and versions of its files, so
+// it changes whenever any file does
+func bundleHash(files []bundleFile) string {
+	hasher := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(hasher, "%s\x00%s\x00%d\x00%s\x00%d\n", file.Name, file.Key, file.Size, file.ETag, file.Modified.UnixNano())
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// streamBundle builds the archive in the background as the returned body is
+// read. Closing the body stops the build.
+func (uc *FileOperationsUseCase) streamBundle(ctx context.Context, providerName provider.ProviderName, bundle *Bundle, files []bundleFile) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(uc.writeBundle(ctx, writer, providerName, bundle, files))
+	}()
+	return reader
+}
+
+// cacheBundle builds the archive in a temporary file, stores it at cachePath
+// and returns the file for serving. A failed store is logged and the archive
+// is served anyway.
+func (uc *FileOperationsUseCase) cacheBundle(ctx context.Context, providerName provider.ProviderName, writer objectWriter, cachePath string, bundle *Bundle, files []bundleFile) (io.ReadCloser, int64, error) {
+	archive, err := os.CreateTemp("", "bundle-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	body := &tempFile{archive}
+
+	if err := uc.writeBundle(ctx, archive, providerName, bundle, files); err != nil {
+		body.Close()
+		return nil, 0, err
+	}
+
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = archive.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		body.Close()
+		return nil, 0, fmt.Errorf("failed to read bundle file: %w", err)
+	}
+
+	headers := &metadata.StorageMetadata{ContentType: bundle.ContentType()}
+	if err := writer.PutObject(ctx.Context(), cachePath, archive, size, headers); err != nil {
+		log.Printf("Failed to cache bundle %s: %v", cachePath, err)
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		body.Close()
+		return nil, 0, fmt.Errorf("failed to read bundle file: %w", err)
+	}
+
+	return body, size, nil
+}
+
+// writeBundle writes the manifest followed by every file in the bundle's format
+func (uc *FileOperationsUseCase) writeBundle(ctx context.Context, w io.Writer, providerName provider.ProviderName, bundle *Bundle, files []bundleFile) error {
+	manifest := dto.BundleManifest{
+		Hash:  bundle.Hash,
+		Files: make([]dto.BundleManifestEntry, len(files)),
+	}
+	for i, file := range files {
+		manifest.Files[i] = file.BundleManifestEntry
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	if bundle.Format == dto.BundleFormatTarGz {
+		return uc.writeTarGz(ctx, w, providerName, manifestJSON, files)
+	}
+	return uc.writeZip(ctx, w, providerName, manifestJSON, files)
+}
+
+func (uc *FileOperationsUseCase) writeZip(ctx context.Context, w io.Writer, providerName provider.ProviderName, manifestJSON []byte, files []bundleFile) error {
+	archive := zip.NewWriter(w)
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: bundleManifestName, Method: zip.Deflate})
+	if err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+	if _, err := entry.Write(manifestJSON); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	for _, file := range files {
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.Name,
+			Method:   zip.Deflate,
+			Modified: file.Modified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write bundle entry %s: %w", file.Name, err)
+		}
+		if err := uc.copyBundleFile(ctx, entry, providerName, file); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return nil
+}
+
+func (uc *FileOperationsUseCase) writeTarGz(ctx context.Context, w io.Writer, providerName provider.ProviderName, manifestJSON []byte, files []bundleFile) error {
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+
+	header := &tar.Header{Name: bundleManifestName, Mode: 0o644, Size: int64(len(manifestJSON))}
+	if err := archive.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+	if _, err := archive.Write(manifestJSON); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    file.Name,
+			Mode:    0o644,
+			Size:    file.Size,
+			ModTime: file.Modified,
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write bundle entry %s: %w", file.Name, err)
+		}
+		if err := uc.copyBundleFile(ctx, archive, providerName, file); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return nil
+}
+
+// copyBundleFile copies a file's contents into its archive entry, failing if
+// the file changed size since its metadata was read
+func (uc *FileOperationsUseCase) copyBundleFile(ctx context.Context, w io.Writer, providerName provider.ProviderName, file bundleFile) error {
+	body, err := uc.objects.open(ctx, providerName, file.StoredPath, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return fmt.Errorf("failed to write bundle entry %s: %w", file.Name, err)
+	}
+	if n != file.Size {
+		return fmt.Errorf("file %s changed while bundling: expected %d bytes, read %d", file.Key, file.Size, n)
+	}
+	return nil
+}
+
+// tempFile removes the file once it is closed
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
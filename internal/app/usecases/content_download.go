@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/provider"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// RangeNotSatisfiableError is returned when the requested range starts
+// beyond the end of the object
+type RangeNotSatisfiableError struct {
+	Size int64
+}
+
+func (e *RangeNotSatisfiableError) Error() string {
+	return fmt.Sprintf("requested range not satisfiable for object of %d bytes", e.Size)
+}
+
+// ByteRange is an inclusive byte range of an object
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// ContentRange formats the range as a Content-Range header value
+func (r *ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.End, size)
+}
+
+// ContentDownload is an object streamed through the server. Body is nil when
+// the client's cached copy is current or only headers were requested.
+type ContentDownload struct {
+	Metadata           *provider.ObjectMetadata
+	ETag               string
+	NotModified        bool
+	Range              *ByteRange
+	Length             int64
+	ContentDisposition string
+	Body               io.ReadCloser
+}
+
+// DownloadContent evaluates the client's conditional and Range headers
+// against the object's metadata and opens the requested bytes. Paths of
+// content-addressed files are read from their blob.
+func (uc *FileOperationsUseCase) DownloadContent(ctx context.Context, req *dto.ContentDownloadRequest) (*ContentDownload, error) {
+	providerName := provider.ProviderName(req.Provider)
+	filePath, err := uc.contentStore.Resolve(ctx, providerName, req.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := uc.manager.GetObjectMetadata(ctx, providerName, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+
+	download := &ContentDownload{
+		Metadata:           object,
+		ETag:               quoteETag(object.ETag),
+		Length:             object.Size,
+		ContentDisposition: contentDisposition(object, req),
+	}
+
+	if notModified(req, download.ETag, object.LastModified) {
+		download.NotModified = true
+		return download, nil
+	}
+
+	if req.Range != "" && rangeApplies(req.IfRange, download.ETag, object.LastModified) {
+		byteRange, err := parseRange(req.Range, object.Size)
+		if err != nil {
+			return nil, err
+		}
+		if byteRange != nil {
+			download.Range = byteRange
+			download.Length = byteRange.End - byteRange.Start + 1
+		}
+	}
+
+	if req.SkipBody {
+		return download, nil
+	}
+
+	body, err := uc.objects.open(ctx, providerName, filePath, download.Range)
+	if err != nil {
+		return nil, err
+	}
+	download.Body = body
+
+	return download, nil
+}
+
+// notModified reports whether the client's cached copy is current.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(req *dto.ContentDownloadRequest, etag string, lastModified *time.Time) bool {
+	if req.IfNoneMatch != "" {
+		for _, candidate := range strings.Split(req.IfNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || (etag != "" && weakETag(candidate) == weakETag(etag)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if req.IfModifiedSince == "" || lastModified == nil {
+		return false
+	}
+	since, err := http.ParseTime(req.IfModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// rangeApplies reports whether the If-Range validator, if any, still matches
+// the object. A stale validator means the full object is sent instead.
+func rangeApplies(ifRange, etag string, lastModified *time.Time) bool {
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		// If-Range requires a strong comparison
+		return etag != "" && !strings.HasPrefix(etag, "W/") && ifRange == etag
+	}
+
+	date, err := http.ParseTime(ifRange)
+	return err == nil && lastModified != nil && lastModified.Truncate(time.Second).Equal(date)
+}
+
+// parseRange parses a single-range Range header. Headers it cannot use,
+// including multiple ranges, return a nil range so the full object is sent.
+func parseRange(header string, size int64) (*ByteRange, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+
+	if first == "" {
+		// Suffix range: the last N bytes
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return nil, nil
+		}
+		if suffix == 0 || size == 0 {
+			return nil, &RangeNotSatisfiableError{Size: size}
+		}
+		return &ByteRange{Start: max(size-suffix, 0), End: size - 1}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		end = min(end, size-1)
+	}
+
+	if start >= size {
+		return nil, &RangeNotSatisfiableError{Size: size}
+	}
+	return &ByteRange{Start: start, End: end}, nil
+}
+
+// contentDisposition applies the requested disposition and file name,
+// falling back to the stored Content-Disposition
+func contentDisposition(object *provider.ObjectMetadata, req *dto.ContentDownloadRequest) string {
+	if req.Disposition == "" && req.FileName == "" {
+		return object.ContentDisposition
+	}
+
+	disposition := req.Disposition
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	fileName := req.FileName
+	if fileName == "" && disposition == "attachment" {
+		fileName = path.Base(req.FilePath)
+	}
+	if fileName == "" {
+		return disposition
+	}
+
+	if formatted := mime.FormatMediaType(disposition, map[string]string{"filename": fileName}); formatted != "" {
+		return formatted
+	}
+	return disposition
+}
+
+// quoteETag returns the ETag in its quoted header form
+func quoteETag(etag string) string {
+	if etag == "" || strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// weakETag strips the weak validator prefix for If-None-Match comparisons
+func weakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
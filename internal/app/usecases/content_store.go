@@ -0,0 +1,290 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+// ErrContentAddressingUnsupported is returned when a content-addressed upload
+// is confirmed on a provider that can neither copy nor stream objects
+var ErrContentAddressingUnsupported = domainerr.New(domainerr.Unsupported, "CONTENT_ADDRESSING_UNSUPPORTED", "provider does not support content-addressed storage")
+
+// ErrContentShared is returned when an operation would change the object
+// behind a content-addressed path, which other paths may share
+var ErrContentShared = domainerr.New(domainerr.PreconditionFailed, "CONTENT_SHARED", "file contents are content-addressed and may be shared")
+
+// ContentStore keeps the files of content-addressed definitions once per
+// distinct content. Confirmed uploads are moved to a blob keyed by their
+// SHA256 checksum, or dropped when that blob already exists, and their
+// logical path is mapped to the blob through a reference-counted table.
+type ContentStore struct {
+	manager  resource.ResourceManager
+	blobRepo repository.ContentBlobRepository
+	registry *DefinitionRegistry
+	objects  *objectOpener
+}
+
+// NewContentStore creates a new content store. The registry tells which
+// definitions are content-addressed.
+func NewContentStore(manager resource.ResourceManager, blobRepo repository.ContentBlobRepository, registry *DefinitionRegistry) *ContentStore {
+	return &ContentStore{
+		manager:  manager,
+		blobRepo: blobRepo,
+		registry: registry,
+		objects:  newObjectOpener(manager, &http.Client{Timeout: 10 * time.Minute}),
+	}
+}
+
+// Deduplicate moves the confirmed upload at logicalPath into its
+// content-addressed blob and maps the path to it. The uploaded object is
+// removed either way, so a duplicate of a stored blob costs no storage.
+// A blob the path referenced before is deleted once nothing references it.
+// It returns nil when the definition is not content-addressed.
+func (s *ContentStore) Deduplicate(ctx context.Context, definition string, providerName provider.ProviderName, logicalPath string) (*entity.ContentReference, error) {
+	addressing := s.registry.ContentAddressing(resolver.DefinitionName(definition))
+	if addressing == nil {
+		return nil, nil
+	}
+
+	object, err := s.manager.GetObjectMetadata(ctx, providerName, logicalPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uploaded file metadata: %w", err)
+	}
+
+	checksum := reportedSHA256(object.Checksums)
+	if checksum == "" {
+		checksum, err = s.hashObject(ctx, providerName, logicalPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ref := &entity.ContentReference{
+		Provider:       string(providerName),
+		Path:           logicalPath,
+		BlobKey:        addressing.BlobPath(logicalPath, checksum),
+		Definition:     definition,
+		ChecksumSHA256: checksum,
+		Size:           object.Size,
+		CreatedAt:      time.Now(),
+	}
+
+	// The blob is stored before it is referenced, so no transaction is held
+	// open across the copy
+	referenced, err := s.blobRepo.IsBlobReferenced(ctx.Context(), ref.Provider, ref.BlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content blob: %w", err)
+	}
+	if !referenced {
+		if err := s.storeBlob(ctx, providerName, logicalPath, ref.BlobKey, object); err != nil {
+			return nil, err
+		}
+	}
+
+	created, orphaned, err := s.blobRepo.AddReference(ctx.Context(), ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reference content blob: %w", err)
+	}
+
+	// A blob whose last reference went away meanwhile may have been deleted
+	// before this reference landed
+	if created {
+		if _, err := s.manager.GetObjectMetadata(ctx, providerName, ref.BlobKey); err != nil {
+			if err := s.storeBlob(ctx, providerName, logicalPath, ref.BlobKey, object); err != nil {
+				if _, releaseErr := s.Release(ctx, providerName, logicalPath); releaseErr != nil {
+					log.Printf("Failed to release content reference %s: %v", logicalPath, releaseErr)
+				}
+				return nil, err
+			}
+		}
+	}
+	if orphaned != "" {
+		s.collectBlob(ctx, providerName, orphaned)
+	}
+
+	if err := s.manager.DeleteObject(ctx, providerName, logicalPath); err != nil {
+		return nil, fmt.Errorf("failed to delete deduplicated upload: %w", err)
+	}
+
+	return ref, nil
+}
+
+// Resolve returns the path holding the contents of filePath: its blob when
+// the path is mapped, otherwise the path itself. An empty provider matches
+// a mapping on any provider.
+func (s *ContentStore) Resolve(ctx context.Context, providerName provider.ProviderName, filePath string) (string, error) {
+	ref, err := s.blobRepo.GetReference(ctx.Context(), string(providerName), filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get content reference: %w", err)
+	}
+	if ref == nil {
+		return filePath, nil
+	}
+	return ref.BlobKey, nil
+}
+
+// Stat returns the metadata of the object holding the contents of filePath
+func (s *ContentStore) Stat(ctx context.Context, providerName provider.ProviderName, filePath string) (*provider.ObjectMetadata, error) {
+	storedPath, err := s.Resolve(ctx, providerName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return s.manager.GetObjectMetadata(ctx, providerName, storedPath)
+}
+
+// Exists reports whether filePath holds a file. A failed metadata lookup
+// only counts as a missing file when the provider answers a download of it
+// with 404 as well, so other failures are returned instead of read as
+// absence.
+func (s *ContentStore) Exists(ctx context.Context, providerName provider.ProviderName, filePath string) (bool, error) {
+	storedPath, err := s.Resolve(ctx, providerName, filePath)
+	if err != nil {
+		return false, err
+	}
+	_, statErr := s.manager.GetObjectMetadata(ctx, providerName, storedPath)
+	if statErr == nil {
+		return true, nil
+	}
+
+	exists, err := s.objects.exists(ctx, storedPath)
+	if err != nil || exists {
+		return false, fmt.Errorf("failed to get file metadata: %w", statErr)
+	}
+	return false, nil
+}
+
+// ListReferences returns up to limit references on the provider whose path
+// starts with prefix and sorts after after, in byte order. A definition
+// limits them to files of the definition and of its children.
+func (s *ContentStore) ListReferences(ctx context.Context, providerName provider.ProviderName, definition, prefix, after string, limit int) ([]*entity.ContentReference, error) {
+	var definitions []string
+	if definition != "" {
+		definitions = append(definitions, definition)
+		for _, child := range childDefinitionNames(s.manager, resolver.DefinitionName(definition)) {
+			definitions = append(definitions, string(child))
+		}
+	}
+
+	refs, err := s.blobRepo.ListReferences(ctx.Context(), string(providerName), definitions, prefix, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content references: %w", err)
+	}
+	return refs, nil
+}
+
+// ListPaths returns up to limit logical paths of the definition's files on
+// the provider that start with prefix and are mapped to a blob
+func (s *ContentStore) ListPaths(ctx context.Context, providerName provider.ProviderName, definition, prefix string, limit int) ([]string, error) {
+	refs, err := s.ListReferences(ctx, providerName, definition, prefix, "", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(refs))
+	for i, ref := range refs {
+		paths[i] = ref.Path
+	}
+	return paths, nil
+}
+
+// MappedPaths returns which of the given paths are mapped to a blob
+func (s *ContentStore) MappedPaths(ctx context.Context, providerName provider.ProviderName, paths []string) (map[string]bool, error) {
+	mapped, err := s.blobRepo.MappedPaths(ctx.Context(), string(providerName), paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content references: %w", err)
+	}
+	return mapped, nil
+}
+
+// Release unmaps filePath and deletes its blob once no other path references
+// it. It reports whether the path was mapped; unmapped paths are left to the
+// caller to delete.
+func (s *ContentStore) Release(ctx context.Context, providerName provider.ProviderName, filePath string) (bool, error) {
+	ref, orphaned, err := s.blobRepo.RemoveReference(ctx.Context(), string(providerName), filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to release content reference: %w", err)
+	}
+	if ref == nil {
+		return false, nil
+	}
+
+	if orphaned {
+		s.collectBlob(ctx, providerName, ref.BlobKey)
+	}
+	return true, nil
+}
+
+// Delete releases filePath when it is mapped and deletes the object stored
+// at it otherwise
+func (s *ContentStore) Delete(ctx context.Context, providerName provider.ProviderName, filePath string) error {
+	released, err := s.Release(ctx, providerName, filePath)
+	if err != nil || released {
+		return err
+	}
+	return s.manager.DeleteObject(ctx, providerName, filePath)
+}
+
+// collectBlob deletes a blob left without references. A failure only leaks
+// the object until the blob is referenced and released again, so it is
+// logged rather than failing the release.
+func (s *ContentStore) collectBlob(ctx context.Context, providerName provider.ProviderName, blobKey string) {
+	_, err := s.blobRepo.DeleteBlob(ctx.Context(), string(providerName), blobKey, func(blobKey string) error {
+		return s.manager.DeleteObject(ctx, providerName, blobKey)
+	})
+	if err != nil {
+		log.Printf("Failed to delete content blob %s: %v", blobKey, err)
+	}
+}
+
+// storeBlob copies the uploaded object to its blob, server-side when the
+// provider supports it and streamed through the server otherwise
+func (s *ContentStore) storeBlob(ctx context.Context, providerName provider.ProviderName, sourcePath, blobKey string, source *provider.ObjectMetadata) error {
+	prov, err := s.manager.GetProvider(providerName)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+
+	if copier, ok := prov.(objectCopier); ok {
+		if err := copier.CopyObject(ctx.Context(), sourcePath, blobKey); err != nil {
+			return fmt.Errorf("failed to copy upload to content blob: %w", err)
+		}
+		return nil
+	}
+
+	writer, ok := prov.(objectWriter)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrContentAddressingUnsupported, providerName)
+	}
+
+	_, err = streamBetweenProviders(ctx, s.objects, writer, providerName, sourcePath, blobKey, source)
+	return err
+}
+
+// hashObject computes the SHA256 of an object whose provider reports none
+func (s *ContentStore) hashObject(ctx context.Context, providerName provider.ProviderName, filePath string) (string, error) {
+	body, err := s.objects.open(ctx, providerName, filePath, nil)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, body); err != nil {
+		return "", fmt.Errorf("failed to hash uploaded file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
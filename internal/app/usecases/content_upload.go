@@ -0,0 +1,413 @@
+package usecases
+
+import (
+	"bytes"
+	"cmp"
+	stdcontext "context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+	"avironactive.com/resource/upload"
+	"github.com/google/uuid"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+// contentPartSize is the part size for uploads streamed through the server.
+// Bodies that fit in one part are uploaded with a single PUT.
+const contentPartSize int64 = 16 << 20
+
+// contentUploadConcurrency bounds the streamed uploads holding a part buffer
+// at once; further uploads wait for a slot
+const contentUploadConcurrency = 8
+
+// ErrUploadTooLarge is returned when a streamed upload exceeds the provider limits
+var ErrUploadTooLarge = domainerr.New(domainerr.TooLarge, "UPLOAD_TOO_LARGE", "upload exceeds the provider size limit")
+
+// UploadContent streams body to the path resolved from the definition and
+// records it as a completed upload. At most one part is held in memory;
+// bodies larger than a part switch to a multipart upload. The checksums
+// required by the definition are computed while streaming. The definition's
+// upload policy is enforced here, as no signed URL constrains the upload:
+// the declared file is checked up front, the body is cut off at the size
+// limit and a required checksum must match the streamed contents. Uploads to
+// content-addressed definitions are then moved to their blob. Failed multipart
+// uploads are aborted with the provider so their parts are discarded.
+func (uc *FileOperationsUseCase) UploadContent(ctx context.Context, req *dto.ContentUploadRequest, body io.Reader) (*dto.ContentUploadResponse, error) {
+	providerName := provider.ProviderName(req.Provider)
+	prov, err := uc.manager.GetProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+
+	caps := prov.Capabilities()
+	if caps.MaxUploadSize > 0 && req.ContentLength > caps.MaxUploadSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds %d", ErrUploadTooLarge, req.ContentLength, caps.MaxUploadSize)
+	}
+
+	definition, err := uc.manager.GetDefinition(resolver.DefinitionName(req.Definition))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDefinitionNotFound, err)
+	}
+	if err := uc.registry.CheckUploadable(definition.Name); err != nil {
+		return nil, err
+	}
+
+	declared := declaredUpload{
+		ContentType: req.ContentType,
+		Size:        max(req.ContentLength, 0),
+	}
+	if req.Upload.Checksum != nil {
+		declared.Checksum = req.Upload.Checksum.ToProviderChecksum()
+	}
+	policy := uc.registry.UploadPolicy(definition.Name)
+	if err := checkServerUpload(policy, declared); err != nil {
+		return nil, err
+	}
+
+	resolved, err := uc.manager.DefinitionResolver().ResolveUploadURL(ctx, definition.Name, req.Upload.To().WithProvider(providerName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve upload path: %w", err)
+	}
+	path := resolved.ResolvedPath.Path
+
+	checksums := newContentChecksums(definition.DefaultStorageMetadata, policy)
+	content := io.TeeReader(limitUploadSize(body, policy), checksums)
+
+	select {
+	case uc.contentUploadSlots <- struct{}{}:
+		defer func() { <-uc.contentUploadSlots }()
+	case <-ctx.Context().Done():
+		return nil, fmt.Errorf("upload interrupted: %w", ctx.Context().Err())
+	}
+
+	buf := make([]byte, partSizeFor(caps))
+	n, err := io.ReadFull(content, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read upload body: %w", err)
+	}
+
+	// A short first read means the whole body is buffered
+	uploadType := upload.UploadTypeSimple
+	if n == len(buf) {
+		uploadType = upload.UploadTypeMultipart
+	}
+
+	uploadManager := uc.manager.UploadManager()
+	record, err := uploadManager.InitiateUpload(ctx, contentUploadOptions(req, path, uploadType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate upload: %w", err)
+	}
+
+	var etags []upload.PartETag
+	if uploadType == upload.UploadTypeSimple {
+		etags, err = uc.uploadSimpleContent(ctx, record, buf[:n], req.ContentType)
+	} else {
+		etags, err = uc.uploadContentParts(ctx, record, buf, content, req.ContentLength, caps)
+	}
+	if err == nil {
+		err = verifyUploadChecksum(policy, declared, checksums.Checksums())
+		// Multipart uploads are only assembled on confirmation
+		if err != nil && uploadType == upload.UploadTypeSimple {
+			if deleteErr := uc.manager.DeleteObject(ctx, providerName, path); deleteErr != nil {
+				log.Printf("Failed to delete rejected upload %s: %v", path, deleteErr)
+			}
+		}
+	}
+	if err != nil {
+		// The upload error is what the client needs; a failed rejection only
+		// leaves the record to expire
+		_ = uploadManager.ConfirmUpload(ctx, record.ID, &upload.UploadConfirmation{Success: false, Error: err.Error()})
+		if aborter, ok := prov.(multipartAborter); ok && uploadType == upload.UploadTypeMultipart {
+			uc.abortContentParts(ctx, aborter, record.ID)
+		}
+		return nil, err
+	}
+
+	var size int64
+	for _, etag := range etags {
+		size += etag.Size
+	}
+
+	storageMetadata := &metadata.StorageMetadata{
+		ContentType: req.ContentType,
+		Checksums:   checksums.Checksums(),
+	}
+	confirmation := &upload.UploadConfirmation{
+		Success:  true,
+		FileSize: size,
+		Metadata: storageMetadata,
+		ETags:    etags,
+	}
+	if err := uploadManager.ConfirmUpload(ctx, record.ID, confirmation); err != nil {
+		return nil, fmt.Errorf("failed to confirm upload: %w", err)
+	}
+
+	ref, err := uc.contentStore.Deduplicate(ctx, req.Definition, providerName, path)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ContentUploadResponse{
+		UploadID:    record.ID.String(),
+		Provider:    req.Provider,
+		Path:        path,
+		Size:        size,
+		ContentType: req.ContentType,
+		UploadType:  dto.ContentUploadSimple,
+		Parts:       len(etags),
+		Checksums:   make([]dto.ChecksumInfo, 0, len(storageMetadata.Checksums)),
+	}
+	if ref != nil {
+		response.BlobKey = ref.BlobKey
+	}
+	if uploadType == upload.UploadTypeMultipart {
+		response.UploadType = dto.ContentUploadMultipart
+	}
+	for _, checksum := range storageMetadata.Checksums {
+		response.Checksums = append(response.Checksums, dto.ChecksumInfo{Algorithm: string(checksum.Algorithm), Value: checksum.Value})
+	}
+
+	return response, nil
+}
+
+// uploadSimpleContent uploads a fully buffered body with a single PUT
+func (uc *FileOperationsUseCase) uploadSimpleContent(ctx context.Context, record *upload.Upload, content []byte, contentType string) ([]upload.PartETag, error) {
+	uploadURL, err := uc.manager.UploadManager().GetSimpleUploadURL(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload URL: %w", err)
+	}
+
+	etag, err := uc.putContent(ctx, uploadURL, content, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return []upload.PartETag{{Part: 1, ETag: etag, Size: int64(len(content))}}, nil
+}
+
+// uploadContentParts uploads buf, which holds the first part, followed by the
+// rest of content one part at a time
+func (uc *FileOperationsUseCase) uploadContentParts(ctx context.Context, record *upload.Upload, buf []byte, content io.Reader, contentLength int64, caps *provider.Capabilities) ([]upload.PartETag, error) {
+	var (
+		etags []upload.PartETag
+		urls  map[int]provider.ObjectURL
+		size  int64
+		err   error
+	)
+
+	for part, n := 1, len(buf); n > 0; part++ {
+		if caps.Multipart != nil && caps.Multipart.MaxParts > 0 && part > caps.Multipart.MaxParts {
+			return nil, fmt.Errorf("%w: more than %d parts", ErrUploadTooLarge, caps.Multipart.MaxParts)
+		}
+
+		size += int64(n)
+		if caps.MaxUploadSize > 0 && size > caps.MaxUploadSize {
+			return nil, fmt.Errorf("%w: body exceeds %d bytes", ErrUploadTooLarge, caps.MaxUploadSize)
+		}
+
+		if _, ok := urls[part]; !ok {
+			urls, err = uc.contentPartURLs(ctx, record, part, contentLength, int64(len(buf)), caps)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		partURL, ok := urls[part]
+		if !ok {
+			return nil, fmt.Errorf("no upload URL returned for part %d", part)
+		}
+
+		etag, err := uc.putContent(ctx, &partURL, buf[:n], "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload part %d: %w", part, err)
+		}
+		etags = append(etags, upload.PartETag{Part: part, ETag: etag, Size: int64(n)})
+
+		if n < len(buf) {
+			break
+		}
+
+		n, err = io.ReadFull(content, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("failed to read upload body: %w", err)
+		}
+	}
+
+	return etags, nil
+}
+
+// abortContentParts aborts a failed multipart upload with the provider. The
+// abort outlives the request, as a failed upload is often an interrupted one.
+// Failed aborts leave the parts to the bucket lifecycle rules.
+func (uc *FileOperationsUseCase) abortContentParts(ctx context.Context, aborter multipartAborter, id upload.UploadID) {
+	abortCtx := stdcontext.WithoutCancel(ctx.Context())
+	tracked, err := uc.uploadRepo.GetByID(abortCtx, uuid.UUID(id))
+	if err != nil {
+		log.Printf("Failed to get multipart upload %s to abort: %v", id, err)
+		return
+	}
+	if !tracked.IsMultipart() {
+		return
+	}
+	if err := aborter.AbortMultipartUpload(abortCtx, tracked.StorageKey, tracked.MultipartID); err != nil {
+		log.Printf("Failed to abort multipart upload %s with provider %s: %v", id, tracked.StorageProvider, err)
+	}
+}
+
+// contentPartURLs requests signed URLs for every expected part starting at
+// next. When the body length is unknown the batch doubles each time it runs out.
+func (uc *FileOperationsUseCase) contentPartURLs(ctx context.Context, record *upload.Upload, next int, contentLength, partSize int64, caps *provider.Capabilities) (map[int]provider.ObjectURL, error) {
+	count := max(next*2, 8)
+	if contentLength > 0 {
+		count = max(int((contentLength+partSize-1)/partSize), next)
+	}
+	if caps.Multipart != nil && caps.Multipart.MaxParts > 0 {
+		count = min(count, caps.Multipart.MaxParts)
+	}
+
+	partURLs, err := uc.manager.UploadManager().GetPartURLs(ctx, record, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get part upload URLs: %w", err)
+	}
+
+	urls := make(map[int]provider.ObjectURL, len(partURLs))
+	for _, partURL := range partURLs {
+		urls[partURL.PartNumber] = partURL.ObjectURL
+	}
+	return urls, nil
+}
+
+// putContent uploads content to a signed URL and returns the ETag reported
+// by the provider
+func (uc *FileOperationsUseCase) putContent(ctx context.Context, target *provider.ObjectURL, content []byte, contentType string) (string, error) {
+	method := target.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context(), method, target.URL, bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for key, value := range target.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := uc.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return "", providerStatusError(resp.StatusCode)
+	}
+
+	return resp.Header.Get("ETag"), nil
+}
+
+// contentUploadOptions builds the upload record for a streamed upload. The
+// record is keyed by the definition and the caller's resource ID, or a new
+// ID when none is given.
+func contentUploadOptions(req *dto.ContentUploadRequest, path string, uploadType upload.UploadType) *upload.UploadOptions {
+	resourceID := req.ResourceID
+	if resourceID == "" {
+		resourceID = uuid.NewString()
+	}
+
+	uploadOpts := &upload.UploadOptions{
+		ResourceType:     req.Definition,
+		ResourceID:       resourceID,
+		ResourceField:    "content",
+		ResourceValue:    path,
+		ResourceProvider: upload.ResourceProvider(req.Provider),
+		UploadType:       uploadType,
+		PathDefinition:   req.Definition,
+		StorageProvider:  upload.ResourceProvider(req.Provider),
+	}
+	uploadOpts.WithPathParameters(req.Upload.Parameters)
+
+	return uploadOpts
+}
+
+// partSizeFor returns contentPartSize kept within the provider's part limits
+func partSizeFor(caps *provider.Capabilities) int64 {
+	size := contentPartSize
+	if caps.Multipart == nil {
+		return size
+	}
+	if caps.Multipart.MinPartSize > size {
+		size = caps.Multipart.MinPartSize
+	}
+	if caps.Multipart.MaxPartSize > 0 && caps.Multipart.MaxPartSize < size {
+		size = caps.Multipart.MaxPartSize
+	}
+	return size
+}
+
+// contentChecksums hashes streamed content with SHA256 plus any other
+// algorithm the definition or its upload policy requires
+type contentChecksums map[metadata.ChecksumAlgorithm]hash.Hash
+
+func newContentChecksums(config *metadata.StorageMetadataConfig, policy *core.UploadPolicy) contentChecksums {
+	checksums := contentChecksums{metadata.ChecksumAlgorithmSHA256: sha256.New()}
+	if config != nil {
+		for _, algorithm := range config.RequiredChecksums {
+			checksums.add(algorithm)
+		}
+	}
+	if policy != nil && policy.RequiredChecksum != "" {
+		checksums.add(policy.RequiredChecksum)
+	}
+	return checksums
+}
+
+func (c contentChecksums) add(algorithm metadata.ChecksumAlgorithm) {
+	switch algorithm {
+	case metadata.ChecksumAlgorithmMD5:
+		c[algorithm] = md5.New()
+	case metadata.ChecksumAlgorithmCRC32C:
+		c[algorithm] = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	}
+}
+
+func (c contentChecksums) Write(p []byte) (int, error) {
+	for _, h := range c {
+		h.Write(p)
+	}
+	return len(p), nil
+}
+
+// Checksums returns the base64 digests ordered by algorithm
+func (c contentChecksums) Checksums() []metadata.Checksum {
+	checksums := make([]metadata.Checksum, 0, len(c))
+	for algorithm, h := range c {
+		checksums = append(checksums, metadata.Checksum{
+			Algorithm: algorithm,
+			Value:     base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		})
+	}
+	slices.SortFunc(checksums, func(a, b metadata.Checksum) int {
+		return cmp.Compare(a.Algorithm, b.Algorithm)
+	})
+	return checksums
+}
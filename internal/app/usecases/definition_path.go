@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+)
+
+// resolveDefinitionRoot resolves a definition's path on a provider for the
+// given scope and parameters, without leading or trailing slashes
+func resolveDefinitionRoot(ctx context.Context, manager resource.ResourceManager, definition string, providerName provider.ProviderName, scope string, scopeValue int16, parameters map[string]string) (string, error) {
+	values := make(map[resolver.ParameterName]string, len(parameters))
+	for k, v := range parameters {
+		values[resolver.ParameterName(k)] = v
+	}
+
+	opts := (&resolver.DefinitionDownloadOptions{}).
+		WithProvider(providerName).
+		WithValues(values)
+	if scope != "" {
+		opts = opts.WithScope(resolver.ScopeType(scope), scopeValue)
+	}
+
+	resolved, err := manager.DefinitionResolver().ResolveDownloadURL(ctx, resolver.DefinitionName(definition), opts)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(resolved.ResolvedPath.Path, "/"), nil
+}
+
+// parentDefinition returns the definition that declares the named definition
+// among its children, or nil for top-level definitions
+func parentDefinition(manager resource.ResourceManager, name resolver.DefinitionName) *resolver.Definition {
+	for _, def := range manager.GetAllDefinitions() {
+		for _, child := range def.Children {
+			if child.Name == name {
+				return def
+			}
+		}
+	}
+	return nil
+}
+
+// childDefinitionNames returns the names of the named definition's children,
+// in name order
+func childDefinitionNames(manager resource.ResourceManager, name resolver.DefinitionName) []resolver.DefinitionName {
+	def, err := manager.GetDefinition(name)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]resolver.DefinitionName, 0, len(def.Children))
+	for _, child := range def.Children {
+		names = append(names, child.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// definitionsForPath returns the names of the definitions whose path pattern
+// on the provider matches filePath or one of its directories in any scope,
+// in name order
+func definitionsForPath(manager resource.ResourceManager, providerName provider.ProviderName, filePath string) []resolver.DefinitionName {
+	var names []resolver.DefinitionName
+	for _, def := range manager.GetAllDefinitions() {
+		if definitionCovers(manager, def, providerName, filePath) {
+			names = append(names, def.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// definitionCovers reports whether the definition's path pattern on the
+// provider matches filePath or one of its directories in any scope. The
+// patterns of child definitions are taken below their parent's.
+func definitionCovers(manager resource.ResourceManager, def *resolver.Definition, providerName provider.ProviderName, filePath string) bool {
+	parent := parentDefinition(manager, def.Name)
+	for scope, pattern := range def.Patterns[providerName].Patterns {
+		if parent != nil {
+			if parentPatterns, ok := parent.Patterns[providerName]; ok {
+				pattern = parentPatterns.Patterns[scope] + "/" + pattern
+			}
+		}
+		for dir := strings.Trim(filePath, "/"); dir != "." && dir != ""; dir = path.Dir(dir) {
+			if patternValues(pattern, dir) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,668 @@
+package usecases
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+var (
+	// ErrInvalidDefinition is returned for definitions that cannot be built
+	ErrInvalidDefinition = domainerr.New(domainerr.Validation, "INVALID_DEFINITION", "invalid resource definition")
+	// ErrDefinitionNotFound is returned when no runtime definition has the name
+	ErrDefinitionNotFound = domainerr.New(domainerr.NotFound, "DEFINITION_NOT_FOUND", "resource definition not found")
+	// ErrDefinitionExists is returned when creating a definition whose name
+	// is taken
+	ErrDefinitionExists = domainerr.New(domainerr.Conflict, "DEFINITION_EXISTS", "resource definition already exists")
+	// ErrDefinitionBuiltIn is returned when changing a definition compiled
+	// into the server
+	ErrDefinitionBuiltIn = domainerr.New(domainerr.Forbidden, "DEFINITION_BUILT_IN", "resource definition is built in")
+	// ErrDefinitionVersionConflict is returned when a definition changed
+	// since the version the caller read
+	ErrDefinitionVersionConflict = domainerr.New(domainerr.PreconditionFailed, "VERSION_CONFLICT", "resource definition version conflict")
+	// ErrDefinitionPathChange is returned when an update would move existing
+	// objects without the migration flag
+	ErrDefinitionPathChange = domainerr.New(domainerr.PreconditionFailed, "PATH_CHANGE", "update changes paths of existing objects")
+	// ErrDefinitionDeprecated is returned when uploading to a deprecated
+	// definition
+	ErrDefinitionDeprecated = domainerr.New(domainerr.PreconditionFailed, "DEFINITION_DEPRECATED", "resource definition is deprecated")
+)
+
+var definitionName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// fallbackParameterNames are the parameters the fallback parameter resolver
+// provides, which patterns may use without declaring them
+var fallbackParameterNames = []string{"env", "version", "app", "client_app"}
+
+var scopeTypes = map[string]resolver.ScopeType{
+	"G":  resolver.ScopeGlobal,
+	"A":  resolver.ScopeApp,
+	"CA": resolver.ScopeClientApp,
+}
+
+var urlTypes = []resolver.URLType{resolver.URLTypeStorage, resolver.URLTypeDelivery}
+
+// policyChecksums are the checksum algorithms an upload policy may require,
+// those the server can compute when it writes an upload itself
+var policyChecksums = []metadata.ChecksumAlgorithm{
+	metadata.ChecksumAlgorithmSHA256,
+	metadata.ChecksumAlgorithmMD5,
+	metadata.ChecksumAlgorithmCRC32C,
+}
+
+// DefinitionRegistry manages resource definitions stored in the database.
+// Changes made on any instance are applied to the resource manager of every
+// instance, so definitions can be added and updated without a restart.
+// Definitions compiled into the server cannot be changed through it. The
+// registry also holds the upload policy and content addressing of every
+// definition, built in or not.
+type DefinitionRegistry struct {
+	repo          repository.ResourceDefinitionRepository
+	manager       resource.ResourceManager
+	builtin       map[string]bool
+	retryInterval time.Duration
+
+	// mu guards applied, the definitions registered with the manager,
+	// policies, the upload policies by definition name, constraints, the
+	// parameter constraints by definition name, and addressing, the content
+	// addressing by definition name
+	mu          sync.Mutex
+	applied     map[string]*entity.ResourceDefinition
+	policies    map[resolver.DefinitionName]*core.UploadPolicy
+	constraints map[resolver.DefinitionName]map[resolver.ParameterName]core.ParameterConstraints
+	addressing  map[resolver.DefinitionName]*core.ContentAddressing
+
+	runMu  sync.Mutex
+	stop   chan struct{}
+	doneWg sync.WaitGroup
+}
+
+// NewDefinitionRegistry creates a new definition registry. Definitions
+// already registered with the manager are treated as built in.
+func NewDefinitionRegistry(repo repository.ResourceDefinitionRepository, manager resource.ResourceManager) *DefinitionRegistry {
+	builtin := make(map[string]bool)
+	for _, def := range manager.GetAllDefinitions() {
+		builtin[string(def.Name)] = true
+	}
+
+	return &DefinitionRegistry{
+		repo:          repo,
+		manager:       manager,
+		builtin:       builtin,
+		retryInterval: 5 * time.Second,
+		applied:       make(map[string]*entity.ResourceDefinition),
+		policies:      core.AllUploadPolicies(),
+		constraints:   core.AllParameterConstraints(),
+		addressing:    core.AllContentAddressing(),
+	}
+}
+
+// CreateDefinition stores and registers a new runtime definition
+func (r *DefinitionRegistry) CreateDefinition(ctx context.Context, req *dto.CreateDefinitionRequest, actor string) (*dto.ResourceDefinitionResponse, error) {
+	if r.builtin[req.Name] {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionBuiltIn, req.Name)
+	}
+	if _, err := r.buildDefinition(req.Name, &req.DefinitionSpec); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	def := &entity.ResourceDefinition{
+		Name:      req.Name,
+		Version:   1,
+		Spec:      req.DefinitionSpec,
+		Status:    entity.DefinitionStatusActive,
+		CreatedBy: actor,
+		UpdatedBy: actor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	created, err := r.repo.Create(ctx.Context(), def)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionExists, req.Name)
+	}
+
+	if err := r.apply(def); err != nil {
+		return nil, err
+	}
+
+	return dto.NewResourceDefinitionResponse(def), nil
+}
+
+// UpdateDefinition stores the spec as the definition's next version and
+// registers it. Changes to the paths of existing objects require
+// req.Migrate; moving the objects themselves is left to the caller.
+func (r *DefinitionRegistry) UpdateDefinition(ctx context.Context, name string, req *dto.UpdateDefinitionRequest, actor string) (*dto.ResourceDefinitionResponse, error) {
+	current, err := r.current(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != 0 && req.Version != current.Version {
+		return nil, fmt.Errorf("%w: %s is at version %d", ErrDefinitionVersionConflict, name, current.Version)
+	}
+	if _, err := r.buildDefinition(name, &req.DefinitionSpec); err != nil {
+		return nil, err
+	}
+
+	changes := pathChanges(&current.Spec, &req.DefinitionSpec)
+	if len(changes) > 0 && !req.Migrate {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionPathChange, strings.Join(changes, "; "))
+	}
+
+	next := *current
+	next.Version = current.Version + 1
+	next.Spec = req.DefinitionSpec
+	next.UpdatedBy = actor
+	next.UpdatedAt = time.Now()
+
+	return r.save(ctx, &next, current.Version, len(changes) > 0)
+}
+
+// DeprecateDefinition marks a runtime definition as deprecated. It stays
+// registered so existing objects remain reachable, but new uploads under it
+// are rejected.
+func (r *DefinitionRegistry) DeprecateDefinition(ctx context.Context, name, actor string) (*dto.ResourceDefinitionResponse, error) {
+	current, err := r.current(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsDeprecated() {
+		return dto.NewResourceDefinitionResponse(current), nil
+	}
+
+	next := *current
+	next.Version = current.Version + 1
+	next.Status = entity.DefinitionStatusDeprecated
+	next.UpdatedBy = actor
+	next.UpdatedAt = time.Now()
+
+	return r.save(ctx, &next, current.Version, false)
+}
+
+// ListDefinitions returns every runtime definition
+func (r *DefinitionRegistry) ListDefinitions(ctx context.Context) ([]*dto.ResourceDefinitionResponse, error) {
+	defs, err := r.repo.List(ctx.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ResourceDefinitionResponse, 0, len(defs))
+	for _, def := range defs {
+		responses = append(responses, dto.NewResourceDefinitionResponse(def))
+	}
+	return responses, nil
+}
+
+// ListVersions returns the version history of a runtime definition, newest
+// first
+func (r *DefinitionRegistry) ListVersions(ctx context.Context, name string) ([]*dto.DefinitionVersionResponse, error) {
+	if _, err := r.current(ctx, name); err != nil {
+		return nil, err
+	}
+
+	versions, err := r.repo.ListVersions(ctx.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.DefinitionVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		responses = append(responses, dto.NewDefinitionVersionResponse(version))
+	}
+	return responses, nil
+}
+
+// Lookup returns the runtime definition registered under name, or nil for
+// built-in and unknown definitions
+func (r *DefinitionRegistry) Lookup(name string) *entity.ResourceDefinition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.applied[name]
+}
+
+// UploadPolicy returns the upload policy of a definition, or nil when its
+// uploads are unconstrained
+func (r *DefinitionRegistry) UploadPolicy(name resolver.DefinitionName) *core.UploadPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.policies[name]
+}
+
+// ContentAddressing returns the content addressing of a definition, or nil
+// when its files are stored at their logical paths
+func (r *DefinitionRegistry) ContentAddressing(name resolver.DefinitionName) *core.ContentAddressing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addressing[name]
+}
+
+// CheckUploadable returns ErrDefinitionDeprecated when the definition is
+// deprecated. Existing objects stay readable, but nothing new may be
+// uploaded under it.
+func (r *DefinitionRegistry) CheckUploadable(name resolver.DefinitionName) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if def := r.applied[string(name)]; def != nil && def.IsDeprecated() {
+		return fmt.Errorf("%w: %s", ErrDefinitionDeprecated, name)
+	}
+	return nil
+}
+
+// ParameterConstraints returns the constraints of a definition's parameter;
+// the zero value accepts any value
+func (r *DefinitionRegistry) ParameterConstraints(definition resolver.DefinitionName, param resolver.ParameterName) core.ParameterConstraints {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.constraints[definition][param]
+}
+
+// Sync registers every stored definition whose version is newer than the
+// registered one
+func (r *DefinitionRegistry) Sync(ctx context.Context) error {
+	defs, err := r.repo.List(ctx.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list resource definitions: %w", err)
+	}
+
+	var errs []error
+	for _, def := range defs {
+		if err := r.apply(def); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reload registers the stored version of one definition
+func (r *DefinitionRegistry) Reload(ctx context.Context, name string) error {
+	def, err := r.repo.GetByName(ctx.Context(), name)
+	if err != nil {
+		return fmt.Errorf("failed to get resource definition %s: %w", name, err)
+	}
+	if def == nil {
+		return nil
+	}
+	return r.apply(def)
+}
+
+// Start registers the stored definitions and follows changes made by any
+// instance in the background until Stop is called. A lost connection is
+// retried, catching up on the changes missed meanwhile.
+func (r *DefinitionRegistry) Start(ctx context.Context) {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+
+	if err := r.Sync(ctx); err != nil {
+		log.Printf("Definition registry sync failed: %v", err)
+	}
+
+	runCtx, cancel := stdcontext.WithCancel(ctx.Context())
+
+	r.doneWg.Add(2)
+	go func(stop <-chan struct{}) {
+		defer r.doneWg.Done()
+		<-stop
+		cancel()
+	}(r.stop)
+
+	go func(stop <-chan struct{}) {
+		defer r.doneWg.Done()
+		defer cancel()
+
+		for {
+			err := r.repo.Watch(runCtx, func(name string) {
+				changeCtx := context.NewContext(runCtx)
+				reload := func() error { return r.Reload(changeCtx, name) }
+				if name == "" {
+					reload = func() error { return r.Sync(changeCtx) }
+				}
+				if err := reload(); err != nil {
+					log.Printf("Definition registry reload failed: %v", err)
+				}
+			})
+			if err != nil {
+				log.Printf("Definition registry stopped listening: %v", err)
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-runCtx.Done():
+				return
+			case <-time.After(r.retryInterval):
+			}
+		}
+	}(r.stop)
+}
+
+// Stop stops following definition changes
+func (r *DefinitionRegistry) Stop() {
+	r.runMu.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.runMu.Unlock()
+
+	r.doneWg.Wait()
+}
+
+// current returns the stored version of a runtime definition
+func (r *DefinitionRegistry) current(ctx context.Context, name string) (*entity.ResourceDefinition, error) {
+	if r.builtin[name] {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionBuiltIn, name)
+	}
+
+	def, err := r.repo.GetByName(ctx.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionNotFound, name)
+	}
+	return def, nil
+}
+
+// save stores def as the version following previousVersion and registers it
+func (r *DefinitionRegistry) save(ctx context.Context, def *entity.ResourceDefinition, previousVersion int, migrated bool) (*dto.ResourceDefinitionResponse, error) {
+	saved, err := r.repo.Update(ctx.Context(), def, previousVersion, migrated)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, fmt.Errorf("%w: %s changed since version %d", ErrDefinitionVersionConflict, def.Name, previousVersion)
+	}
+
+	if err := r.apply(def); err != nil {
+		return nil, err
+	}
+
+	return dto.NewResourceDefinitionResponse(def), nil
+}
+
+// apply registers def with the manager unless the same or a newer version is
+// registered. The manager cannot replace a definition in place, so the
+// previous version is removed and def added in its place; when adding def
+// fails the previous version is registered again and stays in effect.
+func (r *DefinitionRegistry) apply(def *entity.ResourceDefinition) error {
+	built, err := r.buildDefinition(def.Name, &def.Spec)
+	if err != nil {
+		return err
+	}
+	policy, err := buildUploadPolicy(def.Spec.UploadPolicy)
+	if err != nil {
+		return fmt.Errorf("%w: %s: upload policy: %v", ErrInvalidDefinition, def.Name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.applied[def.Name]
+	if ok && registered.Version >= def.Version {
+		return nil
+	}
+	if ok {
+		// Rebuild the previous version before removing it, so a failed
+		// swap can always restore it
+		previous, err := r.buildDefinition(registered.Name, &registered.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild definition %s version %d: %w", def.Name, registered.Version, err)
+		}
+		if err := r.manager.RemoveDefinition(built.Name); err != nil {
+			return fmt.Errorf("failed to unregister definition %s: %w", def.Name, err)
+		}
+		if err := r.manager.AddDefinition(*built); err != nil {
+			if restoreErr := r.manager.AddDefinition(*previous); restoreErr != nil {
+				log.Printf("Failed to restore definition %s version %d: %v", def.Name, registered.Version, restoreErr)
+			}
+			return fmt.Errorf("failed to register definition %s: %w", def.Name, err)
+		}
+	} else if err := r.manager.AddDefinition(*built); err != nil {
+		return fmt.Errorf("failed to register definition %s: %w", def.Name, err)
+	}
+
+	r.applied[def.Name] = def
+	constraints := make(map[resolver.ParameterName]core.ParameterConstraints, len(def.Spec.Parameters))
+	for _, param := range def.Spec.Parameters {
+		constraints[resolver.ParameterName(param.Name)] = parameterConstraints(&param)
+	}
+	r.constraints[built.Name] = constraints
+	if policy != nil {
+		r.policies[built.Name] = policy
+	} else {
+		delete(r.policies, built.Name)
+	}
+	// Runtime definitions have no children, so their files share blobs
+	// by directory
+	if def.Spec.ContentAddressed {
+		r.addressing[built.Name] = &core.ContentAddressing{PathDepth: 1}
+	} else {
+		delete(r.addressing, built.Name)
+	}
+	return nil
+}
+
+// buildDefinition converts a stored spec into a resolver definition,
+// validating everything the manager would otherwise reject at resolution
+func (r *DefinitionRegistry) buildDefinition(name string, spec *entity.DefinitionSpec) (*resolver.Definition, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidDefinition, name, fmt.Sprintf(format, args...))
+	}
+
+	if !definitionName.MatchString(name) {
+		return nil, invalid("name must be lowercase letters, digits, '-' or '_'")
+	}
+	if len(spec.AllowedScopes) == 0 {
+		return nil, invalid("at least one scope is required")
+	}
+	if len(spec.Patterns) == 0 {
+		return nil, invalid("at least one provider pattern is required")
+	}
+
+	def := &resolver.Definition{
+		Name:        resolver.DefinitionName(name),
+		DisplayName: spec.DisplayName,
+		Description: spec.Description,
+		Patterns:    make(map[provider.ProviderName]resolver.PathPatterns, len(spec.Patterns)),
+	}
+
+	for _, code := range spec.AllowedScopes {
+		scope, ok := scopeTypes[code]
+		if !ok {
+			return nil, invalid("unknown scope %q", code)
+		}
+		def.AllowedScopes = append(def.AllowedScopes, scope)
+	}
+
+	declared := slices.Clone(fallbackParameterNames)
+	for _, param := range spec.Parameters {
+		if param.Name == "" {
+			return nil, invalid("parameter name is required")
+		}
+		if slices.Contains(declared, param.Name) {
+			return nil, invalid("parameter %s is declared twice or shadows a fallback parameter", param.Name)
+		}
+		declared = append(declared, param.Name)
+
+		rules, err := parameterConstraints(&param).Rules()
+		if err != nil {
+			return nil, invalid("parameter %s: %v", param.Name, err)
+		}
+		def.Parameters = append(def.Parameters, &resolver.ParameterDefinition{
+			Name:         resolver.ParameterName(param.Name),
+			Rules:        rules,
+			Description:  param.Description,
+			DefaultValue: param.DefaultValue,
+		})
+	}
+
+	for providerName, patterns := range spec.Patterns {
+		if _, err := r.manager.GetProvider(provider.ProviderName(providerName)); err != nil {
+			return nil, invalid("unknown provider %q", providerName)
+		}
+
+		urlType := resolver.URLTypeStorage
+		if patterns.URLType != "" {
+			index := slices.IndexFunc(urlTypes, func(t resolver.URLType) bool { return fmt.Sprint(t) == patterns.URLType })
+			if index < 0 {
+				return nil, invalid("unknown URL type %q for provider %s", patterns.URLType, providerName)
+			}
+			urlType = urlTypes[index]
+		}
+
+		scoped := make(map[resolver.ScopeType]string, len(patterns.Patterns))
+		for code, pattern := range patterns.Patterns {
+			if !slices.Contains(spec.AllowedScopes, code) {
+				return nil, invalid("provider %s has a pattern for scope %q which is not allowed", providerName, code)
+			}
+			if pattern == "" {
+				return nil, invalid("provider %s has an empty %s pattern", providerName, code)
+			}
+			for _, match := range patternPlaceholder.FindAllStringSubmatch(pattern, -1) {
+				if !slices.Contains(declared, match[1]) {
+					return nil, invalid("pattern %q uses undeclared parameter %s", pattern, match[1])
+				}
+			}
+			scoped[scopeTypes[code]] = pattern
+		}
+
+		def.Patterns[provider.ProviderName(providerName)] = resolver.PathPatterns{
+			Patterns: scoped,
+			URLType:  urlType,
+		}
+	}
+
+	if storage := spec.DefaultStorageMetadata; storage != nil {
+		config := &metadata.StorageMetadataConfig{
+			CacheControl: metadata.CacheControlConfig{
+				MaxAge:      storage.CacheMaxAge,
+				AllowPublic: storage.CacheAllowPublic,
+				Default:     storage.CacheControl,
+			},
+			CustomHeaders: storage.CustomHeaders,
+		}
+		for _, algorithm := range storage.RequiredChecksums {
+			config.RequiredChecksums = append(config.RequiredChecksums, metadata.ChecksumAlgorithm(algorithm))
+		}
+		def.DefaultStorageMetadata = config
+	}
+
+	if _, err := buildUploadPolicy(spec.UploadPolicy); err != nil {
+		return nil, invalid("upload policy: %v", err)
+	}
+
+	return def, nil
+}
+
+// buildUploadPolicy converts a stored upload policy spec, returning nil when
+// the definition has none
+func buildUploadPolicy(spec *entity.DefinitionUploadPolicySpec) (*core.UploadPolicy, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	if spec.MaxSize < 0 {
+		return nil, fmt.Errorf("maxSize must not be negative")
+	}
+
+	policy := &core.UploadPolicy{
+		MaxSize:      spec.MaxSize,
+		BindClientIP: spec.BindClientIP,
+	}
+	for _, contentType := range spec.ContentTypes {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content type %q", contentType)
+		}
+		policy.ContentTypes = append(policy.ContentTypes, mediaType)
+	}
+	if spec.RequiredChecksum != "" {
+		algorithm := metadata.ChecksumAlgorithm(spec.RequiredChecksum)
+		if !slices.Contains(policyChecksums, algorithm) {
+			return nil, fmt.Errorf("unsupported checksum algorithm %q", spec.RequiredChecksum)
+		}
+		policy.RequiredChecksum = algorithm
+	}
+	return policy, nil
+}
+
+// parameterConstraints converts a stored parameter spec to the constraints
+// its rules are built from
+func parameterConstraints(param *entity.DefinitionParameterSpec) core.ParameterConstraints {
+	return core.ParameterConstraints{
+		Required:  param.Required,
+		Enum:      param.Enum,
+		Pattern:   param.Pattern,
+		MinLength: param.MinLength,
+		MaxLength: param.MaxLength,
+	}
+}
+
+// pathChanges describes how updating a spec from old to updated changes the
+// paths of objects stored under the old spec: removed or rewritten patterns
+// and new defaults of parameters the old patterns use
+func pathChanges(old, updated *entity.DefinitionSpec) []string {
+	var changes []string
+	used := make(map[string]bool)
+
+	for providerName, oldPatterns := range old.Patterns {
+		newPatterns, ok := updated.Patterns[providerName]
+		for code, pattern := range oldPatterns.Patterns {
+			for _, match := range patternPlaceholder.FindAllStringSubmatch(pattern, -1) {
+				used[match[1]] = true
+			}
+
+			switch newPattern, found := newPatterns.Patterns[code]; {
+			case !ok || !found:
+				changes = append(changes, fmt.Sprintf("%s %s pattern removed", providerName, code))
+			case newPattern != pattern:
+				changes = append(changes, fmt.Sprintf("%s %s pattern changed from %q to %q", providerName, code, pattern, newPattern))
+			}
+		}
+	}
+
+	defaults := make(map[string]string, len(updated.Parameters))
+	for _, param := range updated.Parameters {
+		defaults[param.Name] = param.DefaultValue
+	}
+	for _, param := range old.Parameters {
+		if used[param.Name] && param.DefaultValue != defaults[param.Name] {
+			changes = append(changes, fmt.Sprintf("default of %s changed from %q to %q", param.Name, param.DefaultValue, defaults[param.Name]))
+		}
+	}
+
+	slices.Sort(changes)
+	return changes
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+// ErrScopeNotAllowed is returned when a definition is resolved for a scope it
+// does not allow
+var ErrScopeNotAllowed = domainerr.New(domainerr.Validation, "INVALID_SCOPE", "scope not allowed for definition")
+
+var (
+	patternPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+	repeatedSlashes    = regexp.MustCompile(`/+`)
+)
+
+// ResolvePaths resolves the definition's path on each of its providers for
+// the scope and parameters through the definition resolver, which applies
+// the definitions' defaults and the fallback parameter resolver. Nothing is
+// uploaded or stored. The final parameter values are read back from the
+// resolved path. Required parameters without a value and invalid values are
+// reported per provider instead of failing the resolution; missing ones
+// keep their placeholder in the path.
+func (uc *ResourceDefinitionUseCase) ResolvePaths(ctx context.Context, name string, req *dto.ResolvePathRequest) (*dto.ResolvePathResponse, error) {
+	def, err := uc.manager.GetDefinition(resolver.DefinitionName(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDefinitionNotFound, err)
+	}
+
+	parent := parentDefinition(uc.manager, def.Name)
+	scope := req.ResolveScope()
+
+	allowed := def.AllowedScopes
+	if len(allowed) == 0 && parent != nil {
+		allowed = parent.AllowedScopes
+	}
+	if len(allowed) > 0 && !slices.Contains(allowed, scope) {
+		return nil, fmt.Errorf("%w: %s does not allow scope %s", ErrScopeNotAllowed, name, req.Scope)
+	}
+
+	// Child parameters override the parent's parameters of the same name
+	var params []*resolver.ParameterDefinition
+	if parent != nil {
+		params = append(params, parent.Parameters...)
+	}
+	params = append(params, def.Parameters...)
+	declared := make(map[string]*resolver.ParameterDefinition, len(params))
+	for _, param := range params {
+		declared[string(param.Name)] = param
+	}
+
+	// Resolve with a placeholder for each missing required parameter, so the
+	// rest of the path is still shown
+	values := make(map[string]string, len(req.Parameters))
+	for name, value := range req.Parameters {
+		values[name] = value
+	}
+	errs := make(map[string]string)
+	for name, param := range declared {
+		value, ok := values[name]
+		if !ok || value == "" {
+			if uc.parameterConstraints(def, parent, param.Name).Required && param.DefaultValue == "" {
+				values[name] = "{" + name + "}"
+				errs[name] = "no value provided"
+			}
+			continue
+		}
+		if err := validation.Validate(value, param.Rules...); err != nil {
+			errs[name] = err.Error()
+		}
+	}
+
+	providerNames := make([]provider.ProviderName, 0, len(def.Patterns))
+	for providerName := range def.Patterns {
+		providerNames = append(providerNames, providerName)
+	}
+	slices.Sort(providerNames)
+
+	response := &dto.ResolvePathResponse{
+		Definition: string(def.Name),
+		Scope:      convertScopes([]resolver.ScopeType{scope})[0],
+		ScopeValue: req.ScopeValue,
+		Providers:  make([]dto.ResolvedProviderPath, 0, len(providerNames)),
+	}
+
+	for _, providerName := range providerNames {
+		patterns := def.Patterns[providerName]
+		pattern, ok := patterns.Patterns[scope]
+		if !ok {
+			continue
+		}
+		if parent != nil {
+			if parentPatterns, ok := parent.Patterns[providerName]; ok {
+				pattern = parentPatterns.Patterns[scope] + "/" + pattern
+			}
+		}
+
+		resolved := dto.ResolvedProviderPath{
+			Provider:   string(providerName),
+			URLType:    fmt.Sprint(patterns.URLType),
+			Parameters: make(map[string]string),
+			Errors:     make(map[string]string),
+		}
+		for name, message := range errs {
+			resolved.Errors[name] = message
+		}
+
+		resolvedPath, err := uc.resolvePath(ctx, def.Name, providerName, scope, req.ScopeValue, values)
+		if err != nil {
+			resolved.Errors["path"] = err.Error()
+		} else {
+			resolved.Path = resolvedPath
+			for name, value := range patternValues(pattern, resolvedPath) {
+				// Placeholders of missing parameters are not values
+				if value != "{"+name+"}" {
+					resolved.Parameters[name] = value
+				}
+			}
+		}
+
+		for name, value := range req.Parameters {
+			if _, ok := resolved.Parameters[name]; !ok {
+				resolved.Parameters[name] = value
+			}
+		}
+
+		resolved.Valid = len(resolved.Errors) == 0
+		response.Providers = append(response.Providers, resolved)
+	}
+
+	return response, nil
+}
+
+// resolvePath resolves the definition's path on a provider with the same
+// options resolveDefinitionRoot uses
+func (uc *ResourceDefinitionUseCase) resolvePath(ctx context.Context, name resolver.DefinitionName, providerName provider.ProviderName, scope resolver.ScopeType, scopeValue int16, parameters map[string]string) (string, error) {
+	values := make(map[resolver.ParameterName]string, len(parameters))
+	for k, v := range parameters {
+		values[resolver.ParameterName(k)] = v
+	}
+
+	opts := (&resolver.DefinitionDownloadOptions{}).
+		WithProvider(providerName).
+		WithValues(values).
+		WithScope(scope, scopeValue)
+
+	resolved, err := uc.manager.DefinitionResolver().ResolveDownloadURL(ctx, name, opts)
+	if err != nil {
+		return "", err
+	}
+	return resolved.ResolvedPath.Path, nil
+}
+
+// patternValues reads the value of each placeholder of the pattern back from
+// a path it resolved to. It returns nil when the path does not match.
+func patternValues(pattern, resolvedPath string) map[string]string {
+	normalize := func(p string) string {
+		return strings.Trim(repeatedSlashes.ReplaceAllString(p, "/"), "/")
+	}
+	pattern = normalize(pattern)
+
+	var (
+		expr  strings.Builder
+		names []string
+		last  int
+	)
+	expr.WriteString("^")
+	for _, match := range patternPlaceholder.FindAllStringSubmatchIndex(pattern, -1) {
+		expr.WriteString(regexp.QuoteMeta(pattern[last:match[0]]))
+		expr.WriteString("(.*?)")
+		names = append(names, pattern[match[2]:match[3]])
+		last = match[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(pattern[last:]))
+	expr.WriteString("$")
+
+	matcher, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil
+	}
+	groups := matcher.FindStringSubmatch(normalize(resolvedPath))
+	if groups == nil {
+		return nil
+	}
+
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if _, ok := values[name]; !ok {
+			values[name] = groups[i+1]
+		}
+	}
+	return values
+}
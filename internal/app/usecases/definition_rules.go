@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"avironactive.com/resource/resolver"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// describeParameter renders a parameter's constraints into its response
+func describeParameter(param *dto.PathParameterResponse, constraints core.ParameterConstraints) {
+	param.Rules = constraints.RuleNames()
+	param.Required = constraints.Required
+	param.Enum = constraints.Enum
+	param.Pattern = constraints.Pattern
+	if constraints.MinLength > 0 {
+		minLength := constraints.MinLength
+		param.MinLength = &minLength
+	}
+	if constraints.MaxLength > 0 {
+		maxLength := constraints.MaxLength
+		param.MaxLength = &maxLength
+	}
+}
+
+// parameterConstraints returns the constraints of a parameter of def, or of
+// its parent when def does not declare it
+func (uc *ResourceDefinitionUseCase) parameterConstraints(def, parent *resolver.Definition, name resolver.ParameterName) core.ParameterConstraints {
+	for _, param := range def.Parameters {
+		if param.Name == name {
+			return uc.registry.ParameterConstraints(def.Name, name)
+		}
+	}
+	if parent != nil {
+		return uc.registry.ParameterConstraints(parent.Name, name)
+	}
+	return core.ParameterConstraints{}
+}
@@ -0,0 +1,215 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/resolver"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// scopeValueNames maps the scopes selected by a scope value to the names the
+// values stand for
+var scopeValueNames = map[resolver.ScopeType]func() map[int16]string{
+	resolver.ScopeApp:       core.AllAppNames,
+	resolver.ScopeClientApp: core.AllClientAppNames,
+}
+
+// GetDefinitionSchema returns the JSON Schema of the scope and parameters of
+// upload and download requests for a definition
+func (uc *ResourceDefinitionUseCase) GetDefinitionSchema(ctx context.Context, name string) (*dto.JSONSchema, error) {
+	def, err := uc.manager.GetDefinition(resolver.DefinitionName(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDefinitionNotFound, err)
+	}
+
+	schema := uc.definitionSchema(def)
+	schema.Schema = dto.JSONSchemaDialect
+	schema.ID = "/api/v1/resources/definitions/" + name + "/schema"
+	return schema, nil
+}
+
+// GetSchemas returns one JSON Schema document holding the request schema of
+// every definition under $defs, keyed by definition name
+func (uc *ResourceDefinitionUseCase) GetSchemas(ctx context.Context) (*dto.JSONSchema, error) {
+	definitions := uc.manager.GetAllDefinitions()
+
+	document := &dto.JSONSchema{
+		Schema:      dto.JSONSchemaDialect,
+		ID:          "/api/v1/resources/definitions/schema",
+		Title:       "Resource definitions",
+		Description: "Request schemas of every resource definition, keyed by definition name",
+		Defs:        make(map[string]*dto.JSONSchema, len(definitions)),
+	}
+	for _, def := range definitions {
+		document.Defs[string(def.Name)] = uc.definitionSchema(def)
+		document.OneOf = append(document.OneOf, &dto.JSONSchema{Ref: "#/$defs/" + string(def.Name)})
+	}
+	slices.SortFunc(document.OneOf, func(a, b *dto.JSONSchema) int {
+		return strings.Compare(a.Ref, b.Ref)
+	})
+
+	return document, nil
+}
+
+// definitionSchema describes the request payload of a definition. Parameters
+// of its parent definition are included, since they appear in its paths, as
+// are the placeholders the fallback parameter resolver fills.
+func (uc *ResourceDefinitionUseCase) definitionSchema(def *resolver.Definition) *dto.JSONSchema {
+	parent := parentDefinition(uc.manager, def.Name)
+
+	allowed := def.AllowedScopes
+	var params []*resolver.ParameterDefinition
+	if parent != nil {
+		if len(allowed) == 0 {
+			allowed = parent.AllowedScopes
+		}
+		params = append(params, parent.Parameters...)
+	}
+	params = append(params, def.Parameters...)
+
+	// Child parameters override the parent's parameters of the same name
+	declared := make(map[string]*resolver.ParameterDefinition, len(params))
+	for _, param := range params {
+		declared[string(param.Name)] = param
+	}
+
+	parameters := &dto.JSONSchema{
+		Type:       "object",
+		Properties: make(map[string]*dto.JSONSchema),
+	}
+	for name, param := range declared {
+		rendered := dto.PathParameterResponse{Name: name}
+		describeParameter(&rendered, uc.parameterConstraints(def, parent, param.Name))
+
+		property := &dto.JSONSchema{
+			Type:        "string",
+			Description: param.Description,
+			Pattern:     rendered.Pattern,
+			MinLength:   rendered.MinLength,
+			MaxLength:   rendered.MaxLength,
+		}
+		if param.DefaultValue != "" {
+			property.Default = param.DefaultValue
+		}
+		for _, value := range rendered.Enum {
+			property.Enum = append(property.Enum, value)
+		}
+		// Parameters with a default never need to be sent
+		if rendered.Required && param.DefaultValue == "" {
+			parameters.Required = append(parameters.Required, name)
+		}
+		parameters.Properties[name] = property
+	}
+
+	fallback := map[string]string{"env": core.DefaultEnv, "version": core.DefaultVersion}
+	for _, name := range patternParameters(def, parent) {
+		if _, ok := parameters.Properties[name]; ok {
+			continue
+		}
+		property := &dto.JSONSchema{
+			Type:        "string",
+			Description: "Provided by the server when omitted",
+		}
+		if value, ok := fallback[name]; ok {
+			property.Default = value
+		}
+		parameters.Properties[name] = property
+	}
+	slices.Sort(parameters.Required)
+
+	scopes := convertScopes(allowed)
+	scope := &dto.JSONSchema{
+		Type:        "string",
+		Description: "Scope of the resource: G (global), A (app) or CA (client app)",
+	}
+	for _, code := range scopes {
+		scope.Enum = append(scope.Enum, code)
+	}
+	if len(scopes) > 0 {
+		scope.Default = scopes[0]
+	}
+
+	minScopeValue := 1.0
+	schema := &dto.JSONSchema{
+		Title:       def.DisplayName,
+		Description: def.Description,
+		Type:        "object",
+		Properties: map[string]*dto.JSONSchema{
+			"scope": scope,
+			"scopeValue": {
+				Type:        "integer",
+				Description: "App or client app identifier, required for the A and CA scopes",
+				Minimum:     &minScopeValue,
+			},
+			"parameters": parameters,
+		},
+	}
+	if len(parameters.Required) > 0 {
+		schema.Required = []string{"parameters"}
+	}
+
+	// Scopes selected by a value require one of their known identifiers
+	for _, allowedScope := range allowed {
+		names, ok := scopeValueNames[allowedScope]
+		if !ok {
+			continue
+		}
+		ids := slices.Sorted(maps.Keys(names()))
+		values := make([]any, len(ids))
+		for i, id := range ids {
+			values[i] = id
+		}
+
+		schema.AllOf = append(schema.AllOf, &dto.JSONSchema{
+			If: &dto.JSONSchema{
+				Properties: map[string]*dto.JSONSchema{
+					"scope": {Enum: []any{convertScopes([]resolver.ScopeType{allowedScope})[0]}},
+				},
+				Required: []string{"scope"},
+			},
+			Then: &dto.JSONSchema{
+				Properties: map[string]*dto.JSONSchema{
+					"scopeValue": {Enum: values},
+				},
+				Required: []string{"scopeValue"},
+			},
+		})
+	}
+
+	if record := uc.registry.Lookup(string(def.Name)); record != nil {
+		schema.Deprecated = record.IsDeprecated()
+	}
+
+	return schema
+}
+
+// patternParameters returns the placeholders used by any of the definition's
+// patterns, combined with its parent's, in name order
+func patternParameters(def, parent *resolver.Definition) []string {
+	var names []string
+	collect := func(d *resolver.Definition) {
+		for _, patterns := range d.Patterns {
+			for _, pattern := range patterns.Patterns {
+				for _, match := range patternPlaceholder.FindAllStringSubmatch(pattern, -1) {
+					if !slices.Contains(names, match[1]) {
+						names = append(names, match[1])
+					}
+				}
+			}
+		}
+	}
+
+	collect(def)
+	if parent != nil {
+		collect(parent)
+	}
+
+	slices.Sort(names)
+	return names
+}
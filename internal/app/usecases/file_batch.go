@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	stdcontext "context"
+	"fmt"
+	"sync"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource/provider"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// batchConcurrency bounds the per-file calls in flight for a batch
+const batchConcurrency = 8
+
+// batchDeleter is implemented by providers with a native multi-object delete.
+// The returned map holds errors for the paths that could not be deleted.
+type batchDeleter interface {
+	DeleteObjects(ctx stdcontext.Context, paths []string) (map[string]error, error)
+}
+
+// BatchDelete deletes the selected files, using the provider's batch delete
+// when available. Content-addressed files release their blob, and blobs are
+// never deleted directly. A dry run only reports which files exist.
+func (uc *FileOperationsUseCase) BatchDelete(ctx context.Context, req *dto.BatchDeleteRequest) (*dto.BatchResponse, error) {
+	providerName := provider.ProviderName(req.Provider)
+
+	paths, truncated, err := uc.batchPaths(ctx, providerName, req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		results := uc.matchFiles(ctx, providerName, paths)
+		return dto.NewBatchResponse(req.Provider, true, truncated, results), nil
+	}
+
+	prov, err := uc.manager.GetProvider(providerName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+
+	results, plain, mapped, err := uc.splitShared(ctx, providerName, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	released := runBatch(ctx, mapped, dto.BatchStatusDeleted, func(path string) error {
+		return uc.contentStore.Delete(ctx, providerName, path)
+	})
+
+	// An interrupted batch reports the files it did not get to as failed
+	var deleted []dto.BatchItemResult
+	if deleter, ok := prov.(batchDeleter); ok && len(plain) > 0 && ctx.Context().Err() == nil {
+		failures, err := deleter.DeleteObjects(ctx.Context(), plain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete files: %w", err)
+		}
+
+		deleted = make([]dto.BatchItemResult, len(plain))
+		for i, path := range plain {
+			deleted[i] = batchResult(path, dto.BatchStatusDeleted, failures[path])
+		}
+	} else {
+		deleted = runBatch(ctx, plain, dto.BatchStatusDeleted, func(path string) error {
+			return uc.manager.DeleteObject(ctx, providerName, path)
+		})
+	}
+
+	return dto.NewBatchResponse(req.Provider, false, truncated, mergeResults(paths, results, released, deleted)), nil
+}
+
+// BatchUpdateMetadata applies the same metadata update to the selected files.
+// Content-addressed files and blobs fail, as their blob may back other
+// paths. A dry run only reports which files exist.
+func (uc *FileOperationsUseCase) BatchUpdateMetadata(ctx context.Context, req *dto.BatchUpdateMetadataRequest) (*dto.BatchResponse, error) {
+	providerName := provider.ProviderName(req.Provider)
+
+	paths, truncated, err := uc.batchPaths(ctx, providerName, &req.Batch.BatchTargetRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		results := uc.matchFiles(ctx, providerName, paths)
+		return dto.NewBatchResponse(req.Provider, true, truncated, results), nil
+	}
+
+	results, plain, mapped, err := uc.splitShared(ctx, providerName, paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range mapped {
+		results = append(results, batchResult(path, "", fmt.Errorf("%w: %s", ErrContentShared, path)))
+	}
+
+	update := req.Batch.Metadata.ToUpdateMetadata()
+	updated := runBatch(ctx, plain, dto.BatchStatusUpdated, func(path string) error {
+		return uc.manager.UpdateObjectMetadata(ctx, providerName, path, update)
+	})
+
+	return dto.NewBatchResponse(req.Provider, false, truncated, mergeResults(paths, results, updated)), nil
+}
+
+// batchPaths returns the explicit paths, or lists up to dto.MaxBatchItems
+// files under the prefix within the definition, followed by the
+// content-addressed files stored as blobs. The flag reports whether more
+// files matched.
+func (uc *FileOperationsUseCase) batchPaths(ctx context.Context, providerName provider.ProviderName, target *dto.BatchTargetRequest) ([]string, bool, error) {
+	if target.Definition == "" {
+		return target.Paths, false, nil
+	}
+
+	var (
+		paths []string
+		token string
+	)
+	for len(paths) < dto.MaxBatchItems {
+		maxKeys := int32(dto.MaxBatchItems - len(paths))
+		opts := &provider.ListObjectsOptions{
+			MaxKeys: &maxKeys,
+			Prefix:  &target.Prefix,
+		}
+		if token != "" {
+			opts.ContinuationToken = &token
+		}
+
+		result, err := uc.manager.ListObjects(ctx, providerName, target.Definition, opts)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range result.Objects {
+			if !isInternalPath(object.Key) {
+				paths = append(paths, object.Key)
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == nil {
+			token = ""
+			break
+		}
+		token = *result.NextContinuationToken
+	}
+	if token != "" {
+		return paths, true, nil
+	}
+
+	referenced, err := uc.contentStore.ListPaths(ctx, providerName, target.Definition, target.Prefix, dto.MaxBatchItems+1-len(paths))
+	if err != nil {
+		return nil, false, err
+	}
+	paths = append(paths, referenced...)
+	if len(paths) > dto.MaxBatchItems {
+		return paths[:dto.MaxBatchItems], true, nil
+	}
+
+	return paths, false, nil
+}
+
+// splitShared separates content-addressed paths from plain files. Blob keys
+// are reported as failed results, as they are only changed through the
+// paths referencing them.
+func (uc *FileOperationsUseCase) splitShared(ctx context.Context, providerName provider.ProviderName, paths []string) ([]dto.BatchItemResult, []string, []string, error) {
+	mappedPaths, err := uc.contentStore.MappedPaths(ctx, providerName, paths)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		results       []dto.BatchItemResult
+		plain, mapped []string
+	)
+	for _, path := range paths {
+		switch {
+		case isInternalPath(path):
+			results = append(results, batchResult(path, "", fmt.Errorf("%w: %s is managed by the server", ErrContentShared, path)))
+		case mappedPaths[path]:
+			mapped = append(mapped, path)
+		default:
+			plain = append(plain, path)
+		}
+	}
+	return results, plain, mapped, nil
+}
+
+// mergeResults orders the results of the parts of a batch like its paths
+func mergeResults(paths []string, parts ...[]dto.BatchItemResult) []dto.BatchItemResult {
+	byPath := make(map[string]dto.BatchItemResult, len(paths))
+	for _, part := range parts {
+		for _, result := range part {
+			byPath[result.Path] = result
+		}
+	}
+
+	results := make([]dto.BatchItemResult, 0, len(paths))
+	for _, path := range paths {
+		if result, ok := byPath[path]; ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// matchFiles reports which paths exist without changing them
+func (uc *FileOperationsUseCase) matchFiles(ctx context.Context, providerName provider.ProviderName, paths []string) []dto.BatchItemResult {
+	return runBatch(ctx, paths, dto.BatchStatusMatched, func(path string) error {
+		_, err := uc.contentStore.Stat(ctx, providerName, path)
+		return err
+	})
+}
+
+// runBatch calls fn for every path with at most batchConcurrency calls in
+// flight. Results keep the order of paths. Once the request is cancelled or
+// past its deadline the calls in flight finish, and the paths not started
+// yet are reported as failed.
+func runBatch(ctx context.Context, paths []string, successStatus string, fn func(path string) error) []dto.BatchItemResult {
+	results := make([]dto.BatchItemResult, len(paths))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, batchConcurrency)
+	for i, path := range paths {
+		if err := ctx.Context().Err(); err != nil {
+			results[i] = batchResult(path, successStatus, fmt.Errorf("batch interrupted: %w", err))
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			results[i] = batchResult(path, successStatus, fn(path))
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func batchResult(path, successStatus string, err error) dto.BatchItemResult {
+	if err != nil {
+		return dto.BatchItemResult{Path: path, Status: dto.BatchStatusFailed, Error: err.Error()}
+	}
+	return dto.BatchItemResult{Path: path, Status: successStatus}
+}
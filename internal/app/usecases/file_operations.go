@@ -1,7 +1,16 @@
 package usecases
 
 import (
+	"cmp"
+	stdcontext "context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"avironactive.com/common/context"
 	"avironactive.com/resource/provider"
@@ -9,56 +18,417 @@ import (
 
 	"avironactive.com/resource"
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
 )
 
+var (
+	// ErrDestinationExists is returned when a copy would replace an existing
+	// object without overwrite set
+	ErrDestinationExists = domainerr.New(domainerr.Conflict, "DESTINATION_EXISTS", "destination file already exists")
+	// ErrInvalidCopyDestination is returned when the copy destination cannot be used
+	ErrInvalidCopyDestination = domainerr.New(domainerr.Validation, "INVALID_DESTINATION", "invalid copy destination")
+	// ErrExpiryOutOfRange is returned when a requested signed URL expiry is
+	// outside the provider's MinExpiry and MaxExpiry
+	ErrExpiryOutOfRange = domainerr.New(domainerr.Validation, "INVALID_EXPIRY", "expiry out of range")
+	// ErrInvalidContinuationToken is returned when a delimited listing is
+	// continued with a token it did not return
+	ErrInvalidContinuationToken = domainerr.New(domainerr.Validation, "INVALID_CONTINUATION_TOKEN", "invalid continuation token")
+)
+
+// listPagesPerRequest bounds the provider pages read by one filtered or
+// delimited listing request
+const listPagesPerRequest = 10
+
+// objectCopier is implemented by providers that copy objects server-side
+type objectCopier interface {
+	CopyObject(ctx stdcontext.Context, sourcePath, destinationPath string) error
+}
+
 // FileOperationsUseCase handles file operation use cases
 type FileOperationsUseCase struct {
-	manager resource.ResourceManager
+	manager           resource.ResourceManager
+	pathReferenceRepo repository.PathReferenceRepository
+	uploadRepo        repository.UploadRepository
+	contentStore      *ContentStore
+	registry          *DefinitionRegistry
+	httpClient        *http.Client
+	objects           *objectOpener
+	// contentUploadSlots holds one token per streamed upload buffering a part
+	contentUploadSlots chan struct{}
+	// bundleCacheTTL is how long cached bundles are served; zero keeps them
+	bundleCacheTTL time.Duration
 }
 
 // NewFileOperationsUseCase creates a new file operations use case
-func NewFileOperationsUseCase(manager resource.ResourceManager) *FileOperationsUseCase {
+func NewFileOperationsUseCase(manager resource.ResourceManager, pathReferenceRepo repository.PathReferenceRepository, uploadRepo repository.UploadRepository, contentStore *ContentStore, registry *DefinitionRegistry, bundleCacheTTL time.Duration) *FileOperationsUseCase {
+	httpClient := &http.Client{Timeout: 10 * time.Minute}
 	return &FileOperationsUseCase{
-		manager: manager,
+		manager:            manager,
+		pathReferenceRepo:  pathReferenceRepo,
+		uploadRepo:         uploadRepo,
+		contentStore:       contentStore,
+		registry:           registry,
+		bundleCacheTTL:     bundleCacheTTL,
+		httpClient:         httpClient,
+		objects:            newObjectOpener(manager, httpClient),
+		contentUploadSlots: make(chan struct{}, contentUploadConcurrency),
 	}
 }
 
-// ListFiles lists files in a resource path with pagination
+// ListFiles lists files in a resource path with pagination. Pages are
+// fetched until MaxKeys entries pass the filters, the listing ends or
+// listPagesPerRequest pages were read, so a truncated response may hold fewer
+// than MaxKeys entries. Each provider page is consumed whole so the returned
+// continuation token never skips objects.
+//
+// Content-addressed blobs are left out. Files stored as blobs have no object
+// at their path, so once the provider listing ends their references are
+// listed in path order.
+//
+// Common prefixes are rolled up here from the recursive listing rather than by
+// the provider, so every key under a prefix is still read. Keys sharing a
+// prefix are listed contiguously, and the continuation token carries the last
+// prefix returned so the next page does not repeat it. Sorting orders the
+// entries of each returned page.
 func (uc *FileOperationsUseCase) ListFiles(ctx context.Context, req *dto.ListFilesRequest) (*dto.FileListResponse, error) {
-	listReq := &provider.ListObjectsOptions{
-		MaxKeys:           &req.MaxKeys,
-		ContinuationToken: &req.ContinuationToken,
-		Prefix:            &req.Prefix,
+	opts := req.Options
+	if opts == nil {
+		opts = &dto.ListFilesOptions{}
+	}
+	providerName := provider.ProviderName(req.Provider)
+
+	prefix := req.Prefix
+	if opts.Scoped() {
+		root, err := resolveDefinitionRoot(ctx, uc.manager, req.Definition, providerName, opts.Scope, opts.ScopeValue, opts.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve definition path: %w", err)
+		}
+		prefix = root + "/" + strings.TrimPrefix(req.Prefix, "/")
+	}
+
+	response := &dto.FileListResponse{
+		Files:   []dto.FileInfo{},
+		MaxKeys: int(req.MaxKeys),
+	}
+	var cursor listToken
+	if req.ContinuationToken != "" {
+		var err error
+		if cursor, err = decodeListToken(req.ContinuationToken); err != nil {
+			return nil, err
+		}
+	}
+
+	remaining := func() int {
+		return int(req.MaxKeys) - len(response.Files) - len(response.CommonPrefixes)
 	}
+	// rollUp records the common prefix of key and reports whether key was
+	// rolled up into one
+	rollUp := func(key string, listed func(commonPrefix string) (bool, error)) (bool, error) {
+		if opts.Delimiter == "" {
+			return false, nil
+		}
+		rest, _ := strings.CutPrefix(key, strings.TrimPrefix(prefix, "/"))
+		i := strings.Index(rest, opts.Delimiter)
+		if i < 0 {
+			return false, nil
+		}
+
+		commonPrefix := key[:len(key)-len(rest)+i+len(opts.Delimiter)]
+		if commonPrefix == cursor.Prefix {
+			return true, nil
+		}
+		if listed != nil {
+			if seen, err := listed(commonPrefix); err != nil || seen {
+				return true, err
+			}
+		}
+		cursor.Prefix = commonPrefix
+		response.CommonPrefixes = append(response.CommonPrefixes, commonPrefix)
+		return true, nil
+	}
+
+	pages := 0
+	for ; !cursor.Refs && pages < listPagesPerRequest && remaining() > 0; pages++ {
+		maxKeys := int32(remaining())
+		listReq := &provider.ListObjectsOptions{
+			MaxKeys: &maxKeys,
+			Prefix:  &prefix,
+		}
+		if cursor.Token != "" {
+			listReq.ContinuationToken = &cursor.Token
+		}
+
+		result, err := uc.manager.ListObjects(ctx, providerName, req.Definition, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		var candidates []dto.FileInfo
+		for _, obj := range result.Objects {
+			key := strings.TrimPrefix(obj.Key, "/")
+			if !strings.HasPrefix(key, strings.TrimPrefix(prefix, "/")) || isInternalPath(key) {
+				continue
+			}
+			if rolled, _ := rollUp(key, nil); rolled {
+				continue
+			}
+			if opts.MatchesObject(obj) {
+				candidates = append(candidates, dto.NewFileInfoFromProvider(obj))
+			}
+		}
+
+		if err := uc.appendListed(ctx, providerName, response, candidates, opts); err != nil {
+			return nil, err
+		}
+
+		cursor.Token = ""
+		if result.IsTruncated && result.NextContinuationToken != nil {
+			cursor.Token = *result.NextContinuationToken
+		}
+		cursor.Refs = cursor.Token == ""
+	}
+
+	// A prefix holding plain objects was already returned by the provider
+	// listing
+	listed := func(commonPrefix string) (bool, error) {
+		return uc.hasListedObject(ctx, providerName, req.Definition, commonPrefix)
+	}
+	done := false
+	for ; cursor.Refs && pages < listPagesPerRequest && remaining() > 0; pages++ {
+		limit := remaining()
+		refs, err := uc.contentStore.ListReferences(ctx, providerName, req.Definition, prefix, cursor.After, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []dto.FileInfo
+		for _, ref := range refs {
+			cursor.After = ref.Path
+			key := strings.TrimPrefix(ref.Path, "/")
+			rolled, err := rollUp(key, listed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list objects: %w", err)
+			}
+			if rolled {
+				continue
+			}
 
-	// List objects using the resource manager
-	result, err := uc.manager.ListObjects(ctx, provider.ProviderName(req.Provider), req.Definition, listReq)
+			obj := provider.ObjectInfo{Key: ref.Path, Size: ref.Size, LastModified: &ref.CreatedAt}
+			if opts.MatchesObject(obj) {
+				candidates = append(candidates, dto.NewFileInfoFromProvider(obj))
+			}
+		}
+
+		if err := uc.appendListed(ctx, providerName, response, candidates, opts); err != nil {
+			return nil, err
+		}
+
+		if len(refs) < limit {
+			done = true
+			break
+		}
+	}
+
+	if !done {
+		response.ContinuationToken = encodeListToken(cursor)
+		response.IsTruncated = true
+	}
+	sortFiles(response.Files, opts.Sort, opts.Order == "desc")
+
+	return response, nil
+}
+
+// appendListed adds the listed files to the response, looking up their
+// metadata first when the options need it
+func (uc *FileOperationsUseCase) appendListed(ctx context.Context, providerName provider.ProviderName, response *dto.FileListResponse, files []dto.FileInfo, opts *dto.ListFilesOptions) error {
+	if opts.NeedsMetadata() {
+		var err error
+		if files, err = uc.withMetadata(ctx, providerName, files, opts); err != nil {
+			return err
+		}
+	}
+	response.Files = append(response.Files, files...)
+	return nil
+}
+
+// hasListedObject reports whether the provider holds a file, rather than
+// only blobs, under the prefix
+func (uc *FileOperationsUseCase) hasListedObject(ctx context.Context, providerName provider.ProviderName, definition, prefix string) (bool, error) {
+	var token string
+	for range listPagesPerRequest {
+		listReq := &provider.ListObjectsOptions{Prefix: &prefix}
+		if token != "" {
+			listReq.ContinuationToken = &token
+		}
+
+		result, err := uc.manager.ListObjects(ctx, providerName, definition, listReq)
+		if err != nil {
+			return false, err
+		}
+		for _, obj := range result.Objects {
+			if !isInternalPath(obj.Key) {
+				return true, nil
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == nil {
+			break
+		}
+		token = *result.NextContinuationToken
+	}
+	return false, nil
+}
+
+// listToken is the continuation token of a listing: the provider's token,
+// or once the provider listing ended the last content reference returned,
+// and the last common prefix returned
+type listToken struct {
+	Token  string `json:"t,omitempty"`
+	Refs   bool   `json:"r,omitempty"`
+	After  string `json:"a,omitempty"`
+	Prefix string `json:"p,omitempty"`
+}
+
+func encodeListToken(token listToken) string {
+	encoded, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeListToken(raw string) (listToken, error) {
+	var token listToken
+	encoded, err := base64.RawURLEncoding.DecodeString(raw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
+		return token, fmt.Errorf("%w: %v", ErrInvalidContinuationToken, err)
 	}
 
-	// Convert to response DTO using factory function
-	return dto.NewFileListResponseFromProvider(result, int(req.MaxKeys)), nil
+	if err := json.Unmarshal(encoded, &token); err != nil || (token.Token == "" && !token.Refs) {
+		return token, fmt.Errorf("%w: not returned by a listing", ErrInvalidContinuationToken)
+	}
+	return token, nil
+}
+
+// withMetadata looks up metadata for the files, dropping those that fail the
+// content type filter. Metadata is attached when requested.
+func (uc *FileOperationsUseCase) withMetadata(ctx context.Context, providerName provider.ProviderName, files []dto.FileInfo, opts *dto.ListFilesOptions) ([]dto.FileInfo, error) {
+	keep := make([]bool, len(files))
+
+	var wg sync.WaitGroup
+	var err error
+	slots := make(chan struct{}, batchConcurrency)
+	for i := range files {
+		if err = ctx.Context().Err(); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			objectMetadata, err := uc.contentStore.Stat(ctx, providerName, files[i].Key)
+			if err != nil {
+				// Keep the file when only metadata was requested
+				keep[i] = opts.ContentType == ""
+				return
+			}
+
+			keep[i] = opts.MatchesContentType(objectMetadata.ContentType)
+			files[i].ContentType = objectMetadata.ContentType
+			if opts.IncludeMetadata {
+				files[i].Metadata = dto.NewFileInfoMetadata(objectMetadata)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+
+	filtered := files[:0]
+	for i, file := range files {
+		if keep[i] {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered, nil
+}
+
+// sortFiles orders files by the given key; the provider's key order is kept
+// when no sort is requested
+func sortFiles(files []dto.FileInfo, by string, desc bool) {
+	if by == "" {
+		if desc {
+			slices.Reverse(files)
+		}
+		return
+	}
+
+	slices.SortStableFunc(files, func(a, b dto.FileInfo) int {
+		var c int
+		switch by {
+		case dto.ListSortSize:
+			c = cmp.Compare(a.Size, b.Size)
+		case dto.ListSortLastModified:
+			c = a.LastModified.Compare(b.LastModified)
+		default:
+			c = strings.Compare(a.Key, b.Key)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
 }
 
 // GenerateUploadURL generates a signed URL for file upload
 func (uc *FileOperationsUseCase) GenerateUploadURL(ctx context.Context, req *dto.GenerateUploadURLRequest) (*dto.SignedURLResponse, error) {
-	opts := req.Upload.To()
-	signedURL, err := uc.manager.DefinitionResolver().ResolveUploadURL(ctx, resolver.DefinitionName(req.Definition), opts)
+	providerName := provider.ProviderName(req.Provider)
+	if err := uc.checkExpiry(providerName, req.Upload.Expiry); err != nil {
+		return nil, err
+	}
+
+	definition := resolver.DefinitionName(req.Definition)
+	if err := uc.registry.CheckUploadable(definition); err != nil {
+		return nil, err
+	}
+	constraints, err := uploadConstraints(uc.registry.UploadPolicy(definition), providerName, newDeclaredUpload(req.Upload, req.ClientIP))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := req.Upload.To().WithProvider(providerName)
+	if constraints != nil {
+		opts = opts.WithConstraints(constraints)
+	}
+	signedURL, err := uc.manager.DefinitionResolver().ResolveUploadURL(ctx, definition, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
 	}
 
-	return dto.NewSignedURLResponseFromProvider(signedURL), nil
+	response := dto.NewSignedURLResponseFromProvider(signedURL)
+	response.Constraints = dto.NewUploadConstraintsResponse(constraints)
+	return response, nil
 }
 
-// GenerateDownloadURL generates a signed URL for file download
+// GenerateDownloadURL generates a signed URL for file download. Paths of
+// content-addressed files are signed for their blob.
 func (uc *FileOperationsUseCase) GenerateDownloadURL(ctx context.Context, req *dto.GenerateDownloadURLRequest) (*dto.SignedURLResponse, error) {
 	var opts *resolver.DownloadOptions
 	if req.Download != nil {
+		if err := uc.checkExpiry(provider.ProviderName(req.Provider), req.Download.Expiry); err != nil {
+			return nil, err
+		}
 		opts = req.Download.To()
 	}
-	signedURL, err := uc.manager.URLResolver().ResolveDownloadURL(ctx, req.FilePath, opts)
+
+	filePath, err := uc.contentStore.Resolve(ctx, provider.ProviderName(req.Provider), req.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	signedURL, err := uc.manager.URLResolver().ResolveDownloadURL(ctx, filePath, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate download URL: %w", err)
 	}
@@ -66,9 +436,39 @@ func (uc *FileOperationsUseCase) GenerateDownloadURL(ctx context.Context, req *d
 	return dto.NewSignedURLResponseFromProvider(signedURL), nil
 }
 
-// DeleteFile deletes a file from storage
+// checkExpiry rejects a requested signed URL expiry outside the provider's
+// limits. An empty expiry uses the provider default.
+func (uc *FileOperationsUseCase) checkExpiry(providerName provider.ProviderName, expiry string) error {
+	if expiry == "" {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(expiry)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrExpiryOutOfRange, err)
+	}
+
+	prov, err := uc.manager.GetProvider(providerName)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+
+	caps := prov.Capabilities()
+	minExpiry := max(caps.MinExpiry, time.Second)
+	if duration < minExpiry {
+		return fmt.Errorf("%w: %s is shorter than the minimum %s", ErrExpiryOutOfRange, duration, minExpiry)
+	}
+	if caps.MaxExpiry > 0 && duration > caps.MaxExpiry {
+		return fmt.Errorf("%w: %s is longer than the maximum %s", ErrExpiryOutOfRange, duration, caps.MaxExpiry)
+	}
+
+	return nil
+}
+
+// DeleteFile deletes a file from storage. Content-addressed files release
+// their blob, which is deleted with its last reference.
 func (uc *FileOperationsUseCase) DeleteFile(ctx context.Context, req *dto.DeleteFileRequest) error {
-	err := uc.manager.DeleteObject(ctx, provider.ProviderName(req.Provider), req.FilePath)
+	err := uc.contentStore.Delete(ctx, provider.ProviderName(req.Provider), req.FilePath)
 	if err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
@@ -77,7 +477,7 @@ func (uc *FileOperationsUseCase) DeleteFile(ctx context.Context, req *dto.Delete
 
 // GetFileMetadata retrieves metadata for a specific file
 func (uc *FileOperationsUseCase) GetFileMetadata(ctx context.Context, req *dto.GetFileMetadataRequest) (*dto.FileMetadata, error) {
-	metadata, err := uc.manager.GetObjectMetadata(ctx, provider.ProviderName(req.Provider), req.FilePath)
+	metadata, err := uc.contentStore.Stat(ctx, provider.ProviderName(req.Provider), req.FilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file metadata: %w", err)
 	}
@@ -85,10 +485,20 @@ func (uc *FileOperationsUseCase) GetFileMetadata(ctx context.Context, req *dto.G
 	return dto.NewFileMetadataFromProvider(metadata), nil
 }
 
-// UpdateFileMetadata updates metadata for an existing file
+// UpdateFileMetadata updates metadata for an existing file. Content-addressed
+// files are rejected, as their blob may back other paths.
 func (uc *FileOperationsUseCase) UpdateFileMetadata(ctx context.Context, req *dto.UpdateFileMetadataRequest) (*dto.FileMetadata, error) {
+	providerName := provider.ProviderName(req.Provider)
+	mapped, err := uc.contentStore.MappedPaths(ctx, providerName, []string{req.FilePath})
+	if err != nil {
+		return nil, err
+	}
+	if mapped[req.FilePath] {
+		return nil, fmt.Errorf("%w: %s", ErrContentShared, req.FilePath)
+	}
+
 	updateOpts := req.Metadata.ToUpdateMetadata()
-	err := uc.manager.UpdateObjectMetadata(ctx, provider.ProviderName(req.Provider), req.FilePath, updateOpts)
+	err = uc.manager.UpdateObjectMetadata(ctx, providerName, req.FilePath, updateOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update file metadata: %w", err)
 	}
@@ -100,3 +510,256 @@ func (uc *FileOperationsUseCase) UpdateFileMetadata(ctx context.Context, req *dt
 	}
 	return uc.GetFileMetadata(ctx, getReq)
 }
+
+// CopyFile copies a file to a new location on the same provider. When req.Move
+// is set the source is deleted afterwards and stored references to the source
+// path are rewritten to the destination. A content-addressed source is copied
+// from its blob to a plain object at the destination, and moved by remapping
+// its path to the destination without copying.
+func (uc *FileOperationsUseCase) CopyFile(ctx context.Context, req *dto.CopyFileRequest) (*dto.CopyFileResponse, error) {
+	providerName := provider.ProviderName(req.Provider)
+
+	sourcePath, err := uc.contentStore.Resolve(ctx, providerName, req.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+	source, err := uc.manager.GetObjectMetadata(ctx, providerName, sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source file metadata: %w", err)
+	}
+	mappedSource := sourcePath != req.SourcePath
+
+	destinationPath, uploadURL, err := uc.resolveDestination(ctx, providerName, req.Copy, source)
+	if err != nil {
+		return nil, err
+	}
+	if destinationPath == req.SourcePath {
+		return nil, fmt.Errorf("%w: destination is the source file", ErrInvalidCopyDestination)
+	}
+
+	if !req.Copy.Overwrite {
+		exists, err := uc.contentStore.Exists(ctx, providerName, destinationPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check destination file: %w", err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: %s", ErrDestinationExists, destinationPath)
+		}
+	}
+
+	response := &dto.CopyFileResponse{
+		Provider:        req.Provider,
+		SourcePath:      req.SourcePath,
+		DestinationPath: destinationPath,
+	}
+
+	if req.Move && mappedSource {
+		if err := uc.clearDestination(ctx, providerName, destinationPath); err != nil {
+			return nil, err
+		}
+		response.Method = dto.CopyMethodReference
+	} else {
+		response.Method, err = uc.copyObject(ctx, providerName, sourcePath, destinationPath, source, uploadURL)
+		if err != nil {
+			return nil, err
+		}
+
+		// Unmap a replaced content-addressed destination so the copy is served
+		if _, err := uc.contentStore.Release(ctx, providerName, destinationPath); err != nil {
+			return nil, fmt.Errorf("failed to replace destination file: %w", err)
+		}
+	}
+
+	if req.Move {
+		updated, err := uc.pathReferenceRepo.RewritePath(ctx.Context(), req.Provider, req.SourcePath, destinationPath, req.Actor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update path references: %w", err)
+		}
+		response.UpdatedReferences = updated
+
+		if !mappedSource {
+			if err := uc.manager.DeleteObject(ctx, providerName, req.SourcePath); err != nil {
+				return nil, fmt.Errorf("failed to delete source file after copy: %w", err)
+			}
+		}
+		response.Moved = true
+	}
+
+	if copied, err := uc.contentStore.Stat(ctx, providerName, destinationPath); err == nil {
+		response.Metadata = dto.NewFileMetadataFromProvider(copied)
+	}
+
+	return response, nil
+}
+
+// clearDestination removes the mapping or object at a destination that a
+// moved content-addressed path is remapped to
+func (uc *FileOperationsUseCase) clearDestination(ctx context.Context, providerName provider.ProviderName, destinationPath string) error {
+	released, err := uc.contentStore.Release(ctx, providerName, destinationPath)
+	if err != nil {
+		return fmt.Errorf("failed to replace destination file: %w", err)
+	}
+	if released {
+		return nil
+	}
+
+	if _, err := uc.manager.GetObjectMetadata(ctx, providerName, destinationPath); err != nil {
+		return nil
+	}
+	if err := uc.manager.DeleteObject(ctx, providerName, destinationPath); err != nil {
+		return fmt.Errorf("failed to replace destination file: %w", err)
+	}
+	return nil
+}
+
+// resolveDestination returns the destination path and, for definition
+// destinations, a signed upload URL usable for stream copies. The source
+// must satisfy the upload policy of the destination definition, or of every
+// definition whose pattern matches a raw destination path; its stored
+// checksum stands in for a declared one.
+func (uc *FileOperationsUseCase) resolveDestination(ctx context.Context, providerName provider.ProviderName, req *dto.CopyRequest, source *provider.ObjectMetadata) (string, *provider.ObjectURL, error) {
+	if req.Destination == nil {
+		for _, definition := range definitionsForPath(uc.manager, providerName, req.DestinationPath) {
+			if _, err := uc.checkCopyUpload(definition, source); err != nil {
+				return "", nil, err
+			}
+		}
+		return req.DestinationPath, nil, nil
+	}
+
+	definition := resolver.DefinitionName(req.Destination.Definition)
+	declared, err := uc.checkCopyUpload(definition, source)
+	if err != nil {
+		return "", nil, err
+	}
+	constraints, err := uploadConstraints(uc.registry.UploadPolicy(definition), providerName, declared)
+	if err != nil {
+		return "", nil, err
+	}
+
+	opts := req.Destination.To().WithProvider(providerName)
+	if constraints != nil {
+		opts = opts.WithConstraints(constraints)
+	}
+	resolved, err := uc.manager.DefinitionResolver().ResolveUploadURL(ctx, definition, opts)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: failed to resolve destination definition: %v", ErrInvalidCopyDestination, err)
+	}
+
+	return resolved.ResolvedPath.Path, &resolved.ObjectURL, nil
+}
+
+// checkCopyUpload checks that the definition accepts uploads and that the
+// source satisfies its upload policy, and returns the upload the source
+// declares
+func (uc *FileOperationsUseCase) checkCopyUpload(definition resolver.DefinitionName, source *provider.ObjectMetadata) (declaredUpload, error) {
+	declared := declaredUpload{
+		ContentType: source.ContentType,
+		Size:        source.Size,
+	}
+	if err := uc.registry.CheckUploadable(definition); err != nil {
+		return declared, err
+	}
+	policy := uc.registry.UploadPolicy(definition)
+	if policy != nil {
+		declared.Checksum = storedChecksum(source.Checksums, policy.RequiredChecksum)
+	}
+	return declared, checkServerUpload(policy, declared)
+}
+
+// copyObject copies using the provider's server-side copy when available and
+// streams the object through the server otherwise, to the signed upload URL of
+// definition destinations and through the provider's streaming writes for raw
+// destination paths. Server-side and streamed writes keep the source
+// metadata; uploads to signed URLs reapply it once the upload completes.
+func (uc *FileOperationsUseCase) copyObject(ctx context.Context, providerName provider.ProviderName, sourcePath, destinationPath string, source *provider.ObjectMetadata, uploadURL *provider.ObjectURL) (string, error) {
+	prov, err := uc.manager.GetProvider(providerName)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+	}
+
+	if copier, ok := prov.(objectCopier); ok {
+		if err := copier.CopyObject(ctx.Context(), sourcePath, destinationPath); err != nil {
+			return "", fmt.Errorf("failed to copy file: %w", err)
+		}
+		return dto.CopyMethodNative, nil
+	}
+
+	// Raw destination paths cannot be signed for upload
+	if uploadURL == nil {
+		writer, ok := prov.(objectWriter)
+		if !ok {
+			return "", fmt.Errorf("%w: provider %s supports neither server-side copy nor streaming writes, use a destination definition", ErrInvalidCopyDestination, providerName)
+		}
+		if _, err := streamBetweenProviders(ctx, uc.objects, writer, providerName, sourcePath, destinationPath, source); err != nil {
+			return "", fmt.Errorf("failed to copy file: %w", err)
+		}
+		return dto.CopyMethodStream, nil
+	}
+
+	if err := uc.streamObject(ctx, providerName, sourcePath, source, uploadURL); err != nil {
+		return "", err
+	}
+
+	if err := uc.manager.UpdateObjectMetadata(ctx, providerName, destinationPath, preservedMetadata(source)); err != nil {
+		return "", fmt.Errorf("failed to copy file metadata: %w", err)
+	}
+
+	return dto.CopyMethodStream, nil
+}
+
+// streamObject pipes the source object into the signed upload URL without
+// buffering it in memory
+func (uc *FileOperationsUseCase) streamObject(ctx context.Context, providerName provider.ProviderName, sourcePath string, source *provider.ObjectMetadata, uploadURL *provider.ObjectURL) error {
+	body, err := uc.objects.open(ctx, providerName, sourcePath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download source file: %w", err)
+	}
+	defer body.Close()
+
+	method := uploadURL.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+
+	putReq, err := http.NewRequestWithContext(ctx.Context(), method, uploadURL.URL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	putReq.ContentLength = source.Size
+	if source.ContentType != "" {
+		putReq.Header.Set("Content-Type", source.ContentType)
+	}
+	for key, value := range uploadURL.Headers {
+		putReq.Header.Set(key, value)
+	}
+
+	putResp, err := uc.httpClient.Do(putReq)
+	if err != nil {
+		return fmt.Errorf("failed to upload destination file: %w", err)
+	}
+	defer putResp.Body.Close()
+
+	if putResp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to upload destination file: %w", providerStatusError(putResp.StatusCode))
+	}
+
+	return nil
+}
+
+// preservedMetadata builds a metadata update that restores the source
+// object's headers and custom metadata on a copy
+func preservedMetadata(source *provider.ObjectMetadata) *provider.UpdateMetadata {
+	update := &provider.UpdateMetadata{
+		ContentType:        &source.ContentType,
+		ContentEncoding:    &source.ContentEncoding,
+		ContentLanguage:    &source.ContentLanguage,
+		ContentDisposition: &source.ContentDisposition,
+		CacheControl:       &source.CacheControl,
+		CustomHeaders:      source.Metadata,
+	}
+	if source.ACL != "" {
+		update.ACL = &source.ACL
+	}
+	return update
+}
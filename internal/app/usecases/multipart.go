@@ -13,18 +13,24 @@ import (
 
 // MultipartUseCase handles multipart upload operations
 type MultipartUseCase struct {
-	manager resource.ResourceManager
+	manager  resource.ResourceManager
+	registry *DefinitionRegistry
 }
 
 // NewMultipartUseCase creates a new multipart use case
-func NewMultipartUseCase(manager resource.ResourceManager) *MultipartUseCase {
+func NewMultipartUseCase(manager resource.ResourceManager, registry *DefinitionRegistry) *MultipartUseCase {
 	return &MultipartUseCase{
-		manager: manager,
+		manager:  manager,
+		registry: registry,
 	}
 }
 
 // InitMultipartUpload initializes a multipart upload
 func (uc *MultipartUseCase) InitMultipartUpload(ctx context.Context, req *dto.MultipartInitRequest) (*dto.MultipartInitResponse, error) {
+	if err := uc.registry.CheckUploadable(resolver.DefinitionName(req.DefinitionName)); err != nil {
+		return nil, err
+	}
+
 	opts := req.To()
 
 	// Get the path using ResolveReadURL
@@ -39,13 +45,13 @@ func (uc *MultipartUseCase) InitMultipartUpload(ctx context.Context, req *dto.Mu
 
 	prov, err := uc.manager.GetProvider(provider.ProviderName(req.Provider))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get provider: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrProviderNotFound, err)
 	}
 
 	// Check if provider supports multipart
 	multipartProvider, ok := prov.(provider.MultipartProvider)
 	if !ok {
-		return nil, fmt.Errorf("provider %s does not support multipart uploads", req.Provider)
+		return nil, fmt.Errorf("%w: %s", ErrMultipartUnsupported, req.Provider)
 	}
 
 	var headers *metadata.StorageMetadata
@@ -2,12 +2,27 @@ package usecases
 
 import (
 	"fmt"
+	"net/http"
 
 	"avironactive.com/common/context"
 	"avironactive.com/resource/provider"
 
 	"avironactive.com/resource"
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+var (
+	// ErrProviderNotFound is returned when no storage provider has the name
+	ErrProviderNotFound = domainerr.New(domainerr.NotFound, "PROVIDER_NOT_FOUND", "provider not found")
+	// ErrProviderUnavailable is returned when a provider fails to serve a
+	// request made through one of its signed URLs
+	ErrProviderUnavailable = domainerr.New(domainerr.ProviderUnavailable, "PROVIDER_UNAVAILABLE", "provider unavailable")
+	// ErrObjectNotFound is returned when a provider has no object at the path
+	ErrObjectNotFound = domainerr.New(domainerr.NotFound, "FILE_NOT_FOUND", "file not found")
+	// ErrMultipartUnsupported is returned when starting a multipart upload on
+	// a provider without multipart support
+	ErrMultipartUnsupported = domainerr.New(domainerr.Unsupported, "MULTIPART_UNSUPPORTED", "provider does not support multipart uploads")
 )
 
 // ProviderUseCase handles provider management operations
@@ -38,8 +53,21 @@ func (uc *ProviderUseCase) ListProviders(ctx context.Context) ([]*dto.ProviderRe
 func (uc *ProviderUseCase) GetProvider(ctx context.Context, name string) (*dto.ProviderResponse, error) {
 	provider, err := uc.manager.GetProvider(provider.ProviderName(name))
 	if err != nil {
-		return nil, fmt.Errorf("provider not found: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
 	}
 
 	return dto.NewProviderResponse(provider), nil
 }
+
+// providerStatusError classifies the unexpected status of a request made
+// through a provider's signed URL
+func providerStatusError(status int) error {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrObjectNotFound
+	case status == http.StatusTooManyRequests || status >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: unexpected status %d", ErrProviderUnavailable, status)
+	default:
+		return fmt.Errorf("unexpected status %d", status)
+	}
+}
@@ -0,0 +1,565 @@
+package usecases
+
+import (
+	stdcontext "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/resolver"
+	"github.com/google/uuid"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+const (
+	defaultReplicationConcurrency       = 4
+	replicationPageSize           int32 = 200
+	replicationFailedItemsPreview       = 100
+	// replicationReferencesToken prefixes the continuation token once the
+	// provider listing ended and content references are replicated, followed
+	// by the last path replicated
+	replicationReferencesToken = "content-references:"
+)
+
+var (
+	// ErrInvalidReplicationJobID is returned for job IDs that are not UUIDs
+	ErrInvalidReplicationJobID = domainerr.New(domainerr.Validation, "INVALID_ID", "invalid replication job ID")
+	// ErrInvalidReplicationJob is returned when a job cannot run with the
+	// requested definition, scope or providers
+	ErrInvalidReplicationJob = domainerr.New(domainerr.Validation, "INVALID_REPLICATION_JOB", "invalid replication job")
+	// ErrReplicationJobFinished is returned when cancelling a finished job
+	ErrReplicationJobFinished = repository.ErrReplicationJobFinished
+)
+
+// objectReader is implemented by providers that can stream object contents
+type objectReader interface {
+	GetObject(ctx stdcontext.Context, path string) (io.ReadCloser, error)
+}
+
+// objectWriter is implemented by providers that can store streamed contents
+type objectWriter interface {
+	PutObject(ctx stdcontext.Context, path string, body io.Reader, size int64, headers *metadata.StorageMetadata) error
+}
+
+// ReplicationUseCase copies a definition's objects between providers
+type ReplicationUseCase struct {
+	jobRepo      repository.ReplicationJobRepository
+	manager      resource.ResourceManager
+	contentStore *ContentStore
+	objects      *objectOpener
+}
+
+// NewReplicationUseCase creates a new replication use case
+func NewReplicationUseCase(jobRepo repository.ReplicationJobRepository, manager resource.ResourceManager, contentStore *ContentStore) *ReplicationUseCase {
+	return &ReplicationUseCase{
+		jobRepo:      jobRepo,
+		manager:      manager,
+		contentStore: contentStore,
+		objects:      newObjectOpener(manager, &http.Client{Timeout: 10 * time.Minute}),
+	}
+}
+
+// replicationSource is a file to replicate and the key its contents are
+// stored at, which differs for content-addressed files
+type replicationSource struct {
+	Key       string
+	StoredKey string
+}
+
+// CreateJob validates and queues a replication job. The job is picked up by
+// the ReplicationRunner.
+func (uc *ReplicationUseCase) CreateJob(ctx context.Context, req *dto.CreateReplicationJobRequest) (*dto.ReplicationJobResponse, error) {
+	scope := req.Scope
+	if scope == "" {
+		scope = string(resolver.ScopeGlobal)
+	}
+	concurrency := req.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultReplicationConcurrency
+	}
+
+	job := entity.NewReplicationJob(req.Definition, scope, req.ScopeValue, req.Parameters, req.SourceProvider, req.TargetProvider, concurrency)
+
+	if _, err := uc.streamingProviders(job); err != nil {
+		return nil, err
+	}
+	if _, _, err := uc.definitionRoots(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := uc.jobRepo.Create(ctx.Context(), job); err != nil {
+		return nil, fmt.Errorf("failed to create replication job: %w", err)
+	}
+
+	return dto.NewReplicationJobResponse(job), nil
+}
+
+// GetJob returns the job with its progress summary and a preview of failed keys
+func (uc *ReplicationUseCase) GetJob(ctx context.Context, id string) (*dto.ReplicationJobResponse, error) {
+	job, err := uc.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewReplicationJobResponse(job)
+	if job.FailedCount > 0 {
+		failed, err := uc.jobRepo.ListItems(ctx.Context(), job.ID, entity.ReplicationItemFailed, 0, replicationFailedItemsPreview)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list failed replication items: %w", err)
+		}
+		for _, item := range failed {
+			response.FailedItems = append(response.FailedItems, dto.NewReplicationItemResponse(item))
+		}
+	}
+
+	return response, nil
+}
+
+// ListJobs returns replication jobs, newest first
+func (uc *ReplicationUseCase) ListJobs(ctx context.Context, offset, limit int) ([]*dto.ReplicationJobResponse, error) {
+	jobs, err := uc.jobRepo.List(ctx.Context(), offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replication jobs: %w", err)
+	}
+
+	responses := make([]*dto.ReplicationJobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = dto.NewReplicationJobResponse(job)
+	}
+
+	return responses, nil
+}
+
+// ListJobItems returns the per-key outcomes of a job, optionally filtered by status
+func (uc *ReplicationUseCase) ListJobItems(ctx context.Context, id, status string, offset, limit int) ([]*dto.ReplicationItemResponse, error) {
+	job, err := uc.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.jobRepo.ListItems(ctx.Context(), job.ID, status, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replication items: %w", err)
+	}
+
+	responses := make([]*dto.ReplicationItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = dto.NewReplicationItemResponse(item)
+	}
+
+	return responses, nil
+}
+
+// CancelJob stops a pending or running job after its current page
+func (uc *ReplicationUseCase) CancelJob(ctx context.Context, id string) (*dto.ReplicationJobResponse, error) {
+	job, err := uc.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.IsFinished() {
+		return nil, fmt.Errorf("%w: status is %s", ErrReplicationJobFinished, job.Status)
+	}
+
+	job.Finish(entity.ReplicationStatusCancelled, "")
+	if err := uc.jobRepo.UpdateStatus(ctx.Context(), job); err != nil {
+		return nil, fmt.Errorf("failed to cancel replication job: %w", err)
+	}
+
+	return dto.NewReplicationJobResponse(job), nil
+}
+
+// ClaimJob marks the next job to process as running under owner's lease and
+// returns it, or nil when no job is waiting
+func (uc *ReplicationUseCase) ClaimJob(ctx context.Context, owner string, lease time.Duration) (*entity.ReplicationJob, error) {
+	job, err := uc.jobRepo.ClaimNext(ctx.Context(), owner, lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim replication job: %w", err)
+	}
+	return job, nil
+}
+
+// RunJob processes a claimed job page by page from its saved continuation
+// token. Progress is persisted and the lease renewed after every page, so a
+// restarted job only revisits the page that was in flight, and keys already
+// copied or skipped are not copied again.
+func (uc *ReplicationUseCase) RunJob(ctx context.Context, job *entity.ReplicationJob, lease time.Duration) error {
+	writer, err := uc.streamingProviders(job)
+	if err != nil {
+		return uc.failJob(ctx, job, err)
+	}
+
+	sourceRoot, targetRoot, err := uc.definitionRoots(ctx, job)
+	if err != nil {
+		return uc.failJob(ctx, job, err)
+	}
+
+	for {
+		// Stop between pages on shutdown or when the job was cancelled; an
+		// interrupted job keeps its status and resumes from this page
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+		if cancelled, err := uc.isCancelled(ctx, job); err != nil || cancelled {
+			return err
+		}
+
+		var (
+			sources []replicationSource
+			next    string
+		)
+		if after, ok := strings.CutPrefix(job.ContinuationToken, replicationReferencesToken); ok {
+			sources, next, err = uc.referencePage(ctx, job, sourceRoot, after)
+		} else {
+			sources, next, err = uc.objectPage(ctx, job, sourceRoot)
+		}
+		if err != nil {
+			return uc.failJob(ctx, job, err)
+		}
+
+		items, err := uc.replicatePage(ctx, job, writer, sources, sourceRoot, targetRoot)
+		if err != nil {
+			return uc.failJob(ctx, job, err)
+		}
+		// Copies interrupted by shutdown would be recorded as failures
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+
+		job.ContinuationToken = next
+		job.UpdatedAt = time.Now()
+		job.RenewLease(lease)
+
+		if err := uc.jobRepo.SavePage(ctx.Context(), job, items); err != nil {
+			return fmt.Errorf("failed to save replication progress: %w", err)
+		}
+
+		if job.ContinuationToken == "" {
+			break
+		}
+	}
+
+	if cancelled, err := uc.isCancelled(ctx, job); err != nil || cancelled {
+		return err
+	}
+
+	job.Finish(entity.ReplicationStatusCompleted, "")
+	if err := uc.jobRepo.UpdateStatus(ctx.Context(), job); err != nil {
+		return fmt.Errorf("failed to complete replication job: %w", err)
+	}
+
+	return nil
+}
+
+// objectPage lists the next page of the source provider's objects under the
+// source root, leaving out content-addressed blobs. Once the listing ends
+// the token moves on to the content references.
+func (uc *ReplicationUseCase) objectPage(ctx context.Context, job *entity.ReplicationJob, sourceRoot string) ([]replicationSource, string, error) {
+	maxKeys := replicationPageSize
+	prefix := sourceRoot + "/"
+	opts := &provider.ListObjectsOptions{
+		MaxKeys: &maxKeys,
+		Prefix:  &prefix,
+	}
+	if job.ContinuationToken != "" {
+		token := job.ContinuationToken
+		opts.ContinuationToken = &token
+	}
+
+	page, err := uc.manager.ListObjects(ctx, provider.ProviderName(job.SourceProvider), job.Definition, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list source objects: %w", err)
+	}
+
+	sources := make([]replicationSource, 0, len(page.Objects))
+	for _, object := range page.Objects {
+		if !isInternalPath(object.Key) {
+			sources = append(sources, replicationSource{Key: object.Key, StoredKey: object.Key})
+		}
+	}
+
+	next := replicationReferencesToken
+	if page.IsTruncated && page.NextContinuationToken != nil {
+		next = *page.NextContinuationToken
+	}
+	return sources, next, nil
+}
+
+// referencePage lists the next page of content-addressed files under the
+// source root, which are replicated from their blobs
+func (uc *ReplicationUseCase) referencePage(ctx context.Context, job *entity.ReplicationJob, sourceRoot, after string) ([]replicationSource, string, error) {
+	refs, err := uc.contentStore.ListReferences(ctx, provider.ProviderName(job.SourceProvider), job.Definition, sourceRoot, after, int(replicationPageSize))
+	if err != nil {
+		return nil, "", err
+	}
+
+	sources := make([]replicationSource, len(refs))
+	for i, ref := range refs {
+		sources[i] = replicationSource{Key: ref.Path, StoredKey: ref.BlobKey}
+	}
+
+	if len(refs) < int(replicationPageSize) {
+		return sources, "", nil
+	}
+	return sources, replicationReferencesToken + refs[len(refs)-1].Path, nil
+}
+
+// replicatePage copies the page's files that fall under the job's scope
+// with at most job.Concurrency copies in flight
+func (uc *ReplicationUseCase) replicatePage(ctx context.Context, job *entity.ReplicationJob, writer objectWriter, sources []replicationSource, sourceRoot, targetRoot string) ([]*entity.ReplicationItem, error) {
+	keys := make([]string, 0, len(sources))
+	stored := make(map[string]string, len(sources))
+	for _, source := range sources {
+		if _, ok := relativeKey(source.Key, sourceRoot); ok {
+			keys = append(keys, source.Key)
+			stored[source.Key] = source.StoredKey
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	completed, err := uc.jobRepo.CompletedKeys(ctx.Context(), job.ID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load replication progress: %w", err)
+	}
+
+	var (
+		items = make([]*entity.ReplicationItem, 0, len(keys))
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, max(job.Concurrency, 1))
+	)
+
+	for _, key := range keys {
+		if completed[key] {
+			continue
+		}
+
+		relative, _ := relativeKey(key, sourceRoot)
+		targetKey := targetRoot + "/" + relative
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			item := uc.replicateObject(ctx, job, writer, key, stored[key], targetKey)
+
+			mu.Lock()
+			items = append(items, item)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return items, nil
+}
+
+// replicateObject copies one file from the key its contents are stored at and
+// verifies the stored copy. Targets that already hold the same content are
+// skipped.
+func (uc *ReplicationUseCase) replicateObject(ctx context.Context, job *entity.ReplicationJob, writer objectWriter, sourceKey, storedKey, targetKey string) *entity.ReplicationItem {
+	item := &entity.ReplicationItem{
+		JobID:     job.ID,
+		SourceKey: sourceKey,
+		TargetKey: targetKey,
+		Status:    entity.ReplicationItemFailed,
+	}
+	defer func() { item.UpdatedAt = time.Now() }()
+
+	source, err := uc.manager.GetObjectMetadata(ctx, provider.ProviderName(job.SourceProvider), storedKey)
+	if err != nil {
+		item.Error = fmt.Sprintf("failed to get source metadata: %v", err)
+		return item
+	}
+	item.Size = source.Size
+
+	if target, err := uc.manager.GetObjectMetadata(ctx, provider.ProviderName(job.TargetProvider), targetKey); err == nil && sameContent(source, target) {
+		item.Status = entity.ReplicationItemSkipped
+		item.ChecksumSHA256 = reportedSHA256(source.Checksums)
+		return item
+	}
+
+	checksum, err := streamBetweenProviders(ctx, uc.objects, writer, provider.ProviderName(job.SourceProvider), storedKey, targetKey, source)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	item.ChecksumSHA256 = checksum
+
+	target, err := uc.manager.GetObjectMetadata(ctx, provider.ProviderName(job.TargetProvider), targetKey)
+	if err != nil {
+		item.Error = fmt.Sprintf("failed to verify target: %v", err)
+		return item
+	}
+	if target.Size != source.Size {
+		item.Error = fmt.Sprintf("size mismatch: expected %d, got %d", source.Size, target.Size)
+		return item
+	}
+	if err := verifySHA256(target.Checksums, checksum); err != nil {
+		item.Error = err.Error()
+		return item
+	}
+
+	item.Status = entity.ReplicationItemCopied
+	return item
+}
+
+// streamBetweenProviders copies the object contents and returns the SHA256 of
+// the bytes read, checked against the source's reported checksum
+func streamBetweenProviders(ctx context.Context, objects *objectOpener, writer objectWriter, sourceProvider provider.ProviderName, sourceKey, targetKey string, source *provider.ObjectMetadata) (string, error) {
+	body, err := objects.open(ctx, sourceProvider, sourceKey, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source object: %w", err)
+	}
+	defer body.Close()
+
+	hasher := sha256.New()
+	if err := writer.PutObject(ctx.Context(), targetKey, io.TeeReader(body, hasher), source.Size, replicatedHeaders(source)); err != nil {
+		return "", fmt.Errorf("failed to write target object: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if err := verifySHA256(source.Checksums, checksum); err != nil {
+		return "", fmt.Errorf("source read corrupted: %w", err)
+	}
+
+	return checksum, nil
+}
+
+// replicatedHeaders carries the source object's headers and custom metadata
+// over to the copy
+func replicatedHeaders(source *provider.ObjectMetadata) *metadata.StorageMetadata {
+	return &metadata.StorageMetadata{
+		ContentType:        source.ContentType,
+		ContentEncoding:    source.ContentEncoding,
+		ContentLanguage:    source.ContentLanguage,
+		ContentDisposition: source.ContentDisposition,
+		CacheControl:       source.CacheControl,
+		ACL:                source.ACL,
+		Metadata:           source.Metadata,
+	}
+}
+
+// sameContent reports whether the target already matches the source. Sizes
+// must match and both sides must report the same SHA256; ETags are not
+// comparable across providers.
+func sameContent(source, target *provider.ObjectMetadata) bool {
+	if source.Size != target.Size {
+		return false
+	}
+	sourceSum := reportedSHA256(source.Checksums)
+	return sourceSum != "" && sourceSum == reportedSHA256(target.Checksums)
+}
+
+// streamingProviders checks the job's providers and returns the target
+// writer. Sources without streaming reads are read from signed URLs.
+func (uc *ReplicationUseCase) streamingProviders(job *entity.ReplicationJob) (objectWriter, error) {
+	if _, err := uc.manager.GetProvider(provider.ProviderName(job.SourceProvider)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReplicationJob, err)
+	}
+	target, err := uc.manager.GetProvider(provider.ProviderName(job.TargetProvider))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReplicationJob, err)
+	}
+
+	writer, ok := target.(objectWriter)
+	if !ok {
+		return nil, fmt.Errorf("%w: provider %s does not support streaming writes", ErrInvalidReplicationJob, job.TargetProvider)
+	}
+
+	return writer, nil
+}
+
+// definitionRoots resolves the definition's root path on the source and
+// target providers. Object keys are mapped between providers by swapping one
+// root for the other.
+func (uc *ReplicationUseCase) definitionRoots(ctx context.Context, job *entity.ReplicationJob) (string, string, error) {
+	definition, err := uc.manager.GetDefinition(resolver.DefinitionName(job.Definition))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidReplicationJob, err)
+	}
+
+	roots := make([]string, 2)
+	for i, name := range []string{job.SourceProvider, job.TargetProvider} {
+		patterns, ok := definition.Patterns[provider.ProviderName(name)]
+		if !ok {
+			return "", "", fmt.Errorf("%w: definition %s has no pattern for provider %s", ErrInvalidReplicationJob, job.Definition, name)
+		}
+		if _, ok := patterns.Patterns[resolver.ScopeType(job.Scope)]; !ok {
+			return "", "", fmt.Errorf("%w: definition %s has no %s scope pattern for provider %s", ErrInvalidReplicationJob, job.Definition, job.Scope, name)
+		}
+
+		roots[i], err = uc.resolveRoot(ctx, job, provider.ProviderName(name))
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return roots[0], roots[1], nil
+}
+
+func (uc *ReplicationUseCase) resolveRoot(ctx context.Context, job *entity.ReplicationJob, providerName provider.ProviderName) (string, error) {
+	root, err := resolveDefinitionRoot(ctx, uc.manager, job.Definition, providerName, job.Scope, job.ScopeValue, job.Parameters)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to resolve %s path: %v", ErrInvalidReplicationJob, providerName, err)
+	}
+	return root, nil
+}
+
+// isCancelled reports whether the job was cancelled since it was loaded
+func (uc *ReplicationUseCase) isCancelled(ctx context.Context, job *entity.ReplicationJob) (bool, error) {
+	current, err := uc.jobRepo.GetByID(ctx.Context(), job.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to refresh replication job: %w", err)
+	}
+	return current.Status == entity.ReplicationStatusCancelled, nil
+}
+
+// failJob records a job-level failure and returns err
+func (uc *ReplicationUseCase) failJob(ctx context.Context, job *entity.ReplicationJob, err error) error {
+	// Errors caused by shutdown leave the job running so it resumes
+	if ctx.Context().Err() != nil {
+		return err
+	}
+
+	job.Finish(entity.ReplicationStatusFailed, err.Error())
+	if updateErr := uc.jobRepo.UpdateStatus(ctx.Context(), job); updateErr != nil {
+		return fmt.Errorf("%w (failed to record failure: %v)", err, updateErr)
+	}
+	return err
+}
+
+func (uc *ReplicationUseCase) getJob(ctx context.Context, id string) (*entity.ReplicationJob, error) {
+	jobID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReplicationJobID, err)
+	}
+
+	job, err := uc.jobRepo.GetByID(ctx.Context(), jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replication job: %w", err)
+	}
+
+	return job, nil
+}
+
+// relativeKey returns key relative to root, ignoring leading slashes
+func relativeKey(key, root string) (string, bool) {
+	relative, ok := strings.CutPrefix(strings.TrimPrefix(key, "/"), root+"/")
+	return relative, ok && relative != ""
+}
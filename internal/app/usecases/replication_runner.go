@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	stdcontext "context"
+	"log"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+	"github.com/google/uuid"
+)
+
+// ReplicationRunner processes queued replication jobs in the background. Each
+// job is claimed under a lease, so replicas never run the same job; jobs left
+// running by a stopped process are resumed once their lease expires.
+type ReplicationRunner struct {
+	useCase  *ReplicationUseCase
+	owner    string
+	interval time.Duration
+	lease    time.Duration
+
+	mu     sync.Mutex
+	stop   chan struct{}
+	doneWg sync.WaitGroup
+}
+
+// NewReplicationRunner creates a new replication runner
+func NewReplicationRunner(useCase *ReplicationUseCase, interval, lease time.Duration) *ReplicationRunner {
+	return &ReplicationRunner{
+		useCase:  useCase,
+		owner:    uuid.NewString(),
+		interval: interval,
+		lease:    lease,
+	}
+}
+
+// Start runs the runner in the background until Stop is called
+func (r *ReplicationRunner) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+
+	// Cancelling the run context on stop interrupts the job in flight; its
+	// current page is not saved and is processed again on the next start
+	runCtx, cancel := stdcontext.WithCancel(ctx.Context())
+
+	r.doneWg.Add(2)
+	go func(stop <-chan struct{}) {
+		defer r.doneWg.Done()
+		<-stop
+		cancel()
+	}(r.stop)
+
+	go func(stop <-chan struct{}) {
+		defer r.doneWg.Done()
+		defer cancel()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.RunOnce(context.NewContext(runCtx)); err != nil {
+				log.Printf("Replication runner failed: %v", err)
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.stop)
+}
+
+// Stop halts the background loop and waits for the job in flight to be
+// interrupted. Interrupted jobs stay running and resume once their lease
+// expires.
+func (r *ReplicationRunner) Stop() {
+	r.mu.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.mu.Unlock()
+
+	r.doneWg.Wait()
+}
+
+// RunOnce claims and processes jobs in creation order until none is waiting
+// or ctx is done
+func (r *ReplicationRunner) RunOnce(ctx context.Context) error {
+	for ctx.Context().Err() == nil {
+		job, err := r.useCase.ClaimJob(ctx, r.owner, r.lease)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+
+		log.Printf("Replication job %s: %s %s -> %s", job.ID, job.Definition, job.SourceProvider, job.TargetProvider)
+		if err := r.useCase.RunJob(ctx, job, r.lease); err != nil {
+			log.Printf("Replication job %s stopped: %v", job.ID, err)
+			continue
+		}
+		log.Printf("Replication job %s %s: %d copied, %d skipped, %d failed", job.ID, job.Status, job.CopiedCount, job.SkippedCount, job.FailedCount)
+	}
+
+	return nil
+}
@@ -1,22 +1,29 @@
 package usecases
 
 import (
+	"fmt"
+	"slices"
+
 	"avironactive.com/common/context"
 
 	"avironactive.com/resource"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
 	"avironactive.com/resource/resolver"
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
 )
 
 // ResourceDefinitionUseCase handles resource definition operations
 type ResourceDefinitionUseCase struct {
-	manager resource.ResourceManager
+	manager  resource.ResourceManager
+	registry *DefinitionRegistry
 }
 
 // NewResourceDefinitionUseCase creates a new resource definition use case
-func NewResourceDefinitionUseCase(manager resource.ResourceManager) *ResourceDefinitionUseCase {
+func NewResourceDefinitionUseCase(manager resource.ResourceManager, registry *DefinitionRegistry) *ResourceDefinitionUseCase {
 	return &ResourceDefinitionUseCase{
-		manager: manager,
+		manager:  manager,
+		registry: registry,
 	}
 }
 
@@ -26,7 +33,7 @@ func (uc *ResourceDefinitionUseCase) ListDefinitions(ctx context.Context) ([]*dt
 
 	responses := make([]*dto.PathDefinitionResponse, 0, len(definitions))
 	for _, def := range definitions {
-		responses = append(responses, convertDefinitionPath(def))
+		responses = append(responses, uc.toDefinitionResponse(def))
 	}
 
 	return responses, nil
@@ -36,10 +43,10 @@ func (uc *ResourceDefinitionUseCase) ListDefinitions(ctx context.Context) ([]*dt
 func (uc *ResourceDefinitionUseCase) GetDefinition(ctx context.Context, name string) (*dto.PathDefinitionResponse, error) {
 	def, err := uc.manager.GetDefinition(resolver.DefinitionName(name))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrDefinitionNotFound, err)
 	}
 
-	return convertDefinitionPath(def), nil
+	return uc.toDefinitionResponse(def), nil
 }
 
 // convertScopes converts resource scopes to string representations
@@ -58,33 +65,93 @@ func convertScopes(scopes []resolver.ScopeType) []string {
 	return result
 }
 
-// convertParameters converts path parameters to response DTOs
-func convertParameters(params []*resolver.ParameterDefinition) []dto.PathParameterResponse {
-	result := make([]dto.PathParameterResponse, 0, len(params))
-	for _, param := range params {
+// convertParameters converts a definition's path parameters to response DTOs
+func (uc *ResourceDefinitionUseCase) convertParameters(def *resolver.Definition) []dto.PathParameterResponse {
+	result := make([]dto.PathParameterResponse, 0, len(def.Parameters))
+	for _, param := range def.Parameters {
 		response := dto.PathParameterResponse{
-			Name: string(param.Name),
-			//Required:     param.Required,
+			Name:         string(param.Name),
 			Description:  param.Description,
 			DefaultValue: param.DefaultValue,
 		}
+		describeParameter(&response, uc.registry.ParameterConstraints(def.Name, param.Name))
 		result = append(result, response)
 	}
 	return result
 }
 
-func convertDefinitionPath(def *resolver.Definition) *dto.PathDefinitionResponse {
+// convertPatterns converts a definition's per-provider patterns to response
+// DTOs keyed by provider and scope
+func convertPatterns(patterns map[provider.ProviderName]resolver.PathPatterns) map[string]dto.PathPatternsResponse {
+	result := make(map[string]dto.PathPatternsResponse, len(patterns))
+	for providerName, providerPatterns := range patterns {
+		scopes := make(map[string]string, len(providerPatterns.Patterns))
+		for scope, pattern := range providerPatterns.Patterns {
+			scopes[convertScopes([]resolver.ScopeType{scope})[0]] = pattern
+		}
+		result[string(providerName)] = dto.PathPatternsResponse{
+			URLType:  fmt.Sprint(providerPatterns.URLType),
+			Patterns: scopes,
+		}
+	}
+	return result
+}
+
+// convertStorageDefaults converts a definition's default storage metadata to
+// its response DTO
+func convertStorageDefaults(config *metadata.StorageMetadataConfig) *dto.StorageDefaultsResponse {
+	if config == nil {
+		return nil
+	}
+
+	response := &dto.StorageDefaultsResponse{
+		RequiredChecksums: config.RequiredChecksums,
+		CustomHeaders:     config.CustomHeaders,
+	}
+	if cacheControl := config.CacheControl; cacheControl.MaxAge != 0 || cacheControl.Default != "" {
+		response.CacheControl = &dto.CacheControlDefaults{
+			MaxAge:      int64(cacheControl.MaxAge),
+			AllowPublic: cacheControl.AllowPublic,
+			Default:     cacheControl.Default,
+		}
+	}
+	return response
+}
+
+// toDefinitionResponse converts a definition, along with its child
+// definitions, to its response DTO
+func (uc *ResourceDefinitionUseCase) toDefinitionResponse(def *resolver.Definition) *dto.PathDefinitionResponse {
 	providers := make([]string, 0, len(def.Patterns))
 	for p := range def.Patterns {
 		providers = append(providers, string(p))
 	}
+	slices.Sort(providers)
+
+	response := &dto.PathDefinitionResponse{
+		Name:                   string(def.Name),
+		DisplayName:            def.DisplayName,
+		Description:            def.Description,
+		AllowedScopes:          convertScopes(def.AllowedScopes),
+		Parameters:             uc.convertParameters(def),
+		Providers:              providers,
+		Patterns:               convertPatterns(def.Patterns),
+		DefaultStorageMetadata: convertStorageDefaults(def.DefaultStorageMetadata),
+	}
+	if parent := parentDefinition(uc.manager, def.Name); parent != nil {
+		response.Parent = string(parent.Name)
+	}
+	if record := uc.registry.Lookup(string(def.Name)); record != nil {
+		response.Version = record.Version
+		response.Deprecated = record.IsDeprecated()
+	}
 
-	return &dto.PathDefinitionResponse{
-		Name:          string(def.Name),
-		DisplayName:   def.DisplayName,
-		Description:   def.Description,
-		AllowedScopes: convertScopes(def.AllowedScopes),
-		Parameters:    convertParameters(def.Parameters),
-		Providers:     providers,
+	for _, name := range childDefinitionNames(uc.manager, def.Name) {
+		child, err := uc.manager.GetDefinition(name)
+		if err != nil {
+			continue
+		}
+		response.Children = append(response.Children, uc.toDefinitionResponse(child))
 	}
+
+	return response
 }
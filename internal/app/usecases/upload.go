@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	stdcontext "context"
+	"fmt"
+	"log"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/provider"
+	"github.com/google/uuid"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+const (
+	defaultJanitorLimit = 500
+	janitorAbortReason  = "expired before completion"
+)
+
+var (
+	// ErrUploadFinished is returned when aborting a completed or aborted
+	// upload
+	ErrUploadFinished = domainerr.New(domainerr.PreconditionFailed, "UPLOAD_FINISHED", "upload already finished")
+	// ErrInvalidUploadID is returned for upload IDs that are not UUIDs
+	ErrInvalidUploadID = domainerr.New(domainerr.Validation, "INVALID_ID", "invalid upload ID")
+)
+
+// multipartAborter is implemented by providers that can abort a multipart
+// upload and discard its parts
+type multipartAborter interface {
+	AbortMultipartUpload(ctx stdcontext.Context, path, uploadID string) error
+}
+
+// UploadUseCase inspects and aborts the uploads tracked by the upload manager
+type UploadUseCase struct {
+	uploadRepo repository.UploadRepository
+	manager    resource.ResourceManager
+}
+
+// NewUploadUseCase creates a new upload use case
+func NewUploadUseCase(uploadRepo repository.UploadRepository, manager resource.ResourceManager) *UploadUseCase {
+	return &UploadUseCase{
+		uploadRepo: uploadRepo,
+		manager:    manager,
+	}
+}
+
+// ListUploads returns tracked uploads matching the filter, newest first
+func (uc *UploadUseCase) ListUploads(ctx context.Context, filter entity.UploadFilter, offset, limit int) ([]*dto.UploadResponse, error) {
+	uploads, err := uc.uploadRepo.List(ctx.Context(), filter, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploads: %w", err)
+	}
+
+	responses := make([]*dto.UploadResponse, len(uploads))
+	for i, upload := range uploads {
+		responses[i] = dto.NewUploadResponse(upload)
+	}
+
+	return responses, nil
+}
+
+// GetUpload returns a tracked upload
+func (uc *UploadUseCase) GetUpload(ctx context.Context, id string) (*dto.UploadResponse, error) {
+	upload, err := uc.getUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewUploadResponse(upload), nil
+}
+
+// AbortUpload marks an unfinished upload aborted. Multipart uploads are also
+// aborted with the provider when it supports it.
+func (uc *UploadUseCase) AbortUpload(ctx context.Context, id, reason string) (*dto.AbortUploadResponse, error) {
+	upload, err := uc.getUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.IsFinished() {
+		return nil, fmt.Errorf("%w: status is %s", ErrUploadFinished, upload.Status)
+	}
+
+	providerAborted, err := uc.abortUpload(ctx, upload, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reload for the status and error recorded by the transition
+	upload, err = uc.getUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AbortUploadResponse{
+		Upload:          dto.NewUploadResponse(upload),
+		ProviderAborted: providerAborted,
+	}, nil
+}
+
+// RunJanitor aborts unfinished uploads whose URLs have expired, oldest first.
+// A failure to abort one upload is recorded in its outcome and does not stop
+// the run.
+func (uc *UploadUseCase) RunJanitor(ctx context.Context, req *dto.RunJanitorRequest) (*dto.JanitorResultResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultJanitorLimit
+	}
+
+	expired, err := uc.uploadRepo.ListExpired(ctx.Context(), time.Now(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired uploads: %w", err)
+	}
+
+	result := &dto.JanitorResultResponse{
+		DryRun:  req.DryRun,
+		Scanned: len(expired),
+		Uploads: make([]*dto.JanitorUploadOutcome, 0, len(expired)),
+	}
+	for _, upload := range expired {
+		if ctx.Context().Err() != nil {
+			break
+		}
+
+		outcome := &dto.JanitorUploadOutcome{
+			ID:         upload.ID.String(),
+			Provider:   upload.StorageProvider,
+			StorageKey: upload.StorageKey,
+			ExpiresAt:  upload.ExpiresTime,
+		}
+		result.Uploads = append(result.Uploads, outcome)
+		if req.DryRun {
+			continue
+		}
+
+		outcome.ProviderAborted, err = uc.abortUpload(ctx, upload, janitorAbortReason)
+		if err != nil {
+			outcome.Error = err.Error()
+			result.Failed++
+			continue
+		}
+		outcome.Aborted = true
+		result.Aborted++
+	}
+
+	return result, nil
+}
+
+// abortUpload records the abort and then aborts the provider's multipart
+// upload, when there is one. The record transitions first, so an upload
+// completing concurrently is never aborted with the provider. It reports
+// whether the provider aborted the upload.
+func (uc *UploadUseCase) abortUpload(ctx context.Context, upload *entity.TrackedUpload, reason string) (bool, error) {
+	var aborter multipartAborter
+	if upload.IsMultipart() {
+		prov, err := uc.manager.GetProvider(provider.ProviderName(upload.StorageProvider))
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrProviderNotFound, err)
+		}
+		aborter, _ = prov.(multipartAborter)
+	}
+
+	transitioned, err := uc.uploadRepo.MarkAborted(ctx.Context(), upload.ID, reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to abort upload: %w", err)
+	}
+	if !transitioned {
+		return false, ErrUploadFinished
+	}
+
+	// Providers without abort support, and failed aborts, leave the parts to
+	// the bucket lifecycle rules; the upload is aborted either way
+	if aborter == nil {
+		return false, nil
+	}
+	if err := aborter.AbortMultipartUpload(ctx.Context(), upload.StorageKey, upload.MultipartID); err != nil {
+		log.Printf("Failed to abort multipart upload %s with provider %s: %v", upload.ID, upload.StorageProvider, err)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (uc *UploadUseCase) getUpload(ctx context.Context, id string) (*entity.TrackedUpload, error) {
+	uploadID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUploadID, err)
+	}
+
+	upload, err := uc.uploadRepo.GetByID(ctx.Context(), uploadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	return upload, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"avironactive.com/resource/upload"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// newUploadConfirmation converts a client confirmation into the upload manager's format
+func newUploadConfirmation(req *dto.ConfirmUploadRequest) *upload.UploadConfirmation {
+	confirmation := &upload.UploadConfirmation{
+		Success:  req.Success,
+		Error:    req.ErrorMsg,
+		FileSize: req.FileSize,
+		Metadata: req.Metadata,
+	}
+
+	if req.ETags != nil {
+		if etags, ok := req.ETags.([]dto.PartETag); ok {
+			uploadETags := make([]upload.PartETag, len(etags))
+			for i, etag := range etags {
+				uploadETags[i] = upload.PartETag{
+					Part: etag.Part,
+					ETag: etag.ETag,
+					Size: etag.Size,
+				}
+			}
+			confirmation.ETags = uploadETags
+		} else if etag, ok := req.ETags.(string); ok {
+			confirmation.ETags = []upload.PartETag{{Part: 1, ETag: etag}}
+		}
+	}
+
+	return confirmation
+}
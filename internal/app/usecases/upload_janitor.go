@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"avironactive.com/common/context"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// UploadJanitor periodically aborts tracked uploads whose URLs expired
+// before they completed
+type UploadJanitor struct {
+	useCase  *UploadUseCase
+	interval time.Duration
+
+	mu     sync.Mutex
+	stop   chan struct{}
+	doneWg sync.WaitGroup
+}
+
+// NewUploadJanitor creates a new upload janitor
+func NewUploadJanitor(useCase *UploadUseCase, interval time.Duration) *UploadJanitor {
+	return &UploadJanitor{
+		useCase:  useCase,
+		interval: interval,
+	}
+}
+
+// Start runs the janitor in the background until Stop is called
+func (j *UploadJanitor) Start(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.stop != nil {
+		return
+	}
+	j.stop = make(chan struct{})
+
+	j.doneWg.Add(1)
+	go func(stop <-chan struct{}) {
+		defer j.doneWg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := j.RunOnce(ctx); err != nil {
+				log.Printf("Upload janitor run failed: %v", err)
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-ctx.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(j.stop)
+}
+
+// Stop halts the background loop and waits for the current run to finish
+func (j *UploadJanitor) Stop() {
+	j.mu.Lock()
+	if j.stop != nil {
+		close(j.stop)
+		j.stop = nil
+	}
+	j.mu.Unlock()
+
+	j.doneWg.Wait()
+}
+
+// RunOnce aborts the expired uploads found in one pass
+func (j *UploadJanitor) RunOnce(ctx context.Context) (*dto.JanitorResultResponse, error) {
+	result, err := j.useCase.RunJanitor(ctx, &dto.RunJanitorRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Aborted > 0 || result.Failed > 0 {
+		log.Printf("Upload janitor: %d expired, %d aborted, %d failed", result.Scanned, result.Aborted, result.Failed)
+	}
+	return result, nil
+}
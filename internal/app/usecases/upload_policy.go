@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+// ErrUploadPolicyViolation is returned when a declared upload breaks the
+// definition's upload policy
+var ErrUploadPolicyViolation = domainerr.New(domainerr.Validation, "POLICY_VIOLATION", "upload policy violation")
+
+// declaredUpload is what the client states about a file before uploading it.
+// Size is zero when not declared.
+type declaredUpload struct {
+	ContentType string
+	Size        int64
+	Checksum    *metadata.Checksum
+	ClientIP    string
+}
+
+// newDeclaredUpload reads the declared file details of an upload URL request
+func newDeclaredUpload(req *dto.UploadRequest, clientIP string) declaredUpload {
+	declared := declaredUpload{
+		Size:     req.ContentLength,
+		ClientIP: clientIP,
+	}
+	if req.Metadata != nil {
+		declared.ContentType = req.Metadata.ContentType
+	}
+	if req.Checksum != nil {
+		declared.Checksum = req.Checksum.ToProviderChecksum()
+	}
+	return declared
+}
+
+// uploadConstraints checks a declared upload against a definition's policy
+// and returns the constraints to sign into its upload URL, or nil when the
+// policy is nil. The size limit is always signed; a declared content type
+// must be allowed and is pinned.
+func uploadConstraints(policy *core.UploadPolicy, providerName provider.ProviderName, declared declaredUpload) (*provider.UploadConstraints, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	constraints := &provider.UploadConstraints{MaxSize: policy.MaxSize}
+	if policy.MaxSize > 0 && declared.Size > policy.MaxSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrUploadPolicyViolation, declared.Size, policy.MaxSize)
+	}
+
+	if declared.ContentType != "" {
+		if !policy.AllowsContentType(declared.ContentType) {
+			return nil, fmt.Errorf("%w: content type %q is not one of %s", ErrUploadPolicyViolation, declared.ContentType, strings.Join(policy.ContentTypes, ", "))
+		}
+		constraints.ContentType = declared.ContentType
+	}
+
+	if policy.RequiredChecksum != "" {
+		if declared.Checksum == nil || declared.Checksum.Algorithm != policy.RequiredChecksum || declared.Checksum.Value == "" {
+			return nil, fmt.Errorf("%w: a %s checksum is required", ErrUploadPolicyViolation, policy.RequiredChecksum)
+		}
+		constraints.Checksum = declared.Checksum
+	}
+
+	if policy.BindClientIP && providerName == provider.ProviderCDN {
+		constraints.ClientIP = declared.ClientIP
+	}
+
+	return constraints, nil
+}
+
+// checkServerUpload checks an upload written by the server itself, which no
+// signed constraints protect, against a definition's policy before its
+// contents are read. A content type must be declared when the policy lists
+// them.
+func checkServerUpload(policy *core.UploadPolicy, declared declaredUpload) error {
+	if policy == nil {
+		return nil
+	}
+
+	if _, err := uploadConstraints(policy, "", declared); err != nil {
+		return err
+	}
+	if len(policy.ContentTypes) > 0 && declared.ContentType == "" {
+		return fmt.Errorf("%w: a content type is required", ErrUploadPolicyViolation)
+	}
+
+	return nil
+}
+
+// limitUploadSize fails reads of body past the policy's size limit
+func limitUploadSize(body io.Reader, policy *core.UploadPolicy) io.Reader {
+	if policy == nil || policy.MaxSize <= 0 {
+		return body
+	}
+	return &policyLimitReader{r: body, remaining: policy.MaxSize, limit: policy.MaxSize}
+}
+
+type policyLimitReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (l *policyLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, fmt.Errorf("%w: body exceeds the %d byte limit", ErrUploadPolicyViolation, l.limit)
+	}
+	return n, err
+}
+
+// storedChecksum returns the checksum of the given algorithm recorded for an
+// object, or nil when there is none
+func storedChecksum(checksums []metadata.Checksum, algorithm metadata.ChecksumAlgorithm) *metadata.Checksum {
+	for _, checksum := range checksums {
+		if checksum.Algorithm == algorithm && checksum.Value != "" {
+			return &checksum
+		}
+	}
+	return nil
+}
+
+// verifyUploadChecksum compares the checksum the policy requires, as declared
+// by the client, with the one computed from the stored contents. Declared
+// values may be hex or base64 encoded.
+func verifyUploadChecksum(policy *core.UploadPolicy, declared declaredUpload, computed []metadata.Checksum) error {
+	if policy == nil || policy.RequiredChecksum == "" {
+		return nil
+	}
+
+	for _, checksum := range computed {
+		if checksum.Algorithm != policy.RequiredChecksum {
+			continue
+		}
+
+		digest, err := base64.StdEncoding.DecodeString(checksum.Value)
+		if err != nil {
+			return fmt.Errorf("invalid computed %s checksum: %w", checksum.Algorithm, err)
+		}
+		if declared.Checksum.Value == checksum.Value || strings.EqualFold(declared.Checksum.Value, hex.EncodeToString(digest)) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s checksum mismatch", ErrUploadPolicyViolation, policy.RequiredChecksum)
+	}
+
+	return fmt.Errorf("%w: %s checksum was not computed", ErrUploadPolicyViolation, policy.RequiredChecksum)
+}
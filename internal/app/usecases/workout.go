@@ -0,0 +1,630 @@
+package usecases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"avironactive.com/common/context"
+	"avironactive.com/resource"
+	"avironactive.com/resource/metadata"
+	"avironactive.com/resource/provider"
+	"avironactive.com/resource/upload"
+	"github.com/google/uuid"
+
+	"github.com/anh-nguyen/resource-server/core"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/workoutfile"
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+)
+
+// ErrWorkoutFileNotConfirmed is returned when a workout file is requested in
+// another format before its upload has been confirmed and validated.
+var ErrWorkoutFileNotConfirmed = domainerr.New(domainerr.PreconditionFailed, "FILE_NOT_CONFIRMED", "workout file has not been confirmed")
+
+var (
+	// ErrInvalidWorkoutID is returned for workout IDs that are not UUIDs
+	ErrInvalidWorkoutID = domainerr.New(domainerr.Validation, "INVALID_ID", "invalid workout ID")
+	// ErrWorkoutUploadMismatch is returned when an upload confirmed for a
+	// workout was initiated for another resource.
+	ErrWorkoutUploadMismatch = domainerr.New(domainerr.Validation, "UPLOAD_MISMATCH", "upload does not belong to workout")
+	// ErrWorkoutUploadSuperseded is returned when an upload confirmed for a
+	// workout was initiated for a file that has since been replaced.
+	ErrWorkoutUploadSuperseded = domainerr.New(domainerr.PreconditionFailed, "UPLOAD_SUPERSEDED", "workout file has been replaced since the upload was initiated")
+)
+
+// workoutResourceType identifies workout uploads in upload records
+const workoutResourceType = "workout"
+
+// workoutContentTypes maps workout formats to the content type of stored files
+var workoutContentTypes = map[string]string{
+	workoutfile.FormatERG:  "text/plain",
+	workoutfile.FormatMRC:  "text/plain",
+	workoutfile.FormatZWO:  "application/xml",
+	workoutfile.FormatJSON: "application/json",
+}
+
+type WorkoutUseCase struct {
+	workoutRepo     repository.WorkoutRepository
+	uploadManager   upload.UploadManager
+	resourceManager resource.ResourceManager
+	contentStore    *ContentStore
+	registry        *DefinitionRegistry
+	httpClient      *http.Client
+	objects         *objectOpener
+}
+
+func NewWorkoutUseCase(
+	workoutRepo repository.WorkoutRepository,
+	resourceManager resource.ResourceManager,
+	contentStore *ContentStore,
+	registry *DefinitionRegistry,
+) *WorkoutUseCase {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	return &WorkoutUseCase{
+		workoutRepo:     workoutRepo,
+		uploadManager:   resourceManager.UploadManager(),
+		resourceManager: resourceManager,
+		contentStore:    contentStore,
+		registry:        registry,
+		httpClient:      httpClient,
+		objects:         newObjectOpener(resourceManager, httpClient),
+	}
+}
+
+func (uc *WorkoutUseCase) CreateWorkout(ctx context.Context, req *dto.CreateWorkoutRequest) (*dto.WorkoutUploadResponse, error) {
+	workout := entity.NewWorkout(req.UserID, req.Name, req.Description)
+	workout.Provider = req.Provider
+	workout.Scope = req.Scope
+	workout.ScopeValue = req.ScopeValue
+
+	filePath, err := uc.resolveFilePath(ctx, workout, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	workout.SetFile(filePath, req.Format, strings.ToLower(req.ChecksumSHA256))
+
+	if err := uc.workoutRepo.Create(ctx.Context(), workout); err != nil {
+		return nil, fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	uploadInfo, err := uc.initiateUpload(ctx, workout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkoutUploadResponse{
+		Workout: dto.NewWorkoutResponse(workout),
+		Upload:  uploadInfo,
+	}, nil
+}
+
+func (uc *WorkoutUseCase) GetWorkout(ctx context.Context, id string) (*dto.WorkoutResponse, error) {
+	workout, err := uc.getWorkout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewWorkoutResponse(workout)
+	if workout.FilePath != "" {
+		storedPath, err := uc.storedFilePath(ctx, workout)
+		if err != nil {
+			return nil, err
+		}
+
+		response.DownloadURL, response.ExpiresAt, err = uc.signDownloadURL(ctx, storedPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// DownloadWorkout returns a signed URL to the workout file in the requested
+// format. Other formats are converted from the stored file on first request and
+// kept next to it under the workout path; the conversion is reused while its
+// cache key, derived from the source checksum, format and FTP, still matches.
+func (uc *WorkoutUseCase) DownloadWorkout(ctx context.Context, req *dto.WorkoutDownloadRequest) (*dto.WorkoutDownloadResponse, error) {
+	workout, err := uc.getWorkout(ctx, req.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = workout.Format
+	}
+
+	response := &dto.WorkoutDownloadResponse{
+		WorkoutID:    workout.ID.String(),
+		Format:       format,
+		SourceFormat: workout.Format,
+	}
+
+	filePath, err := uc.storedFilePath(ctx, workout)
+	if err != nil {
+		return nil, err
+	}
+	if format != workout.Format {
+		if !workout.IsConfirmed() {
+			return nil, ErrWorkoutFileNotConfirmed
+		}
+
+		// FTP only affects the output when the power units differ
+		ftp := req.FTP
+		if workoutfile.PowerUnit(format) == workoutfile.PowerUnit(workout.Format) {
+			ftp = 0
+		} else if ftp <= 0 {
+			return nil, workoutfile.ErrFTPRequired
+		}
+
+		filePath, err = uc.resolveFilePath(ctx, workout, format)
+		if err != nil {
+			return nil, err
+		}
+
+		cacheKey := conversionCacheKey(workout.ChecksumSHA256, format, ftp)
+		response.Converted = true
+		response.CacheKey = cacheKey
+		response.Cached = workout.ConversionKey(format) == cacheKey
+
+		if !response.Cached {
+			if err := uc.convertWorkoutFile(ctx, workout, format, filePath, ftp); err != nil {
+				return nil, err
+			}
+
+			workout.SetConversion(format, cacheKey)
+			if err := uc.workoutRepo.Update(ctx.Context(), workout); err != nil {
+				return nil, fmt.Errorf("failed to update workout: %w", err)
+			}
+		}
+	}
+
+	response.DownloadURL, response.ExpiresAt, err = uc.signDownloadURL(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (uc *WorkoutUseCase) ListUserWorkouts(ctx context.Context, userID string, offset, limit int) ([]*dto.WorkoutResponse, error) {
+	workouts, err := uc.workoutRepo.ListByUser(ctx.Context(), userID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workouts: %w", err)
+	}
+
+	result := make([]*dto.WorkoutResponse, len(workouts))
+	for i, workout := range workouts {
+		result[i] = dto.NewWorkoutResponse(workout)
+	}
+
+	return result, nil
+}
+
+// ReplaceWorkoutFile points the workout at a new file and returns an upload URL
+// for it. The previous file and its conversions are removed once the new file
+// has been confirmed.
+func (uc *WorkoutUseCase) ReplaceWorkoutFile(ctx context.Context, req *dto.ReplaceWorkoutFileRequest) (*dto.WorkoutUploadResponse, error) {
+	workout, err := uc.getWorkout(ctx, req.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	workout.AddStaleFiles(append(uc.conversionPaths(ctx, workout), workout.FilePath)...)
+
+	filePath, err := uc.resolveFilePath(ctx, workout, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	workout.SetFile(filePath, req.Format, strings.ToLower(req.ChecksumSHA256))
+	workout.ClearConversions()
+
+	if err := uc.workoutRepo.Update(ctx.Context(), workout); err != nil {
+		return nil, fmt.Errorf("failed to update workout: %w", err)
+	}
+
+	uploadInfo, err := uc.initiateUpload(ctx, workout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkoutUploadResponse{
+		Workout: dto.NewWorkoutResponse(workout),
+		Upload:  uploadInfo,
+	}, nil
+}
+
+// ConfirmUpload confirms a workout file upload. The upload must be the one
+// initiated for the workout's current file, the stored file's SHA256 checksum
+// must match the declared checksum and the file must parse in its declared
+// format. Rejected files mark the upload as failed; accepted files have their
+// summary stored on the workout, are moved to their content-addressed blob and
+// replace the files left behind by earlier replacements.
+func (uc *WorkoutUseCase) ConfirmUpload(ctx context.Context, workoutID string, req *dto.ConfirmUploadRequest) error {
+	uploadID, err := uuid.Parse(req.UploadID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidUploadID, err)
+	}
+
+	workout, err := uc.getWorkout(ctx, workoutID)
+	if err != nil {
+		return err
+	}
+
+	record, err := uc.uploadManager.GetUpload(ctx, upload.UploadID(uploadID))
+	if err != nil {
+		return fmt.Errorf("failed to get upload: %w", err)
+	}
+	if record.ResourceType != workoutResourceType || record.ResourceID != workout.ID.String() {
+		return ErrWorkoutUploadMismatch
+	}
+	if record.ResourceValue != workout.FilePath {
+		return ErrWorkoutUploadSuperseded
+	}
+
+	confirmation := newUploadConfirmation(req)
+	var (
+		summary   *entity.WorkoutSummary
+		rejection error
+	)
+	if req.Success {
+		objectMetadata, err := uc.resourceManager.GetObjectMetadata(ctx, provider.ProviderName(workout.Provider), workout.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to get workout file metadata: %w", err)
+		}
+
+		summary, err = uc.validateWorkoutFile(ctx, workout)
+		var formatErr *workoutfile.FormatError
+		switch {
+		case errors.As(err, &formatErr):
+			rejection = err
+			confirmation.Success = false
+			confirmation.Error = err.Error()
+		case err != nil:
+			return err
+		default:
+			confirmation.FileSize = objectMetadata.Size
+		}
+	}
+
+	if err := uc.uploadManager.ConfirmUpload(ctx, upload.UploadID(uploadID), confirmation); err != nil {
+		return fmt.Errorf("failed to confirm upload: %w", err)
+	}
+	if rejection != nil {
+		return domainerr.Wrap(domainerr.Unprocessable, "INVALID_WORKOUT_FILE", fmt.Errorf("workout file rejected: %w", rejection))
+	}
+	if !confirmation.Success {
+		return nil
+	}
+
+	stalePaths := workout.StaleFiles()
+	workout.ClearStaleFiles()
+	workout.Confirm(confirmation.FileSize, *summary)
+	if err := uc.workoutRepo.Update(ctx.Context(), workout); err != nil {
+		return fmt.Errorf("failed to update workout: %w", err)
+	}
+
+	// Identical workouts share one stored file
+	if _, err := uc.contentStore.Deduplicate(ctx, string(core.WorkoutPathName), provider.ProviderName(workout.Provider), workout.FilePath); err != nil {
+		return err
+	}
+
+	for _, stalePath := range stalePaths {
+		if stalePath == workout.FilePath {
+			continue
+		}
+		if err := uc.contentStore.Delete(ctx, provider.ProviderName(workout.Provider), stalePath); err != nil {
+			// Log error but don't fail the main operation
+			log.Printf("Failed to delete replaced workout file %s: %v", stalePath, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *WorkoutUseCase) DeleteWorkout(ctx context.Context, id string) error {
+	workout, err := uc.getWorkout(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if workout.FilePath != "" {
+		if err := uc.contentStore.Delete(ctx, provider.ProviderName(workout.Provider), workout.FilePath); err != nil {
+			return fmt.Errorf("failed to delete workout file: %w", err)
+		}
+	}
+
+	for _, conversionPath := range uc.conversionPaths(ctx, workout) {
+		if err := uc.resourceManager.DeleteObject(ctx, provider.ProviderName(workout.Provider), conversionPath); err != nil {
+			// Log error but don't fail the main operation
+			log.Printf("Failed to delete converted workout file %s: %v", conversionPath, err)
+		}
+	}
+
+	if err := uc.workoutRepo.Delete(ctx.Context(), workout.ID); err != nil {
+		return fmt.Errorf("failed to delete workout: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *WorkoutUseCase) getWorkout(ctx context.Context, id string) (*entity.Workout, error) {
+	workoutID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkoutID, err)
+	}
+
+	workout, err := uc.workoutRepo.GetByID(ctx.Context(), workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+func (uc *WorkoutUseCase) resolveFilePath(ctx context.Context, workout *entity.Workout, format string) (string, error) {
+	pathWorkout := *workout
+	pathWorkout.Format = format
+
+	pathResult, err := uc.resourceManager.DefinitionResolver().ResolveDownloadURL(
+		ctx,
+		core.WorkoutPathName,
+		dto.WorkoutPathOptions(&pathWorkout),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workout path: %w", err)
+	}
+
+	if pathResult.ResolvedPath.Path == "" {
+		return "", fmt.Errorf("resolved path is empty")
+	}
+
+	return pathResult.ResolvedPath.Path, nil
+}
+
+func (uc *WorkoutUseCase) initiateUpload(ctx context.Context, workout *entity.Workout) (*dto.UploadInfo, error) {
+	uploadOpts := &upload.UploadOptions{
+		ResourceType:     workoutResourceType,
+		ResourceID:       workout.ID.String(),
+		ResourceField:    "file_path",
+		ResourceValue:    workout.FilePath,
+		ResourceProvider: upload.ResourceProvider(workout.Provider),
+		UploadType:       upload.UploadTypeSimple,
+		PathDefinition:   string(core.WorkoutPathName),
+		StorageProvider:  upload.ResourceProvider(workout.Provider),
+	}
+
+	uploadOpts.WithPathParameters(map[string]string{
+		"user_id":    workout.UserID,
+		"workout_id": workout.ID.String(),
+		"format":     workout.Format,
+	})
+
+	// Pin the declared content type and checksum so the storage edge rejects
+	// any other file
+	constraints, err := uploadConstraints(uc.registry.UploadPolicy(core.WorkoutPathName), provider.ProviderName(workout.Provider), declaredUpload{
+		ContentType: workoutContentTypes[workout.Format],
+		Checksum:    sha256Checksum(workout.ChecksumSHA256),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if constraints != nil {
+		uploadOpts.WithConstraints(constraints)
+	}
+
+	uploadRecord, err := uc.uploadManager.InitiateUpload(ctx, uploadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate upload: %w", err)
+	}
+
+	signedURL, err := uc.uploadManager.GetSimpleUploadURL(ctx, uploadRecord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload URL: %w", err)
+	}
+
+	return &dto.UploadInfo{
+		UploadID:  uploadRecord.ID.String(),
+		UploadURL: signedURL.URL,
+		ExpiresAt: uploadRecord.ExpiresTime.Unix(),
+	}, nil
+}
+
+// validateWorkoutFile parses the stored object and checks its contents against
+// the declared checksum. The file is hashed here rather than trusting the
+// provider, which may not report a checksum. Rejections are reported as
+// *workoutfile.FormatError.
+func (uc *WorkoutUseCase) validateWorkoutFile(ctx context.Context, workout *entity.Workout) (*entity.WorkoutSummary, error) {
+	data, err := uc.downloadObject(ctx, workout, workout.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := workoutfile.Parse(workout.Format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != strings.ToLower(workout.ChecksumSHA256) {
+		return nil, &workoutfile.FormatError{
+			Format: workout.Format,
+			Reason: fmt.Sprintf("sha256 checksum mismatch: expected %s, got %s", workout.ChecksumSHA256, actual),
+		}
+	}
+
+	return summary, nil
+}
+
+// downloadObject fetches a stored object, reading at most one byte past
+// workoutfile.MaxFileSize so oversized files are rejected.
+func (uc *WorkoutUseCase) downloadObject(ctx context.Context, workout *entity.Workout, filePath string) ([]byte, error) {
+	body, err := uc.objects.open(ctx, provider.ProviderName(workout.Provider), filePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download workout file: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, workoutfile.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workout file: %w", err)
+	}
+
+	return data, nil
+}
+
+// convertWorkoutFile converts the stored workout file to format and uploads the
+// result to filePath
+func (uc *WorkoutUseCase) convertWorkoutFile(ctx context.Context, workout *entity.Workout, format, filePath string, ftp float64) error {
+	storedPath, err := uc.storedFilePath(ctx, workout)
+	if err != nil {
+		return err
+	}
+
+	data, err := uc.downloadObject(ctx, workout, storedPath)
+	if err != nil {
+		return err
+	}
+
+	converted, err := workoutfile.Convert(workout.Format, format, data, ftp)
+	if err != nil {
+		return fmt.Errorf("failed to convert workout file: %w", err)
+	}
+
+	return uc.uploadObject(ctx, workout, format, converted)
+}
+
+// uploadObject stores data as the workout file in the given format through a
+// signed upload URL
+func (uc *WorkoutUseCase) uploadObject(ctx context.Context, workout *entity.Workout, format string, data []byte) error {
+	pathWorkout := *workout
+	pathWorkout.Format = format
+
+	resolved, err := uc.resourceManager.DefinitionResolver().ResolveUploadURL(ctx, core.WorkoutPathName, dto.WorkoutUploadOptions(&pathWorkout))
+	if err != nil {
+		return fmt.Errorf("failed to resolve converted workout upload URL: %w", err)
+	}
+
+	method := resolved.ObjectURL.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx.Context(), method, resolved.ObjectURL.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", workoutContentTypes[format])
+	for key, value := range resolved.ObjectURL.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := uc.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to upload converted workout file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to upload converted workout file: %w", providerStatusError(resp.StatusCode))
+	}
+
+	return nil
+}
+
+// conversionPaths resolves the storage paths of the workout's converted files
+func (uc *WorkoutUseCase) conversionPaths(ctx context.Context, workout *entity.Workout) []string {
+	var paths []string
+	for format := range workout.Conversions() {
+		filePath, err := uc.resolveFilePath(ctx, workout, format)
+		if err != nil {
+			log.Printf("Failed to resolve converted workout path for %s: %v", format, err)
+			continue
+		}
+		paths = append(paths, filePath)
+	}
+	return paths
+}
+
+// storedFilePath returns where the workout file's contents are stored, which
+// is a shared blob once its upload has been confirmed
+func (uc *WorkoutUseCase) storedFilePath(ctx context.Context, workout *entity.Workout) (string, error) {
+	return uc.contentStore.Resolve(ctx, provider.ProviderName(workout.Provider), workout.FilePath)
+}
+
+func (uc *WorkoutUseCase) signDownloadURL(ctx context.Context, filePath string) (string, *time.Time, error) {
+	resolved, err := uc.resourceManager.URLResolver().ResolveDownloadURL(ctx, filePath, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve workout download URL: %w", err)
+	}
+
+	if resolved.ObjectURL.ExpiresAt.IsZero() {
+		return resolved.ObjectURL.URL, nil, nil
+	}
+	expiresAt := resolved.ObjectURL.ExpiresAt
+	return resolved.ObjectURL.URL, &expiresAt, nil
+}
+
+// conversionCacheKey identifies a converted file by the content it was derived
+// from and the conversion inputs
+func conversionCacheKey(sourceChecksum, format string, ftp float64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%g", sourceChecksum, format, ftp)))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifySHA256 compares the provider-reported SHA256 checksum, which may be hex
+// or base64 encoded, against the declared hex digest. Objects without a
+// reported SHA256 checksum are accepted.
+func verifySHA256(checksums []metadata.Checksum, expectedHex string) error {
+	actual := reportedSHA256(checksums)
+	if actual != "" && actual != strings.ToLower(expectedHex) {
+		return fmt.Errorf("sha256 checksum mismatch: expected %s, got %s", expectedHex, actual)
+	}
+	return nil
+}
+
+// sha256Checksum converts a hex SHA256 digest to the base64 checksum signed
+// into upload URLs
+func sha256Checksum(digestHex string) *metadata.Checksum {
+	digest, err := hex.DecodeString(digestHex)
+	if err != nil {
+		return nil
+	}
+	return &metadata.Checksum{
+		Algorithm: metadata.ChecksumAlgorithmSHA256,
+		Value:     base64.StdEncoding.EncodeToString(digest),
+	}
+}
+
+// reportedSHA256 returns the provider-reported SHA256 checksum of the whole
+// object as lowercase hex, or an empty string when none is reported. Values
+// that are neither a base64 nor a hex digest, such as composite checksums of
+// multipart uploads, are not digests of the object and count as none.
+func reportedSHA256(checksums []metadata.Checksum) string {
+	for _, checksum := range checksums {
+		if checksum.Algorithm != metadata.ChecksumAlgorithmSHA256 {
+			continue
+		}
+
+		if decoded, err := base64.StdEncoding.DecodeString(checksum.Value); err == nil && len(decoded) == sha256.Size {
+			return hex.EncodeToString(decoded)
+		}
+		if decoded, err := hex.DecodeString(checksum.Value); err == nil && len(decoded) == sha256.Size {
+			return hex.EncodeToString(decoded)
+		}
+	}
+
+	return ""
+}
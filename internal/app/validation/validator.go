@@ -8,6 +8,8 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
 )
 
 var (
@@ -32,6 +34,7 @@ func init() {
 	validate.RegisterValidation("filepath", validateFilePath)
 	validate.RegisterValidation("definition", validateDefinition)
 	validate.RegisterValidation("duration", validateDuration)
+	validate.RegisterValidation("responseheader", validateResponseHeader)
 }
 
 // ValidationError represents a validation error with field and message
@@ -168,6 +171,15 @@ func ValidateUUID(uuidStr string) error {
 	return nil
 }
 
+// ValidatePublishWindow validates that a publishing window closes after it opens
+func ValidatePublishWindow(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ValidationError{Field: "unpublishAt", Message: "unpublishAt must be after publishAt"}
+	}
+
+	return nil
+}
+
 // Custom validator functions
 func validateProvider(fl validator.FieldLevel) bool {
 	provider := fl.Field().String()
@@ -202,6 +214,11 @@ func validateDuration(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+func validateResponseHeader(fl validator.FieldLevel) bool {
+	_, ok := dto.ResponseHeaderName(fl.Field().String())
+	return ok
+}
+
 // formatValidationMessage formats validation error messages
 func formatValidationMessage(fe validator.FieldError) string {
 	switch fe.Tag() {
@@ -217,6 +234,8 @@ func formatValidationMessage(fe validator.FieldError) string {
 		return "must contain only alphanumeric characters"
 	case "duration":
 		return "must be a valid duration (e.g., '1h', '30m', '10s')"
+	case "responseheader":
+		return "must be one of: Cache-Control, Content-Disposition, Content-Encoding, Content-Language, Content-Type, Expires"
 	case "provider":
 		return "must be a valid provider (cdn, gcs, r2)"
 	case "filepath":
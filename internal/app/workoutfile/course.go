@@ -0,0 +1,161 @@
+package workoutfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	sectionCourseHeader = "[COURSE HEADER]"
+	sectionEndHeader    = "[END COURSE HEADER]"
+	sectionCourseData   = "[COURSE DATA]"
+	sectionEndData      = "[END COURSE DATA]"
+)
+
+type coursePoint struct {
+	minutes float64
+	value   float64
+}
+
+// parseCourseFile parses the ERG/MRC course format. The header must declare
+// "MINUTES <unit>" and the data section holds "<minutes> <value>" pairs.
+// Consecutive points with increasing time form one interval; equal times mark
+// a step change between intervals.
+func parseCourseFile(format string, data []byte, unit string) (*Workout, error) {
+	var (
+		name        string
+		points      []coursePoint
+		section     string
+		seenHeader  bool
+		seenData    bool
+		declaredUOM bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		upper := strings.ToUpper(line)
+		if strings.HasPrefix(upper, "[") {
+			switch upper {
+			case sectionCourseHeader:
+				seenHeader = true
+			case sectionCourseData:
+				seenData = true
+			}
+			section = upper
+			continue
+		}
+
+		switch section {
+		case sectionCourseHeader:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				if strings.EqualFold(strings.TrimSpace(key), "DESCRIPTION") {
+					name = strings.TrimSpace(value)
+				}
+				continue
+			}
+
+			fields := strings.Fields(upper)
+			if len(fields) == 2 && fields[0] == "MINUTES" {
+				if fields[1] != unit {
+					return nil, formatError(format, "expected MINUTES %s units, got %s", unit, fields[1])
+				}
+				declaredUOM = true
+			}
+		case sectionCourseData:
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				return nil, formatError(format, "line %d: expected minutes and value", lineNo)
+			}
+
+			minutes, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil || !isFinite(minutes) || minutes < 0 || minutes > MaxDurationSeconds/60 {
+				return nil, formatError(format, "line %d: invalid minutes %q", lineNo, fields[0])
+			}
+			value, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil || !isFinite(value) || value < 0 {
+				return nil, formatError(format, "line %d: invalid value %q", lineNo, fields[1])
+			}
+			if len(points) > 0 && minutes < points[len(points)-1].minutes {
+				return nil, formatError(format, "line %d: time goes backwards", lineNo)
+			}
+
+			points = append(points, coursePoint{minutes: minutes, value: value})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, formatError(format, "%v", err)
+	}
+
+	if !seenHeader {
+		return nil, formatError(format, "missing %s section", sectionCourseHeader)
+	}
+	if !declaredUOM {
+		return nil, formatError(format, "header does not declare MINUTES %s", unit)
+	}
+	if !seenData {
+		return nil, formatError(format, "missing %s section", sectionCourseData)
+	}
+	if len(points) < 2 {
+		return nil, formatError(format, "course data needs at least two points")
+	}
+
+	builder := intervalBuilder{format: format}
+	for i := 1; i < len(points); i++ {
+		start, end := points[i-1], points[i]
+		if end.minutes == start.minutes {
+			continue
+		}
+
+		duration := int(math.Round((end.minutes - start.minutes) * 60))
+		if err := builder.add(1, newInterval(duration, start.value, end.value, 0, 0)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Workout{Name: name, Intervals: builder.intervals}, nil
+}
+
+// encodeCourseFile writes the workout as an ERG/MRC course. Each interval
+// becomes a start and end point; target bands are written at their midpoint.
+func encodeCourseFile(workout *Workout, unit string) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(sectionCourseHeader + "\n")
+	buf.WriteString("VERSION = 2\n")
+	buf.WriteString("UNITS = ENGLISH\n")
+	if workout.Name != "" {
+		fmt.Fprintf(&buf, "DESCRIPTION = %s\n", workout.Name)
+	}
+	fmt.Fprintf(&buf, "MINUTES %s\n", unit)
+	buf.WriteString(sectionEndHeader + "\n")
+	buf.WriteString(sectionCourseData + "\n")
+
+	elapsed := 0
+	for _, interval := range workout.Intervals {
+		fmt.Fprintf(&buf, "%s\t%s\n", formatMinutes(elapsed), formatPower(interval.Start()))
+		elapsed += interval.DurationSeconds
+		fmt.Fprintf(&buf, "%s\t%s\n", formatMinutes(elapsed), formatPower(interval.End()))
+	}
+
+	buf.WriteString(sectionEndData + "\n")
+	return buf.Bytes()
+}
+
+func formatMinutes(seconds int) string {
+	return strconv.FormatFloat(math.Round(float64(seconds)/60*10000)/10000, 'f', -1, 64)
+}
+
+func formatPower(value float64) string {
+	return strconv.FormatFloat(roundPower(value), 'f', -1, 64)
+}
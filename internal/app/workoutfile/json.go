@@ -0,0 +1,108 @@
+package workoutfile
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// jsonWorkout is the native workout format. Power targets are percent of FTP.
+// Without "ramp" an interval holds the power band; "up" and "down" ramp
+// across it.
+//
+//	{
+//	  "name": "5x500m",
+//	  "intervals": [
+//	    {"durationSeconds": 300, "powerMin": 40, "powerMax": 65, "ramp": "up"},
+//	    {"durationSeconds": 120, "powerMin": 60, "powerMax": 70, "strokeRateMin": 20, "strokeRateMax": 22, "repeat": 5}
+//	  ]
+//	}
+type jsonWorkout struct {
+	Name      string         `json:"name,omitempty"`
+	Intervals []jsonInterval `json:"intervals"`
+}
+
+type jsonInterval struct {
+	DurationSeconds int           `json:"durationSeconds"`
+	PowerMin        float64       `json:"powerMin"`
+	PowerMax        float64       `json:"powerMax"`
+	Ramp            RampDirection `json:"ramp,omitempty"`
+	StrokeRateMin   int           `json:"strokeRateMin,omitempty"`
+	StrokeRateMax   int           `json:"strokeRateMax,omitempty"`
+	Repeat          int           `json:"repeat,omitempty"`
+}
+
+func parseJSON(data []byte) (*Workout, error) {
+	var file jsonWorkout
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, formatError(FormatJSON, "%v", err)
+	}
+
+	builder := intervalBuilder{format: FormatJSON}
+	for i, item := range file.Intervals {
+		switch {
+		case item.DurationSeconds <= 0:
+			return nil, formatError(FormatJSON, "interval %d: durationSeconds must be positive", i+1)
+		case item.PowerMin < 0 || item.PowerMax < item.PowerMin:
+			return nil, formatError(FormatJSON, "interval %d: invalid power range %g-%g", i+1, item.PowerMin, item.PowerMax)
+		case item.StrokeRateMin < 0 || item.StrokeRateMax < item.StrokeRateMin:
+			return nil, formatError(FormatJSON, "interval %d: invalid stroke rate range %d-%d", i+1, item.StrokeRateMin, item.StrokeRateMax)
+		case item.Repeat < 0:
+			return nil, formatError(FormatJSON, "interval %d: repeat must not be negative", i+1)
+		case item.Ramp != RampNone && item.Ramp != RampUp && item.Ramp != RampDown:
+			return nil, formatError(FormatJSON, "interval %d: ramp must be \"up\" or \"down\"", i+1)
+		}
+
+		interval := Interval{
+			DurationSeconds: item.DurationSeconds,
+			PowerLow:        item.PowerMin,
+			PowerHigh:       item.PowerMax,
+			Ramp:            item.Ramp,
+			StrokeRateMin:   item.StrokeRateMin,
+			StrokeRateMax:   item.StrokeRateMax,
+		}
+		if err := builder.add(max(item.Repeat, 1), interval); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Workout{Name: file.Name, Intervals: builder.intervals}, nil
+}
+
+func encodeJSON(workout *Workout) ([]byte, error) {
+	file := jsonWorkout{
+		Name:      workout.Name,
+		Intervals: make([]jsonInterval, 0, len(workout.Intervals)),
+	}
+
+	for _, interval := range workout.Intervals {
+		item := jsonInterval{
+			DurationSeconds: interval.DurationSeconds,
+			PowerMin:        interval.PowerLow,
+			PowerMax:        interval.PowerHigh,
+			Ramp:            interval.Ramp,
+			StrokeRateMin:   interval.StrokeRateMin,
+			StrokeRateMax:   interval.StrokeRateMax,
+		}
+
+		// Collapse consecutive identical intervals back into repeats
+		if n := len(file.Intervals); n > 0 && sameJSONInterval(file.Intervals[n-1], item) {
+			file.Intervals[n-1].Repeat = max(file.Intervals[n-1].Repeat, 1) + 1
+			continue
+		}
+
+		file.Intervals = append(file.Intervals, item)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func sameJSONInterval(a, b jsonInterval) bool {
+	a.Repeat, b.Repeat = 0, 0
+	return a == b
+}
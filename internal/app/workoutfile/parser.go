@@ -0,0 +1,325 @@
+// Package workoutfile validates uploaded workout files, extracts the summary
+// stored on the workout record and converts workouts between formats.
+package workoutfile
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// Supported workout file formats
+const (
+	FormatERG  = "erg"
+	FormatMRC  = "mrc"
+	FormatZWO  = "zwo"
+	FormatJSON = "json"
+)
+
+// MaxFileSize bounds how much of a workout file is read for validation
+const MaxFileSize = 5 << 20
+
+// Limits of a decoded workout. Repeats expand into copies of their intervals,
+// so the file size alone does not bound the decoded workout.
+const (
+	// MaxRepeat bounds the repeat count of a single step
+	MaxRepeat = 1000
+	// MaxIntervals bounds the intervals of a workout after expanding repeats
+	MaxIntervals = 10000
+	// MaxDurationSeconds bounds the total duration of a workout
+	MaxDurationSeconds = 24 * 60 * 60
+)
+
+// ErrFTPRequired is returned when converting between watts and percent of FTP
+// without a rider FTP
+var ErrFTPRequired = domainerr.New(domainerr.Validation, "FTP_REQUIRED", "ftp is required to convert between watts and percent of FTP")
+
+// RampDirection describes how power moves across an interval. Intervals
+// without a ramp hold a target band between PowerLow and PowerHigh.
+type RampDirection string
+
+const (
+	RampNone RampDirection = ""
+	RampUp   RampDirection = "up"
+	RampDown RampDirection = "down"
+)
+
+// Interval is a single normalized workout step. Power targets use the unit of
+// the enclosing Workout.
+type Interval struct {
+	DurationSeconds int
+	PowerLow        float64
+	PowerHigh       float64
+	Ramp            RampDirection
+	StrokeRateMin   int
+	StrokeRateMax   int
+}
+
+// Start returns the power at the beginning of the interval
+func (i Interval) Start() float64 {
+	switch i.Ramp {
+	case RampUp:
+		return i.PowerLow
+	case RampDown:
+		return i.PowerHigh
+	default:
+		return i.Target()
+	}
+}
+
+// End returns the power at the end of the interval
+func (i Interval) End() float64 {
+	switch i.Ramp {
+	case RampUp:
+		return i.PowerHigh
+	case RampDown:
+		return i.PowerLow
+	default:
+		return i.Target()
+	}
+}
+
+// Target returns the midpoint of the power band
+func (i Interval) Target() float64 {
+	return (i.PowerLow + i.PowerHigh) / 2
+}
+
+// StrokeRate returns the midpoint of the stroke rate band
+func (i Interval) StrokeRate() int {
+	return (i.StrokeRateMin + i.StrokeRateMax) / 2
+}
+
+// Workout is the format-independent workout model
+type Workout struct {
+	Name      string
+	PowerUnit string
+	Intervals []Interval
+}
+
+// FormatError reports a malformed workout file
+type FormatError struct {
+	Format string
+	Reason string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("invalid %s workout file: %s", e.Format, e.Reason)
+}
+
+func formatError(format, reason string, args ...any) error {
+	return &FormatError{Format: format, Reason: fmt.Sprintf(reason, args...)}
+}
+
+// PowerUnit returns the power unit a format stores targets in
+func PowerUnit(format string) string {
+	if format == FormatERG {
+		return entity.PowerUnitWatts
+	}
+	return entity.PowerUnitFTPPercent
+}
+
+// Decode validates data as a workout file of the given format
+func Decode(format string, data []byte) (*Workout, error) {
+	if len(data) == 0 {
+		return nil, formatError(format, "file is empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, formatError(format, "file exceeds %d bytes", MaxFileSize)
+	}
+
+	var (
+		workout *Workout
+		err     error
+	)
+
+	switch format {
+	case FormatERG:
+		workout, err = parseCourseFile(format, data, "WATTS")
+	case FormatMRC:
+		workout, err = parseCourseFile(format, data, "PERCENT")
+	case FormatZWO:
+		workout, err = parseZWO(data)
+	case FormatJSON:
+		workout, err = parseJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported workout format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workout.Intervals) == 0 {
+		return nil, formatError(format, "no intervals found")
+	}
+	workout.PowerUnit = PowerUnit(format)
+
+	return workout, nil
+}
+
+// Parse validates data as a workout file of the given format and returns its summary
+func Parse(format string, data []byte) (*entity.WorkoutSummary, error) {
+	workout, err := Decode(format, data)
+	if err != nil {
+		return nil, err
+	}
+	return workout.Summary(), nil
+}
+
+// Encode writes the workout in the given format. The workout must already use
+// the format's power unit.
+func Encode(format string, workout *Workout) ([]byte, error) {
+	if workout.PowerUnit != PowerUnit(format) {
+		return nil, fmt.Errorf("cannot encode %s power targets as %s", workout.PowerUnit, format)
+	}
+
+	switch format {
+	case FormatERG:
+		return encodeCourseFile(workout, "WATTS"), nil
+	case FormatMRC:
+		return encodeCourseFile(workout, "PERCENT"), nil
+	case FormatZWO:
+		return encodeZWO(workout)
+	case FormatJSON:
+		return encodeJSON(workout)
+	default:
+		return nil, fmt.Errorf("unsupported workout format: %s", format)
+	}
+}
+
+// Convert decodes data in one format and re-encodes it in another. ftp is the
+// rider's functional threshold power in watts and is only needed when the two
+// formats use different power units.
+func Convert(from, to string, data []byte, ftp float64) ([]byte, error) {
+	workout, err := Decode(from, data)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := workout.WithPowerUnit(PowerUnit(to), ftp)
+	if err != nil {
+		return nil, err
+	}
+
+	return Encode(to, converted)
+}
+
+// WithPowerUnit returns a copy of the workout with power targets in unit
+func (w *Workout) WithPowerUnit(unit string, ftp float64) (*Workout, error) {
+	if w.PowerUnit == unit {
+		return w, nil
+	}
+	if ftp <= 0 {
+		return nil, ErrFTPRequired
+	}
+
+	factor := ftp / 100
+	if unit == entity.PowerUnitFTPPercent {
+		factor = 100 / ftp
+	}
+
+	converted := &Workout{
+		Name:      w.Name,
+		PowerUnit: unit,
+		Intervals: make([]Interval, len(w.Intervals)),
+	}
+	for i, interval := range w.Intervals {
+		interval.PowerLow = roundPower(interval.PowerLow * factor)
+		interval.PowerHigh = roundPower(interval.PowerHigh * factor)
+		converted.Intervals[i] = interval
+	}
+
+	return converted, nil
+}
+
+// Summary aggregates the intervals for storage on the workout record
+func (w *Workout) Summary() *entity.WorkoutSummary {
+	summary := &entity.WorkoutSummary{
+		IntervalCount: len(w.Intervals),
+		PowerUnit:     w.PowerUnit,
+	}
+	if len(w.Intervals) == 0 {
+		return summary
+	}
+
+	summary.PowerMin = w.Intervals[0].PowerLow
+	summary.PowerMax = w.Intervals[0].PowerHigh
+	for _, interval := range w.Intervals {
+		summary.DurationSeconds += interval.DurationSeconds
+		summary.PowerMin = min(summary.PowerMin, interval.PowerLow)
+		summary.PowerMax = max(summary.PowerMax, interval.PowerHigh)
+
+		if interval.StrokeRateMin > 0 && (summary.StrokeRateMin == 0 || interval.StrokeRateMin < summary.StrokeRateMin) {
+			summary.StrokeRateMin = interval.StrokeRateMin
+		}
+		summary.StrokeRateMax = max(summary.StrokeRateMax, interval.StrokeRateMax)
+	}
+
+	return summary
+}
+
+// intervalBuilder collects the intervals of a workout being decoded and
+// enforces the workout limits as they are added
+type intervalBuilder struct {
+	format    string
+	intervals []Interval
+	duration  int
+}
+
+// add appends the intervals repeat times
+func (b *intervalBuilder) add(repeat int, intervals ...Interval) error {
+	if repeat > MaxRepeat {
+		return formatError(b.format, "repeat %d exceeds %d", repeat, MaxRepeat)
+	}
+	if len(b.intervals)+repeat*len(intervals) > MaxIntervals {
+		return formatError(b.format, "workout exceeds %d intervals", MaxIntervals)
+	}
+
+	duration := 0
+	for _, interval := range intervals {
+		if interval.DurationSeconds > MaxDurationSeconds-duration {
+			return formatError(b.format, "workout exceeds %d seconds", MaxDurationSeconds)
+		}
+		duration += interval.DurationSeconds
+	}
+	if duration > 0 && repeat > (MaxDurationSeconds-b.duration)/duration {
+		return formatError(b.format, "workout exceeds %d seconds", MaxDurationSeconds)
+	}
+
+	for r := 0; r < repeat; r++ {
+		b.intervals = append(b.intervals, intervals...)
+	}
+	b.duration += repeat * duration
+	return nil
+}
+
+// newInterval builds an interval that moves from start to end power
+func newInterval(duration int, start, end float64, strokeRateMin, strokeRateMax int) Interval {
+	interval := Interval{
+		DurationSeconds: duration,
+		PowerLow:        min(start, end),
+		PowerHigh:       max(start, end),
+		StrokeRateMin:   strokeRateMin,
+		StrokeRateMax:   strokeRateMax,
+	}
+
+	switch {
+	case end > start:
+		interval.Ramp = RampUp
+	case end < start:
+		interval.Ramp = RampDown
+	}
+	return interval
+}
+
+// roundPower rounds a power target to two decimals
+func roundPower(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// isFinite reports whether value is neither NaN nor infinite, which
+// strconv.ParseFloat accepts as "NaN" and "Inf"
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,176 @@
+package workoutfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+const ergCourse = `[COURSE HEADER]
+DESCRIPTION = Threshold
+MINUTES WATTS
+[END COURSE HEADER]
+[COURSE DATA]
+0 100
+5 200
+5 250
+10 250
+[END COURSE DATA]
+`
+
+const zwoIntervals = `<workout_file>
+    <name>Intervals</name>
+    <workout>
+        <Warmup Duration="300" PowerLow="0.4" PowerHigh="0.65"/>
+        <IntervalsT Repeat="3" OnDuration="60" OffDuration="30" OnPower="1.1" OffPower="0.5" Cadence="24"/>
+        <textevent message="go"/>
+    </workout>
+</workout_file>
+`
+
+func TestDecode_Formats(t *testing.T) {
+	testCases := []struct {
+		name      string
+		format    string
+		data      string
+		intervals int
+		duration  int
+		powerUnit string
+	}{
+		{"erg course", FormatERG, ergCourse, 2, 600, entity.PowerUnitWatts},
+		{"mrc course", FormatMRC, strings.Replace(ergCourse, "WATTS", "PERCENT", 1), 2, 600, entity.PowerUnitFTPPercent},
+		{"zwo with repeats", FormatZWO, zwoIntervals, 7, 570, entity.PowerUnitFTPPercent},
+		{"json with repeats", FormatJSON, `{"intervals": [{"durationSeconds": 60, "powerMin": 50, "powerMax": 60, "repeat": 4}]}`, 4, 240, entity.PowerUnitFTPPercent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workout, err := Decode(tc.format, []byte(tc.data))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.powerUnit, workout.PowerUnit)
+			summary := workout.Summary()
+			assert.Equal(t, tc.intervals, summary.IntervalCount)
+			assert.Equal(t, tc.duration, summary.DurationSeconds)
+		})
+	}
+}
+
+func TestDecode_Limits(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		data   string
+		reason string
+	}{
+		{
+			name:   "json repeat over the limit",
+			format: FormatJSON,
+			data:   `{"intervals": [{"durationSeconds": 1, "powerMin": 50, "powerMax": 60, "repeat": 30000000}]}`,
+			reason: "repeat 30000000 exceeds",
+		},
+		{
+			name:   "json intervals over the limit",
+			format: FormatJSON,
+			data:   `{"intervals": [` + strings.TrimSuffix(strings.Repeat(`{"durationSeconds": 1, "powerMin": 50, "powerMax": 60, "repeat": 1000},`, MaxIntervals/MaxRepeat+1), ",") + `]}`,
+			reason: "exceeds 10000 intervals",
+		},
+		{
+			name:   "json duration over the limit",
+			format: FormatJSON,
+			data:   `{"intervals": [{"durationSeconds": 3600, "powerMin": 50, "powerMax": 60, "repeat": 25}]}`,
+			reason: "exceeds 86400 seconds",
+		},
+		{
+			name:   "json duration overflowing int",
+			format: FormatJSON,
+			data:   `{"intervals": [{"durationSeconds": 9223372036854775807, "powerMin": 50, "powerMax": 60}, {"durationSeconds": 10, "powerMin": 50, "powerMax": 60}]}`,
+			reason: "exceeds 86400 seconds",
+		},
+		{
+			name:   "zwo repeat over the limit",
+			format: FormatZWO,
+			data:   `<workout_file><workout><IntervalsT Repeat="30000000" OnDuration="1" OffDuration="1" OnPower="1" OffPower="0.5"/></workout></workout_file>`,
+			reason: "repeat 30000000 exceeds",
+		},
+		{
+			name:   "zwo duration over the limit",
+			format: FormatZWO,
+			data:   `<workout_file><workout><IntervalsT Repeat="1000" OnDuration="60" OffDuration="60" OnPower="1" OffPower="0.5"/></workout></workout_file>`,
+			reason: "exceeds 86400 seconds",
+		},
+		{
+			name:   "zwo step duration over the limit",
+			format: FormatZWO,
+			data:   `<workout_file><workout><SteadyState Duration="1e30" Power="0.8"/></workout></workout_file>`,
+			reason: "invalid Duration",
+		},
+		{
+			name:   "course time over the limit",
+			format: FormatERG,
+			data:   strings.Replace(ergCourse, "10 250", "1e30 250", 1),
+			reason: "invalid minutes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.format, []byte(tc.data))
+
+			var formatErr *FormatError
+			require.ErrorAs(t, err, &formatErr)
+			assert.Equal(t, tc.format, formatErr.Format)
+			assert.Contains(t, formatErr.Reason, tc.reason)
+		})
+	}
+}
+
+func TestDecode_InvalidValues(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		data   string
+	}{
+		{"empty file", FormatJSON, ""},
+		{"json without intervals", FormatJSON, `{"intervals": []}`},
+		{"json unknown field", FormatJSON, `{"intervals": [{"durationSeconds": 60, "watts": 100}]}`},
+		{"json negative repeat", FormatJSON, `{"intervals": [{"durationSeconds": 60, "powerMin": 50, "powerMax": 60, "repeat": -1}]}`},
+		{"zwo non-finite power", FormatZWO, `<workout_file><workout><SteadyState Duration="60" Power="NaN"/></workout></workout_file>`},
+		{"zwo unsupported step", FormatZWO, `<workout_file><workout><Sprint Duration="60"/></workout></workout_file>`},
+		{"erg wrong unit", FormatERG, strings.Replace(ergCourse, "WATTS", "PERCENT", 1)},
+		{"erg time going backwards", FormatERG, strings.Replace(ergCourse, "10 250", "4 250", 1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.format, []byte(tc.data))
+
+			var formatErr *FormatError
+			assert.ErrorAs(t, err, &formatErr)
+		})
+	}
+}
+
+func TestConvert_RoundTrip(t *testing.T) {
+	data, err := Convert(FormatZWO, FormatJSON, []byte(zwoIntervals), 0)
+	require.NoError(t, err)
+
+	workout, err := Decode(FormatJSON, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Intervals", workout.Name)
+	assert.Len(t, workout.Intervals, 7)
+
+	_, err = Convert(FormatJSON, FormatERG, data, 0)
+	assert.ErrorIs(t, err, ErrFTPRequired)
+
+	erg, err := Convert(FormatJSON, FormatERG, data, 200)
+	require.NoError(t, err)
+
+	converted, err := Decode(FormatERG, erg)
+	require.NoError(t, err)
+	assert.InDelta(t, 220, converted.Summary().PowerMax, 0.01)
+}
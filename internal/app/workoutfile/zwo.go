@@ -0,0 +1,195 @@
+package workoutfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+)
+
+type zwoFile struct {
+	XMLName xml.Name    `xml:"workout_file"`
+	Name    string      `xml:"name,omitempty"`
+	Workout *zwoWorkout `xml:"workout"`
+}
+
+type zwoWorkout struct {
+	Steps []zwoStep `xml:",any"`
+}
+
+type zwoStep struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+}
+
+func (s zwoStep) attr(name string) string {
+	for _, a := range s.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// parseZWO parses the Zwift XML workout format. Power values are fractions of
+// FTP in the file and are converted to percent. Cadence is read as stroke rate.
+func parseZWO(data []byte) (*Workout, error) {
+	var file zwoFile
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&file); err != nil {
+		return nil, formatError(FormatZWO, "%v", err)
+	}
+	if file.Workout == nil {
+		return nil, formatError(FormatZWO, "missing <workout> element")
+	}
+
+	builder := intervalBuilder{format: FormatZWO}
+	for i, step := range file.Workout.Steps {
+		p := zwoStepParser{step: step, index: i}
+
+		switch step.XMLName.Local {
+		case "SteadyState", "FreeRide", "Freeride", "MaxEffort":
+			power := ftpPercent(p.optionalFloat("Power"))
+			cadence := p.optionalInt("Cadence")
+			p.add(&builder, 1, newInterval(p.duration("Duration"), power, power, cadence, cadence))
+		case "Warmup", "Cooldown", "Ramp":
+			low := ftpPercent(p.float("PowerLow"))
+			high := ftpPercent(p.float("PowerHigh"))
+			cadence := p.optionalInt("Cadence")
+			p.add(&builder, 1, newInterval(p.duration("Duration"), low, high, cadence, cadence))
+		case "IntervalsT":
+			repeat := p.optionalInt("Repeat")
+			if repeat == 0 {
+				repeat = 1
+			}
+			onPower := ftpPercent(p.float("OnPower"))
+			offPower := ftpPercent(p.float("OffPower"))
+			onCadence := p.optionalInt("Cadence")
+			offCadence := p.optionalInt("CadenceResting")
+			on := newInterval(p.duration("OnDuration"), onPower, onPower, onCadence, onCadence)
+			off := newInterval(p.duration("OffDuration"), offPower, offPower, offCadence, offCadence)
+			p.add(&builder, repeat, on, off)
+		case "textevent", "TextEvent":
+			continue
+		default:
+			return nil, formatError(FormatZWO, "step %d: unsupported element <%s>", i+1, step.XMLName.Local)
+		}
+
+		if p.err != nil {
+			return nil, p.err
+		}
+	}
+
+	return &Workout{Name: file.Name, Intervals: builder.intervals}, nil
+}
+
+// encodeZWO writes the workout as Zwift XML. Ramps become <Ramp> steps and
+// target bands become <SteadyState> at their midpoint.
+func encodeZWO(workout *Workout) ([]byte, error) {
+	file := zwoFile{
+		Name:    workout.Name,
+		Workout: &zwoWorkout{},
+	}
+
+	for _, interval := range workout.Intervals {
+		step := zwoStep{
+			Attrs: []xml.Attr{zwoAttr("Duration", strconv.Itoa(interval.DurationSeconds))},
+		}
+
+		if interval.Ramp == RampNone {
+			step.XMLName.Local = "SteadyState"
+			step.Attrs = append(step.Attrs, zwoAttr("Power", ftpFraction(interval.Target())))
+		} else {
+			step.XMLName.Local = "Ramp"
+			step.Attrs = append(step.Attrs,
+				zwoAttr("PowerLow", ftpFraction(interval.Start())),
+				zwoAttr("PowerHigh", ftpFraction(interval.End())),
+			)
+		}
+
+		if rate := interval.StrokeRate(); rate > 0 {
+			step.Attrs = append(step.Attrs, zwoAttr("Cadence", strconv.Itoa(rate)))
+		}
+
+		file.Workout.Steps = append(file.Workout.Steps, step)
+	}
+
+	data, err := xml.MarshalIndent(file, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func zwoAttr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+// zwoStepParser reads numeric attributes from a step, keeping the first error
+type zwoStepParser struct {
+	step  zwoStep
+	index int
+	err   error
+}
+
+func (p *zwoStepParser) fail(name, raw string) {
+	if p.err == nil {
+		p.err = formatError(FormatZWO, "step %d <%s>: invalid %s %q", p.index+1, p.step.XMLName.Local, name, raw)
+	}
+}
+
+// add adds the step's intervals unless reading its attributes failed
+func (p *zwoStepParser) add(builder *intervalBuilder, repeat int, intervals ...Interval) {
+	if p.err == nil {
+		p.err = builder.add(repeat, intervals...)
+	}
+}
+
+func (p *zwoStepParser) duration(name string) int {
+	raw := p.step.attr(name)
+	seconds, err := strconv.ParseFloat(raw, 64)
+	if err != nil || !isFinite(seconds) || seconds <= 0 || seconds > MaxDurationSeconds {
+		p.fail(name, raw)
+		return 0
+	}
+	return int(seconds)
+}
+
+func (p *zwoStepParser) float(name string) float64 {
+	raw := p.step.attr(name)
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || !isFinite(value) || value < 0 {
+		p.fail(name, raw)
+		return 0
+	}
+	return value
+}
+
+func (p *zwoStepParser) optionalFloat(name string) float64 {
+	if p.step.attr(name) == "" {
+		return 0
+	}
+	return p.float(name)
+}
+
+func (p *zwoStepParser) optionalInt(name string) int {
+	raw := p.step.attr(name)
+	if raw == "" {
+		return 0
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		p.fail(name, raw)
+		return 0
+	}
+	return value
+}
+
+// ftpPercent converts a ZWO FTP fraction to percent, rounded to two decimals
+func ftpPercent(fraction float64) float64 {
+	return roundPower(fraction * 100)
+}
+
+// ftpFraction formats a percent of FTP as a ZWO FTP fraction
+func ftpFraction(percent float64) string {
+	return strconv.FormatFloat(roundPower(percent)/100, 'f', -1, 64)
+}
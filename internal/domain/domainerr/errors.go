@@ -0,0 +1,121 @@
+// Package domainerr classifies the errors repositories and usecases return,
+// so the transports can report them with a matching status and a stable
+// error code instead of treating every failure as internal.
+package domainerr
+
+import "errors"
+
+// Kind is the class of failure an error reports
+type Kind int
+
+const (
+	// Internal is an unclassified failure
+	Internal Kind = iota
+	// NotFound reports a missing resource
+	NotFound
+	// Conflict reports a resource colliding with an existing one
+	Conflict
+	// Validation reports a request with invalid data
+	Validation
+	// PreconditionFailed reports a resource whose state does not allow the
+	// operation, e.g. a finished upload or a stale version
+	PreconditionFailed
+	// ProviderUnavailable reports a storage provider failing to serve a
+	// request
+	ProviderUnavailable
+	// Unsupported reports a capability the storage provider lacks
+	Unsupported
+	// Forbidden reports an operation that is never allowed on the resource
+	Forbidden
+	// TooLarge reports a payload over the accepted size
+	TooLarge
+	// Unprocessable reports well-formed input whose content is invalid
+	Unprocessable
+)
+
+var kindNames = map[Kind]string{
+	Internal:            "internal",
+	NotFound:            "not found",
+	Conflict:            "conflict",
+	Validation:          "validation",
+	PreconditionFailed:  "precondition failed",
+	ProviderUnavailable: "provider unavailable",
+	Unsupported:         "unsupported",
+	Forbidden:           "forbidden",
+	TooLarge:            "too large",
+	Unprocessable:       "unprocessable",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Error is a failure of a given kind. Code is the stable identifier clients
+// match on, Message describes the failure and Err is the underlying cause.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+// New creates an error of the given kind, usually a package-level sentinel
+// that callers wrap with fmt.Errorf("%w: ...") to add detail
+func New(kind Kind, code, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Wrap classifies err as a failure of the given kind
+func Wrap(kind Kind, code string, err error) *Error {
+	return &Error{
+		Kind: kind,
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Classify returns the outermost classified error in err's chain, or nil
+// when err has no kind other than Internal
+func Classify(err error) *Error {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			return nil
+		}
+		if e.Kind != Internal {
+			return e
+		}
+		err = e.Err
+	}
+	return nil
+}
+
+// KindOf returns the kind of the outermost classified error in err's chain
+func KindOf(err error) Kind {
+	if e := Classify(err); e != nil {
+		return e.Kind
+	}
+	return Internal
+}
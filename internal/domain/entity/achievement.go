@@ -7,17 +7,23 @@ import (
 )
 
 type Achievement struct {
-	ID          uuid.UUID              `json:"id" db:"id"`
-	Name        string                 `json:"name" db:"name"`
-	Description string                 `json:"description" db:"description"`
-	IconPath    string                 `json:"icon_path" db:"icon_path"`
-	BannerPath  string                 `json:"banner_path" db:"banner_path"`
-	Category    string                 `json:"category" db:"category"`
-	Points      int                    `json:"points" db:"points"`
-	IsActive    bool                   `json:"is_active" db:"is_active"`
-	Metadata    map[string]interface{} `json:"metadata" db:"metadata"`
-	CreatedAt   time.Time              `json:"createdAt" db:"createdAt"`
-	UpdatedAt   time.Time              `json:"updatedAt" db:"updatedAt"`
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	IconPath    string    `json:"icon_path" db:"icon_path"`
+	// IconProvider is the storage provider holding the icon
+	IconProvider string `json:"icon_provider,omitempty" db:"icon_provider"`
+	BannerPath   string `json:"banner_path" db:"banner_path"`
+	// BannerProvider is the storage provider holding the banner
+	BannerProvider string                 `json:"banner_provider,omitempty" db:"banner_provider"`
+	Category       string                 `json:"category" db:"category"`
+	Points         int                    `json:"points" db:"points"`
+	IsActive       bool                   `json:"is_active" db:"is_active"`
+	PublishAt      *time.Time             `json:"publish_at,omitempty" db:"publish_at"`
+	UnpublishAt    *time.Time             `json:"unpublish_at,omitempty" db:"unpublish_at"`
+	Metadata       map[string]interface{} `json:"metadata" db:"metadata"`
+	CreatedAt      time.Time              `json:"createdAt" db:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt" db:"updatedAt"`
 
 	IconURL   string `json:"iconUrl,omitempty" db:"-"`
 	BannerURL string `json:"bannerUrl,omitempty" db:"-"`
@@ -43,3 +49,46 @@ func (a *Achievement) SetBannerPath(bannerPath string) {
 	a.BannerPath = bannerPath
 	a.UpdatedAt = time.Now()
 }
+
+// HasPublishWindow reports whether publishing is scheduled rather than manual.
+func (a *Achievement) HasPublishWindow() bool {
+	return a.PublishAt != nil || a.UnpublishAt != nil
+}
+
+// ActiveAt reports whether the achievement is visible at the given time. When a
+// publishing window is set it takes precedence over the manual IsActive flag.
+func (a *Achievement) ActiveAt(t time.Time) bool {
+	if !a.HasPublishWindow() {
+		return a.IsActive
+	}
+	if a.PublishAt != nil && a.PublishAt.After(t) {
+		return false
+	}
+	if a.UnpublishAt != nil && !a.UnpublishAt.After(t) {
+		return false
+	}
+	return true
+}
+
+func (a *Achievement) SetPublishWindow(publishAt, unpublishAt *time.Time) {
+	a.PublishAt = publishAt
+	a.UnpublishAt = unpublishAt
+	if a.HasPublishWindow() {
+		a.IsActive = a.ActiveAt(time.Now())
+	}
+	a.UpdatedAt = time.Now()
+}
+
+type PublicationEventType string
+
+const (
+	PublicationEventPublished   PublicationEventType = "published"
+	PublicationEventUnpublished PublicationEventType = "unpublished"
+)
+
+// AchievementPublicationEvent is emitted when a publishing window opens or closes.
+type AchievementPublicationEvent struct {
+	AchievementID uuid.UUID            `json:"achievement_id"`
+	Type          PublicationEventType `json:"type"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate RevisionAction = "create"
+	RevisionActionUpdate RevisionAction = "update"
+	RevisionActionDelete RevisionAction = "delete"
+	RevisionActionRevert RevisionAction = "revert"
+)
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// AchievementRevision records a single change to an achievement. Previous holds
+// the full entity as it was before the change and is nil for creations.
+type AchievementRevision struct {
+	ID            uuid.UUID      `json:"id" db:"id"`
+	AchievementID uuid.UUID      `json:"achievement_id" db:"achievement_id"`
+	Revision      int            `json:"revision" db:"revision"`
+	Action        RevisionAction `json:"action" db:"action"`
+	Previous      *Achievement   `json:"previous" db:"previous"`
+	Changes       []FieldChange  `json:"changes" db:"changes"`
+	IconProvider  string         `json:"icon_provider" db:"icon_provider"`
+	// Actor is the principal the request claimed to act for. It is taken
+	// from the X-Principal-ID header without authentication, so it records
+	// attribution rather than proof of who made the change.
+	Actor     string    `json:"actor" db:"actor"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ReleasedIcon is an icon object no longer used by its achievement, kept so
+// the revisions that replaced it can be reverted
+type ReleasedIcon struct {
+	AchievementID uuid.UUID
+	Provider      string
+	Path          string
+}
+
+func NewAchievementRevision(action RevisionAction, previous, current *Achievement, actor string) *AchievementRevision {
+	achievementID := uuid.Nil
+	if current != nil {
+		achievementID = current.ID
+	} else if previous != nil {
+		achievementID = previous.ID
+	}
+
+	return &AchievementRevision{
+		ID:            uuid.New(),
+		AchievementID: achievementID,
+		Action:        action,
+		Previous:      previous,
+		Changes:       DiffAchievements(previous, current),
+		Actor:         actor,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// DiffAchievements lists the user-visible fields that differ between two
+// achievement states. A nil side is treated as an empty achievement.
+func DiffAchievements(old, new *Achievement) []FieldChange {
+	if old == nil {
+		old = &Achievement{}
+	}
+	if new == nil {
+		new = &Achievement{}
+	}
+
+	fields := []struct {
+		name     string
+		old, new any
+	}{
+		{"name", old.Name, new.Name},
+		{"description", old.Description, new.Description},
+		{"icon_path", old.IconPath, new.IconPath},
+		{"banner_path", old.BannerPath, new.BannerPath},
+		{"category", old.Category, new.Category},
+		{"points", old.Points, new.Points},
+		{"is_active", old.IsActive, new.IsActive},
+		{"publish_at", old.PublishAt, new.PublishAt},
+		{"unpublish_at", old.UnpublishAt, new.UnpublishAt},
+		{"metadata", old.Metadata, new.Metadata},
+	}
+
+	changes := make([]FieldChange, 0, len(fields))
+	for _, f := range fields {
+		if !reflect.DeepEqual(f.old, f.new) {
+			changes = append(changes, FieldChange{Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// ContentReference maps a logical path of a deduplicated definition to the
+// content-addressed blob holding its contents. Blobs are shared by every
+// path with identical contents and removed with their last reference.
+type ContentReference struct {
+	Provider       string    `json:"provider" db:"provider"`
+	Path           string    `json:"path" db:"path"`
+	BlobKey        string    `json:"blob_key" db:"blob_key"`
+	Definition     string    `json:"definition" db:"definition"`
+	ChecksumSHA256 string    `json:"checksum_sha256" db:"checksum_sha256"`
+	Size           int64     `json:"size" db:"size"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Replication job statuses
+const (
+	ReplicationStatusPending   = "pending"
+	ReplicationStatusRunning   = "running"
+	ReplicationStatusCompleted = "completed"
+	ReplicationStatusFailed    = "failed"
+	ReplicationStatusCancelled = "cancelled"
+)
+
+// Replication item statuses
+const (
+	ReplicationItemCopied  = "copied"
+	ReplicationItemSkipped = "skipped"
+	ReplicationItemFailed  = "failed"
+)
+
+// ReplicationJob copies every object of a definition from one provider to
+// another. ContinuationToken marks the next listing page so an interrupted job
+// resumes where it stopped. A running job is processed by the instance named
+// by LeaseOwner until LeaseUntil, after which another instance may claim it.
+type ReplicationJob struct {
+	ID                uuid.UUID         `json:"id" db:"id"`
+	Definition        string            `json:"definition" db:"definition"`
+	Scope             string            `json:"scope" db:"scope"`
+	ScopeValue        int16             `json:"scope_value" db:"scope_value"`
+	Parameters        map[string]string `json:"parameters" db:"parameters"`
+	SourceProvider    string            `json:"source_provider" db:"source_provider"`
+	TargetProvider    string            `json:"target_provider" db:"target_provider"`
+	Concurrency       int               `json:"concurrency" db:"concurrency"`
+	Status            string            `json:"status" db:"status"`
+	ContinuationToken string            `json:"continuation_token" db:"continuation_token"`
+	CopiedCount       int               `json:"copied_count" db:"copied_count"`
+	SkippedCount      int               `json:"skipped_count" db:"skipped_count"`
+	FailedCount       int               `json:"failed_count" db:"failed_count"`
+	Error             string            `json:"error" db:"error"`
+	CreatedAt         time.Time         `json:"createdAt" db:"created_at"`
+	UpdatedAt         time.Time         `json:"updatedAt" db:"updated_at"`
+	StartedAt         *time.Time        `json:"startedAt" db:"started_at"`
+	CompletedAt       *time.Time        `json:"completedAt" db:"completed_at"`
+	LeaseOwner        string            `json:"-" db:"lease_owner"`
+	LeaseUntil        *time.Time        `json:"-" db:"lease_until"`
+}
+
+// ReplicationItem records the outcome for a single source object
+type ReplicationItem struct {
+	JobID          uuid.UUID `json:"job_id" db:"job_id"`
+	SourceKey      string    `json:"source_key" db:"source_key"`
+	TargetKey      string    `json:"target_key" db:"target_key"`
+	Status         string    `json:"status" db:"status"`
+	Size           int64     `json:"size" db:"size"`
+	ChecksumSHA256 string    `json:"checksum_sha256" db:"checksum_sha256"`
+	Error          string    `json:"error" db:"error"`
+	UpdatedAt      time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+func NewReplicationJob(definition, scope string, scopeValue int16, parameters map[string]string, sourceProvider, targetProvider string, concurrency int) *ReplicationJob {
+	return &ReplicationJob{
+		ID:             uuid.New(),
+		Definition:     definition,
+		Scope:          scope,
+		ScopeValue:     scopeValue,
+		Parameters:     parameters,
+		SourceProvider: sourceProvider,
+		TargetProvider: targetProvider,
+		Concurrency:    concurrency,
+		Status:         ReplicationStatusPending,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// IsFinished reports whether the job has reached a terminal status
+func (j *ReplicationJob) IsFinished() bool {
+	switch j.Status {
+	case ReplicationStatusCompleted, ReplicationStatusFailed, ReplicationStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// RenewLease extends the job's lease by lease from now
+func (j *ReplicationJob) RenewLease(lease time.Duration) {
+	until := time.Now().Add(lease)
+	j.LeaseUntil = &until
+}
+
+// Finish moves the job to a terminal status
+func (j *ReplicationJob) Finish(status, errMsg string) {
+	now := time.Now()
+	j.Status = status
+	j.Error = errMsg
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
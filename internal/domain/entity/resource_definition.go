@@ -0,0 +1,95 @@
+package entity
+
+import "time"
+
+// Resource definition statuses
+const (
+	DefinitionStatusActive     = "active"
+	DefinitionStatusDeprecated = "deprecated"
+)
+
+// ResourceDefinition is a definition managed at runtime rather than compiled
+// into the server. Every change is stored as a new version and propagated to
+// all server instances.
+type ResourceDefinition struct {
+	Name      string         `json:"name" db:"name"`
+	Version   int            `json:"version" db:"version"`
+	Spec      DefinitionSpec `json:"spec" db:"spec"`
+	Status    string         `json:"status" db:"status"`
+	CreatedBy string         `json:"created_by" db:"created_by"`
+	UpdatedBy string         `json:"updated_by" db:"updated_by"`
+	CreatedAt time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time      `json:"updatedAt" db:"updated_at"`
+}
+
+// ResourceDefinitionVersion is one stored version of a runtime definition.
+// Migrated marks versions that were allowed to change existing paths.
+type ResourceDefinitionVersion struct {
+	Name      string         `json:"name" db:"name"`
+	Version   int            `json:"version" db:"version"`
+	Spec      DefinitionSpec `json:"spec" db:"spec"`
+	Status    string         `json:"status" db:"status"`
+	Migrated  bool           `json:"migrated" db:"migrated"`
+	CreatedBy string         `json:"created_by" db:"created_by"`
+	CreatedAt time.Time      `json:"createdAt" db:"created_at"`
+}
+
+// DefinitionSpec is the serializable form of a resource definition. Scopes
+// are given by their codes (G, A, CA) and parameter rules by the constraints
+// they enforce.
+type DefinitionSpec struct {
+	DisplayName            string                           `json:"displayName"`
+	Description            string                           `json:"description,omitempty"`
+	AllowedScopes          []string                         `json:"allowedScopes"`
+	Patterns               map[string]DefinitionPatternSpec `json:"patterns"`
+	Parameters             []DefinitionParameterSpec        `json:"parameters,omitempty"`
+	DefaultStorageMetadata *DefinitionStorageSpec           `json:"defaultStorageMetadata,omitempty"`
+	UploadPolicy           *DefinitionUploadPolicySpec      `json:"uploadPolicy,omitempty"`
+	// ContentAddressed stores identical files once, in a blob shared by the
+	// files of the same directory
+	ContentAddressed bool `json:"contentAddressed,omitempty"`
+}
+
+// DefinitionPatternSpec holds a definition's patterns on one provider, keyed
+// by scope code
+type DefinitionPatternSpec struct {
+	URLType  string            `json:"urlType"`
+	Patterns map[string]string `json:"patterns"`
+}
+
+// DefinitionParameterSpec describes a path parameter and its validation
+type DefinitionParameterSpec struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	DefaultValue string   `json:"defaultValue,omitempty"`
+	Required     bool     `json:"required,omitempty"`
+	Enum         []string `json:"enum,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinLength    int      `json:"minLength,omitempty"`
+	MaxLength    int      `json:"maxLength,omitempty"`
+}
+
+// DefinitionStorageSpec holds the storage metadata applied to a definition's
+// uploads by default
+type DefinitionStorageSpec struct {
+	CacheMaxAge       int               `json:"cacheMaxAge,omitempty"`
+	CacheAllowPublic  bool              `json:"cacheAllowPublic,omitempty"`
+	CacheControl      string            `json:"cacheControl,omitempty"`
+	RequiredChecksums []string          `json:"requiredChecksums,omitempty"`
+	CustomHeaders     map[string]string `json:"customHeaders,omitempty"`
+}
+
+// DefinitionUploadPolicySpec constrains uploads to a definition. A zero
+// MaxSize leaves the size unlimited and empty ContentTypes accept any type.
+type DefinitionUploadPolicySpec struct {
+	MaxSize          int64    `json:"maxSize,omitempty"`
+	ContentTypes     []string `json:"contentTypes,omitempty"`
+	RequiredChecksum string   `json:"requiredChecksum,omitempty"`
+	BindClientIP     bool     `json:"bindClientIp,omitempty"`
+}
+
+// IsDeprecated reports whether the definition is kept only for existing
+// objects
+func (d *ResourceDefinition) IsDeprecated() bool {
+	return d.Status == DefinitionStatusDeprecated
+}
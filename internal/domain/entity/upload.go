@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload statuses, matching the upload_status enum of resource_uploads
+const (
+	UploadStatusInitializing = "initializing"
+	UploadStatusPending      = "pending"
+	UploadStatusUploading    = "uploading"
+	UploadStatusProcessing   = "processing"
+	UploadStatusCompleting   = "completing"
+	UploadStatusCompleted    = "completed"
+	UploadStatusFailed       = "failed"
+	UploadStatusAborted      = "aborted"
+)
+
+// Upload types, matching the upload_type enum of resource_uploads
+const (
+	UploadTypeSimple    = "simple"
+	UploadTypeMultipart = "multipart"
+)
+
+// UploadStatuses lists every upload status in lifecycle order
+var UploadStatuses = []string{
+	UploadStatusInitializing,
+	UploadStatusPending,
+	UploadStatusUploading,
+	UploadStatusProcessing,
+	UploadStatusCompleting,
+	UploadStatusCompleted,
+	UploadStatusFailed,
+	UploadStatusAborted,
+}
+
+// TrackedUpload is an upload recorded by the upload manager in
+// resource_uploads. MultipartID, TotalParts and UploadedParts are only set
+// for multipart uploads.
+type TrackedUpload struct {
+	ID              uuid.UUID      `json:"id" db:"id"`
+	ResourceType    string         `json:"resource_type" db:"resource_type"`
+	ResourceID      string         `json:"resource_id" db:"resource_id"`
+	ResourceField   string         `json:"resource_field" db:"resource_field"`
+	UploadType      string         `json:"upload_type" db:"upload_type"`
+	Status          string         `json:"status" db:"upload_status"`
+	Error           string         `json:"error" db:"upload_error"`
+	PathDefinition  string         `json:"path_definition" db:"path_definition"`
+	PathParameters  map[string]any `json:"path_parameters" db:"path_parameters"`
+	StorageProvider string         `json:"storage_provider" db:"storage_provider"`
+	StorageKey      string         `json:"storage_key" db:"storage_key"`
+	StorageSize     *int64         `json:"storage_size" db:"storage_size"`
+	MultipartID     string         `json:"multipart_id" db:"storage_multipart_id"`
+	TotalParts      *int           `json:"total_parts" db:"total_parts"`
+	UploadedParts   *int           `json:"uploaded_parts" db:"uploaded_parts"`
+	CreateTime      time.Time      `json:"createTime" db:"create_time"`
+	UpdateTime      time.Time      `json:"updateTime" db:"update_time"`
+	CompletedTime   *time.Time     `json:"completedTime" db:"completed_time"`
+	ExpiresTime     time.Time      `json:"expiresTime" db:"expires_time"`
+}
+
+// IsFinished reports whether the upload reached a terminal status
+func (u *TrackedUpload) IsFinished() bool {
+	return u.Status == UploadStatusCompleted || u.Status == UploadStatusAborted
+}
+
+// IsMultipart reports whether the upload is a provider multipart upload
+func (u *TrackedUpload) IsMultipart() bool {
+	return u.UploadType == UploadTypeMultipart && u.MultipartID != ""
+}
+
+// IsValidUploadStatus reports whether status is a known upload status
+func IsValidUploadStatus(status string) bool {
+	return slices.Contains(UploadStatuses, status)
+}
+
+// UploadFilter narrows a listing of tracked uploads. Empty fields match
+// every upload.
+type UploadFilter struct {
+	Status       string
+	ResourceType string
+	Provider     string
+}
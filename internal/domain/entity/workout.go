@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Workout struct {
+	ID             uuid.UUID              `json:"id" db:"id"`
+	UserID         string                 `json:"user_id" db:"user_id"`
+	Name           string                 `json:"name" db:"name"`
+	Description    string                 `json:"description" db:"description"`
+	Format         string                 `json:"format" db:"format"`
+	FilePath       string                 `json:"file_path" db:"file_path"`
+	Provider       string                 `json:"provider" db:"provider"`
+	Scope          string                 `json:"scope" db:"scope"`
+	ScopeValue     int16                  `json:"scope_value" db:"scope_value"`
+	FileSize       int64                  `json:"file_size" db:"file_size"`
+	ChecksumSHA256 string                 `json:"checksum_sha256" db:"checksum_sha256"`
+	Metadata       map[string]interface{} `json:"metadata" db:"metadata"`
+	Summary        WorkoutSummary         `json:"summary"`
+	ConfirmedAt    *time.Time             `json:"confirmedAt,omitempty" db:"confirmed_at"`
+	CreatedAt      time.Time              `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time              `json:"updatedAt" db:"updated_at"`
+}
+
+// WorkoutSummary is extracted from the workout file after a confirmed upload.
+// Power targets are in watts for ERG files and percent of FTP for every other
+// format; PowerUnit records which one applies.
+type WorkoutSummary struct {
+	DurationSeconds int     `json:"duration_seconds" db:"duration_seconds"`
+	IntervalCount   int     `json:"interval_count" db:"interval_count"`
+	PowerUnit       string  `json:"power_unit" db:"power_unit"`
+	PowerMin        float64 `json:"power_min" db:"power_min"`
+	PowerMax        float64 `json:"power_max" db:"power_max"`
+	StrokeRateMin   int     `json:"stroke_rate_min" db:"stroke_rate_min"`
+	StrokeRateMax   int     `json:"stroke_rate_max" db:"stroke_rate_max"`
+}
+
+const (
+	PowerUnitWatts      = "watts"
+	PowerUnitFTPPercent = "ftp_percent"
+)
+
+func NewWorkout(userID, name, description string) *Workout {
+	return &Workout{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// SetFile points the workout at a new stored file. The size is unknown until
+// the upload is confirmed.
+func (w *Workout) SetFile(filePath, format, checksumSHA256 string) {
+	w.FilePath = filePath
+	w.Format = format
+	w.ChecksumSHA256 = checksumSHA256
+	w.FileSize = 0
+	w.Summary = WorkoutSummary{}
+	w.ConfirmedAt = nil
+	w.UpdatedAt = time.Now()
+}
+
+// Confirm records the confirmed upload of the current file
+func (w *Workout) Confirm(fileSize int64, summary WorkoutSummary) {
+	now := time.Now()
+	w.FileSize = fileSize
+	w.Summary = summary
+	w.ConfirmedAt = &now
+	w.UpdatedAt = now
+}
+
+// IsConfirmed reports whether the upload of the current file was confirmed
+func (w *Workout) IsConfirmed() bool {
+	return w.ConfirmedAt != nil
+}
+
+const conversionsMetadataKey = "conversions"
+
+// ConversionKey returns the cache key of the stored conversion to format, or
+// an empty string when none has been generated.
+func (w *Workout) ConversionKey(format string) string {
+	return w.Conversions()[format]
+}
+
+// Conversions lists converted copies of the workout file by format
+func (w *Workout) Conversions() map[string]string {
+	conversions := make(map[string]string)
+	raw, _ := w.Metadata[conversionsMetadataKey].(map[string]interface{})
+	for format, key := range raw {
+		if s, ok := key.(string); ok {
+			conversions[format] = s
+		}
+	}
+	return conversions
+}
+
+// SetConversion records a converted copy of the workout file
+func (w *Workout) SetConversion(format, cacheKey string) {
+	conversions := w.Conversions()
+	conversions[format] = cacheKey
+
+	raw := make(map[string]interface{}, len(conversions))
+	for f, key := range conversions {
+		raw[f] = key
+	}
+
+	if w.Metadata == nil {
+		w.Metadata = make(map[string]interface{})
+	}
+	w.Metadata[conversionsMetadataKey] = raw
+	w.UpdatedAt = time.Now()
+}
+
+// ClearConversions forgets all converted copies of the workout file
+func (w *Workout) ClearConversions() {
+	delete(w.Metadata, conversionsMetadataKey)
+}
+
+const staleFilesMetadataKey = "stale_files"
+
+// StaleFiles lists the paths of files the workout no longer points at. They
+// are kept until the file that replaced them has been confirmed.
+func (w *Workout) StaleFiles() []string {
+	raw, _ := w.Metadata[staleFilesMetadataKey].([]interface{})
+	paths := make([]string, 0, len(raw))
+	for _, path := range raw {
+		if s, ok := path.(string); ok {
+			paths = append(paths, s)
+		}
+	}
+	return paths
+}
+
+// AddStaleFiles records files to remove once the replacement is confirmed
+func (w *Workout) AddStaleFiles(paths ...string) {
+	stale := w.StaleFiles()
+	raw := make([]interface{}, 0, len(stale)+len(paths))
+	seen := make(map[string]bool, len(stale)+len(paths))
+	for _, path := range append(stale, paths...) {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		raw = append(raw, path)
+	}
+
+	if w.Metadata == nil {
+		w.Metadata = make(map[string]interface{})
+	}
+	w.Metadata[staleFilesMetadataKey] = raw
+	w.UpdatedAt = time.Now()
+}
+
+// ClearStaleFiles forgets the files left behind by earlier replacements
+func (w *Workout) ClearStaleFiles() {
+	delete(w.Metadata, staleFilesMetadataKey)
+}
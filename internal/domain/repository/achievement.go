@@ -2,17 +2,28 @@ package repository
 
 import (
 	"context"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/google/uuid"
 )
 
+// AchievementRepository stores achievements. Every change is written in one
+// transaction with the revision recording it, which is assigned the next
+// revision number of its achievement.
 type AchievementRepository interface {
-	Create(ctx context.Context, achievement *entity.Achievement) error
+	Create(ctx context.Context, achievement *entity.Achievement, revision *entity.AchievementRevision) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Achievement, error)
-	Update(ctx context.Context, achievement *entity.Achievement) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Update writes the achievement only while it is stored as
+	// revision.Previous, the state the change was made to, and returns
+	// ErrAchievementModified once another change was written.
+	Update(ctx context.Context, achievement *entity.Achievement, revision *entity.AchievementRevision) error
+	Delete(ctx context.Context, id uuid.UUID, revision *entity.AchievementRevision) error
 	List(ctx context.Context, offset, limit int) ([]*entity.Achievement, error)
 	ListActive(ctx context.Context, offset, limit int) ([]*entity.Achievement, error)
+	ListActiveAt(ctx context.Context, at time.Time, offset, limit int) ([]*entity.Achievement, error)
+	// ListPendingPublication returns scheduled achievements whose is_active flag
+	// no longer matches their publishing window at the given time.
+	ListPendingPublication(ctx context.Context, at time.Time) ([]*entity.Achievement, error)
 	ListByCategory(ctx context.Context, category string, offset, limit int) ([]*entity.Achievement, error)
-}
\ No newline at end of file
+}
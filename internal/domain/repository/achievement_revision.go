@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// AchievementRevisionRepository reads achievement history. Revisions are
+// written by AchievementRepository together with the change they record.
+type AchievementRevisionRepository interface {
+	GetByRevision(ctx context.Context, achievementID uuid.UUID, revision int) (*entity.AchievementRevision, error)
+	ListByAchievement(ctx context.Context, achievementID uuid.UUID, offset, limit int) ([]*entity.AchievementRevision, error)
+	// ListReleasedIcons returns up to limit icons that revisions older than
+	// before replaced or deleted, and that neither the achievement's current
+	// icon nor a revision since before still uses.
+	ListReleasedIcons(ctx context.Context, before time.Time, limit int) ([]*entity.ReleasedIcon, error)
+	// MarkIconPruned records that the released icon was deleted, so the
+	// revisions replacing it are no longer listed
+	MarkIconPruned(ctx context.Context, icon *entity.ReleasedIcon) error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// ContentBlobRepository tracks content-addressed blobs and the logical paths
+// referencing them.
+type ContentBlobRepository interface {
+	// GetReference returns the reference of a logical path, or nil when the
+	// path is not mapped. Paths match regardless of a leading slash; an empty
+	// provider matches the path on any provider.
+	GetReference(ctx context.Context, provider, path string) (*entity.ContentReference, error)
+	// ListReferences returns up to limit references whose path, ignoring a
+	// leading slash, starts with prefix and sorts after after, ordered by
+	// path in byte order. Non-empty definitions limit them to references
+	// created by those definitions.
+	ListReferences(ctx context.Context, provider string, definitions []string, prefix, after string, limit int) ([]*entity.ContentReference, error)
+	// MappedPaths returns which of the given paths are mapped to a blob on
+	// the provider.
+	MappedPaths(ctx context.Context, provider string, paths []string) (map[string]bool, error)
+	// IsBlobReferenced reports whether any path references the blob.
+	IsBlobReferenced(ctx context.Context, provider, blobKey string) (bool, error)
+	// AddReference maps the path to its blob, replacing the blob it was
+	// mapped to, and increments the blob's reference count. It reports
+	// whether the blob was created or had no references left, in which case
+	// its object may be missing, and returns the key of a replaced blob that
+	// no longer has references.
+	AddReference(ctx context.Context, ref *entity.ContentReference) (bool, string, error)
+	// RemoveReference unmaps the path and decrements its blob's reference
+	// count. It returns the removed reference, or nil when the path was not
+	// mapped, and reports whether the blob has no references left. The blob
+	// itself is left for DeleteBlob.
+	RemoveReference(ctx context.Context, provider, path string) (*entity.ContentReference, bool, error)
+	// DeleteBlob calls deleteObject and forgets the blob when it has no
+	// references, holding the blob locked so new references to it wait. It
+	// reports whether the blob was deleted.
+	DeleteBlob(ctx context.Context, provider, blobKey string, deleteObject func(blobKey string) error) (bool, error)
+}
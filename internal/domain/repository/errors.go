@@ -0,0 +1,25 @@
+package repository
+
+import "github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+
+// Errors returned by GetByID and similar lookups when no row matches
+var (
+	ErrAchievementNotFound         = domainerr.New(domainerr.NotFound, "ACHIEVEMENT_NOT_FOUND", "achievement not found")
+	ErrAchievementRevisionNotFound = domainerr.New(domainerr.NotFound, "REVISION_NOT_FOUND", "achievement revision not found")
+	ErrUploadNotFound              = domainerr.New(domainerr.NotFound, "UPLOAD_NOT_FOUND", "upload not found")
+	ErrReplicationJobNotFound      = domainerr.New(domainerr.NotFound, "REPLICATION_JOB_NOT_FOUND", "replication job not found")
+	ErrWorkoutNotFound             = domainerr.New(domainerr.NotFound, "WORKOUT_NOT_FOUND", "workout not found")
+)
+
+// Errors returned by conditional updates when the row has moved on
+var (
+	// ErrReplicationJobFinished is returned when updating the status of a job
+	// that already reached a terminal status
+	ErrReplicationJobFinished = domainerr.New(domainerr.PreconditionFailed, "JOB_FINISHED", "replication job already finished")
+	// ErrReplicationLeaseLost is returned when saving progress of a job whose
+	// lease was claimed by another instance
+	ErrReplicationLeaseLost = domainerr.New(domainerr.Conflict, "JOB_LEASE_LOST", "replication job lease lost")
+	// ErrAchievementModified is returned when updating an achievement that
+	// another change was written to since it was read
+	ErrAchievementModified = domainerr.New(domainerr.Conflict, "ACHIEVEMENT_MODIFIED", "achievement was modified concurrently")
+)
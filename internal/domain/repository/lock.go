@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// Locker serializes work that only one server instance may perform at a
+// time, such as the periodic background runs
+type Locker interface {
+	// TryRun runs fn while holding the named lock. It reports false without
+	// running fn when another instance holds the lock.
+	TryRun(ctx context.Context, name string, fn func() error) (bool, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// PathReferenceRepository keeps stored paths referenced by uploads and
+// entities in sync when objects move.
+type PathReferenceRepository interface {
+	// RewritePath points every reference to oldPath on the provider at newPath,
+	// including the content reference mapping oldPath to its blob, and
+	// returns the number of rows updated. Achievements changed are recorded
+	// in their history as changes made by actor.
+	RewritePath(ctx context.Context, provider, oldPath, newPath, actor string) (int64, error)
+}
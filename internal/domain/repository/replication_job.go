@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type ReplicationJobRepository interface {
+	Create(ctx context.Context, job *entity.ReplicationJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ReplicationJob, error)
+	List(ctx context.Context, offset, limit int) ([]*entity.ReplicationJob, error)
+	// ClaimNext marks the oldest pending job, or running job whose lease has
+	// expired, as running under owner's lease and returns it. It returns nil
+	// when no job is available. Jobs locked by a concurrent claim are skipped.
+	ClaimNext(ctx context.Context, owner string, lease time.Duration) (*entity.ReplicationJob, error)
+	// UpdateStatus records the status of a pending or running job. It returns
+	// ErrReplicationJobFinished when the job already reached a terminal status.
+	UpdateStatus(ctx context.Context, job *entity.ReplicationJob) error
+	// SavePage records the items of a processed listing page, advances the
+	// job's continuation token, refreshes its counters and renews its lease
+	// in one transaction. It returns ErrReplicationLeaseLost when the job's
+	// lease is no longer held by job.LeaseOwner.
+	SavePage(ctx context.Context, job *entity.ReplicationJob, items []*entity.ReplicationItem) error
+	// CompletedKeys returns which of the source keys were already copied or
+	// skipped by the job
+	CompletedKeys(ctx context.Context, jobID uuid.UUID, sourceKeys []string) (map[string]bool, error)
+	ListItems(ctx context.Context, jobID uuid.UUID, status string, offset, limit int) ([]*entity.ReplicationItem, error)
+}
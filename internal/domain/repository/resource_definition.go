@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// ResourceDefinitionRepository stores runtime resource definitions and their
+// version history. Every change is announced to all server instances.
+type ResourceDefinitionRepository interface {
+	// Create stores the first version of a definition. It reports false when
+	// a definition with the same name exists.
+	Create(ctx context.Context, def *entity.ResourceDefinition) (bool, error)
+	// Update stores def as a new version when the stored version is still
+	// previousVersion. It reports false when the definition changed since.
+	Update(ctx context.Context, def *entity.ResourceDefinition, previousVersion int, migrated bool) (bool, error)
+	// GetByName returns the current version of a definition, or nil when it
+	// does not exist
+	GetByName(ctx context.Context, name string) (*entity.ResourceDefinition, error)
+	List(ctx context.Context) ([]*entity.ResourceDefinition, error)
+	// ListVersions returns every version of a definition, newest first
+	ListVersions(ctx context.Context, name string) ([]*entity.ResourceDefinitionVersion, error)
+	// Watch calls onChange with the name of every definition changed by any
+	// instance until ctx is done or the connection fails. onChange is first
+	// called with an empty name once listening starts, so callers can catch
+	// up on changes made while they were not listening.
+	Watch(ctx context.Context, onChange func(name string)) error
+}
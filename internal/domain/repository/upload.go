@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type UploadRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.TrackedUpload, error)
+	// List returns the uploads matching the filter, newest first
+	List(ctx context.Context, filter entity.UploadFilter, offset, limit int) ([]*entity.TrackedUpload, error)
+	// ListExpired returns unfinished uploads whose URLs expired before the
+	// given time, oldest first
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*entity.TrackedUpload, error)
+	// MarkAborted moves an unfinished upload to aborted, recording the reason.
+	// It reports false when the upload was already finished.
+	MarkAborted(ctx context.Context, id uuid.UUID, reason string) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type WorkoutRepository interface {
+	Create(ctx context.Context, workout *entity.Workout) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Workout, error)
+	Update(ctx context.Context, workout *entity.Workout) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByUser(ctx context.Context, userID string, offset, limit int) ([]*entity.Workout, error)
+}
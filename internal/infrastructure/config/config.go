@@ -2,18 +2,25 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server    ServerConfig    `yaml:"server"`
-	Database  DatabaseConfig  `yaml:"database"`
-	CORS      CORSConfig      `yaml:"cors"`
-	Providers ProvidersConfig `yaml:"providers"`
-	Logging   LoggingConfig   `yaml:"logging"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Providers   ProvidersConfig   `yaml:"providers"`
+	Logging     LoggingConfig     `yaml:"logging"`
+	Scheduler   SchedulerConfig   `yaml:"scheduler"`
+	Replication ReplicationConfig `yaml:"replication"`
+	Download    DownloadConfig    `yaml:"download"`
+	Uploads     UploadsConfig     `yaml:"uploads"`
+	Admin       AdminConfig       `yaml:"admin"`
 }
 
 type ServerConfig struct {
@@ -21,6 +28,25 @@ type ServerConfig struct {
 	Host         string        `yaml:"host"`
 	ReadTimeout  time.Duration `yaml:"read_timeout"`
 	WriteTimeout time.Duration `yaml:"write_timeout"`
+	// GRPCPort serves the gRPC API alongside the REST API; zero disables it
+	GRPCPort int `yaml:"grpc_port"`
+	// BodyLimit is the largest request body in bytes accepted by routes
+	// other than streamed content uploads
+	BodyLimit int `yaml:"body_limit"`
+	// ProxyHeader names the header holding the client IP set by a reverse
+	// proxy, e.g. "X-Forwarded-For". It is only read from TrustedProxies;
+	// empty uses the connection's remote address.
+	ProxyHeader string `yaml:"proxy_header"`
+	// TrustedProxies lists the proxy IPs and CIDR ranges whose ProxyHeader
+	// is honored
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	// RequestTimeout bounds how long a request's usecase calls may run;
+	// zero disables the deadline
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// RouteTimeouts overrides RequestTimeout for single routes, keyed by
+	// method and route path, e.g. "GET /api/v1/resources/:provider/*/content".
+	// Zero disables the deadline for the route.
+	RouteTimeouts map[string]time.Duration `yaml:"route_timeouts"`
 }
 
 type DatabaseConfig struct {
@@ -57,6 +83,42 @@ type ProviderConfig struct {
 	CredentialsFile string      `yaml:"credentials_file,omitempty"`
 }
 
+type SchedulerConfig struct {
+	PublicationInterval time.Duration `yaml:"publication_interval"`
+	// IconRetention is how long replaced achievement icons are kept so the
+	// revisions replacing them can be reverted with their icon
+	IconRetention time.Duration `yaml:"icon_retention"`
+}
+
+type ReplicationConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Lease is how long a claimed job stays with its instance without
+	// progress before another instance may resume it
+	Lease time.Duration `yaml:"lease"`
+}
+
+// UploadsConfig controls the cleanup of tracked uploads
+type UploadsConfig struct {
+	// JanitorInterval is how often expired uploads are aborted
+	JanitorInterval time.Duration `yaml:"janitor_interval"`
+}
+
+// AdminConfig controls access to the admin API
+type AdminConfig struct {
+	// Tokens are the bearer tokens admitted to the admin routes; without any,
+	// every admin request is rejected
+	Tokens []string `yaml:"tokens"`
+}
+
+// DownloadConfig controls downloads streamed through the server
+type DownloadConfig struct {
+	// BytesPerSecond limits each client's download throughput; zero disables it
+	BytesPerSecond int64 `yaml:"bytes_per_second"`
+	// BundleCacheTTL is how long a cached bundle is served before it is
+	// rebuilt; expired bundles are deleted as new ones are cached
+	BundleCacheTTL time.Duration `yaml:"bundle_cache_ttl"`
+}
+
 type LoggingConfig struct {
 	Level  string `yaml:"level"`
 	Format string `yaml:"format"`
@@ -92,6 +154,48 @@ func (c *Config) validate() error {
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
+	if c.Server.GRPCPort < 0 || c.Server.GRPCPort > 65535 {
+		return fmt.Errorf("invalid gRPC port: %d", c.Server.GRPCPort)
+	}
+	if c.Server.GRPCPort == c.Server.Port {
+		return fmt.Errorf("gRPC port %d is already used by the REST API", c.Server.GRPCPort)
+	}
+
+	if c.Server.BodyLimit < 0 {
+		return fmt.Errorf("invalid body limit: %d", c.Server.BodyLimit)
+	}
+	if c.Server.ProxyHeader != "" && len(c.Server.TrustedProxies) == 0 {
+		return fmt.Errorf("proxy header %s requires trusted proxies", c.Server.ProxyHeader)
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+		}
+	}
+	if c.Server.RequestTimeout < 0 {
+		return fmt.Errorf("invalid request timeout: %s", c.Server.RequestTimeout)
+	}
+	for route, timeout := range c.Server.RouteTimeouts {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || method == "" || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid route %q: expected a method and a path such as \"GET /api/v1/health\"", route)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("invalid timeout for route %q: %s", route, timeout)
+		}
+	}
+
+	if c.Scheduler.IconRetention < 0 {
+		return fmt.Errorf("invalid icon retention: %s", c.Scheduler.IconRetention)
+	}
+	if c.Replication.Lease < 0 {
+		return fmt.Errorf("invalid replication lease: %s", c.Replication.Lease)
+	}
+	if c.Download.BundleCacheTTL < 0 {
+		return fmt.Errorf("invalid bundle cache TTL: %s", c.Download.BundleCacheTTL)
+	}
 
 	if c.Server.Host == "" {
 		return fmt.Errorf("server host cannot be empty")
@@ -114,6 +218,30 @@ func (c *Config) setDefaults() {
 	if c.Server.WriteTimeout == 0 {
 		c.Server.WriteTimeout = 30 * time.Second
 	}
+	if c.Server.BodyLimit == 0 {
+		c.Server.BodyLimit = 4 << 20
+	}
+	if c.Download.BundleCacheTTL == 0 {
+		c.Download.BundleCacheTTL = 24 * time.Hour
+	}
+
+	if c.Scheduler.PublicationInterval == 0 {
+		c.Scheduler.PublicationInterval = time.Minute
+	}
+	if c.Scheduler.IconRetention == 0 {
+		c.Scheduler.IconRetention = 30 * 24 * time.Hour
+	}
+
+	if c.Replication.PollInterval == 0 {
+		c.Replication.PollInterval = 10 * time.Second
+	}
+	if c.Replication.Lease == 0 {
+		c.Replication.Lease = 15 * time.Minute
+	}
+
+	if c.Uploads.JanitorInterval == 0 {
+		c.Uploads.JanitorInterval = 15 * time.Minute
+	}
 
 	if c.Logging.Level == "" {
 		c.Logging.Level = "info"
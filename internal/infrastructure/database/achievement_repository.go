@@ -2,12 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/anh-nguyen/resource-server/internal/domain/entity"
 	"github.com/anh-nguyen/resource-server/internal/domain/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,177 +21,225 @@ func NewAchievementRepository(db *pgxpool.Pool) repository.AchievementRepository
 	return &achievementRepository{db: db}
 }
 
-func (r *achievementRepository) Create(ctx context.Context, achievement *entity.Achievement) error {
+const achievementColumns = `
+	id, name, description, icon_path, icon_provider, banner_path, banner_provider,
+	category, points, is_active, publish_at, unpublish_at,
+	metadata, created_at, updated_at`
+
+func (r *achievementRepository) Create(ctx context.Context, achievement *entity.Achievement, revision *entity.AchievementRevision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO achievements (
-			id, name, description, icon_path, banner_path, 
-			category, points, is_active, metadata, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		INSERT INTO achievements (` + achievementColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		achievement.ID,
 		achievement.Name,
 		achievement.Description,
 		achievement.IconPath,
+		achievement.IconProvider,
 		achievement.BannerPath,
+		achievement.BannerProvider,
 		achievement.Category,
 		achievement.Points,
 		achievement.IsActive,
+		achievement.PublishAt,
+		achievement.UnpublishAt,
 		achievement.Metadata,
 		achievement.CreatedAt,
 		achievement.UpdatedAt,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create achievement: %w", classifyError(err))
+	}
 
-	return err
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *achievementRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Achievement, error) {
-	query := `
-		SELECT id, name, description, icon_path, banner_path,
-		       category, points, is_active, metadata, created_at, updated_at
-		FROM achievements
-		WHERE id = $1`
-
-	var achievement entity.Achievement
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&achievement.ID,
-		&achievement.Name,
-		&achievement.Description,
-		&achievement.IconPath,
-		&achievement.BannerPath,
-		&achievement.Category,
-		&achievement.Points,
-		&achievement.IsActive,
-		&achievement.Metadata,
-		&achievement.CreatedAt,
-		&achievement.UpdatedAt,
-	)
+	query := `SELECT ` + achievementColumns + ` FROM achievements WHERE id = $1`
 
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("achievement not found")
+	achievement, err := scanAchievement(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAchievementNotFound
 	}
 
-	return &achievement, err
+	return achievement, err
 }
 
-func (r *achievementRepository) Update(ctx context.Context, achievement *entity.Achievement) error {
+func (r *achievementRepository) Update(ctx context.Context, achievement *entity.Achievement, revision *entity.AchievementRevision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE achievements
-		SET name = $2, description = $3, icon_path = $4, banner_path = $5,
-		    category = $6, points = $7, is_active = $8, metadata = $9, updated_at = $10
-		WHERE id = $1`
+		SET name = $2, description = $3, icon_path = $4, icon_provider = $5, banner_path = $6,
+		    banner_provider = $7, category = $8, points = $9, is_active = $10, publish_at = $11,
+		    unpublish_at = $12, metadata = $13, updated_at = $14
+		WHERE id = $1 AND updated_at IS NOT DISTINCT FROM $15`
 
-	_, err := r.db.Exec(ctx, query,
+	tag, err := tx.Exec(ctx, query,
 		achievement.ID,
 		achievement.Name,
 		achievement.Description,
 		achievement.IconPath,
+		achievement.IconProvider,
 		achievement.BannerPath,
+		achievement.BannerProvider,
 		achievement.Category,
 		achievement.Points,
 		achievement.IsActive,
+		achievement.PublishAt,
+		achievement.UnpublishAt,
 		achievement.Metadata,
 		achievement.UpdatedAt,
+		revision.Previous.UpdatedAt,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to update achievement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM achievements WHERE id = $1)`, achievement.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to update achievement: %w", err)
+		}
+		if exists {
+			return repository.ErrAchievementModified
+		}
+		return repository.ErrAchievementNotFound
+	}
 
-	return err
-}
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return err
+	}
 
-func (r *achievementRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM achievements WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	return tx.Commit(ctx)
 }
 
-func (r *achievementRepository) List(ctx context.Context, offset, limit int) ([]*entity.Achievement, error) {
-	query := `
-		SELECT id, name, description, icon_path, banner_path,
-		       category, points, is_active, metadata, created_at, updated_at
-		FROM achievements
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
+func (r *achievementRepository) Delete(ctx context.Context, id uuid.UUID, revision *entity.AchievementRevision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	tag, err := tx.Exec(ctx, `DELETE FROM achievements WHERE id = $1`, id)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to delete achievement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrAchievementNotFound
 	}
-	defer rows.Close()
 
-	var achievements []*entity.Achievement
-	for rows.Next() {
-		var achievement entity.Achievement
-		err := rows.Scan(
-			&achievement.ID,
-			&achievement.Name,
-			&achievement.Description,
-			&achievement.IconPath,
-			&achievement.BannerPath,
-			&achievement.Category,
-			&achievement.Points,
-			&achievement.IsActive,
-			&achievement.Metadata,
-			&achievement.CreatedAt,
-			&achievement.UpdatedAt,
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertRevision stores revision with the next revision number of its
+// achievement. The advisory lock serializes the changes to one achievement,
+// including deleted ones whose row is gone, so concurrent changes never
+// compete for the same number.
+func insertRevision(ctx context.Context, tx pgx.Tx, revision *entity.AchievementRevision) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))`, revision.AchievementID); err != nil {
+		return fmt.Errorf("failed to lock achievement history: %w", err)
+	}
+
+	err := tx.QueryRow(ctx, `
+		INSERT INTO achievement_revisions (
+			id, achievement_id, revision, action, previous,
+			changes, icon_provider, actor, created_at
 		)
-		if err != nil {
-			return nil, err
-		}
-		achievements = append(achievements, &achievement)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4, $5, $6, $7, $8
+		FROM achievement_revisions
+		WHERE achievement_id = $2
+		RETURNING revision`,
+		revision.ID,
+		revision.AchievementID,
+		revision.Action,
+		revision.Previous,
+		revision.Changes,
+		revision.IconProvider,
+		revision.Actor,
+		revision.CreatedAt,
+	).Scan(&revision.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to record achievement revision: %w", classifyError(err))
 	}
 
-	return achievements, nil
+	return nil
 }
 
-func (r *achievementRepository) ListActive(ctx context.Context, offset, limit int) ([]*entity.Achievement, error) {
+func (r *achievementRepository) List(ctx context.Context, offset, limit int) ([]*entity.Achievement, error) {
 	query := `
-		SELECT id, name, description, icon_path, banner_path,
-		       category, points, is_active, metadata, created_at, updated_at
+		SELECT ` + achievementColumns + `
 		FROM achievements
-		WHERE is_active = true
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return r.query(ctx, query, limit, offset)
+}
 
-	var achievements []*entity.Achievement
-	for rows.Next() {
-		var achievement entity.Achievement
-		err := rows.Scan(
-			&achievement.ID,
-			&achievement.Name,
-			&achievement.Description,
-			&achievement.IconPath,
-			&achievement.BannerPath,
-			&achievement.Category,
-			&achievement.Points,
-			&achievement.IsActive,
-			&achievement.Metadata,
-			&achievement.CreatedAt,
-			&achievement.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		achievements = append(achievements, &achievement)
-	}
+func (r *achievementRepository) ListActive(ctx context.Context, offset, limit int) ([]*entity.Achievement, error) {
+	return r.ListActiveAt(ctx, time.Now(), offset, limit)
+}
+
+// activeAtCondition mirrors entity.Achievement.ActiveAt: a publishing window,
+// when set, takes precedence over the manual is_active flag.
+const activeAtCondition = `
+		CASE WHEN publish_at IS NULL AND unpublish_at IS NULL THEN is_active
+		     ELSE (publish_at IS NULL OR publish_at <= $1) AND (unpublish_at IS NULL OR unpublish_at > $1)
+		END`
+
+func (r *achievementRepository) ListActiveAt(ctx context.Context, at time.Time, offset, limit int) ([]*entity.Achievement, error) {
+	query := `
+		SELECT ` + achievementColumns + `
+		FROM achievements
+		WHERE` + activeAtCondition + `
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
 
-	return achievements, nil
+	return r.query(ctx, query, at, limit, offset)
+}
+
+func (r *achievementRepository) ListPendingPublication(ctx context.Context, at time.Time) ([]*entity.Achievement, error) {
+	query := `
+		SELECT ` + achievementColumns + `
+		FROM achievements
+		WHERE (publish_at IS NOT NULL OR unpublish_at IS NOT NULL)
+		  AND is_active <> (` + activeAtCondition + `)
+		ORDER BY created_at`
+
+	return r.query(ctx, query, at)
 }
 
 func (r *achievementRepository) ListByCategory(ctx context.Context, category string, offset, limit int) ([]*entity.Achievement, error) {
 	query := `
-		SELECT id, name, description, icon_path, banner_path,
-		       category, points, is_active, metadata, created_at, updated_at
+		SELECT ` + achievementColumns + `
 		FROM achievements
 		WHERE category = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.Query(ctx, query, category, limit, offset)
+	return r.query(ctx, query, category, limit, offset)
+}
+
+func (r *achievementRepository) query(ctx context.Context, query string, args ...any) ([]*entity.Achievement, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -197,25 +247,37 @@ func (r *achievementRepository) ListByCategory(ctx context.Context, category str
 
 	var achievements []*entity.Achievement
 	for rows.Next() {
-		var achievement entity.Achievement
-		err := rows.Scan(
-			&achievement.ID,
-			&achievement.Name,
-			&achievement.Description,
-			&achievement.IconPath,
-			&achievement.BannerPath,
-			&achievement.Category,
-			&achievement.Points,
-			&achievement.IsActive,
-			&achievement.Metadata,
-			&achievement.CreatedAt,
-			&achievement.UpdatedAt,
-		)
+		achievement, err := scanAchievement(rows)
 		if err != nil {
 			return nil, err
 		}
-		achievements = append(achievements, &achievement)
+		achievements = append(achievements, achievement)
 	}
 
-	return achievements, nil
+	return achievements, rows.Err()
+}
+
+func scanAchievement(row pgx.Row) (*entity.Achievement, error) {
+	var achievement entity.Achievement
+	err := row.Scan(
+		&achievement.ID,
+		&achievement.Name,
+		&achievement.Description,
+		&achievement.IconPath,
+		&achievement.IconProvider,
+		&achievement.BannerPath,
+		&achievement.BannerProvider,
+		&achievement.Category,
+		&achievement.Points,
+		&achievement.IsActive,
+		&achievement.PublishAt,
+		&achievement.UnpublishAt,
+		&achievement.Metadata,
+		&achievement.CreatedAt,
+		&achievement.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &achievement, nil
 }
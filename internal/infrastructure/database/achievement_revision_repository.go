@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type achievementRevisionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAchievementRevisionRepository(db *pgxpool.Pool) repository.AchievementRevisionRepository {
+	return &achievementRevisionRepository{db: db}
+}
+
+func (r *achievementRevisionRepository) GetByRevision(ctx context.Context, achievementID uuid.UUID, revision int) (*entity.AchievementRevision, error) {
+	query := `
+		SELECT id, achievement_id, revision, action, previous,
+		       changes, COALESCE(icon_provider, ''), actor, created_at
+		FROM achievement_revisions
+		WHERE achievement_id = $1 AND revision = $2`
+
+	var rev entity.AchievementRevision
+	err := r.db.QueryRow(ctx, query, achievementID, revision).Scan(
+		&rev.ID,
+		&rev.AchievementID,
+		&rev.Revision,
+		&rev.Action,
+		&rev.Previous,
+		&rev.Changes,
+		&rev.IconProvider,
+		&rev.Actor,
+		&rev.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAchievementRevisionNotFound
+	}
+
+	return &rev, err
+}
+
+func (r *achievementRevisionRepository) ListByAchievement(ctx context.Context, achievementID uuid.UUID, offset, limit int) ([]*entity.AchievementRevision, error) {
+	query := `
+		SELECT id, achievement_id, revision, action, previous,
+		       changes, COALESCE(icon_provider, ''), actor, created_at
+		FROM achievement_revisions
+		WHERE achievement_id = $1
+		ORDER BY revision DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, achievementID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*entity.AchievementRevision
+	for rows.Next() {
+		var rev entity.AchievementRevision
+		err := rows.Scan(
+			&rev.ID,
+			&rev.AchievementID,
+			&rev.Revision,
+			&rev.Action,
+			&rev.Previous,
+			&rev.Changes,
+			&rev.IconProvider,
+			&rev.Actor,
+			&rev.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *achievementRevisionRepository) ListReleasedIcons(ctx context.Context, before time.Time, limit int) ([]*entity.ReleasedIcon, error) {
+	// Snapshots taken before achievements recorded their icon provider fall
+	// back to the provider recorded with the revision
+	query := `
+		SELECT DISTINCT r.achievement_id,
+		       COALESCE(NULLIF(r.previous->>'icon_provider', ''), r.icon_provider, ''),
+		       r.previous->>'icon_path'
+		FROM achievement_revisions r
+		LEFT JOIN achievements a ON a.id = r.achievement_id
+		WHERE r.icon_pruned_at IS NULL
+		  AND r.previous IS NOT NULL
+		  AND r.created_at < $1
+		  AND COALESCE(r.previous->>'icon_path', '') <> ''
+		  AND COALESCE(NULLIF(r.previous->>'icon_provider', ''), r.icon_provider, '') <> ''
+		  AND r.previous->>'icon_path' IS DISTINCT FROM a.icon_path
+		  AND NOT EXISTS (
+		      SELECT 1 FROM achievement_revisions n
+		      WHERE n.achievement_id = r.achievement_id
+		        AND n.created_at >= $1
+		        AND n.previous->>'icon_path' = r.previous->>'icon_path'
+		  )
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var icons []*entity.ReleasedIcon
+	for rows.Next() {
+		var icon entity.ReleasedIcon
+		if err := rows.Scan(&icon.AchievementID, &icon.Provider, &icon.Path); err != nil {
+			return nil, err
+		}
+		icons = append(icons, &icon)
+	}
+
+	return icons, rows.Err()
+}
+
+func (r *achievementRevisionRepository) MarkIconPruned(ctx context.Context, icon *entity.ReleasedIcon) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE achievement_revisions
+		SET icon_pruned_at = NOW()
+		WHERE achievement_id = $1 AND previous->>'icon_path' = $2 AND icon_pruned_at IS NULL`,
+		icon.AchievementID, icon.Path,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark icon pruned: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type advisoryLocker struct {
+	db *pgxpool.Pool
+}
+
+// NewAdvisoryLocker returns a Locker backed by PostgreSQL session advisory
+// locks, which the database releases when the holding connection drops
+func NewAdvisoryLocker(db *pgxpool.Pool) repository.Locker {
+	return &advisoryLocker{db: db}
+}
+
+func (l *advisoryLocker) TryRun(ctx context.Context, name string, fn func() error) (bool, error) {
+	// Session locks belong to a connection, so the same connection takes
+	// and releases the lock
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtextextended($1, 0))`, name).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("failed to take lock %s: %w", name, err)
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		// Unlock even when ctx is done; a failed unlock closes the
+		// connection, which releases the lock as well
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtextextended($1, 0))`, name); err != nil {
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	return true, fn()
+}
@@ -0,0 +1,318 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type contentBlobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewContentBlobRepository(db *pgxpool.Pool) repository.ContentBlobRepository {
+	return &contentBlobRepository{db: db}
+}
+
+const contentReferenceColumns = `
+	r.provider, r.path, r.blob_key, r.definition, b.checksum_sha256, b.size, r.created_at`
+
+func (r *contentBlobRepository) GetReference(ctx context.Context, provider, path string) (*entity.ContentReference, error) {
+	query := `
+		SELECT ` + contentReferenceColumns + `
+		FROM content_references r
+		JOIN content_blobs b ON b.provider = r.provider AND b.blob_key = r.blob_key
+		WHERE ltrim(r.path, '/') = ltrim($2, '/') AND ($1 = '' OR r.provider = $1)
+		LIMIT 1`
+
+	ref, err := scanContentReference(r.db.QueryRow(ctx, query, provider, path))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return ref, err
+}
+
+func (r *contentBlobRepository) ListReferences(ctx context.Context, provider string, definitions []string, prefix, after string, limit int) ([]*entity.ContentReference, error) {
+	query := `
+		SELECT ` + contentReferenceColumns + `
+		FROM content_references r
+		JOIN content_blobs b ON b.provider = r.provider AND b.blob_key = r.blob_key
+		WHERE r.provider = $1 AND starts_with(ltrim(r.path, '/'), ltrim($2, '/'))
+		  AND ltrim(r.path, '/') COLLATE "C" > ltrim($3, '/')
+		  AND (coalesce(cardinality($5::text[]), 0) = 0 OR r.definition = ANY($5))
+		ORDER BY ltrim(r.path, '/') COLLATE "C"
+		LIMIT $4`
+
+	rows, err := r.db.Query(ctx, query, provider, prefix, after, limit, definitions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []*entity.ContentReference
+	for rows.Next() {
+		ref, err := scanContentReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
+
+func (r *contentBlobRepository) MappedPaths(ctx context.Context, provider string, paths []string) (map[string]bool, error) {
+	mapped := make(map[string]bool)
+	if len(paths) == 0 {
+		return mapped, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT p.path
+		FROM unnest($2::text[]) AS p(path)
+		WHERE EXISTS (
+			SELECT 1 FROM content_references r
+			WHERE r.provider = $1 AND ltrim(r.path, '/') = ltrim(p.path, '/')
+		)`,
+		provider, paths,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		mapped[path] = true
+	}
+
+	return mapped, rows.Err()
+}
+
+func (r *contentBlobRepository) IsBlobReferenced(ctx context.Context, provider, blobKey string) (bool, error) {
+	var referenced bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM content_blobs
+			WHERE provider = $1 AND blob_key = $2 AND ref_count > 0
+		)`,
+		provider, blobKey,
+	).Scan(&referenced)
+	return referenced, err
+}
+
+func (r *contentBlobRepository) AddReference(ctx context.Context, ref *entity.ContentReference) (bool, string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// Concurrent mappings of the same path queue here rather than racing to
+	// insert the reference
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, referenceLockName(ref.Provider, ref.Path)); err != nil {
+		return false, "", fmt.Errorf("failed to lock content reference: %w", err)
+	}
+
+	var previous string
+	err = tx.QueryRow(ctx, `
+		SELECT blob_key FROM content_references
+		WHERE provider = $1 AND ltrim(path, '/') = ltrim($2, '/')`,
+		ref.Provider, ref.Path,
+	).Scan(&previous)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, "", fmt.Errorf("failed to get content reference: %w", err)
+	}
+	if previous == ref.BlobKey {
+		return false, "", tx.Commit(ctx)
+	}
+
+	// The upsert waits for a concurrent DeleteBlob of the same blob. A blob
+	// back from zero references may have lost its object, so it counts as
+	// created as well.
+	var refCount int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO content_blobs (provider, blob_key, checksum_sha256, size, ref_count)
+		VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (provider, blob_key) DO UPDATE
+		SET ref_count = content_blobs.ref_count + 1, updated_at = NOW()
+		RETURNING ref_count`,
+		ref.Provider, ref.BlobKey, ref.ChecksumSHA256, ref.Size,
+	).Scan(&refCount)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to reference content blob: %w", err)
+	}
+	created := refCount == 1
+
+	var orphaned string
+	if previous != "" {
+		_, err = tx.Exec(ctx, `
+			UPDATE content_references
+			SET blob_key = $3, definition = $4, created_at = $5
+			WHERE provider = $1 AND ltrim(path, '/') = ltrim($2, '/')`,
+			ref.Provider, ref.Path, ref.BlobKey, ref.Definition, ref.CreatedAt,
+		)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to update content reference: %w", err)
+		}
+
+		if orphaned, err = releaseBlob(ctx, tx, ref.Provider, previous); err != nil {
+			return false, "", err
+		}
+	} else {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO content_references (provider, path, blob_key, definition, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			ref.Provider, ref.Path, ref.BlobKey, ref.Definition, ref.CreatedAt,
+		)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to create content reference: %w", classifyError(err))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, "", err
+	}
+
+	return created, orphaned, nil
+}
+
+func (r *contentBlobRepository) RemoveReference(ctx context.Context, provider, path string) (*entity.ContentReference, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, referenceLockName(provider, path)); err != nil {
+		return nil, false, fmt.Errorf("failed to lock content reference: %w", err)
+	}
+
+	ref, err := scanContentReference(tx.QueryRow(ctx, `
+		SELECT `+contentReferenceColumns+`
+		FROM content_references r
+		JOIN content_blobs b ON b.provider = r.provider AND b.blob_key = r.blob_key
+		WHERE r.provider = $1 AND ltrim(r.path, '/') = ltrim($2, '/')`,
+		provider, path,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get content reference: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM content_references
+		WHERE provider = $1 AND ltrim(path, '/') = ltrim($2, '/')`,
+		provider, path,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to delete content reference: %w", err)
+	}
+
+	orphaned, err := releaseBlob(ctx, tx, provider, ref.BlobKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, err
+	}
+
+	return ref, orphaned != "", nil
+}
+
+func (r *contentBlobRepository) DeleteBlob(ctx context.Context, provider, blobKey string, deleteObject func(blobKey string) error) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var refCount int
+	err = tx.QueryRow(ctx, `
+		SELECT ref_count FROM content_blobs
+		WHERE provider = $1 AND blob_key = $2
+		FOR UPDATE`,
+		provider, blobKey,
+	).Scan(&refCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock content blob: %w", err)
+	}
+	if refCount > 0 {
+		return false, nil
+	}
+
+	// A failed commit leaves the row without references, which the next
+	// AddReference of the blob treats as created and stores again
+	if err := deleteObject(blobKey); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM content_blobs WHERE provider = $1 AND blob_key = $2`, provider, blobKey); err != nil {
+		return false, fmt.Errorf("failed to delete content blob: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// releaseBlob decrements a blob's reference count and returns its key when no
+// references remain. The blob is kept until DeleteBlob runs after the commit.
+func releaseBlob(ctx context.Context, tx pgx.Tx, provider, blobKey string) (string, error) {
+	var refCount int
+	err := tx.QueryRow(ctx, `
+		UPDATE content_blobs
+		SET ref_count = ref_count - 1, updated_at = NOW()
+		WHERE provider = $1 AND blob_key = $2
+		RETURNING ref_count`,
+		provider, blobKey,
+	).Scan(&refCount)
+	if err != nil {
+		return "", fmt.Errorf("failed to release content blob: %w", err)
+	}
+
+	if refCount > 0 {
+		return "", nil
+	}
+	return blobKey, nil
+}
+
+// referenceLockName names the advisory lock serializing changes to the
+// mapping of one path
+func referenceLockName(provider, path string) string {
+	return "content_reference:" + provider + ":" + strings.TrimLeft(path, "/")
+}
+
+func scanContentReference(row pgx.Row) (*entity.ContentReference, error) {
+	var ref entity.ContentReference
+	err := row.Scan(
+		&ref.Provider,
+		&ref.Path,
+		&ref.BlobKey,
+		&ref.Definition,
+		&ref.ChecksumSHA256,
+		&ref.Size,
+		&ref.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the Postgres error code of unique constraint violations
+const uniqueViolation = "23505"
+
+// classifyError classifies unique constraint violations as conflicts, so a
+// write racing with another one that stored the same key reaches the client
+// as such rather than as an internal error
+func classifyError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domainerr.Wrap(domainerr.Conflict, "ALREADY_EXISTS", err)
+	}
+	return err
+}
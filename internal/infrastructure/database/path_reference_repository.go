@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pathReferenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPathReferenceRepository(db *pgxpool.Pool) repository.PathReferenceRepository {
+	return &pathReferenceRepository{db: db}
+}
+
+func (r *pathReferenceRepository) RewritePath(ctx context.Context, provider, oldPath, newPath, actor string) (int64, error) {
+	statements := []struct {
+		table string
+		query string
+		args  []any
+	}{
+		{
+			table: "resource_uploads",
+			query: `
+				UPDATE resource_uploads
+				SET storage_key = CASE WHEN storage_key = $2 THEN $3 ELSE storage_key END,
+				    resource_value = CASE WHEN resource_value = $2 THEN $3 ELSE resource_value END,
+				    update_time = NOW()
+				WHERE storage_provider::text = $1 AND (storage_key = $2 OR resource_value = $2)`,
+			args: []any{provider, oldPath, newPath},
+		},
+		{
+			table: "workouts",
+			query: `
+				UPDATE workouts
+				SET file_path = $3, updated_at = NOW()
+				WHERE provider = $1 AND file_path = $2`,
+			args: []any{provider, oldPath, newPath},
+		},
+		{
+			table: "content_references",
+			query: `
+				UPDATE content_references
+				SET path = $3
+				WHERE provider = $1 AND ltrim(path, '/') = ltrim($2, '/')`,
+			args: []any{provider, oldPath, newPath},
+		},
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	updated, err := rewriteAchievementPaths(ctx, tx, provider, oldPath, newPath, actor)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, stmt := range statements {
+		tag, err := tx.Exec(ctx, stmt.query, stmt.args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update %s references: %w", stmt.table, err)
+		}
+		updated += tag.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// rewriteAchievementPaths points the icons and banners stored at oldPath on
+// the provider at newPath, recording a revision for every achievement changed
+func rewriteAchievementPaths(ctx context.Context, tx pgx.Tx, provider, oldPath, newPath, actor string) (int64, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+achievementColumns+`
+		FROM achievements
+		WHERE (icon_provider = $1 AND icon_path = $2) OR (banner_provider = $1 AND banner_path = $2)
+		FOR UPDATE`,
+		provider, oldPath,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find achievement references: %w", err)
+	}
+
+	var achievements []*entity.Achievement
+	for rows.Next() {
+		achievement, err := scanAchievement(rows)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to find achievement references: %w", err)
+		}
+		achievements = append(achievements, achievement)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to find achievement references: %w", err)
+	}
+
+	for _, previous := range achievements {
+		current := *previous
+		if current.IconProvider == provider && current.IconPath == oldPath {
+			current.IconPath = newPath
+		}
+		if current.BannerProvider == provider && current.BannerPath == oldPath {
+			current.BannerPath = newPath
+		}
+		current.UpdatedAt = time.Now()
+
+		_, err := tx.Exec(ctx, `
+			UPDATE achievements
+			SET icon_path = $2, banner_path = $3, updated_at = $4
+			WHERE id = $1`,
+			current.ID, current.IconPath, current.BannerPath, current.UpdatedAt,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update achievement references: %w", err)
+		}
+
+		revision := entity.NewAchievementRevision(entity.RevisionActionUpdate, previous, &current, actor)
+		revision.IconProvider = current.IconProvider
+		if err := insertRevision(ctx, tx, revision); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(achievements)), nil
+}
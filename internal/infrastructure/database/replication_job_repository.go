@@ -0,0 +1,302 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const replicationJobColumns = `
+	id, definition, scope, scope_value, parameters, source_provider, target_provider,
+	concurrency, status, COALESCE(continuation_token, ''), copied_count, skipped_count,
+	failed_count, COALESCE(error, ''), created_at, updated_at, started_at, completed_at,
+	COALESCE(lease_owner, ''), lease_until`
+
+type replicationJobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReplicationJobRepository(db *pgxpool.Pool) repository.ReplicationJobRepository {
+	return &replicationJobRepository{db: db}
+}
+
+func (r *replicationJobRepository) Create(ctx context.Context, job *entity.ReplicationJob) error {
+	query := `
+		INSERT INTO replication_jobs (
+			id, definition, scope, scope_value, parameters, source_provider, target_provider,
+			concurrency, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(ctx, query,
+		job.ID,
+		job.Definition,
+		job.Scope,
+		job.ScopeValue,
+		job.Parameters,
+		job.SourceProvider,
+		job.TargetProvider,
+		job.Concurrency,
+		job.Status,
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+
+	return classifyError(err)
+}
+
+func (r *replicationJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ReplicationJob, error) {
+	query := `SELECT ` + replicationJobColumns + ` FROM replication_jobs WHERE id = $1`
+
+	job, err := scanReplicationJob(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrReplicationJobNotFound
+	}
+
+	return job, err
+}
+
+func (r *replicationJobRepository) List(ctx context.Context, offset, limit int) ([]*entity.ReplicationJob, error) {
+	query := `
+		SELECT ` + replicationJobColumns + `
+		FROM replication_jobs
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	return r.queryJobs(ctx, query, limit, offset)
+}
+
+func (r *replicationJobRepository) ClaimNext(ctx context.Context, owner string, lease time.Duration) (*entity.ReplicationJob, error) {
+	query := `
+		UPDATE replication_jobs
+		SET status = $1, lease_owner = $3, lease_until = $5,
+		    started_at = COALESCE(started_at, $4), updated_at = $4
+		WHERE id = (
+			SELECT id
+			FROM replication_jobs
+			WHERE status IN ($1, $2) AND (lease_until IS NULL OR lease_until < $4)
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + replicationJobColumns
+
+	now := time.Now()
+	job, err := scanReplicationJob(r.db.QueryRow(ctx, query,
+		entity.ReplicationStatusRunning,
+		entity.ReplicationStatusPending,
+		owner,
+		now,
+		now.Add(lease),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return job, err
+}
+
+func (r *replicationJobRepository) UpdateStatus(ctx context.Context, job *entity.ReplicationJob) error {
+	query := `
+		UPDATE replication_jobs
+		SET status = $2, error = NULLIF($3, ''), started_at = $4, completed_at = $5, updated_at = $6
+		WHERE id = $1 AND status IN ($7, $8)`
+
+	tag, err := r.db.Exec(ctx, query,
+		job.ID,
+		job.Status,
+		job.Error,
+		job.StartedAt,
+		job.CompletedAt,
+		job.UpdatedAt,
+		entity.ReplicationStatusPending,
+		entity.ReplicationStatusRunning,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrReplicationJobFinished
+	}
+
+	return nil
+}
+
+func (r *replicationJobRepository) SavePage(ctx context.Context, job *entity.ReplicationJob, items []*entity.ReplicationItem) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	itemQuery := `
+		INSERT INTO replication_job_items (
+			job_id, source_key, target_key, status, size, checksum_sha256, error, updated_at
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8)
+		ON CONFLICT (job_id, source_key) DO UPDATE
+		SET target_key = EXCLUDED.target_key, status = EXCLUDED.status, size = EXCLUDED.size,
+		    checksum_sha256 = EXCLUDED.checksum_sha256, error = EXCLUDED.error,
+		    updated_at = EXCLUDED.updated_at`
+
+	for _, item := range items {
+		_, err := tx.Exec(ctx, itemQuery,
+			item.JobID,
+			item.SourceKey,
+			item.TargetKey,
+			item.Status,
+			item.Size,
+			item.ChecksumSHA256,
+			item.Error,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save replication item %s: %w", item.SourceKey, err)
+		}
+	}
+
+	jobQuery := `
+		UPDATE replication_jobs
+		SET continuation_token = NULLIF($2, ''),
+		    copied_count = (SELECT COUNT(*) FROM replication_job_items WHERE job_id = $1 AND status = $3),
+		    skipped_count = (SELECT COUNT(*) FROM replication_job_items WHERE job_id = $1 AND status = $4),
+		    failed_count = (SELECT COUNT(*) FROM replication_job_items WHERE job_id = $1 AND status = $5),
+		    updated_at = $6, lease_until = $7
+		WHERE id = $1 AND lease_owner = $8
+		RETURNING copied_count, skipped_count, failed_count`
+
+	err = tx.QueryRow(ctx, jobQuery,
+		job.ID,
+		job.ContinuationToken,
+		entity.ReplicationItemCopied,
+		entity.ReplicationItemSkipped,
+		entity.ReplicationItemFailed,
+		job.UpdatedAt,
+		job.LeaseUntil,
+		job.LeaseOwner,
+	).Scan(&job.CopiedCount, &job.SkippedCount, &job.FailedCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.ErrReplicationLeaseLost
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update replication job progress: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *replicationJobRepository) CompletedKeys(ctx context.Context, jobID uuid.UUID, sourceKeys []string) (map[string]bool, error) {
+	query := `
+		SELECT source_key
+		FROM replication_job_items
+		WHERE job_id = $1 AND source_key = ANY($2) AND status IN ($3, $4)`
+
+	rows, err := r.db.Query(ctx, query, jobID, sourceKeys, entity.ReplicationItemCopied, entity.ReplicationItemSkipped)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	completed := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		completed[key] = true
+	}
+
+	return completed, rows.Err()
+}
+
+func (r *replicationJobRepository) ListItems(ctx context.Context, jobID uuid.UUID, status string, offset, limit int) ([]*entity.ReplicationItem, error) {
+	query := `
+		SELECT job_id, source_key, target_key, status, size,
+		       COALESCE(checksum_sha256, ''), COALESCE(error, ''), updated_at
+		FROM replication_job_items
+		WHERE job_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY source_key
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, query, jobID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.ReplicationItem
+	for rows.Next() {
+		var item entity.ReplicationItem
+		err := rows.Scan(
+			&item.JobID,
+			&item.SourceKey,
+			&item.TargetKey,
+			&item.Status,
+			&item.Size,
+			&item.ChecksumSHA256,
+			&item.Error,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *replicationJobRepository) queryJobs(ctx context.Context, query string, args ...any) ([]*entity.ReplicationJob, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*entity.ReplicationJob
+	for rows.Next() {
+		job, err := scanReplicationJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func scanReplicationJob(row pgx.Row) (*entity.ReplicationJob, error) {
+	var job entity.ReplicationJob
+	err := row.Scan(
+		&job.ID,
+		&job.Definition,
+		&job.Scope,
+		&job.ScopeValue,
+		&job.Parameters,
+		&job.SourceProvider,
+		&job.TargetProvider,
+		&job.Concurrency,
+		&job.Status,
+		&job.ContinuationToken,
+		&job.CopiedCount,
+		&job.SkippedCount,
+		&job.FailedCount,
+		&job.Error,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.LeaseOwner,
+		&job.LeaseUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// definitionChannel is the notification channel announcing definition
+// changes; the payload is the definition name
+const definitionChannel = "resource_definitions"
+
+const resourceDefinitionColumns = `
+	name, version, spec, status, created_by, updated_by, created_at, updated_at`
+
+type resourceDefinitionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewResourceDefinitionRepository(db *pgxpool.Pool) repository.ResourceDefinitionRepository {
+	return &resourceDefinitionRepository{db: db}
+}
+
+func (r *resourceDefinitionRepository) Create(ctx context.Context, def *entity.ResourceDefinition) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO resource_definitions (
+			name, version, spec, status, created_by, updated_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (name) DO NOTHING`,
+		def.Name,
+		def.Version,
+		def.Spec,
+		def.Status,
+		def.CreatedBy,
+		def.UpdatedBy,
+		def.CreatedAt,
+		def.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create resource definition: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := r.saveVersion(ctx, tx, def, false); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func (r *resourceDefinitionRepository) Update(ctx context.Context, def *entity.ResourceDefinition, previousVersion int, migrated bool) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE resource_definitions
+		SET version = $2, spec = $3, status = $4, updated_by = $5, updated_at = $6
+		WHERE name = $1 AND version = $7`,
+		def.Name,
+		def.Version,
+		def.Spec,
+		def.Status,
+		def.UpdatedBy,
+		def.UpdatedAt,
+		previousVersion,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update resource definition: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := r.saveVersion(ctx, tx, def, migrated); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// saveVersion records the definition's current version and announces the
+// change, which listeners receive once the transaction commits
+func (r *resourceDefinitionRepository) saveVersion(ctx context.Context, tx pgx.Tx, def *entity.ResourceDefinition, migrated bool) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO resource_definition_versions (name, version, spec, status, migrated, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		def.Name,
+		def.Version,
+		def.Spec,
+		def.Status,
+		migrated,
+		def.UpdatedBy,
+		def.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save resource definition version: %w", classifyError(err))
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, definitionChannel, def.Name); err != nil {
+		return fmt.Errorf("failed to announce resource definition change: %w", err)
+	}
+
+	return nil
+}
+
+func (r *resourceDefinitionRepository) GetByName(ctx context.Context, name string) (*entity.ResourceDefinition, error) {
+	query := `SELECT ` + resourceDefinitionColumns + ` FROM resource_definitions WHERE name = $1`
+
+	def, err := scanResourceDefinition(r.db.QueryRow(ctx, query, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return def, err
+}
+
+func (r *resourceDefinitionRepository) List(ctx context.Context) ([]*entity.ResourceDefinition, error) {
+	query := `SELECT ` + resourceDefinitionColumns + ` FROM resource_definitions ORDER BY name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var defs []*entity.ResourceDefinition
+	for rows.Next() {
+		def, err := scanResourceDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, rows.Err()
+}
+
+func (r *resourceDefinitionRepository) ListVersions(ctx context.Context, name string) ([]*entity.ResourceDefinitionVersion, error) {
+	query := `
+		SELECT name, version, spec, status, migrated, created_by, created_at
+		FROM resource_definition_versions
+		WHERE name = $1
+		ORDER BY version DESC`
+
+	rows, err := r.db.Query(ctx, query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*entity.ResourceDefinitionVersion
+	for rows.Next() {
+		var version entity.ResourceDefinitionVersion
+		err := rows.Scan(
+			&version.Name,
+			&version.Version,
+			&version.Spec,
+			&version.Status,
+			&version.Migrated,
+			&version.CreatedBy,
+			&version.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &version)
+	}
+
+	return versions, rows.Err()
+}
+
+func (r *resourceDefinitionRepository) Watch(ctx context.Context, onChange func(name string)) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire listener connection: %w", err)
+	}
+	// The connection is closed rather than released, so it never returns to
+	// the pool while still listening
+	defer func() {
+		conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+definitionChannel); err != nil {
+		return fmt.Errorf("failed to listen for definition changes: %w", err)
+	}
+	onChange("")
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to wait for definition changes: %w", err)
+		}
+		onChange(notification.Payload)
+	}
+}
+
+func scanResourceDefinition(row pgx.Row) (*entity.ResourceDefinition, error) {
+	var def entity.ResourceDefinition
+	err := row.Scan(
+		&def.Name,
+		&def.Version,
+		&def.Spec,
+		&def.Status,
+		&def.CreatedBy,
+		&def.UpdatedBy,
+		&def.CreatedAt,
+		&def.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const uploadColumns = `
+	id, resource_type, resource_id, COALESCE(resource_field, ''), upload_type::text,
+	upload_status::text, COALESCE(upload_error->>'message', ''), path_definition,
+	COALESCE(path_parameters, '{}'), storage_provider::text, storage_key, storage_size,
+	COALESCE(storage_multipart_id, ''), total_parts, uploaded_parts, create_time,
+	update_time, completed_time, expires_time`
+
+type uploadRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUploadRepository(db *pgxpool.Pool) repository.UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+func (r *uploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TrackedUpload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM resource_uploads WHERE id = $1`
+
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrUploadNotFound
+	}
+
+	return upload, err
+}
+
+func (r *uploadRepository) List(ctx context.Context, filter entity.UploadFilter, offset, limit int) ([]*entity.TrackedUpload, error) {
+	query := `
+		SELECT ` + uploadColumns + `
+		FROM resource_uploads
+		WHERE ($1::text = '' OR upload_status::text = $1)
+		  AND ($2::text = '' OR resource_type = $2)
+		  AND ($3::text = '' OR storage_provider::text = $3)
+		ORDER BY create_time DESC
+		LIMIT $4 OFFSET $5`
+
+	return r.queryUploads(ctx, query, filter.Status, filter.ResourceType, filter.Provider, limit, offset)
+}
+
+func (r *uploadRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*entity.TrackedUpload, error) {
+	query := `
+		SELECT ` + uploadColumns + `
+		FROM resource_uploads
+		WHERE upload_status IN ('initializing', 'pending', 'uploading')
+		  AND expires_time < $1
+		ORDER BY expires_time ASC
+		LIMIT $2`
+
+	return r.queryUploads(ctx, query, before, limit)
+}
+
+func (r *uploadRepository) MarkAborted(ctx context.Context, id uuid.UUID, reason string) (bool, error) {
+	var transitioned bool
+	err := r.db.QueryRow(ctx,
+		`SELECT transition_upload_status($1, 'aborted', NULLIF($2, ''))`,
+		id, reason,
+	).Scan(&transitioned)
+
+	return transitioned, err
+}
+
+func (r *uploadRepository) queryUploads(ctx context.Context, query string, args ...any) ([]*entity.TrackedUpload, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []*entity.TrackedUpload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, rows.Err()
+}
+
+func scanUpload(row pgx.Row) (*entity.TrackedUpload, error) {
+	var upload entity.TrackedUpload
+	err := row.Scan(
+		&upload.ID,
+		&upload.ResourceType,
+		&upload.ResourceID,
+		&upload.ResourceField,
+		&upload.UploadType,
+		&upload.Status,
+		&upload.Error,
+		&upload.PathDefinition,
+		&upload.PathParameters,
+		&upload.StorageProvider,
+		&upload.StorageKey,
+		&upload.StorageSize,
+		&upload.MultipartID,
+		&upload.TotalParts,
+		&upload.UploadedParts,
+		&upload.CreateTime,
+		&upload.UpdateTime,
+		&upload.CompletedTime,
+		&upload.ExpiresTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
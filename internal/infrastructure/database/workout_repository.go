@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type workoutRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWorkoutRepository(db *pgxpool.Pool) repository.WorkoutRepository {
+	return &workoutRepository{db: db}
+}
+
+func (r *workoutRepository) Create(ctx context.Context, workout *entity.Workout) error {
+	query := `
+		INSERT INTO workouts (
+			id, user_id, name, description, format, file_path, provider,
+			scope, scope_value, file_size, checksum_sha256, metadata,
+			duration_seconds, interval_count, power_unit, power_min, power_max,
+			stroke_rate_min, stroke_rate_max, confirmed_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
+
+	_, err := r.db.Exec(ctx, query,
+		workout.ID,
+		workout.UserID,
+		workout.Name,
+		workout.Description,
+		workout.Format,
+		workout.FilePath,
+		workout.Provider,
+		workout.Scope,
+		workout.ScopeValue,
+		workout.FileSize,
+		workout.ChecksumSHA256,
+		workout.Metadata,
+		workout.Summary.DurationSeconds,
+		workout.Summary.IntervalCount,
+		workout.Summary.PowerUnit,
+		workout.Summary.PowerMin,
+		workout.Summary.PowerMax,
+		workout.Summary.StrokeRateMin,
+		workout.Summary.StrokeRateMax,
+		workout.ConfirmedAt,
+		workout.CreatedAt,
+		workout.UpdatedAt,
+	)
+
+	return classifyError(err)
+}
+
+func (r *workoutRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Workout, error) {
+	query := `
+		SELECT id, user_id, name, description, format, file_path, provider,
+		       scope, scope_value, COALESCE(file_size, 0), checksum_sha256, metadata,
+		       duration_seconds, interval_count, power_unit, power_min, power_max,
+		       stroke_rate_min, stroke_rate_max, confirmed_at, created_at, updated_at
+		FROM workouts
+		WHERE id = $1`
+
+	var workout entity.Workout
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&workout.ID,
+		&workout.UserID,
+		&workout.Name,
+		&workout.Description,
+		&workout.Format,
+		&workout.FilePath,
+		&workout.Provider,
+		&workout.Scope,
+		&workout.ScopeValue,
+		&workout.FileSize,
+		&workout.ChecksumSHA256,
+		&workout.Metadata,
+		&workout.Summary.DurationSeconds,
+		&workout.Summary.IntervalCount,
+		&workout.Summary.PowerUnit,
+		&workout.Summary.PowerMin,
+		&workout.Summary.PowerMax,
+		&workout.Summary.StrokeRateMin,
+		&workout.Summary.StrokeRateMax,
+		&workout.ConfirmedAt,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrWorkoutNotFound
+	}
+
+	return &workout, err
+}
+
+func (r *workoutRepository) Update(ctx context.Context, workout *entity.Workout) error {
+	query := `
+		UPDATE workouts
+		SET name = $2, description = $3, format = $4, file_path = $5, provider = $6,
+		    scope = $7, scope_value = $8, file_size = $9, checksum_sha256 = $10,
+		    metadata = $11, duration_seconds = $12, interval_count = $13, power_unit = $14,
+		    power_min = $15, power_max = $16, stroke_rate_min = $17, stroke_rate_max = $18,
+		    confirmed_at = $19, updated_at = $20
+		WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query,
+		workout.ID,
+		workout.Name,
+		workout.Description,
+		workout.Format,
+		workout.FilePath,
+		workout.Provider,
+		workout.Scope,
+		workout.ScopeValue,
+		workout.FileSize,
+		workout.ChecksumSHA256,
+		workout.Metadata,
+		workout.Summary.DurationSeconds,
+		workout.Summary.IntervalCount,
+		workout.Summary.PowerUnit,
+		workout.Summary.PowerMin,
+		workout.Summary.PowerMax,
+		workout.Summary.StrokeRateMin,
+		workout.Summary.StrokeRateMax,
+		workout.ConfirmedAt,
+		workout.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *workoutRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM workouts WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+func (r *workoutRepository) ListByUser(ctx context.Context, userID string, offset, limit int) ([]*entity.Workout, error) {
+	query := `
+		SELECT id, user_id, name, description, format, file_path, provider,
+		       scope, scope_value, COALESCE(file_size, 0), checksum_sha256, metadata,
+		       duration_seconds, interval_count, power_unit, power_min, power_max,
+		       stroke_rate_min, stroke_rate_max, confirmed_at, created_at, updated_at
+		FROM workouts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workouts []*entity.Workout
+	for rows.Next() {
+		var workout entity.Workout
+		err := rows.Scan(
+			&workout.ID,
+			&workout.UserID,
+			&workout.Name,
+			&workout.Description,
+			&workout.Format,
+			&workout.FilePath,
+			&workout.Provider,
+			&workout.Scope,
+			&workout.ScopeValue,
+			&workout.FileSize,
+			&workout.ChecksumSHA256,
+			&workout.Metadata,
+			&workout.Summary.DurationSeconds,
+			&workout.Summary.IntervalCount,
+			&workout.Summary.PowerUnit,
+			&workout.Summary.PowerMin,
+			&workout.Summary.PowerMax,
+			&workout.Summary.StrokeRateMin,
+			&workout.Summary.StrokeRateMax,
+			&workout.ConfirmedAt,
+			&workout.CreatedAt,
+			&workout.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, &workout)
+	}
+
+	return workouts, nil
+}
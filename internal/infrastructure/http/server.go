@@ -4,31 +4,51 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	commoncontext "avironactive.com/common/context"
 	"avironactive.com/resource"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
 	"github.com/anh-nguyen/resource-server/core"
 	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
 	"github.com/anh-nguyen/resource-server/internal/infrastructure/config"
 	"github.com/anh-nguyen/resource-server/internal/infrastructure/database"
+	"github.com/anh-nguyen/resource-server/internal/interfaces/grpc/services"
 	"github.com/anh-nguyen/resource-server/internal/interfaces/http/handlers"
 	"github.com/anh-nguyen/resource-server/internal/interfaces/http/middleware"
 )
 
 type Server struct {
-	app             *fiber.App
-	config          *config.Config
-	db              *pgxpool.Pool
-	resourceManager resource.ResourceManager
+	app                  *fiber.App
+	grpcServer           *grpc.Server
+	config               *config.Config
+	db                   *pgxpool.Pool
+	resourceManager      resource.ResourceManager
+	achievementScheduler *usecases.AchievementScheduler
+	replicationRunner    *usecases.ReplicationRunner
+	uploadJanitor        *usecases.UploadJanitor
+	definitionRegistry   *usecases.DefinitionRegistry
+}
+
+// streamedRoutes read their request body as a stream, so it is not limited
+// to the configured body limit
+var streamedRoutes = []string{
+	"PUT /api/v1/resources/:provider/:definition/content",
 }
 
 func NewServer(cfg *config.Config) *Server {
@@ -36,6 +56,17 @@ func NewServer(cfg *config.Config) *Server {
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		ErrorHandler: middleware.ErrorHandler,
+		BodyLimit:    cfg.Server.BodyLimit,
+		// Bodies above the limit are streamed to the handler instead of
+		// rejected, which content uploads rely on; middleware.BodyLimit
+		// enforces the limit on every other route
+		StreamRequestBody: true,
+		// c.IP reads the client IP that upload URLs are bound to from the
+		// proxy header, but only when a trusted proxy sent the request
+		ProxyHeader:             cfg.Server.ProxyHeader,
+		EnableTrustedProxyCheck: len(cfg.Server.TrustedProxies) > 0,
+		TrustedProxies:          cfg.Server.TrustedProxies,
+		EnableIPValidation:      true,
 	})
 
 	app.Use(recover.New())
@@ -50,6 +81,9 @@ func NewServer(cfg *config.Config) *Server {
 		AllowHeaders: joinStrings(cfg.CORS.AllowedHeaders, ","),
 	}))
 
+	app.Use(middleware.BodyLimit(cfg.Server.BodyLimit, streamedRoutes))
+	app.Use(middleware.RequestContext(cfg.Server.RequestTimeout, cfg.Server.RouteTimeouts))
+
 	// Initialize database connection
 	db, err := database.NewConnection(&cfg.Database)
 	if err != nil {
@@ -75,39 +109,88 @@ func (s *Server) SetupRoutes() {
 	healthHandler := handlers.NewHealthHandler()
 	api.Get("/health", healthHandler.HealthCheck)
 
-	resourceDefinitionUseCase := usecases.NewResourceDefinitionUseCase(s.resourceManager)
+	// API description, generated from the routes registered below
+	openAPIHandler := handlers.NewOpenAPIHandler()
+	api.Get("/openapi.json", openAPIHandler.GetDocument)
+
+	resourceDefinitionRepo := database.NewResourceDefinitionRepository(s.db)
+	s.definitionRegistry = usecases.NewDefinitionRegistry(resourceDefinitionRepo, s.resourceManager)
+	definitionRegistryHandler := handlers.NewDefinitionRegistryHandler(s.definitionRegistry)
+
+	resourceDefinitionUseCase := usecases.NewResourceDefinitionUseCase(s.resourceManager, s.definitionRegistry)
 	resourceDefinitionHandler := handlers.NewResourceDefinitionHandler(resourceDefinitionUseCase)
 
 	providerUseCase := usecases.NewProviderUseCase(s.resourceManager)
 	providerHandler := handlers.NewProviderHandler(providerUseCase)
 
-	fileOperationsUseCase := usecases.NewFileOperationsUseCase(s.resourceManager)
-	fileOperationsHandler := handlers.NewFileOperationsHandler(fileOperationsUseCase)
+	pathReferenceRepo := database.NewPathReferenceRepository(s.db)
+	contentBlobRepo := database.NewContentBlobRepository(s.db)
+	contentStore := usecases.NewContentStore(s.resourceManager, contentBlobRepo, s.definitionRegistry)
+	uploadRepo := database.NewUploadRepository(s.db)
+	fileOperationsUseCase := usecases.NewFileOperationsUseCase(s.resourceManager, pathReferenceRepo, uploadRepo, contentStore, s.definitionRegistry, s.config.Download.BundleCacheTTL)
+	bandwidthLimiter := handlers.NewBandwidthLimiter(s.config.Download.BytesPerSecond)
+	fileOperationsHandler := handlers.NewFileOperationsHandler(fileOperationsUseCase, bandwidthLimiter)
 
-	multipartUseCase := usecases.NewMultipartUseCase(s.resourceManager)
+	multipartUseCase := usecases.NewMultipartUseCase(s.resourceManager, s.definitionRegistry)
 	multipartHandler := handlers.NewMultipartHandler(multipartUseCase)
 
 	// Achievement setup
 	achievementRepo := database.NewAchievementRepository(s.db)
+	achievementRevisionRepo := database.NewAchievementRevisionRepository(s.db)
 	uploadManager := s.resourceManager.UploadManager()
-	achievementUseCase := usecases.NewAchievementUseCase(achievementRepo, s.resourceManager)
+	achievementUseCase := usecases.NewAchievementUseCase(achievementRepo, achievementRevisionRepo, s.resourceManager, contentStore, s.definitionRegistry)
 	achievementHandler := handlers.NewAchievementHandler(achievementUseCase, uploadManager)
 
+	locker := database.NewAdvisoryLocker(s.db)
+	s.achievementScheduler = usecases.NewAchievementScheduler(achievementUseCase, locker, s.config.Scheduler.PublicationInterval, s.config.Scheduler.IconRetention)
+	s.achievementScheduler.OnPublication(func(event entity.AchievementPublicationEvent) {
+		log.Printf("Achievement %s %s at %s", event.AchievementID, event.Type, event.OccurredAt.Format(time.RFC3339))
+	})
+
+	// Workout setup
+	workoutRepo := database.NewWorkoutRepository(s.db)
+	workoutUseCase := usecases.NewWorkoutUseCase(workoutRepo, s.resourceManager, contentStore, s.definitionRegistry)
+	workoutHandler := handlers.NewWorkoutHandler(workoutUseCase)
+
+	// Replication setup
+	replicationJobRepo := database.NewReplicationJobRepository(s.db)
+	replicationUseCase := usecases.NewReplicationUseCase(replicationJobRepo, s.resourceManager, contentStore)
+	replicationHandler := handlers.NewReplicationHandler(replicationUseCase)
+	s.replicationRunner = usecases.NewReplicationRunner(replicationUseCase, s.config.Replication.PollInterval, s.config.Replication.Lease)
+
+	// Upload lifecycle setup
+	uploadUseCase := usecases.NewUploadUseCase(uploadRepo, s.resourceManager)
+	uploadHandler := handlers.NewUploadHandler(uploadUseCase)
+	s.uploadJanitor = usecases.NewUploadJanitor(uploadUseCase, s.config.Uploads.JanitorInterval)
+
+	// Admin routes and batch changes require an admin token
+	adminAuth := middleware.AdminAuth(s.config.Admin.Tokens)
+
 	// Resources group
 	resources := api.Group("/resources")
 
 	// Resource definition routes
 	resources.Get("/definitions", resourceDefinitionHandler.ListDefinitions)
+	resources.Get("/definitions/schema", resourceDefinitionHandler.GetSchemas)
 	resources.Get("/definitions/:name", resourceDefinitionHandler.GetDefinition)
+	resources.Get("/definitions/:name/schema", resourceDefinitionHandler.GetDefinitionSchema)
+	resources.Post("/definitions/:name/resolve", resourceDefinitionHandler.ResolveDefinition)
 
 	// Provider routes
 	resources.Get("/providers", providerHandler.ListProviders)
 	resources.Get("/providers/:name", providerHandler.GetProvider)
 
 	// File operation routes
+	resources.Post("/:provider/batch/delete", adminAuth, fileOperationsHandler.BatchDelete)
+	resources.Post("/:provider/batch/metadata", adminAuth, fileOperationsHandler.BatchUpdateMetadata)
 	resources.Get("/:provider/:definition", fileOperationsHandler.ListFiles)
 	resources.Post("/:provider/:definition/upload", fileOperationsHandler.GenerateUploadURL)
+	resources.Put("/:provider/:definition/content", fileOperationsHandler.UploadContent)
+	resources.Post("/:provider/:definition/bundle", fileOperationsHandler.DownloadBundle)
 	resources.Post("/:provider/*/download", fileOperationsHandler.GenerateDownloadURL)
+	resources.Get("/:provider/*/content", fileOperationsHandler.DownloadContent)
+	resources.Post("/:provider/*/copy", fileOperationsHandler.CopyFile)
+	resources.Post("/:provider/*/move", fileOperationsHandler.MoveFile)
 	resources.Get("/:provider/*/metadata", fileOperationsHandler.GetFileMetadata)
 	resources.Put("/:provider/*/metadata", fileOperationsHandler.UpdateFileMetadata)
 	resources.Delete("/:provider/*", fileOperationsHandler.DeleteFile)
@@ -118,15 +201,66 @@ func (s *Server) SetupRoutes() {
 	achievements := api.Group("/achievements")
 	achievements.Get("/", achievementHandler.ListAchievements)
 	achievements.Post("/", achievementHandler.CreateAchievement)
+	achievements.Get("/preview", achievementHandler.PreviewAchievements)
 	achievements.Get("/:id", achievementHandler.GetAchievement)
+	achievements.Delete("/:id", achievementHandler.DeleteAchievement)
+	achievements.Get("/:id/history", achievementHandler.GetAchievementHistory)
+	achievements.Post("/:id/revert/:revision", achievementHandler.RevertAchievement)
 	achievements.Put("/:id/icon", achievementHandler.UpdateAchievementIcon)
+	achievements.Put("/:id/schedule", achievementHandler.UpdateAchievementSchedule)
 	achievements.Post("/uploads/:id/confirm", achievementHandler.ConfirmUpload)
 	achievements.Post("/uploads/:id/multipart", achievementHandler.GetMultipartURLs)
+
+	// Workout routes
+	workouts := api.Group("/workouts")
+	workouts.Get("/", workoutHandler.ListWorkouts)
+	workouts.Post("/", workoutHandler.CreateWorkout)
+	workouts.Get("/:id", workoutHandler.GetWorkout)
+	workouts.Get("/:id/download", workoutHandler.DownloadWorkout)
+	workouts.Delete("/:id", workoutHandler.DeleteWorkout)
+	workouts.Put("/:id/file", workoutHandler.ReplaceWorkoutFile)
+	workouts.Post("/:id/uploads/:uploadId/confirm", workoutHandler.ConfirmUpload)
+
+	// Admin routes
+	admin := api.Group("/admin", adminAuth)
+	admin.Get("/definitions", definitionRegistryHandler.ListDefinitions)
+	admin.Post("/definitions", definitionRegistryHandler.CreateDefinition)
+	admin.Put("/definitions/:name", definitionRegistryHandler.UpdateDefinition)
+	admin.Post("/definitions/:name/deprecate", definitionRegistryHandler.DeprecateDefinition)
+	admin.Get("/definitions/:name/versions", definitionRegistryHandler.ListVersions)
+	admin.Get("/uploads", uploadHandler.ListUploads)
+	admin.Post("/uploads/janitor", uploadHandler.RunJanitor)
+	admin.Get("/uploads/:id", uploadHandler.GetUpload)
+	admin.Post("/uploads/:id/abort", uploadHandler.AbortUpload)
+	admin.Get("/replication/jobs", replicationHandler.ListJobs)
+	admin.Post("/replication/jobs", replicationHandler.CreateJob)
+	admin.Get("/replication/jobs/:id", replicationHandler.GetJob)
+	admin.Get("/replication/jobs/:id/items", replicationHandler.ListJobItems)
+	admin.Post("/replication/jobs/:id/cancel", replicationHandler.CancelJob)
+
+	// gRPC services share the usecases of the routes above
+	// The upload service exposes the admin upload routes
+	adminUnary, adminStream := services.AdminAuth(s.config.Admin.Tokens, resourcev1.UploadService_ServiceDesc.ServiceName)
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(services.UnaryInterceptor, adminUnary),
+		grpc.ChainStreamInterceptor(services.StreamInterceptor, adminStream),
+	)
+	resourcev1.RegisterDefinitionServiceServer(s.grpcServer, services.NewDefinitionService(resourceDefinitionUseCase))
+	resourcev1.RegisterProviderServiceServer(s.grpcServer, services.NewProviderService(providerUseCase))
+	resourcev1.RegisterFileServiceServer(s.grpcServer, services.NewFileService(fileOperationsUseCase))
+	resourcev1.RegisterMultipartServiceServer(s.grpcServer, services.NewMultipartService(multipartUseCase))
+	resourcev1.RegisterUploadServiceServer(s.grpcServer, services.NewUploadService(uploadUseCase))
+	resourcev1.RegisterAchievementServiceServer(s.grpcServer, services.NewAchievementService(achievementUseCase))
+	healthpb.RegisterHealthServer(s.grpcServer, health.NewServer())
+	reflection.Register(s.grpcServer)
 }
 
 func (s *Server) Start() error {
 	s.SetupRoutes()
 
+	// Runtime definitions are registered before serving requests
+	s.definitionRegistry.Start(commoncontext.Background())
+
 	addr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port)
 
 	go func() {
@@ -137,6 +271,26 @@ func (s *Server) Start() error {
 
 	log.Printf("Server started on %s", addr)
 
+	if s.config.Server.GRPCPort > 0 {
+		grpcAddr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.GRPCPort)
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+		}
+
+		go func() {
+			if err := s.grpcServer.Serve(listener); err != nil {
+				log.Printf("gRPC server failed: %v", err)
+			}
+		}()
+
+		log.Printf("gRPC server started on %s", grpcAddr)
+	}
+
+	s.achievementScheduler.Start(commoncontext.Background())
+	s.replicationRunner.Start(commoncontext.Background())
+	s.uploadJanitor.Start(commoncontext.Background())
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -152,6 +306,26 @@ func (s *Server) Start() error {
 func (s *Server) Shutdown() error {
 	log.Println("Shutting down server...")
 
+	if s.grpcServer != nil {
+		s.stopGRPC(30 * time.Second)
+	}
+
+	if s.achievementScheduler != nil {
+		s.achievementScheduler.Stop()
+	}
+
+	if s.replicationRunner != nil {
+		s.replicationRunner.Stop()
+	}
+
+	if s.uploadJanitor != nil {
+		s.uploadJanitor.Stop()
+	}
+
+	if s.definitionRegistry != nil {
+		s.definitionRegistry.Stop()
+	}
+
 	if err := s.resourceManager.Close(); err != nil {
 		return fmt.Errorf("failed to close resource manager: %w", err)
 	}
@@ -170,6 +344,22 @@ func (s *Server) Shutdown() error {
 	return nil
 }
 
+// stopGRPC waits for in-flight RPCs to finish, cancelling those still running
+// after the timeout
+func (s *Server) stopGRPC(timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		s.grpcServer.Stop()
+	}
+}
+
 func joinStrings(slice []string, separator string) string {
 	if len(slice) == 0 {
 		return ""
@@ -0,0 +1,333 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+// AchievementService implements the achievement RPCs
+type AchievementService struct {
+	resourcev1.UnimplementedAchievementServiceServer
+	useCase *usecases.AchievementUseCase
+}
+
+// NewAchievementService creates a new achievement service
+func NewAchievementService(useCase *usecases.AchievementUseCase) *AchievementService {
+	return &AchievementService{
+		useCase: useCase,
+	}
+}
+
+// ListAchievements mirrors GET /api/v1/achievements
+func (s *AchievementService) ListAchievements(req *resourcev1.ListAchievementsRequest, stream resourcev1.AchievementService_ListAchievementsServer) error {
+	offset, limit, err := listWindow(req.Offset, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	ctx := toContext(stream.Context())
+	return streamList(offset, limit, func(offset, limit int) ([]*dto.AchievementResponse, error) {
+		var result []*dto.AchievementResponse
+		var err error
+		if req.IncludeInactive {
+			result, err = s.useCase.ListAchievements(ctx, offset, limit)
+		} else {
+			result, err = s.useCase.ListActiveAchievements(ctx, offset, limit)
+		}
+		if err != nil {
+			return nil, toStatus(err, codes.Internal, "Failed to list achievements")
+		}
+		return result, nil
+	}, func(achievement *dto.AchievementResponse) error {
+		return stream.Send(newAchievement(achievement))
+	})
+}
+
+// PreviewAchievements mirrors GET /api/v1/achievements/preview
+func (s *AchievementService) PreviewAchievements(req *resourcev1.PreviewAchievementsRequest, stream resourcev1.AchievementService_PreviewAchievementsServer) error {
+	offset, limit, err := listWindow(req.Offset, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	at := time.Now()
+	if req.At != nil {
+		at = req.At.AsTime()
+	}
+
+	ctx := toContext(stream.Context())
+	return streamList(offset, limit, func(offset, limit int) ([]*dto.AchievementResponse, error) {
+		result, err := s.useCase.PreviewActiveAchievements(ctx, at, offset, limit)
+		if err != nil {
+			return nil, toStatus(err, codes.Internal, "Failed to preview achievements")
+		}
+		return result, nil
+	}, func(achievement *dto.AchievementResponse) error {
+		return stream.Send(newAchievement(achievement))
+	})
+}
+
+// GetAchievement mirrors GET /api/v1/achievements/:id
+func (s *AchievementService) GetAchievement(ctx stdcontext.Context, req *resourcev1.GetAchievementRequest) (*resourcev1.Achievement, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid achievement ID", err)
+	}
+
+	result, err := s.useCase.GetAchievement(toContext(ctx), req.Id)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to get achievement")
+	}
+
+	return newAchievement(result), nil
+}
+
+// CreateAchievement mirrors POST /api/v1/achievements
+func (s *AchievementService) CreateAchievement(ctx stdcontext.Context, req *resourcev1.CreateAchievementRequest) (*resourcev1.CreateAchievementResponse, error) {
+	createReq := dto.CreateAchievementRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    req.Category,
+		Points:      int(req.Points),
+		IconFormat:  req.IconFormat,
+		Provider:    req.Provider,
+		PublishAt:   fromTimestamp(req.PublishAt),
+		UnpublishAt: fromTimestamp(req.UnpublishAt),
+	}
+	if validationErrors := validation.ValidateStruct(&createReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+	if err := validation.ValidatePublishWindow(createReq.PublishAt, createReq.UnpublishAt); err != nil {
+		return nil, invalidArgument("Invalid request data", err)
+	}
+
+	if createReq.Provider == "" {
+		createReq.Provider = "r2"
+	}
+	createReq.Actor = requestActor(ctx)
+
+	result, err := s.useCase.CreateAchievement(toContext(ctx), &createReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to create achievement")
+	}
+
+	var upload *resourcev1.UploadInfo
+	if result.Upload != nil {
+		upload = &resourcev1.UploadInfo{
+			UploadId:  result.Upload.UploadID,
+			UploadUrl: result.Upload.UploadURL,
+			ExpiresAt: timestamp(time.Unix(result.Upload.ExpiresAt, 0)),
+		}
+	}
+
+	return &resourcev1.CreateAchievementResponse{
+		Id:          result.ID,
+		Name:        result.Name,
+		Description: result.Description,
+		Category:    result.Category,
+		Points:      int32(result.Points),
+		IconUrl:     result.IconURL,
+		Upload:      upload,
+	}, nil
+}
+
+// DeleteAchievement mirrors DELETE /api/v1/achievements/:id
+func (s *AchievementService) DeleteAchievement(ctx stdcontext.Context, req *resourcev1.DeleteAchievementRequest) (*emptypb.Empty, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid achievement ID", err)
+	}
+
+	if err := s.useCase.DeleteAchievement(toContext(ctx), req.Id, requestActor(ctx)); err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to delete achievement")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetAchievementHistory mirrors GET /api/v1/achievements/:id/history
+func (s *AchievementService) GetAchievementHistory(req *resourcev1.GetAchievementHistoryRequest, stream resourcev1.AchievementService_GetAchievementHistoryServer) error {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return invalidArgument("Invalid achievement ID", err)
+	}
+
+	offset, limit, err := listWindow(req.Offset, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	ctx := toContext(stream.Context())
+	return streamList(offset, limit, func(offset, limit int) ([]*dto.AchievementRevisionResponse, error) {
+		result, err := s.useCase.GetAchievementHistory(ctx, req.Id, offset, limit)
+		if err != nil {
+			return nil, toStatus(err, codes.Internal, "Failed to get achievement history")
+		}
+		return result, nil
+	}, func(revision *dto.AchievementRevisionResponse) error {
+		return stream.Send(newAchievementRevision(revision))
+	})
+}
+
+// RevertAchievement mirrors POST /api/v1/achievements/:id/revert/:revision
+func (s *AchievementService) RevertAchievement(ctx stdcontext.Context, req *resourcev1.RevertAchievementRequest) (*resourcev1.RevertAchievementResponse, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid achievement ID", err)
+	}
+	if req.Revision < 1 {
+		return nil, invalidArgument("Invalid revision number", errors.New("revision must be a positive integer"))
+	}
+
+	result, err := s.useCase.RevertAchievement(toContext(ctx), req.Id, int(req.Revision), requestActor(ctx))
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to revert achievement")
+	}
+
+	return &resourcev1.RevertAchievementResponse{
+		Achievement:  newAchievement(result.Achievement),
+		Revision:     int32(result.Revision),
+		IconRestored: result.IconRestored,
+	}, nil
+}
+
+// UpdateAchievementIcon mirrors PUT /api/v1/achievements/:id/icon
+func (s *AchievementService) UpdateAchievementIcon(ctx stdcontext.Context, req *resourcev1.UpdateAchievementIconRequest) (*resourcev1.UpdateAchievementIconResponse, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid achievement ID", err)
+	}
+
+	iconReq := dto.UpdateIconRequest{
+		AchievementID: req.Id,
+		Format:        req.Format,
+		Provider:      req.Provider,
+		Size:          req.Size,
+		Actor:         requestActor(ctx),
+		ClientIP:      clientIP(ctx),
+	}
+	if validationErrors := validation.ValidateStruct(&iconReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.UpdateAchievementIcon(toContext(ctx), &iconReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to update achievement icon")
+	}
+
+	return &resourcev1.UpdateAchievementIconResponse{
+		UploadId:    result.UploadID,
+		UploadUrl:   result.UploadURL,
+		ExpiresAt:   timestamp(time.Unix(result.ExpiresAt, 0)),
+		NewIconUrl:  result.NewIconURL,
+		Constraints: newUploadConstraints(result.Constraints),
+	}, nil
+}
+
+// UpdateAchievementSchedule mirrors PUT /api/v1/achievements/:id/schedule
+func (s *AchievementService) UpdateAchievementSchedule(ctx stdcontext.Context, req *resourcev1.UpdateAchievementScheduleRequest) (*resourcev1.Achievement, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid achievement ID", err)
+	}
+
+	scheduleReq := dto.UpdateScheduleRequest{
+		AchievementID: req.Id,
+		PublishAt:     fromTimestamp(req.PublishAt),
+		UnpublishAt:   fromTimestamp(req.UnpublishAt),
+		Actor:         requestActor(ctx),
+	}
+	if validationErrors := validation.ValidateStruct(&scheduleReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+	if err := validation.ValidatePublishWindow(scheduleReq.PublishAt, scheduleReq.UnpublishAt); err != nil {
+		return nil, invalidArgument("Invalid request data", err)
+	}
+
+	result, err := s.useCase.UpdateAchievementSchedule(toContext(ctx), &scheduleReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to update achievement schedule")
+	}
+
+	return newAchievement(result), nil
+}
+
+// ConfirmUpload mirrors POST /api/v1/achievements/uploads/:id/confirm
+func (s *AchievementService) ConfirmUpload(ctx stdcontext.Context, req *resourcev1.ConfirmUploadRequest) (*emptypb.Empty, error) {
+	if err := validation.ValidateUUID(req.UploadId); err != nil {
+		return nil, invalidArgument("Invalid upload ID", err)
+	}
+
+	confirmReq := dto.ConfirmUploadRequest{
+		UploadID:     req.UploadId,
+		Success:      req.Success,
+		ErrorMsg:     req.ErrorMsg,
+		FileSize:     req.FileSize,
+		ContentType:  req.ContentType,
+		VerifyExists: req.VerifyExists,
+	}
+	if len(req.Etags) > 0 {
+		etags := make([]dto.PartETag, 0, len(req.Etags))
+		for _, etag := range req.Etags {
+			etags = append(etags, dto.PartETag{
+				Part: int(etag.Part),
+				ETag: etag.Etag,
+				Size: etag.Size,
+			})
+		}
+		confirmReq.ETags = etags
+	}
+	if validationErrors := validation.ValidateStruct(&confirmReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	if err := s.useCase.ConfirmUpload(toContext(ctx), &confirmReq); err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to confirm upload")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func newAchievement(achievement *dto.AchievementResponse) *resourcev1.Achievement {
+	if achievement == nil {
+		return nil
+	}
+	return &resourcev1.Achievement{
+		Id:          achievement.ID,
+		Name:        achievement.Name,
+		Description: achievement.Description,
+		Category:    achievement.Category,
+		Points:      int32(achievement.Points),
+		IconUrl:     achievement.IconURL,
+		BannerUrl:   achievement.BannerURL,
+		IsActive:    achievement.IsActive,
+		PublishAt:   optionalTimestamp(achievement.PublishAt),
+		UnpublishAt: optionalTimestamp(achievement.UnpublishAt),
+		Metadata:    toStruct(achievement.Metadata),
+		CreatedAt:   timestamp(achievement.CreatedAt),
+		UpdatedAt:   timestamp(achievement.UpdatedAt),
+	}
+}
+
+func newAchievementRevision(revision *dto.AchievementRevisionResponse) *resourcev1.AchievementRevision {
+	changes := make([]*resourcev1.FieldChange, 0, len(revision.Changes))
+	for _, change := range revision.Changes {
+		changes = append(changes, &resourcev1.FieldChange{
+			Field: change.Field,
+			Old:   toValue(change.Old),
+			New:   toValue(change.New),
+		})
+	}
+
+	return &resourcev1.AchievementRevision{
+		Revision:  int32(revision.Revision),
+		Action:    revision.Action,
+		Actor:     revision.Actor,
+		Changes:   changes,
+		Previous:  newAchievement(revision.Previous),
+		CreatedAt: timestamp(revision.CreatedAt),
+	}
+}
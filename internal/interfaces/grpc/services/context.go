@@ -0,0 +1,55 @@
+package services
+
+import (
+	stdcontext "context"
+	"net"
+
+	"avironactive.com/common/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// toContext derives the usecase context from an RPC's context, carrying the
+// same request values as the HTTP handlers
+func toContext(ctx stdcontext.Context) context.Context {
+	c := context.NewContext(ctx)
+	c.Set("request_id", incomingHeader(ctx, "x-request-id"))
+	c.Set("principal", requestActor(ctx))
+
+	return c
+}
+
+// requestActor identifies the principal performing a change for audit
+// history, from the x-principal-id metadata. Like the HTTP header, the
+// metadata is set by the client and not authenticated.
+func requestActor(ctx stdcontext.Context) string {
+	if actor := incomingHeader(ctx, "x-principal-id"); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+// clientIP returns the address of the caller, which upload policies may bind
+// signed URLs to
+func clientIP(ctx stdcontext.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func incomingHeader(ctx stdcontext.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
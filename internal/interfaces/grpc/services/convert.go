@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// expiry renders a requested expiry in the duration format of the REST API
+func expiry(d *durationpb.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.AsDuration().String()
+}
+
+func optionalInt32(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	v := int32(*value)
+	return &v
+}
+
+// toValue converts a JSON value to a protobuf Value. The values converted
+// are read from JSON columns and responses, so one that does not encode is
+// left out.
+func toValue(value any) *structpb.Value {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var converted structpb.Value
+	if err := protojson.Unmarshal(data, &converted); err != nil {
+		return nil
+	}
+	return &converted
+}
+
+func toStruct(fields map[string]any) *structpb.Struct {
+	if len(fields) == 0 {
+		return nil
+	}
+	return toValue(fields).GetStructValue()
+}
+
+func newChecksum(checksum *dto.ChecksumInfo) *resourcev1.Checksum {
+	if checksum == nil {
+		return nil
+	}
+	return &resourcev1.Checksum{
+		Algorithm: checksum.Algorithm,
+		Value:     checksum.Value,
+	}
+}
+
+func fromChecksum(checksum *resourcev1.Checksum) *dto.ChecksumInfo {
+	if checksum == nil {
+		return nil
+	}
+	return &dto.ChecksumInfo{
+		Algorithm: checksum.Algorithm,
+		Value:     checksum.Value,
+	}
+}
+
+func fromUploadMetadata(meta *resourcev1.UploadMetadata) *dto.UploadMetadata {
+	if meta == nil {
+		return nil
+	}
+	return &dto.UploadMetadata{
+		ContentType:        meta.ContentType,
+		ContentEncoding:    meta.ContentEncoding,
+		ContentLanguage:    meta.ContentLanguage,
+		ContentDisposition: meta.ContentDisposition,
+		CacheControl:       meta.CacheControl,
+		StorageClass:       meta.StorageClass,
+		ACL:                meta.Acl,
+		CustomHeaders:      meta.CustomHeaders,
+	}
+}
+
+func newUploadConstraints(constraints *dto.UploadConstraintsResponse) *resourcev1.UploadConstraints {
+	if constraints == nil {
+		return nil
+	}
+	return &resourcev1.UploadConstraints{
+		MaxSize:     constraints.MaxSize,
+		ContentType: constraints.ContentType,
+		Checksum:    newChecksum(constraints.Checksum),
+		ClientIp:    constraints.ClientIP,
+	}
+}
+
+func newSignedURL(url *dto.SignedURLResponse) *resourcev1.SignedURL {
+	return &resourcev1.SignedURL{
+		Url:                url.URL,
+		Method:             url.Method,
+		Headers:            url.Headers,
+		ExpiresAt:          timestamp(url.ExpiresAt),
+		ResolvedPath:       url.ResolvedPath,
+		ResolvedParameters: url.ResolvedParameters,
+		Constraints:        newUploadConstraints(url.Constraints),
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+// DefinitionService implements the resource definition RPCs
+type DefinitionService struct {
+	resourcev1.UnimplementedDefinitionServiceServer
+	useCase *usecases.ResourceDefinitionUseCase
+}
+
+// NewDefinitionService creates a new definition service
+func NewDefinitionService(useCase *usecases.ResourceDefinitionUseCase) *DefinitionService {
+	return &DefinitionService{
+		useCase: useCase,
+	}
+}
+
+// ListDefinitions mirrors GET /api/v1/resources/definitions
+func (s *DefinitionService) ListDefinitions(req *resourcev1.ListDefinitionsRequest, stream resourcev1.DefinitionService_ListDefinitionsServer) error {
+	definitions, err := s.useCase.ListDefinitions(toContext(stream.Context()))
+	if err != nil {
+		return toStatus(err, codes.Internal, "Failed to retrieve definitions")
+	}
+
+	for _, definition := range definitions {
+		if err := stream.Send(newPathDefinition(definition)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDefinition mirrors GET /api/v1/resources/definitions/:name
+func (s *DefinitionService) GetDefinition(ctx stdcontext.Context, req *resourcev1.GetDefinitionRequest) (*resourcev1.PathDefinition, error) {
+	if req.Name == "" {
+		return nil, invalidArgument("Definition name is required", errors.New("name is empty"))
+	}
+
+	definition, err := s.useCase.GetDefinition(toContext(ctx), req.Name)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to retrieve definition")
+	}
+
+	return newPathDefinition(definition), nil
+}
+
+// ResolveDefinition mirrors POST /api/v1/resources/definitions/:name/resolve
+func (s *DefinitionService) ResolveDefinition(ctx stdcontext.Context, req *resourcev1.ResolveDefinitionRequest) (*resourcev1.ResolveDefinitionResponse, error) {
+	if req.Name == "" {
+		return nil, invalidArgument("Definition name is required", errors.New("name is empty"))
+	}
+
+	value, err := scopeValue(req.ScopeValue)
+	if err != nil {
+		return nil, err
+	}
+	resolveReq := dto.ResolvePathRequest{
+		Parameters: req.Parameters,
+		Scope:      req.Scope,
+		ScopeValue: value,
+	}
+	if validationErrors := validation.ValidateStruct(&resolveReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	resolved, err := s.useCase.ResolvePaths(toContext(ctx), req.Name, &resolveReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to resolve definition")
+	}
+
+	providers := make([]*resourcev1.ResolvedProviderPath, 0, len(resolved.Providers))
+	for _, path := range resolved.Providers {
+		providers = append(providers, &resourcev1.ResolvedProviderPath{
+			Provider:   path.Provider,
+			Path:       path.Path,
+			UrlType:    path.URLType,
+			Parameters: path.Parameters,
+			Errors:     path.Errors,
+			Valid:      path.Valid,
+		})
+	}
+
+	return &resourcev1.ResolveDefinitionResponse{
+		Definition: resolved.Definition,
+		Scope:      resolved.Scope,
+		ScopeValue: int32(resolved.ScopeValue),
+		Providers:  providers,
+	}, nil
+}
+
+func newPathDefinition(definition *dto.PathDefinitionResponse) *resourcev1.PathDefinition {
+	parameters := make([]*resourcev1.PathParameter, 0, len(definition.Parameters))
+	for _, param := range definition.Parameters {
+		parameters = append(parameters, &resourcev1.PathParameter{
+			Name:         param.Name,
+			Required:     param.Required,
+			Rules:        param.Rules,
+			Enum:         param.Enum,
+			Pattern:      param.Pattern,
+			MinLength:    optionalInt32(param.MinLength),
+			MaxLength:    optionalInt32(param.MaxLength),
+			Description:  param.Description,
+			DefaultValue: param.DefaultValue,
+		})
+	}
+
+	patterns := make(map[string]*resourcev1.PathPatterns, len(definition.Patterns))
+	for provider, providerPatterns := range definition.Patterns {
+		patterns[provider] = &resourcev1.PathPatterns{
+			UrlType:  providerPatterns.URLType,
+			Patterns: providerPatterns.Patterns,
+		}
+	}
+
+	children := make([]*resourcev1.PathDefinition, 0, len(definition.Children))
+	for _, child := range definition.Children {
+		children = append(children, newPathDefinition(child))
+	}
+
+	return &resourcev1.PathDefinition{
+		Name:                   definition.Name,
+		DisplayName:            definition.DisplayName,
+		Description:            definition.Description,
+		Parent:                 definition.Parent,
+		Version:                int32(definition.Version),
+		Deprecated:             definition.Deprecated,
+		AllowedScopes:          definition.AllowedScopes,
+		Parameters:             parameters,
+		Providers:              definition.Providers,
+		Patterns:               patterns,
+		DefaultStorageMetadata: newStorageDefaults(definition.DefaultStorageMetadata),
+		Children:               children,
+	}
+}
+
+func newStorageDefaults(defaults *dto.StorageDefaultsResponse) *resourcev1.StorageDefaults {
+	if defaults == nil {
+		return nil
+	}
+
+	var cacheControl *resourcev1.CacheControlDefaults
+	if defaults.CacheControl != nil {
+		cacheControl = &resourcev1.CacheControlDefaults{
+			MaxAge:      defaults.CacheControl.MaxAge,
+			AllowPublic: defaults.CacheControl.AllowPublic,
+			Default:     defaults.CacheControl.Default,
+		}
+	}
+
+	checksums := make([]string, 0, len(defaults.RequiredChecksums))
+	for _, algorithm := range defaults.RequiredChecksums {
+		checksums = append(checksums, string(algorithm))
+	}
+
+	return &resourcev1.StorageDefaults{
+		CacheControl:      cacheControl,
+		RequiredChecksums: checksums,
+		CustomHeaders:     defaults.CustomHeaders,
+	}
+}
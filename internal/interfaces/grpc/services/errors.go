@@ -0,0 +1,58 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+// kindCodes maps each kind of domain error to the gRPC code matching the
+// HTTP status middleware.ErrorHandler responds with
+var kindCodes = map[domainerr.Kind]codes.Code{
+	domainerr.NotFound:            codes.NotFound,
+	domainerr.Conflict:            codes.AlreadyExists,
+	domainerr.Validation:          codes.InvalidArgument,
+	domainerr.PreconditionFailed:  codes.FailedPrecondition,
+	domainerr.ProviderUnavailable: codes.Unavailable,
+	domainerr.Unsupported:         codes.Unimplemented,
+	domainerr.Forbidden:           codes.PermissionDenied,
+	domainerr.TooLarge:            codes.InvalidArgument,
+	domainerr.Unprocessable:       codes.InvalidArgument,
+}
+
+// toStatus converts a usecase error to a gRPC status error. Errors without a
+// kind are reported with the fallback code, as the REST handler of the same
+// operation does.
+func toStatus(err error, fallback codes.Code, msg string) error {
+	code := fallback
+	switch {
+	case errors.Is(err, stdcontext.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, stdcontext.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	default:
+		if kindCode, ok := kindCodes[domainerr.KindOf(err)]; ok {
+			code = kindCode
+		}
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
+// invalidArgument reports a request failing validation
+func invalidArgument(msg string, err error) error {
+	return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+}
+
+// scopeValue narrows a request's scope value to the resolver's range
+func scopeValue(value int32) (int16, error) {
+	if value < 0 || value > math.MaxInt16 {
+		return 0, invalidArgument("Invalid request data", fmt.Errorf("scope_value must be between 0 and %d", math.MaxInt16))
+	}
+	return int16(value), nil
+}
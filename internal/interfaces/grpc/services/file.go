@@ -0,0 +1,211 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+// FileService implements the file listing and signed URL RPCs
+type FileService struct {
+	resourcev1.UnimplementedFileServiceServer
+	useCase *usecases.FileOperationsUseCase
+}
+
+// NewFileService creates a new file service
+func NewFileService(useCase *usecases.FileOperationsUseCase) *FileService {
+	return &FileService{
+		useCase: useCase,
+	}
+}
+
+// ListFiles mirrors GET /api/v1/resources/:provider/:definition, streaming
+// every page of the listing
+func (s *FileService) ListFiles(req *resourcev1.ListFilesRequest, stream resourcev1.FileService_ListFilesServer) error {
+	if err := validation.ValidateProvider(req.Provider); err != nil {
+		return invalidArgument("Invalid provider", err)
+	}
+	if err := validation.ValidateDefinition(req.Definition); err != nil {
+		return invalidArgument("Invalid definition", err)
+	}
+
+	maxKeys := int(req.MaxKeys)
+	if maxKeys == 0 {
+		maxKeys = 1000
+	}
+	if validationErrors := validation.ValidateListParameters(maxKeys, req.ContinuationToken, req.Prefix); len(validationErrors) > 0 {
+		return invalidArgument("Invalid request data", validationErrors)
+	}
+	if req.MaxPages < 0 {
+		return invalidArgument("Invalid request data", errors.New("max_pages cannot be negative"))
+	}
+
+	options, err := listOptions(req)
+	if err != nil {
+		return err
+	}
+
+	listReq := &dto.ListFilesRequest{
+		Provider:          req.Provider,
+		Definition:        req.Definition,
+		MaxKeys:           int32(maxKeys),
+		ContinuationToken: req.ContinuationToken,
+		Prefix:            req.Prefix,
+		Options:           options,
+	}
+
+	ctx := toContext(stream.Context())
+	for pages := int32(1); ; pages++ {
+		result, err := s.useCase.ListFiles(ctx, listReq)
+		if err != nil {
+			return toStatus(err, codes.Internal, "Failed to list files")
+		}
+		if err := stream.Send(newFileListPage(result)); err != nil {
+			return err
+		}
+
+		if !result.IsTruncated || result.ContinuationToken == "" || (req.MaxPages > 0 && pages >= req.MaxPages) {
+			return nil
+		}
+		listReq.ContinuationToken = result.ContinuationToken
+	}
+}
+
+// listOptions collects the listing refinements of a request, as the REST
+// handler does from the query string. It returns nil when none are set.
+func listOptions(req *resourcev1.ListFilesRequest) (*dto.ListFilesOptions, error) {
+	value, err := scopeValue(req.ScopeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	options := dto.ListFilesOptions{
+		Delimiter:       req.Delimiter,
+		Scope:           req.Scope,
+		ScopeValue:      value,
+		Parameters:      req.Parameters,
+		ModifiedAfter:   fromTimestamp(req.ModifiedAfter),
+		ModifiedBefore:  fromTimestamp(req.ModifiedBefore),
+		MinSize:         req.MinSize,
+		MaxSize:         req.MaxSize,
+		ContentType:     req.ContentType,
+		IncludeMetadata: req.IncludeMetadata,
+		Sort:            req.Sort,
+		Order:           req.Order,
+	}
+	set := options.Delimiter != "" || options.Scope != "" || options.ScopeValue != 0 ||
+		len(options.Parameters) > 0 || options.ModifiedAfter != nil || options.ModifiedBefore != nil ||
+		options.MinSize != nil || options.MaxSize != nil || options.ContentType != "" ||
+		options.IncludeMetadata || options.Sort != "" || options.Order != ""
+	if !set {
+		return nil, nil
+	}
+
+	if validationErrors := validation.ValidateStruct(&options); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+	if options.MinSize != nil && options.MaxSize != nil && *options.MinSize > *options.MaxSize {
+		return nil, invalidArgument("Invalid request data", errors.New("min_size cannot exceed max_size"))
+	}
+	if options.ModifiedAfter != nil && options.ModifiedBefore != nil && options.ModifiedAfter.After(*options.ModifiedBefore) {
+		return nil, invalidArgument("Invalid request data", errors.New("modified_after cannot be later than modified_before"))
+	}
+
+	return &options, nil
+}
+
+func newFileListPage(result *dto.FileListResponse) *resourcev1.FileListPage {
+	files := make([]*resourcev1.FileInfo, 0, len(result.Files))
+	for _, file := range result.Files {
+		files = append(files, &resourcev1.FileInfo{
+			Key:          file.Key,
+			Size:         file.Size,
+			ContentType:  file.ContentType,
+			Etag:         file.ETag,
+			LastModified: timestamp(file.LastModified),
+			Metadata:     toStruct(file.Metadata),
+		})
+	}
+
+	return &resourcev1.FileListPage{
+		Files:             files,
+		CommonPrefixes:    result.CommonPrefixes,
+		ContinuationToken: result.ContinuationToken,
+		IsTruncated:       result.IsTruncated,
+		MaxKeys:           int32(result.MaxKeys),
+	}
+}
+
+// GenerateUploadURL mirrors POST /api/v1/resources/:provider/:definition/upload
+func (s *FileService) GenerateUploadURL(ctx stdcontext.Context, req *resourcev1.GenerateUploadURLRequest) (*resourcev1.SignedURL, error) {
+	if err := validation.ValidateProvider(req.Provider); err != nil {
+		return nil, invalidArgument("Invalid provider", err)
+	}
+	if err := validation.ValidateDefinition(req.Definition); err != nil {
+		return nil, invalidArgument("Invalid definition", err)
+	}
+
+	value, err := scopeValue(req.ScopeValue)
+	if err != nil {
+		return nil, err
+	}
+	upload := dto.UploadRequest{
+		Parameters:    req.Parameters,
+		Scope:         req.Scope,
+		ScopeValue:    value,
+		Expiry:        expiry(req.Expiry),
+		Metadata:      fromUploadMetadata(req.Metadata),
+		ContentLength: req.ContentLength,
+		Checksum:      fromChecksum(req.Checksum),
+	}
+	if validationErrors := validation.ValidateStruct(&upload); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.GenerateUploadURL(toContext(ctx), &dto.GenerateUploadURLRequest{
+		Provider:   req.Provider,
+		Definition: req.Definition,
+		Upload:     &upload,
+		ClientIP:   clientIP(ctx),
+	})
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to generate upload URL")
+	}
+
+	return newSignedURL(result), nil
+}
+
+// GenerateDownloadURL mirrors POST /api/v1/resources/:provider/*/download
+func (s *FileService) GenerateDownloadURL(ctx stdcontext.Context, req *resourcev1.GenerateDownloadURLRequest) (*resourcev1.SignedURL, error) {
+	if err := validation.ValidateProvider(req.Provider); err != nil {
+		return nil, invalidArgument("Invalid provider", err)
+	}
+	if err := validation.ValidateFilePath(req.FilePath); err != nil {
+		return nil, invalidArgument("Invalid file path", err)
+	}
+
+	download := dto.DownloadRequest{
+		Expiry:          expiry(req.Expiry),
+		ResponseHeaders: req.ResponseHeaders,
+	}
+	if validationErrors := validation.ValidateStruct(&download); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.GenerateDownloadURL(toContext(ctx), &dto.GenerateDownloadURLRequest{
+		Provider: req.Provider,
+		FilePath: req.FilePath,
+		Download: &download,
+	})
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to generate download URL")
+	}
+
+	return newSignedURL(result), nil
+}
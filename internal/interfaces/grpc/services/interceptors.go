@@ -0,0 +1,99 @@
+package services
+
+import (
+	stdcontext "context"
+	"crypto/subtle"
+	"log"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryInterceptor recovers from panics in unary RPCs and logs each call, as
+// the recover and logger middleware do for the REST API
+func UnaryInterceptor(ctx stdcontext.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+		logCall(info.FullMethod, start, err)
+	}()
+
+	return handler(ctx, req)
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming RPCs
+func StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+		logCall(info.FullMethod, start, err)
+	}()
+
+	return handler(srv, stream)
+}
+
+// AdminAuth returns interceptors admitting calls to the named services only
+// with one of the tokens as "authorization: Bearer <token>" metadata, as the
+// admin middleware does for the REST API. Calls to other services pass
+// through.
+func AdminAuth(tokens []string, serviceNames ...string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	allowed := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		if token != "" {
+			allowed = append(allowed, []byte(token))
+		}
+	}
+
+	authorize := func(ctx stdcontext.Context, fullMethod string) error {
+		service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		if !slices.Contains(serviceNames, service) {
+			return nil
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return status.Error(codes.Unauthenticated, "missing admin token")
+		}
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return status.Error(codes.Unauthenticated, "missing admin token")
+		}
+		for _, candidate := range allowed {
+			if subtle.ConstantTimeCompare([]byte(token), candidate) == 1 {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "invalid admin token")
+	}
+
+	unary := func(ctx stdcontext.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+	return unary, stream
+}
+
+func logCall(method string, start time.Time, err error) {
+	log.Printf("[gRPC] %s - %s (%s)", status.Code(err), method, time.Since(start))
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	stdcontext "context"
+
+	"google.golang.org/grpc/codes"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+// MultipartService implements the multipart upload RPCs
+type MultipartService struct {
+	resourcev1.UnimplementedMultipartServiceServer
+	useCase *usecases.MultipartUseCase
+}
+
+// NewMultipartService creates a new multipart service
+func NewMultipartService(useCase *usecases.MultipartUseCase) *MultipartService {
+	return &MultipartService{
+		useCase: useCase,
+	}
+}
+
+// InitMultipartUpload mirrors POST /api/v1/resources/multipart/init
+func (s *MultipartService) InitMultipartUpload(ctx stdcontext.Context, req *resourcev1.InitMultipartUploadRequest) (*resourcev1.InitMultipartUploadResponse, error) {
+	value, err := scopeValue(req.ScopeValue)
+	if err != nil {
+		return nil, err
+	}
+	initReq := dto.MultipartInitRequest{
+		DefinitionName: req.DefinitionName,
+		Provider:       req.Provider,
+		Scope:          req.Scope,
+		ScopeValue:     value,
+		ParamResolver:  req.Parameters,
+		Metadata:       fromUploadMetadata(req.Metadata),
+	}
+	if validationErrors := validation.ValidateStruct(&initReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.InitMultipartUpload(toContext(ctx), &initReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to initialize multipart upload")
+	}
+
+	return &resourcev1.InitMultipartUploadResponse{
+		UploadId:    result.UploadID,
+		Path:        result.Path,
+		Provider:    result.Provider,
+		MaxPartSize: result.MaxPartSize,
+		MinPartSize: result.MinPartSize,
+		MaxParts:    int32(result.MaxParts),
+	}, nil
+}
+
+// GetMultipartURLs mirrors POST /api/v1/resources/multipart/urls
+func (s *MultipartService) GetMultipartURLs(ctx stdcontext.Context, req *resourcev1.GetMultipartURLsRequest) (*resourcev1.GetMultipartURLsResponse, error) {
+	parts := make([]*dto.PartRequest, 0, len(req.Parts))
+	for _, part := range req.Parts {
+		partReq := &dto.PartRequest{PartNumber: int(part.PartNumber)}
+		if checksum := fromChecksum(part.Checksum); checksum != nil {
+			partReq.Checksum = *checksum
+		}
+		parts = append(parts, partReq)
+	}
+
+	urlsReq := dto.MultipartURLsRequest{
+		Path:       req.Path,
+		UploadID:   req.UploadId,
+		Provider:   req.Provider,
+		URLOptions: parts,
+	}
+	if validationErrors := validation.ValidateStruct(&urlsReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.GetMultipartURLs(toContext(ctx), &urlsReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to get multipart URLs")
+	}
+
+	partURLs := make([]*resourcev1.MultipartPartURL, 0, len(result.PartURLs))
+	for i := range result.PartURLs {
+		partURLs = append(partURLs, &resourcev1.MultipartPartURL{
+			PartNumber: int32(result.PartURLs[i].PartNumber),
+			Url:        newSignedURL(&result.PartURLs[i].SignedURLResponse),
+		})
+	}
+
+	return &resourcev1.GetMultipartURLsResponse{
+		PartUrls:    partURLs,
+		CompleteUrl: newSignedURL(&result.CompleteURL),
+		AbortUrl:    newSignedURL(&result.AbortURL),
+	}, nil
+}
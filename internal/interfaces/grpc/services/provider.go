@@ -0,0 +1,92 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+)
+
+// ProviderService implements the storage provider RPCs
+type ProviderService struct {
+	resourcev1.UnimplementedProviderServiceServer
+	useCase *usecases.ProviderUseCase
+}
+
+// NewProviderService creates a new provider service
+func NewProviderService(useCase *usecases.ProviderUseCase) *ProviderService {
+	return &ProviderService{
+		useCase: useCase,
+	}
+}
+
+// ListProviders mirrors GET /api/v1/resources/providers
+func (s *ProviderService) ListProviders(req *resourcev1.ListProvidersRequest, stream resourcev1.ProviderService_ListProvidersServer) error {
+	providers, err := s.useCase.ListProviders(toContext(stream.Context()))
+	if err != nil {
+		return toStatus(err, codes.Internal, "Failed to retrieve providers")
+	}
+
+	for _, provider := range providers {
+		if err := stream.Send(newProvider(provider)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetProvider mirrors GET /api/v1/resources/providers/:name
+func (s *ProviderService) GetProvider(ctx stdcontext.Context, req *resourcev1.GetProviderRequest) (*resourcev1.Provider, error) {
+	if req.Name == "" {
+		return nil, invalidArgument("Provider name is required", errors.New("name is empty"))
+	}
+
+	provider, err := s.useCase.GetProvider(toContext(ctx), req.Name)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to retrieve provider")
+	}
+
+	return newProvider(provider), nil
+}
+
+func newProvider(provider *dto.ProviderResponse) *resourcev1.Provider {
+	caps := provider.Capabilities
+
+	var multipart *resourcev1.MultipartCapabilities
+	if caps.Multipart != nil {
+		multipart = &resourcev1.MultipartCapabilities{
+			MinPartSize: caps.Multipart.MinPartSize,
+			MaxPartSize: caps.Multipart.MaxPartSize,
+			MaxParts:    int32(caps.Multipart.MaxParts),
+		}
+	}
+
+	checksums := make([]string, 0, len(caps.SupportsChecksumAlgorithms))
+	for _, algorithm := range caps.SupportsChecksumAlgorithms {
+		checksums = append(checksums, string(algorithm))
+	}
+
+	return &resourcev1.Provider{
+		Name: provider.Name,
+		Capabilities: &resourcev1.ProviderCapabilities{
+			SupportsRead:               caps.SupportsRead,
+			SupportsWrite:              caps.SupportsWrite,
+			SupportsDelete:             caps.SupportsDelete,
+			SupportsListing:            caps.SupportsListing,
+			SupportsMetadata:           caps.SupportsMetadata,
+			SupportsMultipart:          caps.SupportsMultipart,
+			SupportsResumableUploads:   caps.SupportsResumableUploads,
+			SupportsSignedUrls:         caps.SupportsSignedURLs,
+			SupportsChecksumAlgorithms: checksums,
+			MaxUploadSize:              caps.MaxUploadSize,
+			MaxExpiry:                  durationpb.New(caps.MaxExpiry),
+			MinExpiry:                  durationpb.New(caps.MinExpiry),
+			Multipart:                  multipart,
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import "errors"
+
+var errNegativeWindow = errors.New("offset and limit cannot be negative")
+
+// listBatchSize is the page size listings are read with while streaming them
+const listBatchSize = 100
+
+// streamList reads a listing in batches starting at offset and sends each
+// item, stopping after limit items when limit is positive. Errors of list are
+// returned as is, so it reports them as gRPC statuses.
+func streamList[T any](offset, limit int, list func(offset, limit int) ([]T, error), send func(T) error) error {
+	sent := 0
+	for {
+		batch := listBatchSize
+		if limit > 0 && limit-sent < batch {
+			batch = limit - sent
+		}
+
+		items, err := list(offset, batch)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := send(item); err != nil {
+				return err
+			}
+		}
+		sent += len(items)
+
+		if len(items) < batch || (limit > 0 && sent >= limit) {
+			return nil
+		}
+		offset += len(items)
+	}
+}
+
+// listWindow validates the offset and limit of a streamed listing
+func listWindow(offset, limit int32) (int, int, error) {
+	if offset < 0 || limit < 0 {
+		return 0, 0, invalidArgument("Invalid request data", errNegativeWindow)
+	}
+	return int(offset), int(limit), nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	stdcontext "context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// UploadService implements the RPCs inspecting and aborting tracked uploads
+type UploadService struct {
+	resourcev1.UnimplementedUploadServiceServer
+	useCase *usecases.UploadUseCase
+}
+
+// NewUploadService creates a new upload service
+func NewUploadService(useCase *usecases.UploadUseCase) *UploadService {
+	return &UploadService{
+		useCase: useCase,
+	}
+}
+
+// ListUploads mirrors GET /api/v1/admin/uploads
+func (s *UploadService) ListUploads(req *resourcev1.ListUploadsRequest, stream resourcev1.UploadService_ListUploadsServer) error {
+	filter := entity.UploadFilter{
+		Status:       req.Status,
+		ResourceType: req.ResourceType,
+		Provider:     req.Provider,
+	}
+	if filter.Status != "" && !entity.IsValidUploadStatus(filter.Status) {
+		return invalidArgument("Invalid request data", errors.New("status is not a known upload status"))
+	}
+
+	offset, limit, err := listWindow(req.Offset, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	ctx := toContext(stream.Context())
+	return streamList(offset, limit, func(offset, limit int) ([]*dto.UploadResponse, error) {
+		uploads, err := s.useCase.ListUploads(ctx, filter, offset, limit)
+		if err != nil {
+			return nil, toStatus(err, codes.Internal, "Failed to list uploads")
+		}
+		return uploads, nil
+	}, func(upload *dto.UploadResponse) error {
+		return stream.Send(newUpload(upload))
+	})
+}
+
+// GetUpload mirrors GET /api/v1/admin/uploads/:id
+func (s *UploadService) GetUpload(ctx stdcontext.Context, req *resourcev1.GetUploadRequest) (*resourcev1.Upload, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid upload ID", err)
+	}
+
+	result, err := s.useCase.GetUpload(toContext(ctx), req.Id)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to get upload")
+	}
+
+	return newUpload(result), nil
+}
+
+// AbortUpload mirrors POST /api/v1/admin/uploads/:id/abort
+func (s *UploadService) AbortUpload(ctx stdcontext.Context, req *resourcev1.AbortUploadRequest) (*resourcev1.AbortUploadResponse, error) {
+	if err := validation.ValidateUUID(req.Id); err != nil {
+		return nil, invalidArgument("Invalid upload ID", err)
+	}
+
+	abortReq := dto.AbortUploadRequest{Reason: req.Reason}
+	if validationErrors := validation.ValidateStruct(&abortReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	reason := abortReq.Reason
+	if reason == "" {
+		reason = "aborted by " + requestActor(ctx)
+	}
+
+	result, err := s.useCase.AbortUpload(toContext(ctx), req.Id, reason)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to abort upload")
+	}
+
+	return &resourcev1.AbortUploadResponse{
+		Upload:          newUpload(result.Upload),
+		ProviderAborted: result.ProviderAborted,
+	}, nil
+}
+
+// RunJanitor mirrors POST /api/v1/admin/uploads/janitor
+func (s *UploadService) RunJanitor(ctx stdcontext.Context, req *resourcev1.RunJanitorRequest) (*resourcev1.JanitorResult, error) {
+	janitorReq := dto.RunJanitorRequest{
+		Limit:  int(req.Limit),
+		DryRun: req.DryRun,
+	}
+	if validationErrors := validation.ValidateStruct(&janitorReq); len(validationErrors) > 0 {
+		return nil, invalidArgument("Invalid request data", validationErrors)
+	}
+
+	result, err := s.useCase.RunJanitor(toContext(ctx), &janitorReq)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal, "Failed to run upload janitor")
+	}
+
+	outcomes := make([]*resourcev1.JanitorUploadOutcome, 0, len(result.Uploads))
+	for _, outcome := range result.Uploads {
+		outcomes = append(outcomes, &resourcev1.JanitorUploadOutcome{
+			Id:              outcome.ID,
+			Provider:        outcome.Provider,
+			StorageKey:      outcome.StorageKey,
+			ExpiresAt:       timestamp(outcome.ExpiresAt),
+			Aborted:         outcome.Aborted,
+			ProviderAborted: outcome.ProviderAborted,
+			Error:           outcome.Error,
+		})
+	}
+
+	return &resourcev1.JanitorResult{
+		DryRun:  result.DryRun,
+		Scanned: int32(result.Scanned),
+		Aborted: int32(result.Aborted),
+		Failed:  int32(result.Failed),
+		Uploads: outcomes,
+	}, nil
+}
+
+func newUpload(upload *dto.UploadResponse) *resourcev1.Upload {
+	return &resourcev1.Upload{
+		Id:             upload.ID,
+		ResourceType:   upload.ResourceType,
+		ResourceId:     upload.ResourceID,
+		ResourceField:  upload.ResourceField,
+		UploadType:     upload.UploadType,
+		Status:         upload.Status,
+		Error:          upload.Error,
+		PathDefinition: upload.PathDefinition,
+		PathParameters: toStruct(upload.PathParameters),
+		Provider:       upload.Provider,
+		StorageKey:     upload.StorageKey,
+		Size:           upload.Size,
+		MultipartId:    upload.MultipartID,
+		TotalParts:     optionalInt32(upload.TotalParts),
+		UploadedParts:  optionalInt32(upload.UploadedParts),
+		CreatedAt:      timestamp(upload.CreatedAt),
+		UpdatedAt:      timestamp(upload.UpdatedAt),
+		CompletedAt:    optionalTimestamp(upload.CompletedAt),
+		ExpiresAt:      timestamp(upload.ExpiresAt),
+	}
+}
@@ -1,10 +1,11 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
-	"avironactive.com/common/context"
 	"avironactive.com/resource/upload"
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
 	"github.com/anh-nguyen/resource-server/internal/app/usecases"
@@ -40,16 +41,21 @@ func (h *AchievementHandler) CreateAchievement(c *fiber.Ctx) error {
 		)
 	}
 
+	if err := validation.ValidatePublishWindow(req.PublishAt, req.UnpublishAt); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", err.Error()),
+		)
+	}
+
 	if req.Provider == "" {
 		req.Provider = "r2"
 	}
+	req.Actor = requestActor(c)
 
-	ctx := context.Background()
+	ctx := toContext(c)
 	result, err := h.useCase.CreateAchievement(ctx, &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("CREATE_ERROR", "Failed to create achievement", err.Error()),
-		)
+		return failed(err, "CREATE_ERROR", "Failed to create achievement")
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(dto.NewSuccessResponse(result))
@@ -64,12 +70,10 @@ func (h *AchievementHandler) GetAchievement(c *fiber.Ctx) error {
 		)
 	}
 
-	ctx := context.Background()
+	ctx := toContext(c)
 	result, err := h.useCase.GetAchievement(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("GET_ERROR", "Failed to get achievement", err.Error()),
-		)
+		return failed(err, "GET_ERROR", "Failed to get achievement")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -92,6 +96,8 @@ func (h *AchievementHandler) UpdateAchievementIcon(c *fiber.Ctx) error {
 	}
 
 	req.AchievementID = id
+	req.Actor = requestActor(c)
+	req.ClientIP = c.IP()
 
 	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(
@@ -99,12 +105,10 @@ func (h *AchievementHandler) UpdateAchievementIcon(c *fiber.Ctx) error {
 		)
 	}
 
-	ctx := context.Background()
+	ctx := toContext(c)
 	result, err := h.useCase.UpdateAchievementIcon(ctx, &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("UPDATE_ERROR", "Failed to update achievement icon", err.Error()),
-		)
+		return failed(err, "UPDATE_ERROR", "Failed to update achievement icon")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -134,12 +138,10 @@ func (h *AchievementHandler) ConfirmUpload(c *fiber.Ctx) error {
 		)
 	}
 
-	ctx := context.Background()
+	ctx := toContext(c)
 	err := h.useCase.ConfirmUpload(ctx, &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("CONFIRM_ERROR", "Failed to confirm upload", err.Error()),
-		)
+		return failed(err, "CONFIRM_ERROR", "Failed to confirm upload")
 	}
 
 	return c.JSON(dto.NewSuccessResponseWithMessage(nil, "Upload confirmed successfully"))
@@ -154,9 +156,7 @@ func (h *AchievementHandler) GetMultipartURLs(c *fiber.Ctx) error {
 		)
 	}
 
-	var req struct {
-		PartCount int `json:"part_count" validate:"required,min=1,max=10000"`
-	}
+	var req dto.PartURLsRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(
 			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
@@ -170,20 +170,16 @@ func (h *AchievementHandler) GetMultipartURLs(c *fiber.Ctx) error {
 	}
 
 	uploadIDUUID, _ := uuid.Parse(uploadID)
-	ctx := context.Background()
+	ctx := toContext(c)
 
 	uploadRecord, err := h.uploadManager.GetUpload(ctx, upload.UploadID(uploadIDUUID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("UPLOAD_NOT_FOUND", "Upload not found", err.Error()),
-		)
+		return failed(err, "UPLOAD_NOT_FOUND", "Upload not found")
 	}
 
 	multipartURLs, err := h.uploadManager.GetPartURLs(ctx, uploadRecord, req.PartCount)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("MULTIPART_URLS_ERROR", "Failed to get multipart URLs", err.Error()),
-		)
+		return failed(err, "MULTIPART_URLS_ERROR", "Failed to get multipart URLs")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(multipartURLs))
@@ -203,7 +199,7 @@ func (h *AchievementHandler) ListAchievements(c *fiber.Ctx) error {
 
 	offset := (page - 1) * pageSize
 
-	ctx := context.Background()
+	ctx := toContext(c)
 	var result []*dto.AchievementResponse
 	var err error
 
@@ -214,13 +210,171 @@ func (h *AchievementHandler) ListAchievements(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("LIST_ERROR", "Failed to list achievements", err.Error()),
+		return failed(err, "LIST_ERROR", "Failed to list achievements")
+	}
+
+	response := map[string]interface{}{
+		"achievements": result,
+		"page":         page,
+		"pageSize":     pageSize,
+		"total":        len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+func (h *AchievementHandler) DeleteAchievement(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid achievement ID", err.Error()),
 		)
 	}
 
+	ctx := toContext(c)
+	if err := h.useCase.DeleteAchievement(ctx, id, requestActor(c)); err != nil {
+		return failed(err, "DELETE_ERROR", "Failed to delete achievement")
+	}
+
+	return c.JSON(dto.NewSuccessResponseWithMessage(nil, "Achievement deleted successfully"))
+}
+
+func (h *AchievementHandler) GetAchievementHistory(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid achievement ID", err.Error()),
+		)
+	}
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	ctx := toContext(c)
+	result, err := h.useCase.GetAchievementHistory(ctx, id, offset, pageSize)
+	if err != nil {
+		return failed(err, "HISTORY_ERROR", "Failed to get achievement history")
+	}
+
+	response := map[string]interface{}{
+		"revisions": result,
+		"page":      page,
+		"pageSize":  pageSize,
+		"total":     len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+func (h *AchievementHandler) RevertAchievement(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid achievement ID", err.Error()),
+		)
+	}
+
+	revision, err := c.ParamsInt("revision")
+	if err != nil || revision < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REVISION", "Invalid revision number", "revision must be a positive integer"),
+		)
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.RevertAchievement(ctx, id, revision, requestActor(c))
+	if err != nil {
+		return failed(err, "REVERT_ERROR", "Failed to revert achievement")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *AchievementHandler) UpdateAchievementSchedule(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid achievement ID", err.Error()),
+		)
+	}
+
+	var req dto.UpdateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	req.AchievementID = id
+	req.Actor = requestActor(c)
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	if err := validation.ValidatePublishWindow(req.PublishAt, req.UnpublishAt); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", err.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.UpdateAchievementSchedule(ctx, &req)
+	if err != nil {
+		return failed(err, "SCHEDULE_ERROR", "Failed to update achievement schedule")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *AchievementHandler) PreviewAchievements(c *fiber.Ctx) error {
+	at := time.Now()
+	if raw := c.Query("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_TIMESTAMP", "Invalid preview timestamp", "at must be an RFC3339 timestamp"),
+			)
+		}
+		at = parsed
+	}
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	ctx := toContext(c)
+	result, err := h.useCase.PreviewActiveAchievements(ctx, at, offset, pageSize)
+	if err != nil {
+		return failed(err, "PREVIEW_ERROR", "Failed to preview achievements")
+	}
+
 	response := map[string]interface{}{
 		"achievements": result,
+		"at":           at,
 		"page":         page,
 		"pageSize":     pageSize,
 		"total":        len(result),
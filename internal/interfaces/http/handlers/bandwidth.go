@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// bandwidthChunk bounds each paced read so throughput stays smooth
+const bandwidthChunk = 32 << 10
+
+// BandwidthLimiter caps the download throughput of each client. Concurrent
+// downloads by the same client share its budget.
+type BandwidthLimiter struct {
+	bytesPerSecond int64
+
+	mu      sync.Mutex
+	clients map[string]*clientBandwidth
+}
+
+type clientBandwidth struct {
+	// next is when the bytes already sent are paid for at the allowed rate
+	next    time.Time
+	streams int
+}
+
+// NewBandwidthLimiter creates a limiter allowing bytesPerSecond per client.
+// Zero disables the limit.
+func NewBandwidthLimiter(bytesPerSecond int64) *BandwidthLimiter {
+	return &BandwidthLimiter{
+		bytesPerSecond: bytesPerSecond,
+		clients:        make(map[string]*clientBandwidth),
+	}
+}
+
+// Reader paces reads from body to the client's budget. Closing the returned
+// reader closes body and releases the client once its last stream ends.
+func (l *BandwidthLimiter) Reader(client string, body io.ReadCloser) io.ReadCloser {
+	if l == nil || l.bytesPerSecond <= 0 {
+		return body
+	}
+
+	l.mu.Lock()
+	bandwidth, ok := l.clients[client]
+	if !ok {
+		bandwidth = &clientBandwidth{}
+		l.clients[client] = bandwidth
+	}
+	bandwidth.streams++
+	l.mu.Unlock()
+
+	return &limitedReader{limiter: l, client: client, body: body}
+}
+
+// reserve records n bytes sent to the client and returns how long to wait so
+// the client stays within its rate
+func (l *BandwidthLimiter) reserve(client string, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bandwidth := l.clients[client]
+	now := time.Now()
+	if bandwidth.next.Before(now) {
+		bandwidth.next = now
+	}
+	bandwidth.next = bandwidth.next.Add(time.Duration(n) * time.Second / time.Duration(l.bytesPerSecond))
+
+	return bandwidth.next.Sub(now)
+}
+
+func (l *BandwidthLimiter) release(client string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bandwidth := l.clients[client]
+	bandwidth.streams--
+	if bandwidth.streams == 0 {
+		delete(l.clients, client)
+	}
+}
+
+type limitedReader struct {
+	limiter *BandwidthLimiter
+	client  string
+	body    io.ReadCloser
+	once    sync.Once
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > bandwidthChunk {
+		p = p[:bandwidthChunk]
+	}
+
+	n, err := r.body.Read(p)
+	if n > 0 {
+		time.Sleep(r.limiter.reserve(r.client, n))
+	}
+	return n, err
+}
+
+func (r *limitedReader) Close() error {
+	r.once.Do(func() { r.limiter.release(r.client) })
+	return r.body.Close()
+}
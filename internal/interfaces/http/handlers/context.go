@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	stdcontext "context"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+
+	"avironactive.com/common/context"
+	"github.com/anh-nguyen/resource-server/internal/interfaces/http/middleware"
+)
+
+// toContext derives the usecase context from the request context set by
+// middleware.RequestContext, so usecases stop once the request's deadline
+// passes or its handler returns
+func toContext(c *fiber.Ctx) context.Context {
+	ctx := context.NewContext(c.UserContext())
+	ctx.Set("request_id", c.Get("X-Request-ID"))
+	ctx.Set("principal", requestActor(c))
+
+	return ctx
+}
+
+// requestActor identifies the principal performing a change for audit
+// history. The X-Principal-ID header is set by the client and not
+// authenticated, so history records who the caller claimed to be.
+func requestActor(c *fiber.Ctx) string {
+	if actor := c.Get("X-Principal-ID"); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+// streamBody keeps the request context alive while body is streamed after
+// the handler returns, cancelling it once the body is closed
+func streamBody(c *fiber.Ctx, body io.ReadCloser) io.ReadCloser {
+	return &cancelOnClose{ReadCloser: body, cancel: middleware.DeferCancel(c)}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel stdcontext.CancelFunc
+}
+
+func (r *cancelOnClose) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+// DefinitionRegistryHandler handles the admin endpoints managing runtime
+// resource definitions
+type DefinitionRegistryHandler struct {
+	registry *usecases.DefinitionRegistry
+}
+
+// NewDefinitionRegistryHandler creates a new definition registry handler
+func NewDefinitionRegistryHandler(registry *usecases.DefinitionRegistry) *DefinitionRegistryHandler {
+	return &DefinitionRegistryHandler{
+		registry: registry,
+	}
+}
+
+// ListDefinitions handles GET /api/v1/admin/definitions
+func (h *DefinitionRegistryHandler) ListDefinitions(c *fiber.Ctx) error {
+	definitions, err := h.registry.ListDefinitions(toContext(c))
+	if err != nil {
+		return failed(err, "DEFINITIONS_ERROR", "Failed to retrieve definitions")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(definitions))
+}
+
+// CreateDefinition handles POST /api/v1/admin/definitions
+func (h *DefinitionRegistryHandler) CreateDefinition(c *fiber.Ctx) error {
+	var req dto.CreateDefinitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	result, err := h.registry.CreateDefinition(toContext(c), &req, requestActor(c))
+	if err != nil {
+		return failed(err, "CREATE_ERROR", "Failed to create definition")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.NewSuccessResponse(result))
+}
+
+// UpdateDefinition handles PUT /api/v1/admin/definitions/:name
+func (h *DefinitionRegistryHandler) UpdateDefinition(c *fiber.Ctx) error {
+	var req dto.UpdateDefinitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	result, err := h.registry.UpdateDefinition(toContext(c), c.Params("name"), &req, requestActor(c))
+	if err != nil {
+		return failed(err, "UPDATE_ERROR", "Failed to update definition")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// DeprecateDefinition handles POST /api/v1/admin/definitions/:name/deprecate
+func (h *DefinitionRegistryHandler) DeprecateDefinition(c *fiber.Ctx) error {
+	result, err := h.registry.DeprecateDefinition(toContext(c), c.Params("name"), requestActor(c))
+	if err != nil {
+		return failed(err, "UPDATE_ERROR", "Failed to deprecate definition")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// ListVersions handles GET /api/v1/admin/definitions/:name/versions
+func (h *DefinitionRegistryHandler) ListVersions(c *fiber.Ctx) error {
+	versions, err := h.registry.ListVersions(toContext(c), c.Params("name"))
+	if err != nil {
+		return failed(err, "DEFINITIONS_ERROR", "Failed to retrieve definition versions")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(versions))
+}
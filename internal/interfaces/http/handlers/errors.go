@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
+)
+
+// failed hands a usecase error to middleware.ErrorHandler, naming the
+// operation that failed. Classified errors keep their status and code;
+// anything else is reported as a 500 with the operation's code.
+func failed(err error, code, message string) error {
+	return &domainerr.Error{
+		Kind:    domainerr.Internal,
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
@@ -1,7 +1,16 @@
 package handlers
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
 	"github.com/anh-nguyen/resource-server/internal/app/usecases"
@@ -11,13 +20,15 @@ import (
 
 // FileOperationsHandler handles file operation endpoints
 type FileOperationsHandler struct {
-	useCase *usecases.FileOperationsUseCase
+	useCase   *usecases.FileOperationsUseCase
+	bandwidth *BandwidthLimiter
 }
 
 // NewFileOperationsHandler creates a new file operations handler
-func NewFileOperationsHandler(useCase *usecases.FileOperationsUseCase) *FileOperationsHandler {
+func NewFileOperationsHandler(useCase *usecases.FileOperationsUseCase, bandwidth *BandwidthLimiter) *FileOperationsHandler {
 	return &FileOperationsHandler{
-		useCase: useCase,
+		useCase:   useCase,
+		bandwidth: bandwidth,
 	}
 }
 
@@ -51,6 +62,14 @@ func (h *FileOperationsHandler) ListFiles(c *fiber.Ctx) error {
 		)
 	}
 
+	// Parse listing options
+	options, err := parseListOptions(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid query parameters", err.Error()),
+		)
+	}
+
 	// Create structured request
 	req := &dto.ListFilesRequest{
 		Provider:          provider,
@@ -58,14 +77,13 @@ func (h *FileOperationsHandler) ListFiles(c *fiber.Ctx) error {
 		MaxKeys:           int32(maxKeys),
 		ContinuationToken: continuationToken,
 		Prefix:            prefix,
+		Options:           options,
 	}
 
 	// Call use case
 	result, err := h.useCase.ListFiles(toContext(c), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("LIST_FILES_ERROR", "Failed to list files", err.Error()),
-		)
+		return failed(err, "LIST_FILES_ERROR", "Failed to list files")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -109,19 +127,158 @@ func (h *FileOperationsHandler) GenerateUploadURL(c *fiber.Ctx) error {
 		Provider:   provider,
 		Definition: definition,
 		Upload:     &req,
+		ClientIP:   c.IP(),
 	}
 
 	// Call use case
 	result, err := h.useCase.GenerateUploadURL(toContext(c), uploadReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("UPLOAD_URL_ERROR", "Failed to generate upload URL", err.Error()),
-		)
+		return failed(err, "UPLOAD_URL_ERROR", "Failed to generate upload URL")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
 }
 
+// UploadContent handles PUT /api/v1/resources/:provider/:definition/content.
+// The body is the raw file, or multipart/form-data whose "file" part follows
+// any parameter fields. Parameters use the same names as the query string.
+func (h *FileOperationsHandler) UploadContent(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	definition := c.Params("definition")
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	if err := validation.ValidateDefinition(definition); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_DEFINITION", "Invalid definition", err.Error()),
+		)
+	}
+
+	req := &dto.ContentUploadRequest{
+		Provider:      provider,
+		Definition:    definition,
+		ContentType:   c.Get(fiber.HeaderContentType),
+		ContentLength: -1,
+		Upload:        &dto.UploadRequest{},
+	}
+	if length := c.Request().Header.ContentLength(); length >= 0 {
+		req.ContentLength = int64(length)
+	}
+
+	for key, value := range c.Queries() {
+		if err := setContentUploadField(req, key, value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_REQUEST", "Invalid query parameters", err.Error()),
+			)
+		}
+	}
+
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	if mediaType, params, err := mime.ParseMediaType(req.ContentType); err == nil && mediaType == fiber.MIMEMultipartForm {
+		part, err := contentFormFile(multipart.NewReader(body, params["boundary"]), req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_REQUEST", "Invalid multipart body", err.Error()),
+			)
+		}
+		defer part.Close()
+
+		body = part
+		req.ContentType = part.Header.Get(fiber.HeaderContentType)
+		req.ContentLength = -1
+	}
+
+	// Validate request
+	if validationErrors := validation.ValidateStruct(req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	// Call use case
+	result, err := h.useCase.UploadContent(toContext(c), req, body)
+	if err != nil {
+		return failed(err, "UPLOAD_ERROR", "Failed to upload file")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.NewSuccessResponse(result))
+}
+
+// contentFileField is the multipart form field holding the uploaded file
+const contentFileField = "file"
+
+// contentFormFile applies the form fields preceding the file part to req and
+// returns the file part, positioned to stream its contents
+func contentFormFile(form *multipart.Reader, req *dto.ContentUploadRequest) (*multipart.Part, error) {
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("missing %q file part", contentFileField)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == contentFileField {
+			return part, nil
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, 1024))
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+		if err := setContentUploadField(req, part.FormName(), string(value)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// setContentUploadField applies a query or form field to a content upload.
+// Unknown names are ignored.
+func setContentUploadField(req *dto.ContentUploadRequest, name, value string) error {
+	if parameter, ok := strings.CutPrefix(name, listParameterPrefix); ok {
+		if req.Upload.Parameters == nil {
+			req.Upload.Parameters = make(map[string]string)
+		}
+		req.Upload.Parameters[parameter] = value
+		return nil
+	}
+
+	switch name {
+	case "scope":
+		req.Upload.Scope = value
+	case "scope_value":
+		scopeValue, err := strconv.ParseInt(value, 10, 16)
+		if err != nil {
+			return fmt.Errorf("scope_value must be an integer")
+		}
+		req.Upload.ScopeValue = int16(scopeValue)
+	case "resource_id":
+		req.ResourceID = value
+	case "checksum_algorithm":
+		if req.Upload.Checksum == nil {
+			req.Upload.Checksum = &dto.ChecksumInfo{}
+		}
+		req.Upload.Checksum.Algorithm = value
+	case "checksum":
+		if req.Upload.Checksum == nil {
+			req.Upload.Checksum = &dto.ChecksumInfo{}
+		}
+		req.Upload.Checksum.Value = value
+	}
+	return nil
+}
+
 // GenerateDownloadURL handles POST /api/v1/resources/:provider/*/download
 func (h *FileOperationsHandler) GenerateDownloadURL(c *fiber.Ctx) error {
 	provider := c.Params("provider")
@@ -171,9 +328,7 @@ func (h *FileOperationsHandler) GenerateDownloadURL(c *fiber.Ctx) error {
 	// Call use case
 	result, err := h.useCase.GenerateDownloadURL(toContext(c), downloadReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("DOWNLOAD_URL_ERROR", "Failed to generate download URL", err.Error()),
-		)
+		return failed(err, "DOWNLOAD_URL_ERROR", "Failed to generate download URL")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -206,9 +361,7 @@ func (h *FileOperationsHandler) DeleteFile(c *fiber.Ctx) error {
 	// Call use case
 	err := h.useCase.DeleteFile(toContext(c), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("DELETE_ERROR", "Failed to delete file", err.Error()),
-		)
+		return failed(err, "DELETE_ERROR", "Failed to delete file")
 	}
 
 	return c.JSON(dto.NewSuccessResponseWithMessage(nil, "File deleted successfully"))
@@ -243,14 +396,166 @@ func (h *FileOperationsHandler) GetFileMetadata(c *fiber.Ctx) error {
 	// Call use case
 	result, err := h.useCase.GetFileMetadata(toContext(c), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("METADATA_ERROR", "Failed to get file metadata", err.Error()),
-		)
+		return failed(err, "METADATA_ERROR", "Failed to get file metadata")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
 }
 
+// DownloadContent handles GET /api/v1/resources/:provider/*/content. It
+// streams the file through the server, honouring Range and conditional
+// request headers; disposition and filename override Content-Disposition.
+func (h *FileOperationsHandler) DownloadContent(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	// Get the wildcard path parameter and remove "/content" suffix
+	filePath := c.Params("*")
+	filePath = strings.TrimSuffix(filePath, "/content")
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	if err := validation.ValidateFilePath(filePath); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_FILE_PATH", "Invalid file path", err.Error()),
+		)
+	}
+
+	// Create structured request
+	req := &dto.ContentDownloadRequest{
+		Provider:        provider,
+		FilePath:        filePath,
+		Range:           c.Get(fiber.HeaderRange),
+		IfRange:         c.Get(fiber.HeaderIfRange),
+		IfNoneMatch:     c.Get(fiber.HeaderIfNoneMatch),
+		IfModifiedSince: c.Get(fiber.HeaderIfModifiedSince),
+		Disposition:     c.Query("disposition"),
+		FileName:        c.Query("filename"),
+		SkipBody:        c.Method() == fiber.MethodHead,
+	}
+
+	// Validate request
+	if validationErrors := validation.ValidateStruct(req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid query parameters", validationErrors.Error()),
+		)
+	}
+
+	// Call use case
+	download, err := h.useCase.DownloadContent(toContext(c), req)
+	if err != nil {
+		var rangeErr *usecases.RangeNotSatisfiableError
+		if errors.As(err, &rangeErr) {
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", rangeErr.Size))
+			return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(
+				dto.NewErrorResponse("RANGE_NOT_SATISFIABLE", "Requested range not satisfiable", err.Error()),
+			)
+		}
+		return failed(err, "DOWNLOAD_ERROR", "Failed to download file")
+	}
+
+	object := download.Metadata
+	if download.ETag != "" {
+		c.Set(fiber.HeaderETag, download.ETag)
+	}
+	if object.LastModified != nil {
+		c.Set(fiber.HeaderLastModified, object.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if object.CacheControl != "" {
+		c.Set(fiber.HeaderCacheControl, object.CacheControl)
+	}
+
+	if download.NotModified {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	if object.ContentType != "" {
+		c.Set(fiber.HeaderContentType, object.ContentType)
+	}
+	if object.ContentEncoding != "" {
+		c.Set(fiber.HeaderContentEncoding, object.ContentEncoding)
+	}
+	if object.ContentLanguage != "" {
+		c.Set(fiber.HeaderContentLanguage, object.ContentLanguage)
+	}
+	if download.ContentDisposition != "" {
+		c.Set(fiber.HeaderContentDisposition, download.ContentDisposition)
+	}
+
+	status := fiber.StatusOK
+	if download.Range != nil {
+		status = fiber.StatusPartialContent
+		c.Set(fiber.HeaderContentRange, download.Range.ContentRange(object.Size))
+	}
+	c.Status(status)
+
+	if download.Body == nil {
+		c.Response().Header.SetContentLength(int(download.Length))
+		return nil
+	}
+
+	return c.SendStream(streamBody(c, h.bandwidth.Reader(c.IP(), download.Body)), int(download.Length))
+}
+
+// DownloadBundle handles POST /api/v1/resources/:provider/:definition/bundle.
+// The archive is streamed as it is built, so its length is only known when
+// it is served from the bundle cache.
+func (h *FileOperationsHandler) DownloadBundle(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+	definition := c.Params("definition")
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	if err := validation.ValidateDefinition(definition); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_DEFINITION", "Invalid definition", err.Error()),
+		)
+	}
+
+	// Parse request body
+	var req dto.BundleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+	req.Provider = provider
+	req.Definition = definition
+
+	// Validate request body
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	// Call use case
+	bundle, err := h.useCase.DownloadBundle(toContext(c), &req)
+	if err != nil {
+		return failed(err, "BUNDLE_ERROR", "Failed to build bundle")
+	}
+
+	c.Set(fiber.HeaderContentType, bundle.ContentType())
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": bundle.FileName(definition)}))
+	c.Set(fiber.HeaderETag, `"`+bundle.Hash+`"`)
+	if bundle.Cached {
+		c.Set("X-Bundle-Cache", "hit")
+	} else {
+		c.Set("X-Bundle-Cache", "miss")
+	}
+
+	return c.SendStream(streamBody(c, h.bandwidth.Reader(c.IP(), bundle.Body)), int(bundle.Size))
+}
+
 // UpdateFileMetadata handles PUT /api/v1/resources/:provider/*/metadata
 func (h *FileOperationsHandler) UpdateFileMetadata(c *fiber.Ctx) error {
 	provider := c.Params("provider")
@@ -296,10 +601,277 @@ func (h *FileOperationsHandler) UpdateFileMetadata(c *fiber.Ctx) error {
 	// Call use case
 	result, err := h.useCase.UpdateFileMetadata(toContext(c), updateReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("METADATA_UPDATE_ERROR", "Failed to update file metadata", err.Error()),
+		return failed(err, "METADATA_UPDATE_ERROR", "Failed to update file metadata")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// CopyFile handles POST /api/v1/resources/:provider/*/copy
+func (h *FileOperationsHandler) CopyFile(c *fiber.Ctx) error {
+	return h.copyFile(c, "/copy", false)
+}
+
+// MoveFile handles POST /api/v1/resources/:provider/*/move
+func (h *FileOperationsHandler) MoveFile(c *fiber.Ctx) error {
+	return h.copyFile(c, "/move", true)
+}
+
+func (h *FileOperationsHandler) copyFile(c *fiber.Ctx, suffix string, move bool) error {
+	provider := c.Params("provider")
+	// Get the wildcard path parameter and remove the operation suffix
+	filePath := c.Params("*")
+	filePath = strings.TrimSuffix(filePath, suffix)
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	if err := validation.ValidateFilePath(filePath); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_FILE_PATH", "Invalid file path", err.Error()),
+		)
+	}
+
+	// Parse request body
+	var req dto.CopyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	// Validate request body
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	if req.DestinationPath != "" {
+		if err := validation.ValidateFilePath(req.DestinationPath); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_FILE_PATH", "Invalid destination path", err.Error()),
+			)
+		}
+	}
+
+	// Create structured request
+	copyReq := &dto.CopyFileRequest{
+		Provider:   provider,
+		SourcePath: filePath,
+		Copy:       &req,
+		Move:       move,
+		Actor:      requestActor(c),
+	}
+
+	// Call use case
+	result, err := h.useCase.CopyFile(toContext(c), copyReq)
+	if err != nil {
+		if move {
+			return failed(err, "MOVE_ERROR", "Failed to move file")
+		}
+		return failed(err, "COPY_ERROR", "Failed to copy file")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// BatchDelete handles POST /api/v1/resources/:provider/batch/delete
+func (h *FileOperationsHandler) BatchDelete(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	// Parse request body
+	var req dto.BatchTargetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
 		)
 	}
 
+	// Validate request body
+	if err := validateBatchTarget(&req, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", err.Error()),
+		)
+	}
+
+	// Create structured request
+	deleteReq := &dto.BatchDeleteRequest{
+		Provider: provider,
+		Target:   &req,
+		DryRun:   c.QueryBool("dry_run", false),
+	}
+
+	// Call use case
+	result, err := h.useCase.BatchDelete(toContext(c), deleteReq)
+	if err != nil {
+		return failed(err, "BATCH_DELETE_ERROR", "Failed to delete files")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// BatchUpdateMetadata handles POST /api/v1/resources/:provider/batch/metadata
+func (h *FileOperationsHandler) BatchUpdateMetadata(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	// Validate path parameters
+	if err := validation.ValidateProvider(provider); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PROVIDER", "Invalid provider", err.Error()),
+		)
+	}
+
+	// Parse request body
+	var req dto.BatchMetadataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	// Validate request body
+	if err := validateBatchTarget(&req, &req.BatchTargetRequest); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", err.Error()),
+		)
+	}
+
+	// Create structured request
+	updateReq := &dto.BatchUpdateMetadataRequest{
+		Provider: provider,
+		Batch:    &req,
+		DryRun:   c.QueryBool("dry_run", false),
+	}
+
+	// Call use case
+	result, err := h.useCase.BatchUpdateMetadata(toContext(c), updateReq)
+	if err != nil {
+		return failed(err, "BATCH_METADATA_ERROR", "Failed to update file metadata")
+	}
+
 	return c.JSON(dto.NewSuccessResponse(result))
 }
+
+// validateBatchTarget validates the request body and each explicit path
+func validateBatchTarget(req interface{}, target *dto.BatchTargetRequest) error {
+	if validationErrors := validation.ValidateStruct(req); len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	if target.Definition != "" {
+		if err := validation.ValidateDefinition(target.Definition); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range target.Paths {
+		if err := validation.ValidateFilePath(path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// listParameterPrefix marks definition parameters in list and upload query
+// strings, e.g. param.app=rower
+const listParameterPrefix = "param."
+
+// parseListOptions reads the optional listing query parameters. It returns
+// nil when none are set so plain listings keep a single provider call.
+func parseListOptions(c *fiber.Ctx) (*dto.ListFilesOptions, error) {
+	var (
+		options dto.ListFilesOptions
+		set     bool
+	)
+
+	for key, value := range c.Queries() {
+		name, ok := strings.CutPrefix(key, listParameterPrefix)
+		if !ok {
+			continue
+		}
+		if options.Parameters == nil {
+			options.Parameters = make(map[string]string)
+		}
+		options.Parameters[name] = value
+		set = true
+	}
+
+	stringOptions := map[string]*string{
+		"delimiter":    &options.Delimiter,
+		"scope":        &options.Scope,
+		"content_type": &options.ContentType,
+		"sort":         &options.Sort,
+		"order":        &options.Order,
+	}
+	for name, target := range stringOptions {
+		if value := c.Query(name); value != "" {
+			*target = value
+			set = true
+		}
+	}
+
+	if value := c.Query("scope_value"); value != "" {
+		scopeValue, err := strconv.ParseInt(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("scope_value must be an integer")
+		}
+		options.ScopeValue = int16(scopeValue)
+		set = true
+	}
+
+	for name, target := range map[string]**int64{"min_size": &options.MinSize, "max_size": &options.MaxSize} {
+		if value := c.Query(name); value != "" {
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be an integer", name)
+			}
+			*target = &size
+			set = true
+		}
+	}
+
+	for name, target := range map[string]**time.Time{"modified_after": &options.ModifiedAfter, "modified_before": &options.ModifiedBefore} {
+		if value := c.Query(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+			}
+			*target = &t
+			set = true
+		}
+	}
+
+	if c.QueryBool("include_metadata", false) {
+		options.IncludeMetadata = true
+		set = true
+	}
+
+	if !set {
+		return nil, nil
+	}
+
+	if validationErrors := validation.ValidateStruct(&options); len(validationErrors) > 0 {
+		return nil, validationErrors
+	}
+	if options.MinSize != nil && options.MaxSize != nil && *options.MinSize > *options.MaxSize {
+		return nil, fmt.Errorf("min_size cannot exceed max_size")
+	}
+	if options.ModifiedAfter != nil && options.ModifiedBefore != nil && options.ModifiedAfter.After(*options.ModifiedBefore) {
+		return nil, fmt.Errorf("modified_after cannot be later than modified_before")
+	}
+
+	return &options, nil
+}
@@ -39,9 +39,7 @@ func (h *MultipartHandler) InitMultipartUpload(c *fiber.Ctx) error {
 	// Call use case
 	result, err := h.useCase.InitMultipartUpload(toContext(c), &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("MULTIPART_INIT_ERROR", "Failed to initialize multipart upload", err.Error()),
-		)
+		return failed(err, "MULTIPART_INIT_ERROR", "Failed to initialize multipart upload")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -67,9 +65,7 @@ func (h *MultipartHandler) GetMultipartURLs(c *fiber.Ctx) error {
 	// Call use case
 	result, err := h.useCase.GetMultipartURLs(toContext(c), &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("MULTIPART_URLS_ERROR", "Failed to get multipart URLs", err.Error()),
-		)
+		return failed(err, "MULTIPART_URLS_ERROR", "Failed to get multipart URLs")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(result))
@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+	"github.com/anh-nguyen/resource-server/internal/interfaces/http/openapi"
+)
+
+// OpenAPIHandler serves the OpenAPI document of the app's routes
+type OpenAPIHandler struct {
+	once     sync.Once
+	document *openapi.Document
+}
+
+// NewOpenAPIHandler creates a new OpenAPI handler
+func NewOpenAPIHandler() *OpenAPIHandler {
+	return &OpenAPIHandler{}
+}
+
+// GetDocument handles GET /api/v1/openapi.json. The document is generated
+// on first request, once every route has been registered.
+func (h *OpenAPIHandler) GetDocument(c *fiber.Ctx) error {
+	h.once.Do(func() {
+		h.document = openapi.Generate(openapi.Info{
+			Title:       "Resource Server API",
+			Description: "Signed URLs, content and metadata of resources stored across providers",
+			Version:     "1.0.0",
+		}, c.App().GetRoutes(true), routeSpecs)
+	})
+
+	return c.JSON(h.document)
+}
+
+var (
+	pageQuery = []openapi.QueryParam{
+		{Name: "page", Type: "integer", Default: 1},
+		{Name: "pageSize", Type: "integer", Default: 20, Description: "Between 1 and 100"},
+	}
+	dryRunQuery = openapi.QueryParam{
+		Name:        "dry_run",
+		Type:        "boolean",
+		Default:     false,
+		Description: "Report the affected files without changing them",
+	}
+	scopeQuery = []openapi.QueryParam{
+		{Name: "scope", Enum: []any{"G", "A", "CA"}},
+		{Name: "scope_value", Type: "integer"},
+	}
+)
+
+// routeSpecs documents every route registered by the server, keyed by method
+// and route path. Routes missing here are flagged as undocumented.
+var routeSpecs = map[string]openapi.Spec{
+	"GET /api/v1/health": {
+		Summary:         "Check server health",
+		Tag:             "health",
+		Response:        HealthResponse{},
+		ResponseContent: []string{fiber.MIMEApplicationJSON},
+	},
+	"GET /api/v1/openapi.json": {
+		Summary:         "Get the OpenAPI document",
+		Tag:             "health",
+		ResponseContent: []string{fiber.MIMEApplicationJSON},
+		Response:        map[string]any{},
+	},
+
+	// Resource definitions
+	"GET /api/v1/resources/definitions": {
+		Summary:  "List resource definitions",
+		Tag:      "definitions",
+		Response: []dto.PathDefinitionResponse{},
+	},
+	"GET /api/v1/resources/definitions/schema": {
+		Summary:         "Get the request schemas of every definition",
+		Tag:             "definitions",
+		Response:        dto.JSONSchema{},
+		ResponseContent: []string{"application/schema+json"},
+	},
+	"GET /api/v1/resources/definitions/:name": {
+		Summary:  "Get a resource definition",
+		Tag:      "definitions",
+		Response: dto.PathDefinitionResponse{},
+	},
+	"GET /api/v1/resources/definitions/:name/schema": {
+		Summary:         "Get the request schema of a definition",
+		Tag:             "definitions",
+		Response:        dto.JSONSchema{},
+		ResponseContent: []string{"application/schema+json"},
+	},
+	"POST /api/v1/resources/definitions/:name/resolve": {
+		Summary:  "Resolve the paths of a definition without touching storage",
+		Tag:      "definitions",
+		Request:  dto.ResolvePathRequest{},
+		Response: dto.ResolvePathResponse{},
+	},
+
+	// Providers
+	"GET /api/v1/resources/providers": {
+		Summary:  "List storage providers",
+		Tag:      "providers",
+		Response: []dto.ProviderResponse{},
+	},
+	"GET /api/v1/resources/providers/:name": {
+		Summary:  "Get a storage provider",
+		Tag:      "providers",
+		Response: dto.ProviderResponse{},
+	},
+
+	// File operations
+	"POST /api/v1/resources/:provider/batch/delete": {
+		Summary:  "Delete files in bulk",
+		Tag:      "files",
+		Query:    []openapi.QueryParam{dryRunQuery},
+		Request:  dto.BatchTargetRequest{},
+		Response: dto.BatchResponse{},
+	},
+	"POST /api/v1/resources/:provider/batch/metadata": {
+		Summary:  "Update the metadata of files in bulk",
+		Tag:      "files",
+		Query:    []openapi.QueryParam{dryRunQuery},
+		Request:  dto.BatchMetadataRequest{},
+		Response: dto.BatchResponse{},
+	},
+	"GET /api/v1/resources/:provider/:definition": {
+		Summary: "List files of a definition. Path parameters are passed as param.<name>.",
+		Tag:     "files",
+		Query: append([]openapi.QueryParam{
+			{Name: "max_keys", Type: "integer", Default: 1000},
+			{Name: "continuation_token"},
+			{Name: "prefix"},
+			{Name: "delimiter"},
+			{Name: "content_type"},
+			{Name: "sort", Enum: []any{"key", "size", "last_modified"}},
+			{Name: "order", Enum: []any{"asc", "desc"}},
+			{Name: "min_size", Type: "integer"},
+			{Name: "max_size", Type: "integer"},
+			{Name: "modified_after", Format: "date-time"},
+			{Name: "modified_before", Format: "date-time"},
+			{Name: "include_metadata", Type: "boolean", Default: false},
+		}, scopeQuery...),
+		Response: dto.FileListResponse{},
+	},
+	"POST /api/v1/resources/:provider/:definition/upload": {
+		Summary:  "Generate a signed upload URL",
+		Tag:      "files",
+		Request:  dto.UploadRequest{},
+		Response: dto.SignedURLResponse{},
+	},
+	"PUT /api/v1/resources/:provider/:definition/content": {
+		Summary:        "Upload file content through the server. Path parameters are passed as param.<name>.",
+		Tag:            "files",
+		Query:          append([]openapi.QueryParam{{Name: "resource_id"}}, scopeQuery...),
+		RequestContent: []string{fiber.MIMEOctetStream, fiber.MIMEMultipartForm},
+		Response:       dto.ContentUploadResponse{},
+		Status:         http.StatusCreated,
+	},
+	"POST /api/v1/resources/:provider/:definition/bundle": {
+		Summary:         "Download files as a zip or tar.gz archive",
+		Tag:             "files",
+		Request:         dto.BundleRequest{},
+		ResponseContent: []string{"application/zip", "application/gzip"},
+	},
+	"POST /api/v1/resources/:provider/*/download": {
+		Summary:  "Generate a signed download URL",
+		Tag:      "files",
+		Request:  dto.DownloadRequest{},
+		Response: dto.SignedURLResponse{},
+	},
+	"GET /api/v1/resources/:provider/*/content": {
+		Summary: "Download file content through the server",
+		Tag:     "files",
+		Query: []openapi.QueryParam{
+			{Name: "disposition", Enum: []any{"inline", "attachment"}},
+			{Name: "filename"},
+		},
+		ResponseContent: []string{fiber.MIMEOctetStream},
+	},
+	"POST /api/v1/resources/:provider/*/copy": {
+		Summary:  "Copy a file",
+		Tag:      "files",
+		Request:  dto.CopyRequest{},
+		Response: dto.CopyFileResponse{},
+	},
+	"POST /api/v1/resources/:provider/*/move": {
+		Summary:  "Move a file",
+		Tag:      "files",
+		Request:  dto.CopyRequest{},
+		Response: dto.CopyFileResponse{},
+	},
+	"GET /api/v1/resources/:provider/*/metadata": {
+		Summary:  "Get file metadata",
+		Tag:      "files",
+		Response: dto.FileMetadata{},
+	},
+	"PUT /api/v1/resources/:provider/*/metadata": {
+		Summary:  "Update file metadata",
+		Tag:      "files",
+		Request:  dto.MetadataUpdateRequest{},
+		Response: dto.FileMetadata{},
+	},
+	"DELETE /api/v1/resources/:provider/*": {
+		Summary: "Delete a file",
+		Tag:     "files",
+	},
+	"POST /api/v1/resources/multipart/init": {
+		Summary:  "Start a multipart upload",
+		Tag:      "multipart",
+		Request:  dto.MultipartInitRequest{},
+		Response: dto.MultipartInitResponse{},
+	},
+	"POST /api/v1/resources/multipart/urls": {
+		Summary:  "Generate signed URLs for multipart upload parts",
+		Tag:      "multipart",
+		Request:  dto.MultipartURLsRequest{},
+		Response: dto.MultipartURLsResponse{},
+	},
+
+	// Achievements
+	"GET /api/v1/achievements": {
+		Summary:  "List achievements",
+		Tag:      "achievements",
+		Query:    append([]openapi.QueryParam{{Name: "only_active", Type: "boolean", Default: true}}, pageQuery...),
+		Response: openapi.Page("achievements", []dto.AchievementResponse{}, nil),
+	},
+	"POST /api/v1/achievements": {
+		Summary:  "Create an achievement",
+		Tag:      "achievements",
+		Request:  dto.CreateAchievementRequest{},
+		Response: dto.CreateAchievementResponse{},
+		Status:   http.StatusCreated,
+	},
+	"GET /api/v1/achievements/preview": {
+		Summary:  "Preview the achievements active at a time",
+		Tag:      "achievements",
+		Query:    append([]openapi.QueryParam{{Name: "at", Format: "date-time"}}, pageQuery...),
+		Response: openapi.Page("achievements", []dto.AchievementResponse{}, map[string]any{"at": time.Time{}}),
+	},
+	"GET /api/v1/achievements/:id": {
+		Summary:  "Get an achievement",
+		Tag:      "achievements",
+		Response: dto.AchievementResponse{},
+	},
+	"DELETE /api/v1/achievements/:id": {
+		Summary: "Delete an achievement",
+		Tag:     "achievements",
+	},
+	"GET /api/v1/achievements/:id/history": {
+		Summary:  "List the revisions of an achievement",
+		Tag:      "achievements",
+		Query:    pageQuery,
+		Response: openapi.Page("revisions", []dto.AchievementRevisionResponse{}, nil),
+	},
+	"POST /api/v1/achievements/:id/revert/:revision": {
+		Summary:  "Revert an achievement to a revision",
+		Tag:      "achievements",
+		Response: dto.RevertAchievementResponse{},
+	},
+	"PUT /api/v1/achievements/:id/icon": {
+		Summary:  "Replace the icon of an achievement",
+		Tag:      "achievements",
+		Request:  dto.UpdateIconRequest{},
+		Response: dto.UpdateIconResponse{},
+	},
+	"PUT /api/v1/achievements/:id/schedule": {
+		Summary:  "Set the publication window of an achievement",
+		Tag:      "achievements",
+		Request:  dto.UpdateScheduleRequest{},
+		Response: dto.AchievementResponse{},
+	},
+	"POST /api/v1/achievements/uploads/:id/confirm": {
+		Summary: "Confirm an achievement icon upload",
+		Tag:     "achievements",
+		Request: dto.ConfirmUploadRequest{},
+	},
+	"POST /api/v1/achievements/uploads/:id/multipart": {
+		Summary:  "Generate signed URLs for the parts of an icon upload",
+		Tag:      "achievements",
+		Request:  dto.PartURLsRequest{},
+		Response: map[string]any{},
+	},
+
+	// Workouts
+	"GET /api/v1/workouts": {
+		Summary:  "List the workouts of a user",
+		Tag:      "workouts",
+		Query:    append([]openapi.QueryParam{{Name: "user_id", Required: true}}, pageQuery...),
+		Response: openapi.Page("workouts", []dto.WorkoutResponse{}, nil),
+	},
+	"POST /api/v1/workouts": {
+		Summary:  "Create a workout",
+		Tag:      "workouts",
+		Request:  dto.CreateWorkoutRequest{},
+		Response: dto.WorkoutUploadResponse{},
+		Status:   http.StatusCreated,
+	},
+	"GET /api/v1/workouts/:id": {
+		Summary:  "Get a workout",
+		Tag:      "workouts",
+		Response: dto.WorkoutResponse{},
+	},
+	"GET /api/v1/workouts/:id/download": {
+		Summary: "Generate a download URL for a workout file, converting it when needed",
+		Tag:     "workouts",
+		Query: []openapi.QueryParam{
+			{Name: "format", Enum: []any{"erg", "mrc", "zwo", "json"}},
+			{Name: "ftp", Type: "number", Description: "Functional threshold power, required by some conversions"},
+		},
+		Response: dto.WorkoutDownloadResponse{},
+	},
+	"DELETE /api/v1/workouts/:id": {
+		Summary: "Delete a workout",
+		Tag:     "workouts",
+	},
+	"PUT /api/v1/workouts/:id/file": {
+		Summary:  "Replace the file of a workout",
+		Tag:      "workouts",
+		Request:  dto.ReplaceWorkoutFileRequest{},
+		Response: dto.WorkoutUploadResponse{},
+	},
+	"POST /api/v1/workouts/:id/uploads/:uploadId/confirm": {
+		Summary: "Confirm a workout file upload",
+		Tag:     "workouts",
+		Request: dto.ConfirmUploadRequest{},
+	},
+
+	// Replication
+	"GET /api/v1/admin/replication/jobs": {
+		Summary:  "List replication jobs",
+		Tag:      "replication",
+		Query:    pageQuery,
+		Response: openapi.Page("jobs", []dto.ReplicationJobResponse{}, nil),
+	},
+	"POST /api/v1/admin/replication/jobs": {
+		Summary:  "Start a replication job",
+		Tag:      "replication",
+		Request:  dto.CreateReplicationJobRequest{},
+		Response: dto.ReplicationJobResponse{},
+		Status:   http.StatusAccepted,
+	},
+	"GET /api/v1/admin/replication/jobs/:id": {
+		Summary:  "Get a replication job",
+		Tag:      "replication",
+		Response: dto.ReplicationJobResponse{},
+	},
+	"GET /api/v1/admin/replication/jobs/:id/items": {
+		Summary:  "List the items of a replication job",
+		Tag:      "replication",
+		Query:    append([]openapi.QueryParam{{Name: "status", Enum: []any{"copied", "skipped", "failed"}}}, pageQuery...),
+		Response: openapi.Page("items", []dto.ReplicationItemResponse{}, nil),
+	},
+	"POST /api/v1/admin/replication/jobs/:id/cancel": {
+		Summary:  "Cancel a replication job",
+		Tag:      "replication",
+		Response: dto.ReplicationJobResponse{},
+	},
+
+	// Definition registry
+	"GET /api/v1/admin/definitions": {
+		Summary:  "List runtime definitions",
+		Tag:      "admin",
+		Response: []dto.ResourceDefinitionResponse{},
+	},
+	"POST /api/v1/admin/definitions": {
+		Summary:  "Register a runtime definition",
+		Tag:      "admin",
+		Request:  dto.CreateDefinitionRequest{},
+		Response: dto.ResourceDefinitionResponse{},
+		Status:   http.StatusCreated,
+	},
+	"PUT /api/v1/admin/definitions/:name": {
+		Summary:  "Update a runtime definition",
+		Tag:      "admin",
+		Request:  dto.UpdateDefinitionRequest{},
+		Response: dto.ResourceDefinitionResponse{},
+	},
+	"POST /api/v1/admin/definitions/:name/deprecate": {
+		Summary:  "Deprecate a runtime definition",
+		Tag:      "admin",
+		Response: dto.ResourceDefinitionResponse{},
+	},
+	"GET /api/v1/admin/definitions/:name/versions": {
+		Summary:  "List the versions of a runtime definition",
+		Tag:      "admin",
+		Response: []dto.DefinitionVersionResponse{},
+	},
+
+	// Upload lifecycle
+	"GET /api/v1/admin/uploads": {
+		Summary: "List tracked uploads",
+		Tag:     "admin",
+		Query: append([]openapi.QueryParam{
+			{Name: "status", Enum: uploadStatusEnum()},
+			{Name: "resourceType"},
+			{Name: "provider"},
+		}, pageQuery...),
+		Response: openapi.Page("uploads", []dto.UploadResponse{}, nil),
+	},
+	"POST /api/v1/admin/uploads/janitor": {
+		Summary:  "Abort expired uploads once",
+		Tag:      "admin",
+		Request:  dto.RunJanitorRequest{},
+		Response: dto.JanitorResultResponse{},
+	},
+	"GET /api/v1/admin/uploads/:id": {
+		Summary:  "Get a tracked upload",
+		Tag:      "admin",
+		Response: dto.UploadResponse{},
+	},
+	"POST /api/v1/admin/uploads/:id/abort": {
+		Summary:  "Abort a tracked upload",
+		Tag:      "admin",
+		Request:  dto.AbortUploadRequest{},
+		Response: dto.AbortUploadResponse{},
+	},
+}
+
+func uploadStatusEnum() []any {
+	statuses := make([]any, len(entity.UploadStatuses))
+	for i, status := range entity.UploadStatuses {
+		statuses[i] = status
+	}
+	return statuses
+}
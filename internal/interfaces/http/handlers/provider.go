@@ -22,9 +22,7 @@ func NewProviderHandler(useCase *usecases.ProviderUseCase) *ProviderHandler {
 func (h *ProviderHandler) ListProviders(c *fiber.Ctx) error {
 	providers, err := h.useCase.ListProviders(toContext(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("PROVIDERS_ERROR", "Failed to retrieve providers", err.Error()),
-		)
+		return failed(err, "PROVIDERS_ERROR", "Failed to retrieve providers")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(providers))
@@ -41,9 +39,7 @@ func (h *ProviderHandler) GetProvider(c *fiber.Ctx) error {
 
 	provider, err := h.useCase.GetProvider(toContext(c), name)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(
-			dto.NewErrorResponse("PROVIDER_NOT_FOUND", "Provider not found", err.Error()),
-		)
+		return failed(err, "PROVIDER_ERROR", "Failed to retrieve provider")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(provider))
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+type ReplicationHandler struct {
+	useCase *usecases.ReplicationUseCase
+}
+
+func NewReplicationHandler(useCase *usecases.ReplicationUseCase) *ReplicationHandler {
+	return &ReplicationHandler{
+		useCase: useCase,
+	}
+}
+
+// CreateJob handles POST /api/v1/admin/replication/jobs
+func (h *ReplicationHandler) CreateJob(c *fiber.Ctx) error {
+	var req dto.CreateReplicationJobRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	result, err := h.useCase.CreateJob(toContext(c), &req)
+	if err != nil {
+		return failed(err, "CREATE_ERROR", "Failed to create replication job")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.NewSuccessResponse(result))
+}
+
+// ListJobs handles GET /api/v1/admin/replication/jobs
+func (h *ReplicationHandler) ListJobs(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	result, err := h.useCase.ListJobs(toContext(c), offset, pageSize)
+	if err != nil {
+		return failed(err, "LIST_ERROR", "Failed to list replication jobs")
+	}
+
+	response := map[string]interface{}{
+		"jobs":     result,
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+// GetJob handles GET /api/v1/admin/replication/jobs/:id
+func (h *ReplicationHandler) GetJob(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid replication job ID", err.Error()),
+		)
+	}
+
+	result, err := h.useCase.GetJob(toContext(c), id)
+	if err != nil {
+		return failed(err, "GET_ERROR", "Failed to get replication job")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// ListJobItems handles GET /api/v1/admin/replication/jobs/:id/items
+func (h *ReplicationHandler) ListJobItems(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid replication job ID", err.Error()),
+		)
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", entity.ReplicationItemCopied, entity.ReplicationItemSkipped, entity.ReplicationItemFailed:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid query parameters", "status must be one of copied, skipped, failed"),
+		)
+	}
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	result, err := h.useCase.ListJobItems(toContext(c), id, status, offset, pageSize)
+	if err != nil {
+		return failed(err, "LIST_ERROR", "Failed to list replication items")
+	}
+
+	response := map[string]interface{}{
+		"items":    result,
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+// CancelJob handles POST /api/v1/admin/replication/jobs/:id/cancel
+func (h *ReplicationHandler) CancelJob(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid replication job ID", err.Error()),
+		)
+	}
+
+	result, err := h.useCase.CancelJob(toContext(c), id)
+	if err != nil {
+		return failed(err, "CANCEL_ERROR", "Failed to cancel replication job")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
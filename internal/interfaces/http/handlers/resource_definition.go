@@ -1,10 +1,9 @@
 package handlers
 
 import (
-	"avironactive.com/common/context"
-
 	"github.com/anh-nguyen/resource-server/internal/app/dto"
 	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -24,9 +23,7 @@ func NewResourceDefinitionHandler(useCase *usecases.ResourceDefinitionUseCase) *
 func (h *ResourceDefinitionHandler) ListDefinitions(c *fiber.Ctx) error {
 	definitions, err := h.useCase.ListDefinitions(toContext(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			dto.NewErrorResponse("DEFINITIONS_ERROR", "Failed to retrieve definitions", err.Error()),
-		)
+		return failed(err, "DEFINITIONS_ERROR", "Failed to retrieve definitions")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(definitions))
@@ -43,18 +40,59 @@ func (h *ResourceDefinitionHandler) GetDefinition(c *fiber.Ctx) error {
 
 	definition, err := h.useCase.GetDefinition(toContext(c), name)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(
-			dto.NewErrorResponse("DEFINITION_NOT_FOUND", "Definition not found", err.Error()),
-		)
+		return failed(err, "DEFINITIONS_ERROR", "Failed to retrieve definition")
 	}
 
 	return c.JSON(dto.NewSuccessResponse(definition))
 }
 
-func toContext(c *fiber.Ctx) context.Context {
-	ctx := context.NewContext(c.Context())
-	// Add any request-specific values if needed
-	ctx.Set("request_id", c.Get("X-Request-ID"))
+// GetSchemas handles GET /api/v1/resources/definitions/schema. The schema
+// document is returned as is so schema tooling can load it directly.
+func (h *ResourceDefinitionHandler) GetSchemas(c *fiber.Ctx) error {
+	schema, err := h.useCase.GetSchemas(toContext(c))
+	if err != nil {
+		return failed(err, "DEFINITIONS_ERROR", "Failed to generate definition schemas")
+	}
+
+	return c.JSON(schema, "application/schema+json")
+}
+
+// GetDefinitionSchema handles GET /api/v1/resources/definitions/:name/schema
+func (h *ResourceDefinitionHandler) GetDefinitionSchema(c *fiber.Ctx) error {
+	schema, err := h.useCase.GetDefinitionSchema(toContext(c), c.Params("name"))
+	if err != nil {
+		return failed(err, "DEFINITIONS_ERROR", "Failed to generate definition schema")
+	}
+
+	return c.JSON(schema, "application/schema+json")
+}
+
+// ResolveDefinition handles POST /api/v1/resources/definitions/:name/resolve
+func (h *ResourceDefinitionHandler) ResolveDefinition(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_PARAMETER", "Definition name is required", ""),
+		)
+	}
+
+	var req dto.ResolvePathRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	resolved, err := h.useCase.ResolvePaths(toContext(c), name, &req)
+	if err != nil {
+		return failed(err, "RESOLVE_ERROR", "Failed to resolve definition")
+	}
 
-	return ctx
+	return c.JSON(dto.NewSuccessResponse(resolved))
 }
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+	"github.com/anh-nguyen/resource-server/internal/domain/entity"
+)
+
+// UploadHandler handles the admin endpoints inspecting and aborting tracked
+// uploads
+type UploadHandler struct {
+	useCase *usecases.UploadUseCase
+}
+
+// NewUploadHandler creates a new upload handler
+func NewUploadHandler(useCase *usecases.UploadUseCase) *UploadHandler {
+	return &UploadHandler{
+		useCase: useCase,
+	}
+}
+
+// ListUploads handles GET /api/v1/admin/uploads
+func (h *UploadHandler) ListUploads(c *fiber.Ctx) error {
+	filter := entity.UploadFilter{
+		Status:       c.Query("status"),
+		ResourceType: c.Query("resourceType"),
+		Provider:     c.Query("provider"),
+	}
+	if filter.Status != "" && !entity.IsValidUploadStatus(filter.Status) {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid query parameters", "status is not a known upload status"),
+		)
+	}
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	result, err := h.useCase.ListUploads(toContext(c), filter, offset, pageSize)
+	if err != nil {
+		return failed(err, "LIST_ERROR", "Failed to list uploads")
+	}
+
+	response := map[string]interface{}{
+		"uploads":  result,
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+// GetUpload handles GET /api/v1/admin/uploads/:id
+func (h *UploadHandler) GetUpload(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid upload ID", err.Error()),
+		)
+	}
+
+	result, err := h.useCase.GetUpload(toContext(c), id)
+	if err != nil {
+		return failed(err, "GET_ERROR", "Failed to get upload")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// AbortUpload handles POST /api/v1/admin/uploads/:id/abort
+func (h *UploadHandler) AbortUpload(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid upload ID", err.Error()),
+		)
+	}
+
+	var req dto.AbortUploadRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+			)
+		}
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "aborted by " + requestActor(c)
+	}
+
+	result, err := h.useCase.AbortUpload(toContext(c), id, reason)
+	if err != nil {
+		return failed(err, "ABORT_ERROR", "Failed to abort upload")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+// RunJanitor handles POST /api/v1/admin/uploads/janitor
+func (h *UploadHandler) RunJanitor(c *fiber.Ctx) error {
+	var req dto.RunJanitorRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+			)
+		}
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	result, err := h.useCase.RunJanitor(toContext(c), &req)
+	if err != nil {
+		return failed(err, "JANITOR_ERROR", "Failed to run upload janitor")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+	"github.com/anh-nguyen/resource-server/internal/app/usecases"
+	"github.com/anh-nguyen/resource-server/internal/app/validation"
+)
+
+type WorkoutHandler struct {
+	useCase *usecases.WorkoutUseCase
+}
+
+func NewWorkoutHandler(useCase *usecases.WorkoutUseCase) *WorkoutHandler {
+	return &WorkoutHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *WorkoutHandler) CreateWorkout(c *fiber.Ctx) error {
+	var req dto.CreateWorkoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	if req.Provider == "" {
+		req.Provider = "r2"
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.CreateWorkout(ctx, &req)
+	if err != nil {
+		return failed(err, "CREATE_ERROR", "Failed to create workout")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *WorkoutHandler) ListWorkouts(c *fiber.Ctx) error {
+	userID := c.Query("user_id")
+	if userID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", "user_id is required"),
+		)
+	}
+
+	page := c.QueryInt("page", 1)
+	pageSize := c.QueryInt("pageSize", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	ctx := toContext(c)
+	result, err := h.useCase.ListUserWorkouts(ctx, userID, offset, pageSize)
+	if err != nil {
+		return failed(err, "LIST_ERROR", "Failed to list workouts")
+	}
+
+	response := map[string]interface{}{
+		"workouts": result,
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    len(result),
+	}
+
+	return c.JSON(dto.NewSuccessResponse(response))
+}
+
+func (h *WorkoutHandler) GetWorkout(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid workout ID", err.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.GetWorkout(ctx, id)
+	if err != nil {
+		return failed(err, "GET_ERROR", "Failed to get workout")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *WorkoutHandler) DownloadWorkout(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid workout ID", err.Error()),
+		)
+	}
+
+	req := dto.WorkoutDownloadRequest{
+		WorkoutID: id,
+		Format:    c.Query("format"),
+		FTP:       c.QueryFloat("ftp", 0),
+	}
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.DownloadWorkout(ctx, &req)
+	if err != nil {
+		return failed(err, "DOWNLOAD_ERROR", "Failed to download workout")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *WorkoutHandler) ReplaceWorkoutFile(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid workout ID", err.Error()),
+		)
+	}
+
+	var req dto.ReplaceWorkoutFileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	req.WorkoutID = id
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	result, err := h.useCase.ReplaceWorkoutFile(ctx, &req)
+	if err != nil {
+		return failed(err, "UPDATE_ERROR", "Failed to replace workout file")
+	}
+
+	return c.JSON(dto.NewSuccessResponse(result))
+}
+
+func (h *WorkoutHandler) DeleteWorkout(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid workout ID", err.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	if err := h.useCase.DeleteWorkout(ctx, id); err != nil {
+		return failed(err, "DELETE_ERROR", "Failed to delete workout")
+	}
+
+	return c.JSON(dto.NewSuccessResponseWithMessage(nil, "Workout deleted successfully"))
+}
+
+func (h *WorkoutHandler) ConfirmUpload(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := validation.ValidateUUID(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid workout ID", err.Error()),
+		)
+	}
+
+	uploadID := c.Params("uploadId")
+
+	if err := validation.ValidateUUID(uploadID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_ID", "Invalid upload ID", err.Error()),
+		)
+	}
+
+	var req dto.ConfirmUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("INVALID_REQUEST", "Invalid request body", err.Error()),
+		)
+	}
+
+	req.UploadID = uploadID
+
+	if validationErrors := validation.ValidateStruct(&req); len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("VALIDATION_ERROR", "Invalid request data", validationErrors.Error()),
+		)
+	}
+
+	ctx := toContext(c)
+	if err := h.useCase.ConfirmUpload(ctx, id, &req); err != nil {
+		return failed(err, "CONFIRM_ERROR", "Failed to confirm upload")
+	}
+
+	return c.JSON(dto.NewSuccessResponseWithMessage(nil, "Upload confirmed successfully"))
+}
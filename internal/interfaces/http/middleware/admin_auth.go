@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminAuth admits only requests presenting one of the tokens as
+// "Authorization: Bearer <token>". Empty tokens are ignored, so without any
+// configured token every request is rejected.
+func AdminAuth(tokens []string) fiber.Handler {
+	allowed := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		if token != "" {
+			allowed = append(allowed, []byte(token))
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		scheme, token, ok := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return fiber.NewError(fiber.StatusUnauthorized, "missing admin token")
+		}
+
+		presented := []byte(token)
+		for _, candidate := range allowed {
+			if subtle.ConstantTimeCompare(presented, candidate) == 1 {
+				return c.Next()
+			}
+		}
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid admin token")
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects request bodies larger than limit bytes, except on the
+// streamed routes whose handlers read the body as a stream. Route keys are a
+// method and a route path as registered with Fiber, e.g.
+// "PUT /api/v1/resources/:provider/:definition/content".
+//
+// The server streams bodies above its own limit to the handlers instead of
+// rejecting them, so every other route has its body checked here before a
+// handler buffers it.
+func BodyLimit(limit int, streamedRoutes []string) fiber.Handler {
+	routes := make([]routePattern, 0, len(streamedRoutes))
+	for _, route := range streamedRoutes {
+		routes = append(routes, newRoutePattern(route))
+	}
+
+	return func(c *fiber.Ctx) error {
+		path := splitPath(c.Path())
+		for _, route := range routes {
+			if route.matches(c.Method(), path) {
+				return c.Next()
+			}
+		}
+
+		if c.Request().Header.ContentLength() > limit {
+			return rejectBody(c)
+		}
+
+		// Bodies without a declared length are streamed too; read at most one
+		// byte past the limit before handing them over in memory
+		if c.Request().IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(c.Context().RequestBodyStream(), int64(limit)+1))
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "failed to read request body")
+			}
+			if len(body) > limit {
+				return rejectBody(c)
+			}
+			c.Request().SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+// rejectBody fails the request with 413 and closes the connection, since
+// the rest of the rejected body is never read
+func rejectBody(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return fiber.ErrRequestEntityTooLarge
+}
@@ -1,16 +1,20 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/domain/domainerr"
 )
 
 type ErrorResponse struct {
-	Success bool        `json:"success"`
-	Data    any         `json:"data"`
-	Message string      `json:"message"`
-	Error   *ErrorInfo  `json:"error,omitempty"`
+	Success bool       `json:"success"`
+	Data    any        `json:"data"`
+	Message string     `json:"message"`
+	Error   *ErrorInfo `json:"error,omitempty"`
 }
 
 type ErrorInfo struct {
@@ -18,16 +22,33 @@ type ErrorInfo struct {
 	Details string `json:"details,omitempty"`
 }
 
+// kindStatuses maps each kind of domain error to the status it is reported
+// with
+var kindStatuses = map[domainerr.Kind]int{
+	domainerr.NotFound:            fiber.StatusNotFound,
+	domainerr.Conflict:            fiber.StatusConflict,
+	domainerr.Validation:          fiber.StatusBadRequest,
+	domainerr.PreconditionFailed:  fiber.StatusPreconditionFailed,
+	domainerr.ProviderUnavailable: fiber.StatusServiceUnavailable,
+	domainerr.Unsupported:         fiber.StatusNotImplemented,
+	domainerr.Forbidden:           fiber.StatusForbidden,
+	domainerr.TooLarge:            fiber.StatusRequestEntityTooLarge,
+	domainerr.Unprocessable:       fiber.StatusUnprocessableEntity,
+}
+
 func ErrorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 	message := "Internal Server Error"
 	errorCode := "INTERNAL_ERROR"
 	details := ""
 
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-		message = e.Message
-		
+	var fiberErr *fiber.Error
+	var domainErr *domainerr.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		code = fiberErr.Code
+		message = fiberErr.Message
+
 		switch code {
 		case fiber.StatusBadRequest:
 			errorCode = "BAD_REQUEST"
@@ -44,7 +65,40 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 		default:
 			errorCode = "HTTP_ERROR"
 		}
-		details = e.Message
+		details = fiberErr.Message
+	case errors.As(err, &domainErr):
+		// The outermost error names the failed operation; the classified
+		// error it wraps, if any, decides the status and the code
+		if domainErr.Message != "" {
+			message = domainErr.Message
+		}
+		if domainErr.Code != "" {
+			errorCode = domainErr.Code
+		}
+		details = domainErr.Error()
+		if domainErr.Err != nil {
+			details = domainErr.Err.Error()
+		}
+
+		if classified := domainerr.Classify(err); classified != nil {
+			if status, ok := kindStatuses[classified.Kind]; ok {
+				code = status
+			}
+			errorCode = classified.Code
+		}
+	}
+
+	// A usecase cut short by the request context reports the deadline rather
+	// than the operation it interrupted
+	if domainerr.Classify(err) == nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			code = fiber.StatusGatewayTimeout
+			errorCode = "DEADLINE_EXCEEDED"
+		case errors.Is(err, context.Canceled):
+			code = fiber.StatusServiceUnavailable
+			errorCode = "CANCELLED"
+		}
 	}
 
 	log.Printf("Error: %v", err)
@@ -60,4 +114,4 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	}
 
 	return c.Status(code).JSON(response)
-}
\ No newline at end of file
+}
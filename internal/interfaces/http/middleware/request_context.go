@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// cancelKey holds the cancel function of the request context in the locals
+const cancelKey = "requestContextCancel"
+
+// routePattern matches requests by method and route path as registered with
+// Fiber
+type routePattern struct {
+	method   string
+	segments []string
+}
+
+// routeTimeout is the deadline of the routes matching a pattern
+type routeTimeout struct {
+	routePattern
+	timeout time.Duration
+}
+
+// RequestContext derives the context of each request from the Fiber request
+// and sets it as the request's user context, bounded by the timeout
+// configured for its route or the default timeout. Route keys are a method
+// and a route path as registered with Fiber, e.g.
+// "GET /api/v1/resources/:provider/*/content"; a zero timeout disables the
+// deadline.
+//
+// The context is cancelled once the handler returns, or once a streamed
+// response body taken over with DeferCancel is closed. Fasthttp does not
+// report client disconnects, so a disconnect cancels the context only when
+// writing a streamed body fails.
+func RequestContext(timeout time.Duration, routeTimeouts map[string]time.Duration) fiber.Handler {
+	routes := make([]routeTimeout, 0, len(routeTimeouts))
+	for route, routeDeadline := range routeTimeouts {
+		routes = append(routes, routeTimeout{newRoutePattern(route), routeDeadline})
+	}
+	// The most specific pattern wins when several match a path
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].literals() > routes[j].literals()
+	})
+
+	return func(c *fiber.Ctx) error {
+		deadline := timeout
+		path := splitPath(c.Path())
+		for _, route := range routes {
+			if route.matches(c.Method(), path) {
+				deadline = route.timeout
+				break
+			}
+		}
+
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if deadline > 0 {
+			ctx, cancel = context.WithTimeout(c.Context(), deadline)
+		} else {
+			ctx, cancel = context.WithCancel(c.Context())
+		}
+		c.SetUserContext(ctx)
+		c.Locals(cancelKey, cancel)
+
+		err := c.Next()
+
+		if cancel, ok := c.Locals(cancelKey).(context.CancelFunc); ok {
+			cancel()
+		}
+		return err
+	}
+}
+
+// DeferCancel hands the cancellation of the request context to the caller,
+// which must call the returned function once the response body has been
+// written. Handlers streaming a body read after they return use it to keep
+// the context alive while the body is sent.
+func DeferCancel(c *fiber.Ctx) context.CancelFunc {
+	cancel, ok := c.Locals(cancelKey).(context.CancelFunc)
+	if !ok {
+		return func() {}
+	}
+	c.Locals(cancelKey, nil)
+	return cancel
+}
+
+// newRoutePattern parses a route key such as "GET /api/v1/health"
+func newRoutePattern(route string) routePattern {
+	method, path, _ := strings.Cut(route, " ")
+	return routePattern{
+		method:   method,
+		segments: splitPath(path),
+	}
+}
+
+func (r routePattern) matches(method string, path []string) bool {
+	return r.method == method && matchSegments(r.segments, path)
+}
+
+// literals counts the segments matching a single value
+func (r routePattern) literals() int {
+	count := 0
+	for _, segment := range r.segments {
+		if segment != "*" && !strings.HasPrefix(segment, ":") {
+			count++
+		}
+	}
+	return count
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchSegments reports whether path matches pattern, where ":param" matches
+// one segment and "*" one or more
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	switch segment := pattern[0]; {
+	case segment == "*":
+		for i := len(path); i >= 1; i-- {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	case len(path) == 0:
+		return false
+	case strings.HasPrefix(segment, ":"), segment == path[0]:
+		return matchSegments(pattern[1:], path[1:])
+	default:
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI           string              `json:"openapi"`
+	JSONSchemaDialect string              `json:"jsonSchemaDialect"`
+	Info              Info                `json:"info"`
+	Paths             map[string]PathItem `json:"paths"`
+	Components        Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the schemas operations refer to
+type Components struct {
+	Schemas map[string]*dto.JSONSchema `json:"schemas"`
+}
+
+// PathItem holds the operations of a path, keyed by lower case method
+type PathItem map[string]*Operation
+
+// Operation describes a single route
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []*Parameter        `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+
+	// Undocumented marks routes registered without a catalogue entry
+	Undocumented bool `json:"x-undocumented,omitempty"`
+}
+
+// Parameter describes a path or query parameter
+type Parameter struct {
+	Name        string          `json:"name"`
+	In          string          `json:"in"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Schema      *dto.JSONSchema `json:"schema"`
+}
+
+// RequestBody describes the body an operation accepts
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of one content type
+type MediaType struct {
+	Schema *dto.JSONSchema `json:"schema"`
+}
+
+// Response describes a response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
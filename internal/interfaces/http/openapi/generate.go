@@ -0,0 +1,294 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+// Spec documents a route. Specs are keyed by method and Fiber route path,
+// e.g. "GET /api/v1/achievements/:id".
+type Spec struct {
+	Summary string
+	Tag     string
+	Query   []QueryParam
+
+	// Request is a value of the JSON request body type
+	Request any
+	// RequestContent lists the media types of a raw request body, used
+	// instead of Request
+	RequestContent []string
+
+	// Response is a value of the type returned in the data field of the
+	// APIResponse envelope, or of the whole body with ResponseContent
+	Response any
+	// ResponseContent lists the media types of a body returned without the
+	// APIResponse envelope
+	ResponseContent []string
+	// Status is the success status, 200 when zero
+	Status int
+}
+
+// QueryParam documents a query string parameter
+type QueryParam struct {
+	Name        string
+	Description string
+	Type        string // string when empty
+	Format      string
+	Enum        []any
+	Default     any
+	Required    bool
+}
+
+// page describes the paginated list responses of the API
+type page struct {
+	key   string
+	items any
+	extra map[string]any
+}
+
+// Page returns a Spec response holding items under key alongside the page,
+// pageSize and total fields of paginated lists. Extra fields are described
+// by example values.
+func Page(key string, items any, extra map[string]any) any {
+	return page{key: key, items: items, extra: extra}
+}
+
+// Generate documents the routes of an app. Routes without a spec are still
+// listed, flagged with x-undocumented, so missing entries are easy to find.
+func Generate(info Info, routes []fiber.Route, specs map[string]Spec) *Document {
+	registry := newSchemaRegistry()
+	envelope := registry.schemaOf(dto.APIResponse{})
+
+	doc := &Document{
+		OpenAPI:           Version,
+		JSONSchemaDialect: dto.JSONSchemaDialect,
+		Info:              info,
+		Paths:             make(map[string]PathItem),
+	}
+
+	seen := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		// Fiber registers a HEAD route for every GET route
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+
+		routePath := RoutePath(route.Path)
+		key := route.Method + " " + routePath
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		specPath, parameters := convertPath(routePath)
+		operation := &Operation{
+			OperationID: operationID(route),
+			Parameters:  parameters,
+			Responses: map[string]Response{
+				"default": {
+					Description: "Error",
+					Content:     jsonContent(envelope),
+				},
+			},
+		}
+
+		if spec, ok := specs[key]; ok {
+			registry.describe(operation, spec, envelope)
+		} else {
+			operation.Undocumented = true
+		}
+
+		item, ok := doc.Paths[specPath]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[specPath] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	doc.Components.Schemas = registry.schemas
+	return doc
+}
+
+// RoutePath normalises a Fiber route path the way specs are keyed: routes
+// registered as "/" on a group have no trailing slash
+func RoutePath(routePath string) string {
+	if len(routePath) > 1 {
+		return strings.TrimRight(routePath, "/")
+	}
+	return routePath
+}
+
+// describe fills an operation from its spec
+func (r *schemaRegistry) describe(operation *Operation, spec Spec, envelope *dto.JSONSchema) {
+	operation.Summary = spec.Summary
+	if spec.Tag != "" {
+		operation.Tags = []string{spec.Tag}
+	}
+
+	for _, query := range spec.Query {
+		schema := &dto.JSONSchema{
+			Type:    query.Type,
+			Format:  query.Format,
+			Enum:    query.Enum,
+			Default: query.Default,
+		}
+		if schema.Type == "" {
+			schema.Type = "string"
+		}
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        query.Name,
+			In:          "query",
+			Description: query.Description,
+			Required:    query.Required,
+			Schema:      schema,
+		})
+	}
+
+	switch {
+	case spec.Request != nil:
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(r.valueSchema(spec.Request)),
+		}
+	case len(spec.RequestContent) > 0:
+		content := make(map[string]MediaType, len(spec.RequestContent))
+		for _, mediaType := range spec.RequestContent {
+			content[mediaType] = MediaType{Schema: &dto.JSONSchema{Type: "string", Format: "binary"}}
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: content}
+	}
+
+	status := spec.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var content map[string]MediaType
+	switch {
+	case len(spec.ResponseContent) > 0:
+		content = make(map[string]MediaType, len(spec.ResponseContent))
+		for _, mediaType := range spec.ResponseContent {
+			schema := &dto.JSONSchema{Type: "string", Format: "binary"}
+			if spec.Response != nil {
+				schema = r.valueSchema(spec.Response)
+			}
+			content[mediaType] = MediaType{Schema: schema}
+		}
+	case spec.Response != nil:
+		content = jsonContent(&dto.JSONSchema{
+			AllOf: []*dto.JSONSchema{
+				envelope,
+				{
+					Type:       "object",
+					Properties: map[string]*dto.JSONSchema{"data": r.valueSchema(spec.Response)},
+				},
+			},
+		})
+	default:
+		content = jsonContent(envelope)
+	}
+
+	operation.Responses[strconv.Itoa(status)] = Response{
+		Description: http.StatusText(status),
+		Content:     content,
+	}
+}
+
+// valueSchema returns the schema of a Spec request or response value,
+// expanding pages
+func (r *schemaRegistry) valueSchema(value any) *dto.JSONSchema {
+	p, ok := value.(page)
+	if !ok {
+		return r.schemaOf(value)
+	}
+
+	schema := &dto.JSONSchema{
+		Type: "object",
+		Properties: map[string]*dto.JSONSchema{
+			p.key:      r.schemaOf(p.items),
+			"page":     {Type: "integer", Minimum: floatPtr(1)},
+			"pageSize": {Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(100)},
+			"total":    {Type: "integer", Minimum: floatPtr(0)},
+		},
+		Required: []string{p.key, "page", "pageSize", "total"},
+	}
+	for name, example := range p.extra {
+		schema.Properties[name] = r.schemaOf(example)
+		schema.Required = append(schema.Required, name)
+	}
+	slices.Sort(schema.Required)
+
+	return schema
+}
+
+// convertPath turns a Fiber route path into an OpenAPI path template and
+// its path parameters. The wildcard is exposed as the path parameter.
+func convertPath(routePath string) (string, []*Parameter) {
+	var parameters []*Parameter
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		var name, description string
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name = strings.TrimSuffix(segment[1:], "?")
+		case segment == "*" || segment == "+":
+			name, description = "path", "Object path within the provider"
+		default:
+			continue
+		}
+
+		segments[i] = "{" + name + "}"
+		parameters = append(parameters, &Parameter{
+			Name:        name,
+			In:          "path",
+			Description: description,
+			Required:    true,
+			Schema:      &dto.JSONSchema{Type: "string"},
+		})
+	}
+
+	return strings.Join(segments, "/"), parameters
+}
+
+// operationID derives an operation ID from the route's handler, e.g.
+// achievementListAchievements for AchievementHandler.ListAchievements
+func operationID(route fiber.Route) string {
+	if len(route.Handlers) > 0 {
+		handler := route.Handlers[len(route.Handlers)-1]
+		if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil {
+			name := strings.TrimSuffix(fn.Name(), "-fm")
+			name = name[strings.LastIndex(name, "/")+1:]
+			if parts := strings.Split(name, "."); len(parts) == 3 {
+				receiver := strings.Trim(parts[1], "(*)")
+				receiver = strings.TrimSuffix(receiver, "Handler")
+				if receiver != "" {
+					return string(unicode.ToLower(rune(receiver[0]))) + receiver[1:] + parts[2]
+				}
+			}
+		}
+	}
+
+	// Handlers that are not methods are named after the route
+	id := strings.ToLower(route.Method)
+	for _, segment := range strings.Split(route.Path, "/") {
+		segment = strings.Trim(segment, ":*?")
+		if segment != "" {
+			id += strings.ToUpper(segment[:1]) + segment[1:]
+		}
+	}
+	return id
+}
+
+func jsonContent(schema *dto.JSONSchema) map[string]MediaType {
+	return map[string]MediaType{fiber.MIMEApplicationJSON: {Schema: schema}}
+}
@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anh-nguyen/resource-server/internal/app/dto"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// tagSchemas describes the custom validators registered by the validation
+// package, which cannot be derived from the tag itself
+var tagSchemas = map[string]func(*dto.JSONSchema){
+	"provider": func(s *dto.JSONSchema) {
+		s.Enum = []any{"cdn", "gcs", "r2"}
+	},
+	"filepath": func(s *dto.JSONSchema) {
+		s.Pattern = `^[a-zA-Z0-9._/-]+$`
+		s.MaxLength = intPtr(1024)
+	},
+	"definition": func(s *dto.JSONSchema) {
+		s.Pattern = `^[a-zA-Z0-9_-]+$`
+		s.MaxLength = intPtr(64)
+	},
+	"duration": func(s *dto.JSONSchema) {
+		s.Pattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+		s.Description = "Go duration, e.g. 15m or 24h"
+	},
+	"uuid": func(s *dto.JSONSchema) {
+		s.Format = "uuid"
+	},
+	"url": func(s *dto.JSONSchema) {
+		s.Format = "uri"
+	},
+	"email": func(s *dto.JSONSchema) {
+		s.Format = "email"
+	},
+	"alphanum": func(s *dto.JSONSchema) {
+		s.Pattern = `^[a-zA-Z0-9]+$`
+	},
+	"hexadecimal": func(s *dto.JSONSchema) {
+		s.Pattern = `^(0[xX])?[0-9a-fA-F]+$`
+	},
+}
+
+// schemaRegistry converts Go types to JSON Schemas. Named struct types are
+// collected as components and referred to by $ref.
+type schemaRegistry struct {
+	schemas map[string]*dto.JSONSchema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*dto.JSONSchema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf returns the schema of the value's type
+func (r *schemaRegistry) schemaOf(value any) *dto.JSONSchema {
+	return r.schema(reflect.TypeOf(value))
+}
+
+func (r *schemaRegistry) schema(t reflect.Type) *dto.JSONSchema {
+	if t == nil {
+		return &dto.JSONSchema{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &dto.JSONSchema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &dto.JSONSchema{Type: "integer", Description: "Duration in nanoseconds"}
+	case rawMessageType:
+		return &dto.JSONSchema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &dto.JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &dto.JSONSchema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &dto.JSONSchema{Type: "integer", Minimum: floatPtr(0)}
+	case reflect.Float32, reflect.Float64:
+		return &dto.JSONSchema{Type: "number"}
+	case reflect.String:
+		return &dto.JSONSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &dto.JSONSchema{Type: "string", Format: "byte"}
+		}
+		return &dto.JSONSchema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &dto.JSONSchema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		return r.ref(t)
+	}
+
+	// Interfaces accept any value
+	return &dto.JSONSchema{}
+}
+
+// ref registers a named struct type as a component and returns a reference
+// to it. Anonymous structs are described inline.
+func (r *schemaRegistry) ref(t reflect.Type) *dto.JSONSchema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := r.schemas[name]; taken {
+			name = path.Base(t.PkgPath()) + "." + name
+		}
+		r.names[t] = name
+		// Reserve the name first so self-referencing types terminate
+		r.schemas[name] = nil
+		r.schemas[name] = r.structSchema(t)
+	}
+
+	return &dto.JSONSchema{Ref: "#/components/schemas/" + name}
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *dto.JSONSchema {
+	schema := &dto.JSONSchema{
+		Type:       "object",
+		Properties: make(map[string]*dto.JSONSchema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a name are flattened like encoding/json does
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				flat := r.structSchema(embedded)
+				for key, property := range flat.Properties {
+					schema.Properties[key] = property
+				}
+				schema.Required = append(schema.Required, flat.Required...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.schema(field.Type)
+		if applyValidateTag(property, field.Type, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// applyValidateTag maps the rules of a validate tag onto the schema of a
+// field of type t and reports whether the field is required. Cross-field
+// rules such as required_without have no schema equivalent and are skipped.
+func applyValidateTag(schema *dto.JSONSchema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	target, targetType := schema, derefType(t)
+	inKeys := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		// Map keys have no schema of their own
+		switch name {
+		case "keys":
+			inKeys = true
+			continue
+		case "endkeys":
+			inKeys = false
+			continue
+		}
+		if inKeys {
+			continue
+		}
+
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			} else if targetType.Kind() == reflect.String && target.MinLength == nil {
+				target.MinLength = intPtr(1)
+			}
+		case "dive":
+			next := target.Items
+			if targetType.Kind() == reflect.Map {
+				next = target.AdditionalProperties
+			}
+			if next == nil {
+				return required
+			}
+			target, targetType = next, derefType(targetType.Elem())
+		case "min", "gte":
+			setBound(target, targetType, param, true, false)
+		case "max", "lte":
+			setBound(target, targetType, param, false, false)
+		case "gt":
+			setBound(target, targetType, param, true, true)
+		case "lt":
+			setBound(target, targetType, param, false, true)
+		case "len":
+			setBound(target, targetType, param, true, false)
+			setBound(target, targetType, param, false, false)
+		case "oneof":
+			target.Enum = nil
+			for _, value := range strings.Fields(param) {
+				target.Enum = append(target.Enum, enumValue(targetType, value))
+			}
+		default:
+			if describe, ok := tagSchemas[name]; ok {
+				describe(target)
+			}
+		}
+	}
+
+	return required
+}
+
+// setBound applies a size rule: a length for strings, an item count for
+// slices and a value for numbers
+func setBound(schema *dto.JSONSchema, t reflect.Type, param string, lower, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = intPtr(int(value))
+		} else {
+			schema.MaxLength = intPtr(int(value))
+		}
+	case reflect.Slice, reflect.Array:
+		if lower {
+			schema.MinItems = intPtr(int(value))
+		} else {
+			schema.MaxItems = intPtr(int(value))
+		}
+	case reflect.Map:
+		// minProperties and maxProperties are not part of the schema subset
+	default:
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = &value
+		case lower:
+			schema.Minimum = &value
+		case exclusive:
+			schema.ExclusiveMaximum = &value
+		default:
+			schema.Maximum = &value
+		}
+	}
+}
+
+// enumValue converts a oneof value to the JSON type of the field
+func enumValue(t reflect.Type, value string) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
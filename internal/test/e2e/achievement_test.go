@@ -3,7 +3,9 @@ package e2e
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/anh-nguyen/resource-server/internal/test/helpers"
 	"github.com/google/uuid"
@@ -355,7 +357,7 @@ func (s *AchievementTestSuite) TestGetAchievement_NotFound() {
 	defer resp.Body.Close()
 
 	s.Equal(http.StatusNotFound, resp.StatusCode)
-	helpers.AssertErrorResponse(s.T(), resp, "")
+	helpers.AssertErrorResponse(s.T(), resp, "ACHIEVEMENT_NOT_FOUND")
 }
 
 // AC-016: Invalid UUID format
@@ -443,6 +445,260 @@ func (s *AchievementTestSuite) TestUpdateAchievementIcon_Success() {
 	s.Contains(updateResult, "upload_url")
 }
 
+// AC-022: History records creation
+func (s *AchievementTestSuite) TestGetAchievementHistory_Create() {
+	body := map[string]any{
+		"name":        "History Achievement",
+		"description": "Achievement for history test",
+		"category":    "test",
+		"points":      50,
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	achievementID := achievement["id"].(string)
+
+	resp2, err := s.GET(fmt.Sprintf("/api/v1/achievements/%s/history", achievementID))
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Equal(http.StatusOK, resp2.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp2, &result)
+
+	revisions := result["revisions"].([]any)
+	s.Require().Len(revisions, 1)
+
+	revision := revisions[0].(map[string]any)
+	s.Equal(float64(1), revision["revision"])
+	s.Equal("create", revision["action"])
+	s.NotEmpty(revision["changes"])
+}
+
+// AC-023: History records icon update with previous state
+func (s *AchievementTestSuite) TestGetAchievementHistory_IconUpdate() {
+	body := map[string]any{
+		"name":        "History Icon Achievement",
+		"description": "Achievement for history icon test",
+		"points":      60,
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	achievementID := achievement["id"].(string)
+
+	updateBody := map[string]any{
+		"format":   "png",
+		"provider": "r2",
+	}
+
+	resp2, err := s.PUT(fmt.Sprintf("/api/v1/achievements/%s/icon", achievementID), updateBody)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+	s.Equal(http.StatusOK, resp2.StatusCode)
+
+	resp3, err := s.GET(fmt.Sprintf("/api/v1/achievements/%s/history", achievementID))
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp3, &result)
+
+	revisions := result["revisions"].([]any)
+	s.Require().Len(revisions, 2)
+
+	latest := revisions[0].(map[string]any)
+	s.Equal("update", latest["action"])
+	s.Contains(latest, "previous")
+}
+
+// AC-024: Revert restores a deleted achievement
+func (s *AchievementTestSuite) TestRevertAchievement_AfterDelete() {
+	body := map[string]any{
+		"name":        "Revert Achievement",
+		"description": "Achievement for revert test",
+		"points":      70,
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	achievementID := achievement["id"].(string)
+
+	resp2, err := s.DELETE(fmt.Sprintf("/api/v1/achievements/%s", achievementID))
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+	s.Equal(http.StatusOK, resp2.StatusCode)
+
+	resp3, err := s.POST(fmt.Sprintf("/api/v1/achievements/%s/revert/2", achievementID), nil)
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+
+	s.Equal(http.StatusOK, resp3.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp3, &result)
+
+	restored := result["achievement"].(map[string]any)
+	s.Equal("Revert Achievement", restored["name"])
+	s.Equal(float64(70), restored["points"])
+}
+
+// AC-025: Invalid revision number
+func (s *AchievementTestSuite) TestRevertAchievement_InvalidRevision() {
+	resp, err := s.POST(fmt.Sprintf("/api/v1/achievements/%s/revert/abc", uuid.New().String()), nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_REVISION")
+}
+
+// AC-026: Scheduled achievement appears in preview only inside its window
+func (s *AchievementTestSuite) TestPreviewAchievements_PublishWindow() {
+	publishAt := time.Now().Add(24 * time.Hour).UTC()
+	unpublishAt := publishAt.Add(48 * time.Hour)
+
+	body := map[string]any{
+		"name":        "Seasonal Achievement",
+		"description": "Achievement for preview test",
+		"points":      80,
+		"publishAt":   publishAt.Format(time.RFC3339),
+		"unpublishAt": unpublishAt.Format(time.RFC3339),
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	achievementID := achievement["id"].(string)
+
+	containsAchievement := func(at time.Time) bool {
+		resp, err := s.GET("/api/v1/achievements/preview?pageSize=100&at=" + url.QueryEscape(at.Format(time.RFC3339)))
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var result map[string]any
+		s.ParseSuccessResponse(resp, &result)
+
+		for _, a := range result["achievements"].([]any) {
+			if a.(map[string]any)["id"] == achievementID {
+				return true
+			}
+		}
+		return false
+	}
+
+	s.False(containsAchievement(time.Now()))
+	s.True(containsAchievement(publishAt.Add(time.Hour)))
+	s.False(containsAchievement(unpublishAt.Add(time.Hour)))
+}
+
+// AC-027: Publish window must close after it opens
+func (s *AchievementTestSuite) TestUpdateAchievementSchedule_InvalidWindow() {
+	publishAt := time.Now().Add(24 * time.Hour).UTC()
+
+	body := map[string]any{
+		"publishAt":   publishAt.Format(time.RFC3339),
+		"unpublishAt": publishAt.Add(-time.Hour).Format(time.RFC3339),
+	}
+
+	resp, err := s.PUT(fmt.Sprintf("/api/v1/achievements/%s/schedule", uuid.New().String()), body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// AC-028: Invalid preview timestamp
+func (s *AchievementTestSuite) TestPreviewAchievements_InvalidTimestamp() {
+	resp, err := s.GET("/api/v1/achievements/preview?at=tomorrow")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_TIMESTAMP")
+}
+
+// AC-029: Icon upload URLs pin the icon's content type and size limit
+func (s *AchievementTestSuite) TestUpdateAchievementIcon_PolicyConstraints() {
+	body := map[string]any{
+		"name":        "Constrained Icon Achievement",
+		"description": "Achievement for icon policy test",
+		"category":    "test",
+		"points":      10,
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	iconPath := fmt.Sprintf("/api/v1/achievements/%s/icon", achievement["id"].(string))
+
+	resp2, err := s.PUT(iconPath, map[string]any{"format": "webp", "provider": "r2"})
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Equal(http.StatusOK, resp2.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp2, &result)
+
+	s.Require().Contains(result, "constraints")
+	constraints := result["constraints"].(map[string]any)
+	s.Equal("image/webp", constraints["contentType"])
+	s.Equal(float64(2<<20), constraints["maxSize"])
+
+	resp3, err := s.PUT(iconPath, map[string]any{"format": "png", "provider": "r2", "size": 3 << 20})
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp3.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp3, "POLICY_VIOLATION")
+}
+
+// AC-030: The revision creating an achievement cannot be reverted
+func (s *AchievementTestSuite) TestRevertAchievement_NoPreviousState() {
+	body := map[string]any{
+		"name":        "Unrevertible Achievement",
+		"description": "Achievement whose creation cannot be reverted",
+		"points":      10,
+	}
+
+	resp1, err := s.POST("/api/v1/achievements/", body)
+	s.Require().NoError(err)
+	defer resp1.Body.Close()
+
+	var achievement map[string]any
+	s.ParseSuccessResponse(resp1, &achievement)
+	achievementID := achievement["id"].(string)
+
+	resp2, err := s.POST(fmt.Sprintf("/api/v1/achievements/%s/revert/1", achievementID), nil)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Equal(http.StatusPreconditionFailed, resp2.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp2, "REVISION_NOT_REVERTIBLE")
+}
+
 func TestAchievementSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping achievement tests in short mode")
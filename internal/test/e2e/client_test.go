@@ -0,0 +1,55 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/anh-nguyen/resource-server/pkg/client"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientTestSuite struct {
+	E2ETestSuite
+	api *client.Client
+}
+
+func (s *ClientTestSuite) SetupSuite() {
+	s.E2ETestSuite.SetupSuite()
+	s.api = client.New(s.baseURL, client.WithHTTPClient(s.client))
+}
+
+// Test Cases for the Go client
+
+// CL-001: Definitions are decoded from the envelope
+func (s *ClientTestSuite) TestClient_ListDefinitions() {
+	definitions, err := s.api.ListDefinitions(s.ctx)
+	s.Require().NoError(err)
+	s.NotEmpty(definitions)
+
+	definition, err := s.api.GetDefinition(s.ctx, "achievement")
+	s.Require().NoError(err)
+	s.Equal("achievement", definition.Name)
+}
+
+// CL-002: Providers are decoded from the envelope
+func (s *ClientTestSuite) TestClient_GetProvider() {
+	provider, err := s.api.GetProvider(s.ctx, "cdn")
+	s.Require().NoError(err)
+	s.Equal("cdn", provider.Name)
+}
+
+// CL-003: API errors carry the status and error code
+func (s *ClientTestSuite) TestClient_NotFoundError() {
+	_, err := s.api.GetDefinition(s.ctx, "nonexistent")
+	s.Require().Error(err)
+
+	s.True(client.IsNotFound(err))
+	s.NotEmpty(client.ErrorCode(err))
+}
+
+func TestClientSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping client tests in short mode")
+	}
+
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -1,10 +1,26 @@
 package e2e
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/anh-nguyen/resource-server/internal/test/helpers"
 	"github.com/google/uuid"
@@ -396,6 +412,1086 @@ func (s *FileOperationsTestSuite) TestDeleteFile() {
 	s.Contains([]int{http.StatusOK, http.StatusNotFound}, resp.StatusCode)
 }
 
+// FO-034: Copy requires a destination
+func (s *FileOperationsTestSuite) TestCopyFile_MissingDestination() {
+	resp, err := s.POST("/api/v1/resources/r2/achievements/icons/test.png/copy", map[string]interface{}{})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-035: Copy rejects both a raw path and a definition destination
+func (s *FileOperationsTestSuite) TestCopyFile_ConflictingDestination() {
+	body := map[string]interface{}{
+		"destinationPath": "achievements/icons/copy.png",
+		"destination": map[string]interface{}{
+			"definition": "achievement",
+			"parameters": map[string]string{"achievementId": uuid.New().String()},
+		},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievements/icons/test.png/copy", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-036: Copy to a definition destination
+func (s *FileOperationsTestSuite) TestCopyFile_DefinitionDestination() {
+	achievementID := uuid.New().String()
+	body := map[string]interface{}{
+		"destination": map[string]interface{}{
+			"definition": "achievement",
+			"parameters": map[string]string{"achievementId": achievementID},
+		},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievements/icons/test.png/copy", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	// May fail if the source file doesn't exist
+	if resp.StatusCode == http.StatusOK {
+		var result map[string]interface{}
+		s.ParseSuccessResponse(resp, &result)
+
+		s.Contains(result["destinationPath"], achievementID)
+		s.Contains([]interface{}{"native", "stream"}, result["method"])
+		s.Equal(false, result["moved"])
+	} else {
+		s.Contains([]int{http.StatusNotFound, http.StatusInternalServerError}, resp.StatusCode)
+	}
+}
+
+// FO-037: Move rewrites references to the source path
+func (s *FileOperationsTestSuite) TestMoveFile_UpdatesReferences() {
+	source := fmt.Sprintf("achievements/icons/move-%s.png", uuid.New().String())
+	destination := fmt.Sprintf("achievements/icons/moved-%s.png", uuid.New().String())
+	body := map[string]interface{}{
+		"destinationPath": destination,
+	}
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/resources/r2/%s/move", source), body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	// May fail if the source file doesn't exist or the provider cannot copy
+	// to a raw path server-side
+	if resp.StatusCode == http.StatusOK {
+		var result map[string]interface{}
+		s.ParseSuccessResponse(resp, &result)
+
+		s.Equal(destination, result["destinationPath"])
+		s.Equal(true, result["moved"])
+		s.Contains(result, "updatedReferences")
+	} else {
+		s.Contains([]int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError}, resp.StatusCode)
+	}
+}
+
+// FO-038: Batch delete dry run reports matched files without deleting
+func (s *FileOperationsTestSuite) TestBatchDelete_DryRun() {
+	body := map[string]interface{}{
+		"paths": []string{
+			"achievements/icons/batch-1.png",
+			"achievements/icons/batch-2.png",
+		},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/batch/delete?dry_run=true", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal(true, result["dryRun"])
+	s.Equal(float64(2), result["total"])
+
+	results := result["results"].([]interface{})
+	s.Len(results, 2)
+	for _, item := range results {
+		status := item.(map[string]interface{})["status"]
+		s.Contains([]interface{}{"matched", "failed"}, status)
+	}
+}
+
+// FO-039: Batch delete requires paths or a definition, but not both
+func (s *FileOperationsTestSuite) TestBatchDelete_InvalidTarget() {
+	testCases := []map[string]interface{}{
+		{},
+		{"paths": []string{"achievements/icons/a.png"}, "definition": "achievement"},
+		{"prefix": "icons/"},
+	}
+
+	for i, body := range testCases {
+		s.Run(fmt.Sprintf("case-%d", i+1), func() {
+			resp, err := s.POST("/api/v1/resources/r2/batch/delete", body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+		})
+	}
+}
+
+// FO-040: Batch delete rejects more than the maximum number of paths
+func (s *FileOperationsTestSuite) TestBatchDelete_TooManyPaths() {
+	paths := make([]string, 1001)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("achievements/icons/%d.png", i)
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/batch/delete", map[string]interface{}{"paths": paths})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-041: Batch delete reports a result per path
+func (s *FileOperationsTestSuite) TestBatchDelete_PerItemResults() {
+	paths := []string{
+		fmt.Sprintf("achievements/icons/%s.png", uuid.New().String()),
+		fmt.Sprintf("achievements/icons/%s.png", uuid.New().String()),
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/batch/delete", map[string]interface{}{"paths": paths})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal(false, result["dryRun"])
+	results := result["results"].([]interface{})
+	s.Len(results, len(paths))
+	for i, item := range results {
+		s.Equal(paths[i], item.(map[string]interface{})["path"])
+	}
+}
+
+// FO-042: Batch metadata dry run by definition prefix
+func (s *FileOperationsTestSuite) TestBatchUpdateMetadata_DefinitionDryRun() {
+	body := map[string]interface{}{
+		"definition": "achievements",
+		"prefix":     "icons/",
+		"metadata": map[string]string{
+			"cacheControl": "max-age=3600",
+		},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/batch/metadata?dry_run=true", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal(true, result["dryRun"])
+	s.Contains(result, "truncated")
+	s.Contains(result, "results")
+}
+
+// FO-043: Batch metadata requires a metadata update
+func (s *FileOperationsTestSuite) TestBatchUpdateMetadata_MissingMetadata() {
+	body := map[string]interface{}{
+		"paths": []string{"achievements/icons/test.png"},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/batch/metadata", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-068: Batch changes require a valid admin token
+func (s *FileOperationsTestSuite) TestBatch_RequiresAdminToken() {
+	for _, route := range []string{"delete", "metadata"} {
+		resp, err := http.DefaultClient.Post(s.baseURL+"/api/v1/resources/r2/batch/"+route+"?dry_run=true", "application/json", strings.NewReader(`{"paths": ["achievements/icons/test.png"]}`))
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Equal(http.StatusUnauthorized, resp.StatusCode)
+		helpers.AssertErrorResponse(s.T(), resp, "UNAUTHORIZED")
+	}
+}
+
+// FO-044: List with delimiter rolls nested keys into common prefixes
+func (s *FileOperationsTestSuite) TestListFiles_Delimiter() {
+	resp, err := s.GET("/api/v1/resources/r2/achievements?delimiter=/&max_keys=50")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Contains(result, "files")
+	if prefixes, ok := result["commonPrefixes"].([]interface{}); ok {
+		for _, prefix := range prefixes {
+			s.True(strings.HasSuffix(prefix.(string), "/"))
+		}
+	}
+}
+
+// FO-045: List by scope and definition parameters
+func (s *FileOperationsTestSuite) TestListFiles_ScopeAndParameters() {
+	resp, err := s.GET("/api/v1/resources/r2/achievements?scope=A&param.app=rower")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	for _, file := range result["files"].([]interface{}) {
+		s.Contains(file.(map[string]interface{})["key"], "rower")
+	}
+}
+
+// FO-046: Filters, sorting and metadata
+func (s *FileOperationsTestSuite) TestListFiles_FiltersAndSort() {
+	query := url.Values{}
+	query.Set("min_size", "1")
+	query.Set("modified_after", "2020-01-01T00:00:00Z")
+	query.Set("content_type", "image/*")
+	query.Set("include_metadata", "true")
+	query.Set("sort", "size")
+	query.Set("order", "desc")
+
+	resp, err := s.GET("/api/v1/resources/r2/achievements?" + query.Encode())
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	files := result["files"].([]interface{})
+	for i, item := range files {
+		file := item.(map[string]interface{})
+		s.GreaterOrEqual(file["size"].(float64), float64(1))
+		s.True(strings.HasPrefix(file["contentType"].(string), "image/"))
+		if i > 0 {
+			s.LessOrEqual(file["size"].(float64), files[i-1].(map[string]interface{})["size"].(float64))
+		}
+	}
+}
+
+// FO-047: Invalid listing options
+func (s *FileOperationsTestSuite) TestListFiles_InvalidOptions() {
+	testCases := []string{
+		"sort=name",
+		"order=up",
+		"min_size=10&max_size=5",
+		"modified_after=yesterday",
+		"modified_after=2024-02-01T00:00:00Z&modified_before=2024-01-01T00:00:00Z",
+		"scope=X",
+	}
+
+	for _, query := range testCases {
+		s.Run(query, func() {
+			resp, err := s.GET("/api/v1/resources/r2/achievements?" + query)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+		})
+	}
+}
+
+// FO-048: Stream a raw body through the server
+func (s *FileOperationsTestSuite) TestUploadContent_RawBody() {
+	data := []byte("streamed achievement icon")
+	path := fmt.Sprintf("/api/v1/resources/r2/achievement/content?scope=G&param.achievementId=%s", uuid.New().String())
+
+	resp, err := s.putContent(path, "image/png", bytes.NewReader(data))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	sum := sha256.Sum256(data)
+	s.NotEmpty(result["uploadId"])
+	s.NotEmpty(result["path"])
+	s.Equal(float64(len(data)), result["size"])
+	s.Equal("simple", result["uploadType"])
+	s.Equal(float64(1), result["parts"])
+	s.Contains(result["checksums"], map[string]interface{}{
+		"algorithm": "sha256",
+		"value":     base64.StdEncoding.EncodeToString(sum[:]),
+	})
+}
+
+// FO-049: Stream a multipart/form-data upload with parameter fields
+func (s *FileOperationsTestSuite) TestUploadContent_MultipartForm() {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	s.Require().NoError(form.WriteField("scope", "G"))
+	s.Require().NoError(form.WriteField("param.achievementId", uuid.New().String()))
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="icon.png"`)
+	header.Set("Content-Type", "image/png")
+	part, err := form.CreatePart(header)
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("form achievement icon"))
+	s.Require().NoError(err)
+	s.Require().NoError(form.Close())
+
+	resp, err := s.putContent("/api/v1/resources/r2/achievement/content", form.FormDataContentType(), &body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal("image/png", result["contentType"])
+	s.Equal(float64(len("form achievement icon")), result["size"])
+}
+
+// FO-050: Bodies larger than one part switch to a multipart upload
+func (s *FileOperationsTestSuite) TestUploadContent_LargeBodyUsesMultipart() {
+	data := bytes.Repeat([]byte("0123456789abcdef"), (17<<20)/16)
+	// The achievements root has no upload policy limiting its size
+	path := "/api/v1/resources/r2/achievements/content?scope=G"
+
+	resp, err := s.putContent(path, "application/octet-stream", bytes.NewReader(data))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal("multipart", result["uploadType"])
+	s.Equal(float64(2), result["parts"])
+	s.Equal(float64(len(data)), result["size"])
+
+	deleteResp, err := s.DELETE(fmt.Sprintf("/api/v1/resources/r2/*?path=%s", url.QueryEscape(result["path"].(string))))
+	s.Require().NoError(err)
+	deleteResp.Body.Close()
+}
+
+// FO-051: Invalid content uploads
+func (s *FileOperationsTestSuite) TestUploadContent_InvalidRequests() {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	s.Require().NoError(form.WriteField("scope", "G"))
+	s.Require().NoError(form.Close())
+
+	testCases := []struct {
+		name         string
+		path         string
+		contentType  string
+		body         io.Reader
+		expectedCode string
+	}{
+		{"invalid provider", "/api/v1/resources/s3/achievement/content", "image/png", strings.NewReader("x"), "INVALID_PROVIDER"},
+		{"invalid scope value", "/api/v1/resources/r2/achievement/content?scope_value=abc", "image/png", strings.NewReader("x"), "INVALID_REQUEST"},
+		{"invalid scope", "/api/v1/resources/r2/achievement/content?scope=X", "image/png", strings.NewReader("x"), "VALIDATION_ERROR"},
+		{"missing file part", "/api/v1/resources/r2/achievement/content", form.FormDataContentType(), &body, "INVALID_REQUEST"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.putContent(tc.path, tc.contentType, tc.body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, tc.expectedCode)
+		})
+	}
+}
+
+// FO-052: Stream a file back through the server
+func (s *FileOperationsTestSuite) TestDownloadContent_FullBody() {
+	data := []byte("downloadable achievement icon")
+	filePath := s.uploadTestContent(data)
+
+	resp, err := s.GET("/api/v1/resources/r2/" + filePath + "/content")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("bytes", resp.Header.Get("Accept-Ranges"))
+	s.NotEmpty(resp.Header.Get("ETag"))
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	s.Equal(data, body)
+}
+
+// FO-053: Range requests return partial content
+func (s *FileOperationsTestSuite) TestDownloadContent_Range() {
+	data := []byte("0123456789")
+	contentPath := "/api/v1/resources/r2/" + s.uploadTestContent(data) + "/content"
+
+	testCases := []struct {
+		name         string
+		rangeHeader  string
+		expectedBody string
+		contentRange string
+	}{
+		{"first bytes", "bytes=0-4", "01234", "bytes 0-4/10"},
+		{"open ended", "bytes=7-", "789", "bytes 7-9/10"},
+		{"suffix", "bytes=-3", "789", "bytes 7-9/10"},
+		{"end past size", "bytes=8-100", "89", "bytes 8-9/10"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.getContent(contentPath, map[string]string{"Range": tc.rangeHeader})
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusPartialContent, resp.StatusCode)
+			s.Equal(tc.contentRange, resp.Header.Get("Content-Range"))
+
+			body, err := io.ReadAll(resp.Body)
+			s.Require().NoError(err)
+			s.Equal(tc.expectedBody, string(body))
+		})
+	}
+
+	resp, err := s.getContent(contentPath, map[string]string{"Range": "bytes=20-"})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	s.Equal("bytes */10", resp.Header.Get("Content-Range"))
+}
+
+// FO-054: Conditional requests return 304 for current copies
+func (s *FileOperationsTestSuite) TestDownloadContent_Conditional() {
+	contentPath := "/api/v1/resources/r2/" + s.uploadTestContent([]byte("cached icon")) + "/content"
+
+	resp, err := s.GET(contentPath)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	s.Require().NotEmpty(etag)
+
+	testCases := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"other etag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": "Mon, 01 Jan 2018 00:00:00 GMT"}, http.StatusOK},
+		{"stale if-range", map[string]string{"Range": "bytes=0-1", "If-Range": `"other"`}, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.getContent(contentPath, tc.headers)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+// FO-055: Content-Disposition overrides
+func (s *FileOperationsTestSuite) TestDownloadContent_Disposition() {
+	contentPath := "/api/v1/resources/r2/" + s.uploadTestContent([]byte("named icon")) + "/content"
+
+	resp, err := s.GET(contentPath + "?disposition=attachment&filename=icon.png")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`attachment; filename=icon.png`, resp.Header.Get("Content-Disposition"))
+
+	resp, err = s.GET(contentPath + "?disposition=download")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-056: Requested expiry is applied to signed URLs
+func (s *FileOperationsTestSuite) TestGenerateURL_ExpiryApplied() {
+	body := map[string]interface{}{
+		"parameters": map[string]string{"achievementId": uuid.New().String()},
+		"scope":      "G",
+		"expiry":     "2h",
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievement/upload", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var upload map[string]interface{}
+	s.ParseSuccessResponse(resp, &upload)
+
+	expiresAt, err := time.Parse(time.RFC3339, upload["expiresAt"].(string))
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now().Add(2*time.Hour), expiresAt, time.Minute)
+
+	filePath := s.uploadTestContent([]byte("expiring icon"))
+	resp, err = s.POST("/api/v1/resources/r2/"+filePath+"/download", map[string]interface{}{
+		"expiry": "15m",
+		"responseHeaders": map[string]string{
+			"response-content-disposition": "attachment; filename=icon.png",
+		},
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var download map[string]interface{}
+	s.ParseSuccessResponse(resp, &download)
+
+	expiresAt, err = time.Parse(time.RFC3339, download["expiresAt"].(string))
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+}
+
+// FO-057: Expiry outside the provider's limits is rejected
+func (s *FileOperationsTestSuite) TestGenerateURL_ExpiryOutOfRange() {
+	for _, expiry := range []string{"500ms", "8760h"} {
+		s.Run(expiry, func() {
+			body := map[string]interface{}{
+				"parameters": map[string]string{"achievementId": uuid.New().String()},
+				"scope":      "G",
+				"expiry":     expiry,
+			}
+
+			resp, err := s.POST("/api/v1/resources/r2/achievement/upload", body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "INVALID_EXPIRY")
+
+			resp, err = s.POST("/api/v1/resources/r2/achievements/icons/test.png/download", map[string]interface{}{"expiry": expiry})
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "INVALID_EXPIRY")
+		})
+	}
+}
+
+// FO-058: Only known response headers can be overridden
+func (s *FileOperationsTestSuite) TestGenerateDownloadURL_InvalidResponseHeader() {
+	body := map[string]interface{}{
+		"responseHeaders": map[string]string{
+			"X-Forwarded-For": "127.0.0.1",
+		},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievements/icons/test.png/download", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// FO-059: Upload URLs carry the definition's upload policy
+func (s *FileOperationsTestSuite) TestGenerateUploadURL_PolicyConstraints() {
+	body := map[string]interface{}{
+		"parameters":    map[string]string{"achievementId": uuid.New().String()},
+		"scope":         "G",
+		"contentLength": 1024,
+		"metadata":      map[string]string{"contentType": "image/png"},
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievement/upload", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Require().Contains(result, "constraints")
+	constraints := result["constraints"].(map[string]interface{})
+	s.Equal(float64(2<<20), constraints["maxSize"])
+	s.Equal("image/png", constraints["contentType"])
+}
+
+// FO-060: Uploads violating the definition's policy are rejected
+func (s *FileOperationsTestSuite) TestGenerateUploadURL_PolicyViolations() {
+	testCases := []struct {
+		name       string
+		definition string
+		body       map[string]interface{}
+	}{
+		{
+			name:       "disallowed content type",
+			definition: "achievement",
+			body: map[string]interface{}{
+				"parameters": map[string]string{"achievementId": uuid.New().String()},
+				"scope":      "G",
+				"metadata":   map[string]string{"contentType": "application/pdf"},
+			},
+		},
+		{
+			name:       "declared size over limit",
+			definition: "achievement",
+			body: map[string]interface{}{
+				"parameters":    map[string]string{"achievementId": uuid.New().String()},
+				"scope":         "G",
+				"contentLength": 3 << 20,
+			},
+		},
+		{
+			name:       "missing required checksum",
+			definition: "workout",
+			body: map[string]interface{}{
+				"parameters": map[string]string{"workoutId": uuid.New().String(), "format": "erg"},
+				"scope":      "G",
+				"metadata":   map[string]string{"contentType": "text/plain"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.POST("/api/v1/resources/r2/"+tc.definition+"/upload", tc.body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "POLICY_VIOLATION")
+		})
+	}
+}
+
+// FO-061: Bundle explicit paths as a ZIP with a manifest
+func (s *FileOperationsTestSuite) TestDownloadBundle_Zip() {
+	contents := map[string][]byte{
+		s.uploadTestContent([]byte("first icon")):  []byte("first icon"),
+		s.uploadTestContent([]byte("second icon")): []byte("second icon"),
+	}
+	paths := make([]string, 0, len(contents))
+	for path := range contents {
+		paths = append(paths, path)
+	}
+
+	resp, err := s.POST("/api/v1/resources/r2/achievement/bundle", map[string]interface{}{"paths": paths})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("application/zip", resp.Header.Get("Content-Type"))
+	s.Contains(resp.Header.Get("Content-Disposition"), "attachment")
+
+	data, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	s.Require().NoError(err)
+
+	s.Require().Len(archive.File, len(contents)+1)
+	s.Equal("manifest.json", archive.File[0].Name)
+
+	var manifest map[string]interface{}
+	s.Require().NoError(json.Unmarshal(s.readZipEntry(archive.File[0]), &manifest))
+	s.Equal(strings.Trim(resp.Header.Get("ETag"), `"`), manifest["hash"])
+
+	files := manifest["files"].([]interface{})
+	s.Require().Len(files, len(contents))
+	for i, file := range files {
+		entry := file.(map[string]interface{})
+		expected := contents[entry["key"].(string)]
+		s.Equal(float64(len(expected)), entry["size"])
+		s.Equal(entry["name"], archive.File[i+1].Name)
+		s.Equal(expected, s.readZipEntry(archive.File[i+1]))
+	}
+}
+
+// FO-062: Bundle a scoped selection as tar.gz, cached by hash
+func (s *FileOperationsTestSuite) TestDownloadBundle_TarGzCached() {
+	achievementID := uuid.New().String()
+	path := fmt.Sprintf("/api/v1/resources/r2/achievement/content?scope=G&param.achievementId=%s", achievementID)
+	resp, err := s.putContent(path, "image/png", strings.NewReader("scoped icon"))
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	body := map[string]interface{}{
+		"scope":      "G",
+		"parameters": map[string]string{"achievementId": achievementID},
+		"format":     "tar.gz",
+		"cache":      true,
+	}
+
+	var etags []string
+	for _, cache := range []string{"miss", "hit"} {
+		resp, err := s.POST("/api/v1/resources/r2/achievement/bundle", body)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+		s.Equal("application/gzip", resp.Header.Get("Content-Type"))
+		s.Equal(cache, resp.Header.Get("X-Bundle-Cache"))
+		etags = append(etags, resp.Header.Get("ETag"))
+
+		compressed, err := gzip.NewReader(resp.Body)
+		s.Require().NoError(err)
+		archive := tar.NewReader(compressed)
+
+		var names []string
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			s.Require().NoError(err)
+			names = append(names, header.Name)
+		}
+		s.Require().NotEmpty(names)
+		s.Equal("manifest.json", names[0])
+	}
+
+	s.Equal(etags[0], etags[1])
+}
+
+// FO-063: Invalid bundle requests
+func (s *FileOperationsTestSuite) TestDownloadBundle_InvalidRequests() {
+	testCases := []struct {
+		name           string
+		body           map[string]interface{}
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid format", map[string]interface{}{"paths": []string{"a.png"}, "format": "rar"}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"paths with scope", map[string]interface{}{"paths": []string{"a.png"}, "scope": "G"}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"empty selection", map[string]interface{}{"prefix": "no-such-prefix-" + uuid.New().String()}, http.StatusNotFound, "EMPTY_BUNDLE"},
+		{"path outside definition", map[string]interface{}{"paths": []string{"/other-bucket/secret.png"}}, http.StatusBadRequest, "INVALID_BUNDLE_PATH"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.POST("/api/v1/resources/r2/achievement/bundle", tc.body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(tc.expectedStatus, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, tc.expectedCode)
+		})
+	}
+}
+
+// FO-069: Bundles reject server-managed paths and names climbing out of the
+// archive, even within the definition
+func (s *FileOperationsTestSuite) TestDownloadBundle_UnsafePaths() {
+	dir := path.Dir(s.uploadTestContent([]byte("icon")))
+
+	for _, unsafe := range []string{dir + "/.bundles/cached.zip", dir + "/../../achievements/x.png"} {
+		resp, err := s.POST("/api/v1/resources/r2/achievement/bundle", map[string]interface{}{"paths": []string{unsafe}})
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+		helpers.AssertErrorResponse(s.T(), resp, "INVALID_BUNDLE_PATH")
+	}
+}
+
+// FO-070: A streamed download keeps streaming after its handler returned,
+// while the client is still reading the body
+func (s *FileOperationsTestSuite) TestDownloadContent_StreamsAfterHandlerReturns() {
+	filePath, data := s.uploadLargeContent()
+
+	resp, err := s.GET("/api/v1/resources/r2/" + filePath + "/content")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(sha256.Sum256(data), sha256.Sum256(s.readSlowly(resp.Body)))
+}
+
+// FO-071: A bundle keeps streaming after its handler returned, while the
+// client is still reading the archive
+func (s *FileOperationsTestSuite) TestDownloadBundle_StreamsAfterHandlerReturns() {
+	filePath, data := s.uploadLargeContent()
+
+	resp, err := s.POST("/api/v1/resources/r2/achievements/bundle", map[string]interface{}{"paths": []string{filePath}})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	archiveData := s.readSlowly(resp.Body)
+	archive, err := zip.NewReader(bytes.NewReader(archiveData), int64(len(archiveData)))
+	s.Require().NoError(err)
+	s.Require().Len(archive.File, 2)
+	s.Equal(sha256.Sum256(data), sha256.Sum256(s.readZipEntry(archive.File[1])))
+}
+
+// FO-064: Identical uploads to a content-addressed definition share one blob
+func (s *FileOperationsTestSuite) TestUploadContent_Deduplicated() {
+	data := []byte("shared placeholder icon " + uuid.New().String())
+
+	var paths, blobKeys []string
+	for range 2 {
+		path := fmt.Sprintf("/api/v1/resources/r2/achievement/content?scope=G&param.achievementId=%s", uuid.New().String())
+		resp, err := s.putContent(path, "image/png", bytes.NewReader(data))
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+		var result map[string]interface{}
+		s.ParseSuccessResponse(resp, &result)
+		s.Require().NotEmpty(result["blobKey"])
+		paths = append(paths, result["path"].(string))
+		blobKeys = append(blobKeys, result["blobKey"].(string))
+	}
+
+	sum := sha256.Sum256(data)
+	s.NotEqual(paths[0], paths[1])
+	s.Equal(blobKeys[0], blobKeys[1])
+	s.True(strings.HasSuffix(blobKeys[0], fmt.Sprintf("%x", sum)))
+
+	// Deleting one path keeps the blob for the other
+	resp, err := s.DELETE("/api/v1/resources/r2/" + paths[0])
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = s.GET("/api/v1/resources/r2/" + paths[1] + "/content")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	s.Equal(data, body)
+}
+
+// FO-067: Content-addressed files are moved by reference and their shared
+// blobs are never changed directly
+func (s *FileOperationsTestSuite) TestContentAddressed_SharedBlob() {
+	data := []byte("shared moved icon " + uuid.New().String())
+
+	resp, err := s.putContent(s.achievementContentPath(), "image/png", bytes.NewReader(data))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var uploaded map[string]interface{}
+	s.ParseSuccessResponse(resp, &uploaded)
+	source := uploaded["path"].(string)
+	blobKey := uploaded["blobKey"].(string)
+
+	resp, err = s.PUT("/api/v1/resources/r2/*/metadata", map[string]interface{}{
+		"path":     source,
+		"metadata": map[string]string{"cacheControl": "no-store"},
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "CONTENT_SHARED")
+
+	resp, err = s.POST("/api/v1/resources/r2/batch/delete", map[string]interface{}{"paths": []string{blobKey}})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var batch map[string]interface{}
+	s.ParseSuccessResponse(resp, &batch)
+	results := batch["results"].([]interface{})
+	s.Require().Len(results, 1)
+	s.Equal("failed", results[0].(map[string]interface{})["status"])
+
+	destination := source + "-moved"
+	resp, err = s.POST(fmt.Sprintf("/api/v1/resources/r2/%s/move", source), map[string]interface{}{
+		"destinationPath": destination,
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var moved map[string]interface{}
+	s.ParseSuccessResponse(resp, &moved)
+	s.Equal("reference", moved["method"])
+	s.Equal(true, moved["moved"])
+
+	resp, err = s.GET("/api/v1/resources/r2/" + destination + "/content")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	s.Equal(data, body)
+
+	resp, err = s.DELETE("/api/v1/resources/r2/" + destination)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
+
+// FO-066: Proxied uploads are held to the definition's upload policy
+func (s *FileOperationsTestSuite) TestUploadContent_PolicyViolations() {
+	workout := []byte(`{"name":"policy"}`)
+	sum := sha256.Sum256([]byte("other contents"))
+	workoutPath := fmt.Sprintf("/api/v1/resources/r2/workout/content?scope=G&param.workoutId=%s", uuid.New().String())
+
+	testCases := []struct {
+		name        string
+		path        string
+		contentType string
+		body        io.Reader
+	}{
+		{"disallowed content type", s.achievementContentPath(), "application/octet-stream", strings.NewReader("x")},
+		{"body over the size limit", s.achievementContentPath(), "image/png", bytes.NewReader(make([]byte, 2<<20+1))},
+		{"missing required checksum", workoutPath, "application/json", bytes.NewReader(workout)},
+		{"checksum mismatch", workoutPath + "&checksum_algorithm=sha256&checksum=" + hex.EncodeToString(sum[:]), "application/json", bytes.NewReader(workout)},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			resp, err := s.putContent(tc.path, tc.contentType, tc.body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "POLICY_VIOLATION")
+		})
+	}
+}
+
+func (s *FileOperationsTestSuite) achievementContentPath() string {
+	return fmt.Sprintf("/api/v1/resources/r2/achievement/content?scope=G&param.achievementId=%s", uuid.New().String())
+}
+
+func (s *FileOperationsTestSuite) putContent(path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPut, s.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return s.client.Do(req)
+}
+
+// uploadTestContent stores data through the content endpoint and returns its path
+func (s *FileOperationsTestSuite) uploadTestContent(data []byte) string {
+	path := fmt.Sprintf("/api/v1/resources/r2/achievement/content?scope=G&param.achievementId=%s", uuid.New().String())
+
+	resp, err := s.putContent(path, "image/png", bytes.NewReader(data))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+	return result["path"].(string)
+}
+
+func (s *FileOperationsTestSuite) getContent(path string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return s.client.Do(req)
+}
+
+// uploadLargeContent stores a random body larger than one upload part under
+// the achievements root, which has no size limit, and returns its path
+func (s *FileOperationsTestSuite) uploadLargeContent() (string, []byte) {
+	data := make([]byte, 17<<20)
+	_, err := rand.Read(data)
+	s.Require().NoError(err)
+
+	resp, err := s.putContent("/api/v1/resources/r2/achievements/content?scope=G", "application/octet-stream", bytes.NewReader(data))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+	filePath := result["path"].(string)
+
+	s.T().Cleanup(func() {
+		resp, err := s.DELETE(fmt.Sprintf("/api/v1/resources/r2/*?path=%s", url.QueryEscape(filePath)))
+		if err == nil {
+			resp.Body.Close()
+		}
+	})
+	return filePath, data
+}
+
+// readSlowly reads body in pauses, so the server is still streaming long
+// after the handler returned
+func (s *FileOperationsTestSuite) readSlowly(body io.Reader) []byte {
+	var data bytes.Buffer
+	chunk := make([]byte, 1<<20)
+	for {
+		n, err := io.ReadFull(body, chunk)
+		data.Write(chunk[:n])
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return data.Bytes()
+		}
+		s.Require().NoError(err)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// readZipEntry returns the contents of an archive entry
+func (s *FileOperationsTestSuite) readZipEntry(file *zip.File) []byte {
+	entry, err := file.Open()
+	s.Require().NoError(err)
+	defer entry.Close()
+
+	data, err := io.ReadAll(entry)
+	s.Require().NoError(err)
+	return data
+}
+
+// FO-065: Delimited listings only continue from their own tokens
+func (s *FileOperationsTestSuite) TestListFiles_DelimiterInvalidToken() {
+	resp, err := s.GET("/api/v1/resources/r2/achievements?delimiter=/&continuation_token=not-a-listing-token")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_CONTINUATION_TOKEN")
+}
+
 func TestFileOperationsSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping file operations tests in short mode")
@@ -0,0 +1,128 @@
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	resourcev1 "github.com/anh-nguyen/resource-server/api/resource/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCTestSuite struct {
+	E2ETestSuite
+	conn *grpc.ClientConn
+}
+
+func (s *GRPCTestSuite) SetupSuite() {
+	s.E2ETestSuite.SetupSuite()
+
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "9081"
+	}
+
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%s", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.conn = conn
+}
+
+func (s *GRPCTestSuite) TearDownSuite() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.E2ETestSuite.TearDownSuite()
+}
+
+// Test Cases for the gRPC API
+
+// GR-001: Definitions are streamed
+func (s *GRPCTestSuite) TestListDefinitions_Streams() {
+	stream, err := resourcev1.NewDefinitionServiceClient(s.conn).ListDefinitions(s.ctx, &resourcev1.ListDefinitionsRequest{})
+	s.Require().NoError(err)
+
+	var names []string
+	for {
+		definition, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().NoError(err)
+		names = append(names, definition.Name)
+	}
+	s.NotEmpty(names)
+}
+
+// GR-002: A provider is returned with its capabilities
+func (s *GRPCTestSuite) TestGetProvider_Success() {
+	provider, err := resourcev1.NewProviderServiceClient(s.conn).GetProvider(s.ctx, &resourcev1.GetProviderRequest{Name: "cdn"})
+	s.Require().NoError(err)
+
+	s.Equal("cdn", provider.Name)
+	s.NotNil(provider.Capabilities)
+}
+
+// GR-003: An unknown definition maps to NotFound
+func (s *GRPCTestSuite) TestGetDefinition_NotFound() {
+	_, err := resourcev1.NewDefinitionServiceClient(s.conn).GetDefinition(s.ctx, &resourcev1.GetDefinitionRequest{Name: "nonexistent"})
+	s.Require().Error(err)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+// GR-004: An invalid achievement ID maps to InvalidArgument
+func (s *GRPCTestSuite) TestGetAchievement_InvalidID() {
+	_, err := resourcev1.NewAchievementServiceClient(s.conn).GetAchievement(s.ctx, &resourcev1.GetAchievementRequest{Id: "not-a-uuid"})
+	s.Require().Error(err)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+// GR-005: Uploads are streamed up to the limit
+func (s *GRPCTestSuite) TestListUploads_Limit() {
+	stream, err := resourcev1.NewUploadServiceClient(s.conn).ListUploads(s.ctx, &resourcev1.ListUploadsRequest{Limit: 3})
+	s.Require().NoError(err)
+
+	received := 0
+	for {
+		_, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().NoError(err)
+		received++
+	}
+	s.LessOrEqual(received, 3)
+}
+
+// GR-006: An unknown upload status is rejected
+func (s *GRPCTestSuite) TestListUploads_InvalidStatus() {
+	stream, err := resourcev1.NewUploadServiceClient(s.conn).ListUploads(s.ctx, &resourcev1.ListUploadsRequest{Status: "unknown"})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Require().Error(err)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+// GR-007: Abort of an unknown upload fails
+func (s *GRPCTestSuite) TestAbortUpload_NotFound() {
+	_, err := resourcev1.NewUploadServiceClient(s.conn).AbortUpload(s.ctx, &resourcev1.AbortUploadRequest{
+		Id:     uuid.NewString(),
+		Reason: "e2e",
+	})
+	s.Require().Error(err)
+}
+
+func TestGRPCSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping gRPC tests in short mode")
+	}
+
+	suite.Run(t, new(GRPCTestSuite))
+}
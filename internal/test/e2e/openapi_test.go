@@ -0,0 +1,84 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPITestSuite struct {
+	E2ETestSuite
+}
+
+// Test Cases for the OpenAPI document
+
+func (s *OpenAPITestSuite) getDocument() map[string]interface{} {
+	resp, err := s.GET("/api/v1/openapi.json")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var document map[string]interface{}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&document))
+	return document
+}
+
+// OA-001: Document is OpenAPI 3.1
+func (s *OpenAPITestSuite) TestOpenAPI_Version() {
+	document := s.getDocument()
+
+	s.Equal("3.1.0", document["openapi"])
+	s.Contains(document, "info")
+	s.Contains(document, "paths")
+}
+
+// OA-002: Every registered route has a spec entry
+func (s *OpenAPITestSuite) TestOpenAPI_AllRoutesDocumented() {
+	document := s.getDocument()
+
+	paths, ok := document["paths"].(map[string]interface{})
+	s.Require().True(ok)
+	s.NotEmpty(paths)
+
+	for path, item := range paths {
+		for method, operation := range item.(map[string]interface{}) {
+			op := operation.(map[string]interface{})
+			s.Nil(op["x-undocumented"], "%s %s has no spec entry", method, path)
+			s.NotEmpty(op["operationId"], "%s %s has no operation ID", method, path)
+		}
+	}
+
+	s.Contains(paths, "/api/v1/resources/{provider}/{path}/download")
+	s.Contains(paths, "/api/v1/achievements/{id}")
+}
+
+// OA-003: Validate tags become schema constraints
+func (s *OpenAPITestSuite) TestOpenAPI_ValidateConstraints() {
+	document := s.getDocument()
+
+	schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	s.Contains(schemas, "APIResponse")
+
+	upload, ok := schemas["UploadRequest"].(map[string]interface{})
+	s.Require().True(ok, "UploadRequest schema should exist")
+	properties := upload["properties"].(map[string]interface{})
+
+	scope := properties["scope"].(map[string]interface{})
+	s.ElementsMatch([]interface{}{"G", "A", "CA"}, scope["enum"])
+
+	create := schemas["CreateAchievementRequest"].(map[string]interface{})
+	s.Contains(create["required"], "name")
+	name := create["properties"].(map[string]interface{})["name"].(map[string]interface{})
+	s.NotNil(name["maxLength"])
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping OpenAPI tests in short mode")
+	}
+
+	suite.Run(t, new(OpenAPITestSuite))
+}
@@ -0,0 +1,241 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/anh-nguyen/resource-server/internal/test/helpers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplicationTestSuite struct {
+	E2ETestSuite
+	testDB *helpers.TestDatabase
+}
+
+func (s *ReplicationTestSuite) SetupSuite() {
+	s.E2ETestSuite.SetupSuite()
+	s.testDB = helpers.SetupTestDatabase(s.T())
+}
+
+func (s *ReplicationTestSuite) TearDownSuite() {
+	if s.testDB != nil {
+		s.testDB.Close()
+	}
+	s.E2ETestSuite.TearDownSuite()
+}
+
+func (s *ReplicationTestSuite) SetupTest() {
+	s.testDB.Cleanup(s.T())
+}
+
+// createJob queues an achievements job from r2 to cdn. Providers that cannot
+// stream objects reject the job, in which case the test is skipped.
+func (s *ReplicationTestSuite) createJob() map[string]any {
+	body := map[string]any{
+		"definition":     "achievements",
+		"scope":          "G",
+		"sourceProvider": "r2",
+		"targetProvider": "cdn",
+		"concurrency":    2,
+	}
+
+	resp, err := s.POST("/api/v1/admin/replication/jobs", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		s.T().Skip("Configured providers do not support replication")
+	}
+	s.Require().Equal(http.StatusAccepted, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+	return result
+}
+
+// RJ-001: Create replication job
+func (s *ReplicationTestSuite) TestCreateJob() {
+	job := s.createJob()
+
+	s.NotEmpty(job["id"])
+	s.Equal("achievements", job["definition"])
+	s.Equal("r2", job["sourceProvider"])
+	s.Equal("cdn", job["targetProvider"])
+	s.Equal(float64(2), job["concurrency"])
+	s.Contains([]any{"pending", "running", "completed"}, job["status"])
+
+	summary := job["summary"].(map[string]any)
+	s.Contains(summary, "copied")
+	s.Contains(summary, "skipped")
+	s.Contains(summary, "failed")
+}
+
+// RJ-002: Source and target provider must differ
+func (s *ReplicationTestSuite) TestCreateJob_SameProvider() {
+	body := map[string]any{
+		"definition":     "achievements",
+		"sourceProvider": "r2",
+		"targetProvider": "r2",
+	}
+
+	resp, err := s.POST("/api/v1/admin/replication/jobs", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// RJ-003: Invalid provider and concurrency are rejected
+func (s *ReplicationTestSuite) TestCreateJob_ValidationErrors() {
+	testCases := []map[string]any{
+		{"definition": "achievements", "sourceProvider": "s3", "targetProvider": "r2"},
+		{"definition": "achievements", "sourceProvider": "r2", "targetProvider": "cdn", "concurrency": 100},
+		{"sourceProvider": "r2", "targetProvider": "cdn"},
+	}
+
+	for i, body := range testCases {
+		s.Run(fmt.Sprintf("case-%d", i+1), func() {
+			resp, err := s.POST("/api/v1/admin/replication/jobs", body)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+		})
+	}
+}
+
+// RJ-004: Unknown definitions cannot be replicated
+func (s *ReplicationTestSuite) TestCreateJob_UnknownDefinition() {
+	body := map[string]any{
+		"definition":     "unknown",
+		"sourceProvider": "r2",
+		"targetProvider": "cdn",
+	}
+
+	resp, err := s.POST("/api/v1/admin/replication/jobs", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "")
+}
+
+// RJ-005: Get and list jobs
+func (s *ReplicationTestSuite) TestGetAndListJobs() {
+	job := s.createJob()
+	id := job["id"].(string)
+
+	resp, err := s.GET("/api/v1/admin/replication/jobs/" + id)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var fetched map[string]any
+	s.ParseSuccessResponse(resp, &fetched)
+	s.Equal(id, fetched["id"])
+
+	listResp, err := s.GET("/api/v1/admin/replication/jobs?page=1&pageSize=10")
+	s.Require().NoError(err)
+	defer listResp.Body.Close()
+
+	s.Equal(http.StatusOK, listResp.StatusCode)
+
+	var list map[string]any
+	s.ParseSuccessResponse(listResp, &list)
+	jobs := list["jobs"].([]any)
+	s.NotEmpty(jobs)
+}
+
+// RJ-006: Cancel job, then cancelling again fails its precondition
+func (s *ReplicationTestSuite) TestCancelJob() {
+	job := s.createJob()
+	id := job["id"].(string)
+
+	resp, err := s.POST("/api/v1/admin/replication/jobs/"+id+"/cancel", nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	// The job may already have completed if the definition has no objects
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return
+	}
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var cancelled map[string]any
+	s.ParseSuccessResponse(resp, &cancelled)
+	s.Equal("cancelled", cancelled["status"])
+
+	againResp, err := s.POST("/api/v1/admin/replication/jobs/"+id+"/cancel", nil)
+	s.Require().NoError(err)
+	defer againResp.Body.Close()
+
+	s.Equal(http.StatusPreconditionFailed, againResp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), againResp, "JOB_FINISHED")
+}
+
+// RJ-007: List job items filtered by status
+func (s *ReplicationTestSuite) TestListJobItems() {
+	job := s.createJob()
+	id := job["id"].(string)
+
+	resp, err := s.GET("/api/v1/admin/replication/jobs/" + id + "/items?status=failed")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+	s.Contains(result, "items")
+
+	badResp, err := s.GET("/api/v1/admin/replication/jobs/" + id + "/items?status=unknown")
+	s.Require().NoError(err)
+	defer badResp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, badResp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), badResp, "VALIDATION_ERROR")
+}
+
+// RJ-008: Invalid job ID
+func (s *ReplicationTestSuite) TestGetJob_InvalidID() {
+	resp, err := s.GET("/api/v1/admin/replication/jobs/not-a-uuid")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_ID")
+}
+
+// RJ-009: Unknown job
+func (s *ReplicationTestSuite) TestGetJob_NotFound() {
+	resp, err := s.GET("/api/v1/admin/replication/jobs/" + uuid.New().String())
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.NotEqual(http.StatusOK, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "")
+}
+
+// RJ-010: Replication jobs require a valid admin token
+func (s *ReplicationTestSuite) TestJobs_RequireAdminToken() {
+	resp, err := http.DefaultClient.Get(s.baseURL + "/api/v1/admin/replication/jobs")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "UNAUTHORIZED")
+}
+
+func TestReplicationSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping replication tests in short mode")
+	}
+
+	suite.Run(t, new(ReplicationTestSuite))
+}
@@ -1,8 +1,12 @@
 package e2e
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/anh-nguyen/resource-server/internal/test/helpers"
 	"github.com/stretchr/testify/suite"
@@ -211,6 +215,367 @@ func (s *ResourceDefinitionTestSuite) TestListDefinitions_ContentType() {
 	helpers.AssertContentType(s.T(), resp, "application/json")
 }
 
+// RD-011: Definition exposes patterns, children, rules and storage defaults
+func (s *ResourceDefinitionTestSuite) TestGetDefinition_Details() {
+	resp, err := s.GET("/api/v1/resources/definitions/workouts")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var definition map[string]interface{}
+	s.ParseSuccessResponse(resp, &definition)
+
+	patterns := definition["patterns"].(map[string]interface{})
+	r2 := patterns["r2"].(map[string]interface{})
+	s.Equal("/aviron-assets/{env}/shared/{app}/workouts", r2["patterns"].(map[string]interface{})["A"])
+	s.NotEmpty(r2["urlType"])
+
+	defaults := definition["defaultStorageMetadata"].(map[string]interface{})
+	s.Equal("true", defaults["customHeaders"].(map[string]interface{})["x-aviron-private"])
+	s.NotEmpty(defaults["requiredChecksums"])
+	s.Equal(float64(86400*7), defaults["cacheControl"].(map[string]interface{})["maxAge"])
+
+	children := definition["children"].([]interface{})
+	s.Require().Len(children, 1)
+	child := children[0].(map[string]interface{})
+	s.Equal("workout", child["name"])
+	s.Equal("workouts", child["parent"])
+
+	for _, p := range child["parameters"].([]interface{}) {
+		param := p.(map[string]interface{})
+		switch param["name"] {
+		case "workout_id":
+			s.True(param["required"].(bool))
+			s.Contains(param["rules"], "required")
+		case "user_id":
+			s.False(param["required"].(bool))
+			s.Equal("anonymous", param["defaultValue"])
+		}
+	}
+}
+
+// RD-009: Resolve a definition's paths without signing
+func (s *ResourceDefinitionTestSuite) TestResolveDefinition_Success() {
+	resp, err := s.POST("/api/v1/resources/definitions/achievement/resolve", map[string]interface{}{
+		"scope":      "A",
+		"scopeValue": 1,
+		"parameters": map[string]string{"achievement_id": "first-row"},
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var resolved map[string]interface{}
+	s.ParseSuccessResponse(resp, &resolved)
+
+	s.Equal("achievement", resolved["definition"])
+	s.Equal("A", resolved["scope"])
+
+	providers := resolved["providers"].([]interface{})
+	s.Len(providers, 2)
+	for _, p := range providers {
+		entry := p.(map[string]interface{})
+		s.True(entry["valid"].(bool))
+		s.NotContains(entry, "errors")
+
+		params := entry["parameters"].(map[string]interface{})
+		s.Equal("dev", params["env"])
+		s.Equal("rower", params["app"])
+		s.Equal("png", params["format"])
+
+		switch entry["provider"] {
+		case "r2":
+			s.Equal("/aviron-game-assets/dev/shared/rower/achievements/first-row.png", entry["path"])
+		case "cdn":
+			s.Equal("/game/dev/shared/rower/achievements/first-row.png", entry["path"])
+		}
+	}
+}
+
+// RD-010: Resolve reports missing parameters and rejects disallowed scopes
+func (s *ResourceDefinitionTestSuite) TestResolveDefinition_Errors() {
+	resp, err := s.POST("/api/v1/resources/definitions/workout/resolve", map[string]interface{}{
+		"scope": "G",
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var resolved map[string]interface{}
+	s.ParseSuccessResponse(resp, &resolved)
+
+	for _, p := range resolved["providers"].([]interface{}) {
+		entry := p.(map[string]interface{})
+		s.False(entry["valid"].(bool))
+		s.Contains(entry["errors"], "workout_id")
+		s.Contains(entry["path"], "anonymous/{workout_id}.json")
+	}
+
+	resp2, err := s.POST("/api/v1/resources/definitions/workout/resolve", map[string]interface{}{
+		"scope":      "CA",
+		"scopeValue": 1,
+	})
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp2.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp2, "INVALID_SCOPE")
+
+	resp3, err := s.POST("/api/v1/resources/definitions/nonexistent/resolve", map[string]interface{}{})
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+
+	s.Equal(http.StatusNotFound, resp3.StatusCode)
+}
+
+// RD-012: Runtime definitions are versioned and guard existing paths
+func (s *ResourceDefinitionTestSuite) TestRuntimeDefinition_Lifecycle() {
+	name := fmt.Sprintf("e2e-def-%d", time.Now().UnixNano())
+	spec := func(pattern string) map[string]interface{} {
+		return map[string]interface{}{
+			"displayName":   "E2E Definition",
+			"allowedScopes": []string{"G"},
+			"patterns": map[string]interface{}{
+				"r2": map[string]interface{}{
+					"patterns": map[string]string{"G": pattern},
+				},
+			},
+			"parameters": []map[string]interface{}{
+				{"name": "item_id", "required": true, "pattern": "^[a-z0-9-]+$"},
+			},
+		}
+	}
+
+	create := spec("/e2e/{env}/" + name + "/{item_id}.bin")
+	create["name"] = name
+	resp, err := s.POST("/api/v1/admin/definitions", create)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal(http.StatusCreated, resp.StatusCode)
+
+	// Registered without a restart
+	resp2, err := s.GET("/api/v1/resources/definitions/" + name)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+	s.Equal(http.StatusOK, resp2.StatusCode)
+
+	var definition map[string]interface{}
+	s.ParseSuccessResponse(resp2, &definition)
+	s.Equal(float64(1), definition["version"])
+
+	// Moving existing paths needs the migration flag
+	update := spec("/e2e/{env}/" + name + "/v2/{item_id}.bin")
+	resp3, err := s.PUT("/api/v1/admin/definitions/"+name, update)
+	s.Require().NoError(err)
+	defer resp3.Body.Close()
+	s.Equal(http.StatusPreconditionFailed, resp3.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp3, "PATH_CHANGE")
+
+	update["migrate"] = true
+	update["version"] = 1
+	resp4, err := s.PUT("/api/v1/admin/definitions/"+name, update)
+	s.Require().NoError(err)
+	defer resp4.Body.Close()
+	s.Equal(http.StatusOK, resp4.StatusCode)
+
+	resp5, err := s.POST("/api/v1/admin/definitions/"+name+"/deprecate", nil)
+	s.Require().NoError(err)
+	defer resp5.Body.Close()
+	s.Equal(http.StatusOK, resp5.StatusCode)
+
+	var deprecated map[string]interface{}
+	s.ParseSuccessResponse(resp5, &deprecated)
+	s.Equal("deprecated", deprecated["status"])
+	s.Equal(float64(3), deprecated["version"])
+
+	// Deprecated definitions take no new uploads
+	respUpload, err := s.POST("/api/v1/resources/r2/"+name+"/upload", map[string]interface{}{
+		"parameters": map[string]string{"item_id": "item-1"},
+		"scope":      "G",
+		"scopeValue": 0,
+	})
+	s.Require().NoError(err)
+	defer respUpload.Body.Close()
+	s.Equal(http.StatusPreconditionFailed, respUpload.StatusCode)
+	helpers.AssertErrorResponse(s.T(), respUpload, "DEFINITION_DEPRECATED")
+
+	resp6, err := s.GET("/api/v1/admin/definitions/" + name + "/versions")
+	s.Require().NoError(err)
+	defer resp6.Body.Close()
+
+	var versions []map[string]interface{}
+	s.ParseSuccessResponse(resp6, &versions)
+	s.Require().Len(versions, 3)
+	s.True(versions[1]["migrated"].(bool))
+}
+
+// RD-013: Built-in definitions cannot be changed at runtime
+func (s *ResourceDefinitionTestSuite) TestRuntimeDefinition_BuiltIn() {
+	resp, err := s.POST("/api/v1/admin/definitions/achievements/deprecate", nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "DEFINITION_BUILT_IN")
+}
+
+// RD-016: Runtime definitions carry their own upload policy
+func (s *ResourceDefinitionTestSuite) TestRuntimeDefinition_UploadPolicy() {
+	name := fmt.Sprintf("e2e-policy-%d", time.Now().UnixNano())
+	create := map[string]interface{}{
+		"name":          name,
+		"displayName":   "E2E Policy Definition",
+		"allowedScopes": []string{"G"},
+		"patterns": map[string]interface{}{
+			"r2": map[string]interface{}{
+				"patterns": map[string]string{"G": "/e2e/{env}/" + name + "/{item_id}.txt"},
+			},
+		},
+		"parameters": []map[string]interface{}{
+			{"name": "item_id", "required": true},
+		},
+		"uploadPolicy": map[string]interface{}{
+			"maxSize":          16,
+			"contentTypes":     []string{"text/plain"},
+			"requiredChecksum": "sha1",
+		},
+	}
+
+	resp, err := s.POST("/api/v1/admin/definitions", create)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_DEFINITION")
+
+	create["uploadPolicy"] = map[string]interface{}{
+		"maxSize":      16,
+		"contentTypes": []string{"text/plain"},
+	}
+	resp2, err := s.POST("/api/v1/admin/definitions", create)
+	s.Require().NoError(err)
+	defer resp2.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp2.StatusCode)
+
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"disallowed content type", "image/png", "policy"},
+		{"body over the size limit", "text/plain", strings.Repeat("x", 17)},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			path := "/api/v1/resources/r2/" + name + "/content?scope=G&param.itemId=policy"
+			req, err := http.NewRequest(http.MethodPut, s.baseURL+path, strings.NewReader(tc.body))
+			s.Require().NoError(err)
+			req.Header.Set("Content-Type", tc.contentType)
+
+			resp, err := s.client.Do(req)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			helpers.AssertErrorResponse(s.T(), resp, "POLICY_VIOLATION")
+		})
+	}
+}
+
+// RD-017: Runtime definitions opt into content-addressed storage
+func (s *ResourceDefinitionTestSuite) TestRuntimeDefinition_ContentAddressed() {
+	name := fmt.Sprintf("e2e-addressed-%d", time.Now().UnixNano())
+	create := map[string]interface{}{
+		"name":          name,
+		"displayName":   "E2E Content-Addressed Definition",
+		"allowedScopes": []string{"G"},
+		"patterns": map[string]interface{}{
+			"r2": map[string]interface{}{
+				"patterns": map[string]string{"G": "/e2e/{env}/" + name + "/{item_id}.txt"},
+			},
+		},
+		"parameters": []map[string]interface{}{
+			{"name": "item_id", "required": true},
+		},
+		"contentAddressed": true,
+	}
+
+	resp, err := s.POST("/api/v1/admin/definitions", create)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var blobKeys []string
+	for _, item := range []string{"first", "second"} {
+		path := "/api/v1/resources/r2/" + name + "/content?scope=G&param.itemId=" + item
+		req, err := http.NewRequest(http.MethodPut, s.baseURL+path, strings.NewReader("shared contents"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "text/plain")
+
+		resp, err := s.client.Do(req)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+		var result map[string]interface{}
+		s.ParseSuccessResponse(resp, &result)
+		s.Require().NotEmpty(result["blobKey"])
+		blobKeys = append(blobKeys, result["blobKey"].(string))
+	}
+
+	s.Equal(blobKeys[0], blobKeys[1])
+}
+
+// RD-014: JSON Schema of a definition's request payload
+func (s *ResourceDefinitionTestSuite) TestGetDefinitionSchema() {
+	resp, err := s.GET("/api/v1/resources/definitions/achievement/schema")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	helpers.AssertContentType(s.T(), resp, "application/schema+json")
+
+	var schema map[string]interface{}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&schema))
+
+	s.Equal("https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	properties := schema["properties"].(map[string]interface{})
+
+	scope := properties["scope"].(map[string]interface{})
+	s.ElementsMatch([]interface{}{"A", "G"}, scope["enum"])
+
+	parameters := properties["parameters"].(map[string]interface{})
+	s.Equal([]interface{}{"achievement_id"}, parameters["required"])
+
+	params := parameters["properties"].(map[string]interface{})
+	format := params["format"].(map[string]interface{})
+	s.Equal("png", format["default"])
+	s.Contains(format["enum"], "webp")
+	s.Equal("dev", params["env"].(map[string]interface{})["default"])
+
+	s.NotEmpty(schema["allOf"], "App scope should require a known scope value")
+}
+
+// RD-015: Aggregated schema document for all definitions
+func (s *ResourceDefinitionTestSuite) TestGetSchemas() {
+	resp, err := s.GET("/api/v1/resources/definitions/schema")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var document map[string]interface{}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&document))
+
+	defs := document["$defs"].(map[string]interface{})
+	s.Contains(defs, "achievement")
+	s.Contains(defs, "workout")
+	s.Len(document["oneOf"], len(defs))
+}
+
 func TestResourceDefinitionSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping resource definition tests in short mode")
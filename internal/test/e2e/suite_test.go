@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,13 +32,34 @@ func (s *E2ETestSuite) SetupSuite() {
 		port = "8081"
 	}
 
-	// Setup HTTP client
+	// Setup HTTP client; admin routes get the admin token
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	if adminToken == "" {
+		adminToken = "test-admin-token"
+	}
 	s.client = &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: &adminTransport{token: adminToken, base: http.DefaultTransport},
 	}
 	s.baseURL = fmt.Sprintf("http://localhost:%s", port)
 }
 
+// adminTransport authorizes requests to the admin API and to batch changes
+// with a bearer token
+type adminTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *adminTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	admin := strings.HasPrefix(req.URL.Path, "/api/v1/admin/") || strings.Contains(req.URL.Path, "/batch/")
+	if admin && req.Header.Get("Authorization") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	return t.base.RoundTrip(req)
+}
+
 func (s *E2ETestSuite) TearDownSuite() {
 	s.cancel()
 }
@@ -0,0 +1,115 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/anh-nguyen/resource-server/internal/test/helpers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type UploadTestSuite struct {
+	E2ETestSuite
+}
+
+// Test Cases for the upload lifecycle admin APIs
+
+// UP-001: List tracked uploads
+func (s *UploadTestSuite) TestListUploads_Success() {
+	resp, err := s.GET("/api/v1/admin/uploads?pageSize=5")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+	s.Contains(result, "uploads")
+	s.Equal(float64(5), result["pageSize"])
+}
+
+// UP-002: Reject an unknown status filter
+func (s *UploadTestSuite) TestListUploads_InvalidStatus() {
+	resp, err := s.GET("/api/v1/admin/uploads?status=unknown")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// UP-003: Reject an invalid upload ID
+func (s *UploadTestSuite) TestAbortUpload_InvalidID() {
+	resp, err := s.POST("/api/v1/admin/uploads/not-a-uuid/abort", nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_ID")
+}
+
+// UP-004: Abort of an unknown upload fails
+func (s *UploadTestSuite) TestAbortUpload_NotFound() {
+	resp, err := s.POST("/api/v1/admin/uploads/"+uuid.NewString()+"/abort", map[string]interface{}{
+		"reason": "e2e",
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.GreaterOrEqual(resp.StatusCode, http.StatusBadRequest)
+}
+
+// UP-005: A janitor dry run reports without aborting
+func (s *UploadTestSuite) TestRunJanitor_DryRun() {
+	resp, err := s.POST("/api/v1/admin/uploads/janitor", map[string]interface{}{
+		"dryRun": true,
+		"limit":  10,
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	s.ParseSuccessResponse(resp, &result)
+	s.Equal(true, result["dryRun"])
+	s.Equal(float64(0), result["aborted"])
+	s.LessOrEqual(result["scanned"], float64(10))
+}
+
+// UP-006: An unknown upload is reported as not found
+func (s *UploadTestSuite) TestGetUpload_NotFound() {
+	resp, err := s.GET("/api/v1/admin/uploads/" + uuid.NewString())
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "UPLOAD_NOT_FOUND")
+}
+
+// UP-007: The admin API requires a valid admin token
+func (s *UploadTestSuite) TestAdminAPI_RequiresToken() {
+	for _, header := range []string{"", "Bearer not-the-admin-token"} {
+		req, err := http.NewRequest(http.MethodGet, s.baseURL+"/api/v1/admin/uploads", nil)
+		s.Require().NoError(err)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		s.Equal(http.StatusUnauthorized, resp.StatusCode)
+		helpers.AssertErrorResponse(s.T(), resp, "UNAUTHORIZED")
+	}
+}
+
+func TestUploadSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping upload tests in short mode")
+	}
+
+	suite.Run(t, new(UploadTestSuite))
+}
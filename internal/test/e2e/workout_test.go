@@ -0,0 +1,443 @@
+package e2e
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anh-nguyen/resource-server/internal/test/helpers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+const testWorkoutChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+type WorkoutTestSuite struct {
+	E2ETestSuite
+	testDB *helpers.TestDatabase
+}
+
+func (s *WorkoutTestSuite) SetupSuite() {
+	s.E2ETestSuite.SetupSuite()
+	s.testDB = helpers.SetupTestDatabase(s.T())
+}
+
+func (s *WorkoutTestSuite) TearDownSuite() {
+	if s.testDB != nil {
+		s.testDB.Close()
+	}
+	s.E2ETestSuite.TearDownSuite()
+}
+
+func (s *WorkoutTestSuite) SetupTest() {
+	s.testDB.Cleanup(s.T())
+}
+
+func (s *WorkoutTestSuite) createWorkout(userID, format string) map[string]any {
+	body := map[string]any{
+		"userId":         userID,
+		"name":           "Interval Session",
+		"description":    "5x500m intervals",
+		"format":         format,
+		"checksumSha256": testWorkoutChecksum,
+		"provider":       "r2",
+	}
+
+	resp, err := s.POST("/api/v1/workouts/", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+	return result
+}
+
+// uploadWorkoutFixture creates a workout for a fixture file, uploads the file to
+// the signed URL and returns the workout ID and upload ID. The test is skipped
+// when the storage provider is not reachable.
+func (s *WorkoutTestSuite) uploadWorkoutFixture(name, format string) (string, string) {
+	data, err := os.ReadFile(filepath.Join("..", "fixtures", "files", "workouts", name))
+	s.Require().NoError(err)
+
+	sum := sha256.Sum256(data)
+	body := map[string]any{
+		"userId":         "user-1",
+		"name":           name,
+		"format":         format,
+		"checksumSha256": hex.EncodeToString(sum[:]),
+		"provider":       "r2",
+	}
+
+	resp, err := s.POST("/api/v1/workouts/", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+
+	workout := result["workout"].(map[string]any)
+	upload := result["upload"].(map[string]any)
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPut, upload["upload_url"].(string), bytes.NewReader(data))
+	s.Require().NoError(err)
+
+	uploadResp, err := s.client.Do(req)
+	if err != nil || uploadResp.StatusCode >= http.StatusBadRequest {
+		s.T().Skip("Storage provider not available, skipping upload test")
+	}
+	uploadResp.Body.Close()
+
+	return workout["id"].(string), upload["upload_id"].(string)
+}
+
+// Test Cases for Workout APIs
+
+// WO-001: Create workout returns tracked upload
+func (s *WorkoutTestSuite) TestCreateWorkout_Success() {
+	result := s.createWorkout("user-1", "erg")
+
+	s.Contains(result, "workout")
+	s.Contains(result, "upload")
+
+	workout := result["workout"].(map[string]any)
+	s.NotEmpty(workout["id"])
+	s.Equal("user-1", workout["userId"])
+	s.Equal("erg", workout["format"])
+	s.Equal(testWorkoutChecksum, workout["checksumSha256"])
+
+	upload := result["upload"].(map[string]any)
+	s.NotEmpty(upload["upload_id"])
+	s.NotEmpty(upload["upload_url"])
+}
+
+// WO-002: Unsupported workout format
+func (s *WorkoutTestSuite) TestCreateWorkout_InvalidFormat() {
+	body := map[string]any{
+		"userId":         "user-1",
+		"name":           "Bad Format",
+		"format":         "exe",
+		"checksumSha256": testWorkoutChecksum,
+	}
+
+	resp, err := s.POST("/api/v1/workouts/", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// WO-003: Missing or malformed checksum
+func (s *WorkoutTestSuite) TestCreateWorkout_InvalidChecksum() {
+	body := map[string]any{
+		"userId":         "user-1",
+		"name":           "Bad Checksum",
+		"format":         "zwo",
+		"checksumSha256": "not-a-checksum",
+	}
+
+	resp, err := s.POST("/api/v1/workouts/", body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// WO-004: List a user's workouts
+func (s *WorkoutTestSuite) TestListWorkouts_ByUser() {
+	s.createWorkout("user-1", "erg")
+	s.createWorkout("user-1", "zwo")
+	s.createWorkout("user-2", "mrc")
+
+	resp, err := s.GET("/api/v1/workouts/?user_id=user-1")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+
+	workouts := result["workouts"].([]any)
+	s.Len(workouts, 2)
+	for _, w := range workouts {
+		s.Equal("user-1", w.(map[string]any)["userId"])
+	}
+}
+
+// WO-005: List without user_id
+func (s *WorkoutTestSuite) TestListWorkouts_MissingUser() {
+	resp, err := s.GET("/api/v1/workouts/")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// WO-006: Get workout with signed download URL
+func (s *WorkoutTestSuite) TestGetWorkout_DownloadURL() {
+	created := s.createWorkout("user-1", "json")
+	id := created["workout"].(map[string]any)["id"].(string)
+
+	resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s", id))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var workout map[string]any
+	s.ParseSuccessResponse(resp, &workout)
+
+	s.Equal(id, workout["id"])
+	s.NotEmpty(workout["downloadUrl"])
+	s.True(strings.Contains(workout["downloadUrl"].(string), id))
+}
+
+// WO-007: Get non-existent workout
+func (s *WorkoutTestSuite) TestGetWorkout_NotFound() {
+	resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s", uuid.New().String()))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "WORKOUT_NOT_FOUND")
+}
+
+// WO-008: Replace workout file with a new format
+func (s *WorkoutTestSuite) TestReplaceWorkoutFile_Success() {
+	created := s.createWorkout("user-1", "erg")
+	id := created["workout"].(map[string]any)["id"].(string)
+
+	body := map[string]any{
+		"format":         "mrc",
+		"checksumSha256": strings.Repeat("a", 64),
+	}
+
+	resp, err := s.PUT(fmt.Sprintf("/api/v1/workouts/%s/file", id), body)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+
+	workout := result["workout"].(map[string]any)
+	s.Equal("mrc", workout["format"])
+	s.Equal(strings.Repeat("a", 64), workout["checksumSha256"])
+
+	upload := result["upload"].(map[string]any)
+	s.NotEmpty(upload["upload_url"])
+}
+
+// WO-009: Delete workout
+func (s *WorkoutTestSuite) TestDeleteWorkout_Success() {
+	created := s.createWorkout("user-1", "erg")
+	id := created["workout"].(map[string]any)["id"].(string)
+
+	resp, err := s.DELETE(fmt.Sprintf("/api/v1/workouts/%s", id))
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = s.GET(fmt.Sprintf("/api/v1/workouts/%s", id))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.GreaterOrEqual(resp.StatusCode, http.StatusBadRequest)
+}
+
+// WO-010: Invalid workout ID
+func (s *WorkoutTestSuite) TestDeleteWorkout_InvalidUUID() {
+	resp, err := s.DELETE("/api/v1/workouts/invalid-uuid")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_ID")
+}
+
+// WO-011: Confirmed upload stores the parsed summary
+func (s *WorkoutTestSuite) TestConfirmUpload_ExtractsSummary() {
+	workoutID, uploadID := s.uploadWorkoutFixture("valid.zwo", "zwo")
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/workouts/%s/uploads/%s/confirm", workoutID, uploadID), map[string]any{
+		"success": true,
+	})
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = s.GET(fmt.Sprintf("/api/v1/workouts/%s", workoutID))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var workout map[string]any
+	s.ParseSuccessResponse(resp, &workout)
+
+	s.NotEmpty(workout["confirmedAt"])
+
+	summary := workout["summary"].(map[string]any)
+	s.Equal(float64(1800), summary["durationSeconds"])
+	s.Equal(float64(11), summary["intervalCount"])
+	s.Equal("ftp_percent", summary["powerUnit"])
+	s.Equal(float64(35), summary["powerMin"])
+	s.Equal(float64(110), summary["powerMax"])
+	s.Equal(float64(18), summary["strokeRateMin"])
+	s.Equal(float64(28), summary["strokeRateMax"])
+}
+
+// WO-012: Malformed workout file is rejected
+func (s *WorkoutTestSuite) TestConfirmUpload_MalformedFile() {
+	workoutID, uploadID := s.uploadWorkoutFixture("malformed.erg", "erg")
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/workouts/%s/uploads/%s/confirm", workoutID, uploadID), map[string]any{
+		"success": true,
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "INVALID_WORKOUT_FILE")
+}
+
+// WO-013: Download in the stored format
+func (s *WorkoutTestSuite) TestDownloadWorkout_SourceFormat() {
+	created := s.createWorkout("user-1", "erg")
+	id := created["workout"].(map[string]any)["id"].(string)
+
+	resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s/download", id))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var result map[string]any
+	s.ParseSuccessResponse(resp, &result)
+
+	s.Equal("erg", result["format"])
+	s.Equal(false, result["converted"])
+	s.NotEmpty(result["downloadUrl"])
+}
+
+// WO-014: Conversion before the upload is confirmed
+func (s *WorkoutTestSuite) TestDownloadWorkout_NotConfirmed() {
+	created := s.createWorkout("user-1", "zwo")
+	id := created["workout"].(map[string]any)["id"].(string)
+
+	resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s/download?format=json", id))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "FILE_NOT_CONFIRMED")
+}
+
+// WO-015: Unsupported download format
+func (s *WorkoutTestSuite) TestDownloadWorkout_InvalidFormat() {
+	resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s/download?format=fit", uuid.New().String()))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "VALIDATION_ERROR")
+}
+
+// WO-016: Convert ZWO to JSON and reuse the cached conversion
+func (s *WorkoutTestSuite) TestDownloadWorkout_ConvertAndCache() {
+	workoutID, uploadID := s.uploadWorkoutFixture("valid.zwo", "zwo")
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/workouts/%s/uploads/%s/confirm", workoutID, uploadID), map[string]any{
+		"success": true,
+	})
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	download := func() map[string]any {
+		resp, err := s.GET(fmt.Sprintf("/api/v1/workouts/%s/download?format=json", workoutID))
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		var result map[string]any
+		s.ParseSuccessResponse(resp, &result)
+		return result
+	}
+
+	first := download()
+	s.Equal("json", first["format"])
+	s.Equal("zwo", first["sourceFormat"])
+	s.Equal(true, first["converted"])
+	s.Equal(false, first["cached"])
+	s.True(strings.Contains(first["downloadUrl"].(string), workoutID+".json"))
+
+	second := download()
+	s.Equal(true, second["cached"])
+	s.Equal(first["cacheKey"], second["cacheKey"])
+}
+
+// WO-017: Converting between watts and percent of FTP requires an FTP
+func (s *WorkoutTestSuite) TestDownloadWorkout_FTPRequired() {
+	workoutID, uploadID := s.uploadWorkoutFixture("valid.zwo", "zwo")
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/workouts/%s/uploads/%s/confirm", workoutID, uploadID), map[string]any{
+		"success": true,
+	})
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = s.GET(fmt.Sprintf("/api/v1/workouts/%s/download?format=erg", workoutID))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "FTP_REQUIRED")
+}
+
+// WO-018: An upload initiated for another workout cannot be confirmed
+func (s *WorkoutTestSuite) TestConfirmUpload_OtherWorkoutUpload() {
+	_, uploadID := s.uploadWorkoutFixture("valid.zwo", "zwo")
+	otherID, _ := s.uploadWorkoutFixture("valid.zwo", "zwo")
+
+	resp, err := s.POST(fmt.Sprintf("/api/v1/workouts/%s/uploads/%s/confirm", otherID, uploadID), map[string]any{
+		"success": true,
+	})
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "UPLOAD_MISMATCH")
+}
+
+// WO-019: A route whose timeout fires reports the deadline as 504. The test
+// configuration gives this workout's route a timeout that expires at once.
+func (s *WorkoutTestSuite) TestGetWorkout_RouteTimeout() {
+	resp, err := s.GET("/api/v1/workouts/00000000-0000-0000-0000-000000000504")
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusGatewayTimeout, resp.StatusCode)
+	helpers.AssertErrorResponse(s.T(), resp, "DEADLINE_EXCEEDED")
+}
+
+func TestWorkoutSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping workout tests in short mode")
+	}
+
+	suite.Run(t, new(WorkoutTestSuite))
+}
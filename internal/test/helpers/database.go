@@ -44,6 +44,10 @@ func (td *TestDatabase) Cleanup(t *testing.T) {
 	// Clean up test data
 	tables := []string{
 		"achievements",
+		"achievement_revisions",
+		"workouts",
+		"replication_jobs",
+		"replication_job_items",
 		"resource_uploads",
 	}
 
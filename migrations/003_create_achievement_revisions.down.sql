@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_achievement_revisions_achievement;
+DROP TABLE IF EXISTS achievement_revisions;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS achievement_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_id UUID NOT NULL,
+    revision INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    previous JSONB,
+    changes JSONB NOT NULL DEFAULT '[]',
+    icon_provider VARCHAR(20),
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT uq_achievement_revision UNIQUE (achievement_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_revisions_achievement ON achievement_revisions(achievement_id, revision DESC);
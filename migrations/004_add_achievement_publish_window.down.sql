@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_achievement_publish_window;
+ALTER TABLE achievements DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE achievements DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,5 @@
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_publish_window ON achievements(publish_at, unpublish_at)
+WHERE publish_at IS NOT NULL OR unpublish_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_workouts_user;
+DROP TABLE IF EXISTS workouts;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS workouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    format VARCHAR(10) NOT NULL,
+    file_path VARCHAR(500),
+    provider VARCHAR(20) NOT NULL,
+    scope VARCHAR(2) NOT NULL DEFAULT 'G',
+    scope_value SMALLINT NOT NULL DEFAULT 0,
+    file_size BIGINT,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    metadata JSONB,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workouts_user ON workouts(user_id, created_at DESC);
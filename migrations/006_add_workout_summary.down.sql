@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_workout_power;
+DROP INDEX IF EXISTS idx_workout_duration;
+
+ALTER TABLE workouts DROP COLUMN IF EXISTS stroke_rate_max;
+ALTER TABLE workouts DROP COLUMN IF EXISTS stroke_rate_min;
+ALTER TABLE workouts DROP COLUMN IF EXISTS power_max;
+ALTER TABLE workouts DROP COLUMN IF EXISTS power_min;
+ALTER TABLE workouts DROP COLUMN IF EXISTS power_unit;
+ALTER TABLE workouts DROP COLUMN IF EXISTS interval_count;
+ALTER TABLE workouts DROP COLUMN IF EXISTS duration_seconds;
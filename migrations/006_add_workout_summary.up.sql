@@ -0,0 +1,10 @@
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS duration_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS interval_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS power_unit VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS power_min DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS power_max DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS stroke_rate_min INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS stroke_rate_max INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_workout_duration ON workouts(duration_seconds);
+CREATE INDEX IF NOT EXISTS idx_workout_power ON workouts(power_unit, power_min, power_max);
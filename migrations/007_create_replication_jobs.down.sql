@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_replication_job_items_status;
+DROP TABLE IF EXISTS replication_job_items;
+DROP INDEX IF EXISTS idx_replication_jobs_status;
+DROP TABLE IF EXISTS replication_jobs;
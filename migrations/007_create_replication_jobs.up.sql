@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS replication_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    definition VARCHAR(128) NOT NULL,
+    scope VARCHAR(2) NOT NULL DEFAULT 'G',
+    scope_value SMALLINT NOT NULL DEFAULT 0,
+    parameters JSONB,
+    source_provider VARCHAR(20) NOT NULL,
+    target_provider VARCHAR(20) NOT NULL,
+    concurrency SMALLINT NOT NULL DEFAULT 4,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    continuation_token TEXT,
+    copied_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_replication_jobs_status ON replication_jobs(status, created_at);
+
+CREATE TABLE IF NOT EXISTS replication_job_items (
+    job_id UUID NOT NULL REFERENCES replication_jobs(id) ON DELETE CASCADE,
+    source_key VARCHAR(1024) NOT NULL,
+    target_key VARCHAR(1024) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    checksum_sha256 VARCHAR(64),
+    error TEXT,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (job_id, source_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_replication_job_items_status ON replication_job_items(job_id, status);
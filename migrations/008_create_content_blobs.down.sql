@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_content_references_blob;
+DROP INDEX IF EXISTS idx_content_references_path;
+DROP TABLE IF EXISTS content_references;
+DROP TABLE IF EXISTS content_blobs;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS content_blobs (
+    provider VARCHAR(20) NOT NULL,
+    blob_key VARCHAR(1024) NOT NULL,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    ref_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (provider, blob_key)
+);
+
+CREATE TABLE IF NOT EXISTS content_references (
+    provider VARCHAR(20) NOT NULL,
+    path VARCHAR(1024) NOT NULL,
+    blob_key VARCHAR(1024) NOT NULL,
+    definition VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (provider, path),
+    FOREIGN KEY (provider, blob_key) REFERENCES content_blobs(provider, blob_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_references_path ON content_references((ltrim(path, '/')));
+CREATE INDEX IF NOT EXISTS idx_content_references_blob ON content_references(provider, blob_key);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS resource_definition_versions;
+DROP TABLE IF EXISTS resource_definitions;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS resource_definitions (
+    name VARCHAR(128) PRIMARY KEY,
+    version INTEGER NOT NULL DEFAULT 1,
+    spec JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_by VARCHAR(255) NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resource_definition_versions (
+    name VARCHAR(128) NOT NULL REFERENCES resource_definitions(name) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    spec JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    migrated BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (name, version)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_achievement_revisions_unpruned;
+ALTER TABLE achievement_revisions DROP COLUMN IF EXISTS icon_pruned_at;
+ALTER TABLE achievements DROP COLUMN IF EXISTS icon_provider;
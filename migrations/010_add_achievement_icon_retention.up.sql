@@ -0,0 +1,5 @@
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS icon_provider VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE achievement_revisions ADD COLUMN IF NOT EXISTS icon_pruned_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_revisions_unpruned ON achievement_revisions(created_at)
+WHERE icon_pruned_at IS NULL AND previous IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_replication_jobs_claimable;
+ALTER TABLE replication_jobs DROP COLUMN IF EXISTS lease_until;
+ALTER TABLE replication_jobs DROP COLUMN IF EXISTS lease_owner;
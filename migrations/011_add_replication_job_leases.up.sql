@@ -0,0 +1,5 @@
+ALTER TABLE replication_jobs ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(64);
+ALTER TABLE replication_jobs ADD COLUMN IF NOT EXISTS lease_until TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_replication_jobs_claimable ON replication_jobs(created_at)
+WHERE status IN ('pending', 'running');
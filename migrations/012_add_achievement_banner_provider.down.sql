@@ -0,0 +1 @@
+ALTER TABLE achievements DROP COLUMN IF EXISTS banner_provider;
@@ -0,0 +1 @@
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS banner_provider VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
+
+-- Only confirmed files have a summary
+UPDATE workouts SET confirmed_at = updated_at WHERE confirmed_at IS NULL AND interval_count > 0;
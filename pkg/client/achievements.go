@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CreateAchievementRequest creates an achievement. An icon upload URL is
+// returned when IconFormat is set.
+type CreateAchievementRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Category    string     `json:"category,omitempty"`
+	Points      int        `json:"points"`
+	IconFormat  string     `json:"iconFormat,omitempty"`
+	Provider    string     `json:"provider,omitempty"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
+
+// CreateAchievementResponse is a created achievement
+type CreateAchievementResponse struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    string      `json:"category,omitempty"`
+	Points      int         `json:"points"`
+	IconURL     string      `json:"iconUrl,omitempty"`
+	Upload      *UploadInfo `json:"upload,omitempty"`
+}
+
+// UploadInfo is a pending icon upload, confirmed with ConfirmAchievementUpload
+type UploadInfo struct {
+	UploadID  string `json:"upload_id"`
+	UploadURL string `json:"upload_url"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// Achievement is a stored achievement
+type Achievement struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Category    string         `json:"category,omitempty"`
+	Points      int            `json:"points"`
+	IconURL     string         `json:"iconUrl,omitempty"`
+	BannerURL   string         `json:"bannerUrl,omitempty"`
+	IsActive    bool           `json:"isActive"`
+	PublishAt   *time.Time     `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time     `json:"unpublishAt,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// AchievementList is a page of achievements. At is set by previews only.
+type AchievementList struct {
+	Achievements []*Achievement `json:"achievements"`
+	At           *time.Time     `json:"at,omitempty"`
+	Page         int            `json:"page"`
+	PageSize     int            `json:"pageSize"`
+	Total        int            `json:"total"`
+}
+
+// UpdateIconRequest replaces the icon of an achievement
+type UpdateIconRequest struct {
+	Format   string `json:"format"`
+	Provider string `json:"provider"`
+	Size     int64  `json:"size,omitempty"`
+}
+
+// UpdateIconResponse holds the upload URL of a new icon
+type UpdateIconResponse struct {
+	UploadID    string             `json:"upload_id"`
+	UploadURL   string             `json:"upload_url"`
+	ExpiresAt   int64              `json:"expires_at"`
+	NewIconURL  string             `json:"new_iconUrl"`
+	Constraints *UploadConstraints `json:"constraints,omitempty"`
+}
+
+// UpdateScheduleRequest sets the publication window of an achievement. Nil
+// times clear the bound.
+type UpdateScheduleRequest struct {
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+}
+
+// PartETag is the ETag of an uploaded part
+type PartETag struct {
+	Part int    `json:"part"`
+	ETag string `json:"etag"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// ConfirmUploadRequest reports the outcome of an achievement upload
+type ConfirmUploadRequest struct {
+	UploadID     string          `json:"upload_id"`
+	Success      bool            `json:"success"`
+	ErrorMsg     string          `json:"error_msg,omitempty"`
+	FileSize     int64           `json:"file_size,omitempty"`
+	ContentType  string          `json:"content_type,omitempty"`
+	ETags        []PartETag      `json:"etags,omitempty"`
+	Metadata     *UploadMetadata `json:"metadata,omitempty"`
+	VerifyExists bool            `json:"verify_exists,omitempty"`
+}
+
+// FieldChange is a field changed by a revision
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// AchievementRevision is a recorded change of an achievement
+type AchievementRevision struct {
+	Revision  int           `json:"revision"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	Changes   []FieldChange `json:"changes"`
+	Previous  *Achievement  `json:"previous,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// AchievementHistory is a page of revisions, newest first
+type AchievementHistory struct {
+	Revisions []*AchievementRevision `json:"revisions"`
+	Page      int                    `json:"page"`
+	PageSize  int                    `json:"pageSize"`
+	Total     int                    `json:"total"`
+}
+
+// RevertAchievementResponse is an achievement restored to a revision
+type RevertAchievementResponse struct {
+	Achievement  *Achievement `json:"achievement"`
+	Revision     int          `json:"revision"`
+	IconRestored bool         `json:"iconRestored"`
+}
+
+func achievementPath(id string) string {
+	return "/achievements/" + url.PathEscape(id)
+}
+
+// ListAchievements returns a page of achievements, only the active ones
+// unless includeInactive is set
+func (c *Client) ListAchievements(ctx context.Context, includeInactive bool, page *PageOptions) (*AchievementList, error) {
+	query := page.query()
+	query.Set("only_active", strconv.FormatBool(!includeInactive))
+
+	var result AchievementList
+	if err := c.call(ctx, http.MethodGet, "/achievements", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PreviewAchievements returns a page of the achievements active at the given
+// time
+func (c *Client) PreviewAchievements(ctx context.Context, at time.Time, page *PageOptions) (*AchievementList, error) {
+	query := page.query()
+	if !at.IsZero() {
+		query.Set("at", at.Format(time.RFC3339))
+	}
+
+	var result AchievementList
+	if err := c.call(ctx, http.MethodGet, "/achievements/preview", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAchievement creates an achievement
+func (c *Client) CreateAchievement(ctx context.Context, req *CreateAchievementRequest) (*CreateAchievementResponse, error) {
+	var result CreateAchievementResponse
+	if err := c.call(ctx, http.MethodPost, "/achievements", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAchievement returns an achievement by ID
+func (c *Client) GetAchievement(ctx context.Context, id string) (*Achievement, error) {
+	var result Achievement
+	if err := c.call(ctx, http.MethodGet, achievementPath(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAchievement deletes an achievement
+func (c *Client) DeleteAchievement(ctx context.Context, id string) error {
+	return c.call(ctx, http.MethodDelete, achievementPath(id), nil, nil, nil)
+}
+
+// GetAchievementHistory returns a page of the revisions of an achievement
+func (c *Client) GetAchievementHistory(ctx context.Context, id string, page *PageOptions) (*AchievementHistory, error) {
+	var result AchievementHistory
+	if err := c.call(ctx, http.MethodGet, achievementPath(id)+"/history", page.query(), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RevertAchievement restores an achievement to the state of a revision
+func (c *Client) RevertAchievement(ctx context.Context, id string, revision int) (*RevertAchievementResponse, error) {
+	var result RevertAchievementResponse
+	path := fmt.Sprintf("%s/revert/%d", achievementPath(id), revision)
+	if err := c.call(ctx, http.MethodPost, path, nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAchievementIcon returns an upload URL for a new achievement icon
+func (c *Client) UpdateAchievementIcon(ctx context.Context, id string, req *UpdateIconRequest) (*UpdateIconResponse, error) {
+	var result UpdateIconResponse
+	if err := c.call(ctx, http.MethodPut, achievementPath(id)+"/icon", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAchievementSchedule sets the publication window of an achievement
+func (c *Client) UpdateAchievementSchedule(ctx context.Context, id string, req *UpdateScheduleRequest) (*Achievement, error) {
+	var result Achievement
+	if err := c.call(ctx, http.MethodPut, achievementPath(id)+"/schedule", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ConfirmAchievementUpload reports the outcome of an achievement upload
+func (c *Client) ConfirmAchievementUpload(ctx context.Context, req *ConfirmUploadRequest) error {
+	path := "/achievements/uploads/" + url.PathEscape(req.UploadID) + "/confirm"
+	return c.call(ctx, http.MethodPost, path, nil, req, nil)
+}
+
+// GetAchievementPartURLs returns signed URLs for the parts of a multipart
+// achievement upload, as reported by the upload manager
+func (c *Client) GetAchievementPartURLs(ctx context.Context, uploadID string, partCount int) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := "/achievements/uploads/" + url.PathEscape(uploadID) + "/multipart"
+	req := map[string]int{"part_count": partCount}
+	if err := c.call(ctx, http.MethodPost, path, nil, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
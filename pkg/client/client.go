@@ -0,0 +1,334 @@
+// Package client is a Go client for the resource server API. Responses are
+// unwrapped from the API envelope into typed values, and failures are
+// returned as *Error carrying the API error code.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each HTTP request of a client built without its own
+// HTTP client
+const DefaultTimeout = 30 * time.Second
+
+// RetryPolicy controls how idempotent calls are retried after network errors
+// and 429, 502, 503 and 504 responses. Backoff doubles after every attempt,
+// with jitter, up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// Client calls the resource server API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    http.Header
+	retry      RetryPolicy
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for every request, including
+// part uploads to signed URLs
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy. A MaxAttempts of 1
+// disables retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithPrincipal sets the X-Principal-ID header the server records as the
+// actor of changes. The server does not authenticate it.
+func WithPrincipal(principalID string) Option {
+	return WithHeader("X-Principal-ID", principalID)
+}
+
+// WithHeader adds a header to every API request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. http://localhost:8081
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + "/api/v1",
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		headers:    make(http.Header),
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// envelope is the body of every JSON API response
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+	Error   *struct {
+		Code    string `json:"code"`
+		Details string `json:"details"`
+	} `json:"error"`
+}
+
+// request is a single API call
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	contentType string
+	// body is replayed on retries; stream is sent once and disables them
+	body   []byte
+	stream io.Reader
+	// contentLength declares the length of stream when positive
+	contentLength int64
+}
+
+// call sends a JSON request and decodes the data of the response envelope
+// into out, which may be nil
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	req := &request{method: method, path: path, query: query}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		req.body = body
+		req.contentType = "application/json"
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decodeData(resp, out)
+}
+
+// callRaw sends a JSON request and returns the response body as is, for the
+// endpoints that do not use the envelope
+func (c *Client) callRaw(ctx context.Context, method, path string, query url.Values, in any) (*http.Response, error) {
+	req := &request{method: method, path: path, query: query}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		req.body = body
+		req.contentType = "application/json"
+	}
+
+	return c.send(ctx, req)
+}
+
+// send performs an API request, retrying idempotent methods. Responses with
+// an error status are returned as *Error.
+func (c *Client) send(ctx context.Context, r *request) (*http.Response, error) {
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	idempotent := r.stream == nil && isIdempotent(r.method)
+	resp, err := c.doWithRetry(ctx, idempotent, func() (*http.Request, error) {
+		var body io.Reader
+		switch {
+		case r.stream != nil:
+			body = r.stream
+		case r.body != nil:
+			body = bytes.NewReader(r.body)
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, r.method, target, body)
+		if err != nil {
+			return nil, err
+		}
+		if r.contentLength > 0 {
+			httpReq.ContentLength = r.contentLength
+		}
+		for key, values := range c.headers {
+			httpReq.Header[key] = values
+		}
+		for key, values := range r.header {
+			httpReq.Header[key] = values
+		}
+		if httpReq.Header.Get("Accept") == "" {
+			httpReq.Header.Set("Accept", "application/json")
+		}
+		if r.contentType != "" {
+			httpReq.Header.Set("Content-Type", r.contentType)
+		}
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// doWithRetry sends the request built by newRequest, retrying idempotent
+// requests on network errors and retryable statuses
+func (c *Client) doWithRetry(ctx context.Context, idempotent bool, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	attempts := 1
+	if idempotent {
+		attempts = max(c.retry.MaxAttempts, 1)
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= attempts || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait before the next attempt, honouring Retry-After
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			return min(time.Duration(seconds)*time.Second, p.MaxBackoff)
+		}
+	}
+
+	wait := p.InitialBackoff << (attempt - 1)
+	if wait <= 0 || wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Jitter spreads out clients retrying the same failure
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// decodeData unwraps the response envelope into out
+func decodeData(resp *http.Response, out any) error {
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !env.Success {
+		apiErr := &Error{StatusCode: resp.StatusCode, Message: env.Message}
+		if env.Error != nil {
+			apiErr.Code = env.Error.Code
+			apiErr.Details = env.Error.Details
+		}
+		return apiErr
+	}
+
+	if out == nil || len(env.Data) == 0 || string(env.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response data: %w", err)
+	}
+	return nil
+}
+
+// pathEscape escapes each segment of a file path, keeping the separators
+func pathEscape(filePath string) string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// PageOptions selects a page of a paginated list. Zero values use the
+// server defaults.
+type PageOptions struct {
+	Page     int
+	PageSize int
+}
+
+func (o *PageOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(o.PageSize))
+	}
+	return query
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// PathDefinition is a resource path definition
+type PathDefinition struct {
+	Name                   string                  `json:"name"`
+	DisplayName            string                  `json:"displayName"`
+	Description            string                  `json:"description"`
+	Parent                 string                  `json:"parent,omitempty"`
+	Version                int                     `json:"version,omitempty"`
+	Deprecated             bool                    `json:"deprecated,omitempty"`
+	AllowedScopes          []string                `json:"allowedScopes"`
+	Parameters             []PathParameter         `json:"parameters"`
+	Providers              []string                `json:"providers"`
+	Patterns               map[string]PathPatterns `json:"patterns"`
+	DefaultStorageMetadata *StorageDefaults        `json:"defaultStorageMetadata,omitempty"`
+	Children               []*PathDefinition       `json:"children,omitempty"`
+}
+
+// PathParameter is a parameter of a definition's path patterns
+type PathParameter struct {
+	Name         string   `json:"name"`
+	Required     bool     `json:"required"`
+	Rules        []string `json:"rules,omitempty"`
+	Enum         []string `json:"enum,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinLength    *int     `json:"minLength,omitempty"`
+	MaxLength    *int     `json:"maxLength,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	DefaultValue string   `json:"defaultValue,omitempty"`
+}
+
+// PathPatterns are a definition's path patterns on one provider, keyed by scope
+type PathPatterns struct {
+	URLType  string            `json:"urlType"`
+	Patterns map[string]string `json:"patterns"`
+}
+
+// StorageDefaults is the storage metadata applied to a definition's uploads
+type StorageDefaults struct {
+	CacheControl      *CacheControlDefaults `json:"cacheControl,omitempty"`
+	RequiredChecksums []string              `json:"requiredChecksums,omitempty"`
+	CustomHeaders     map[string]string     `json:"customHeaders,omitempty"`
+}
+
+// CacheControlDefaults is a definition's default cache control
+type CacheControlDefaults struct {
+	MaxAge      int64  `json:"maxAge"`
+	AllowPublic bool   `json:"allowPublic"`
+	Default     string `json:"default,omitempty"`
+}
+
+// ResolvePathRequest is a dry-run resolution of a definition's paths
+type ResolvePathRequest struct {
+	Parameters map[string]string `json:"parameters"`
+	Scope      string            `json:"scope,omitempty"`
+	ScopeValue int16             `json:"scopeValue,omitempty"`
+}
+
+// ResolvePathResponse holds the paths a definition resolves to on each of
+// its providers
+type ResolvePathResponse struct {
+	Definition string                 `json:"definition"`
+	Scope      string                 `json:"scope"`
+	ScopeValue int16                  `json:"scopeValue,omitempty"`
+	Providers  []ResolvedProviderPath `json:"providers"`
+}
+
+// ResolvedProviderPath is a definition's path on one provider
+type ResolvedProviderPath struct {
+	Provider   string            `json:"provider"`
+	Path       string            `json:"path"`
+	URLType    string            `json:"urlType"`
+	Parameters map[string]string `json:"parameters"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	Valid      bool              `json:"valid"`
+}
+
+// ListDefinitions returns every resource definition
+func (c *Client) ListDefinitions(ctx context.Context) ([]PathDefinition, error) {
+	var definitions []PathDefinition
+	if err := c.call(ctx, http.MethodGet, "/resources/definitions", nil, nil, &definitions); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// GetDefinition returns a resource definition
+func (c *Client) GetDefinition(ctx context.Context, name string) (*PathDefinition, error) {
+	var definition PathDefinition
+	if err := c.call(ctx, http.MethodGet, "/resources/definitions/"+url.PathEscape(name), nil, nil, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// GetSchemas returns the JSON Schema document holding the request schema of
+// every definition
+func (c *Client) GetSchemas(ctx context.Context) (json.RawMessage, error) {
+	return c.getSchema(ctx, "/resources/definitions/schema")
+}
+
+// GetDefinitionSchema returns the JSON Schema of a definition's requests
+func (c *Client) GetDefinitionSchema(ctx context.Context, name string) (json.RawMessage, error) {
+	return c.getSchema(ctx, "/resources/definitions/"+url.PathEscape(name)+"/schema")
+}
+
+func (c *Client) getSchema(ctx context.Context, path string) (json.RawMessage, error) {
+	resp, err := c.callRaw(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// ResolveDefinition resolves a definition's paths without touching storage
+func (c *Client) ResolveDefinition(ctx context.Context, name string, req *ResolvePathRequest) (*ResolvePathResponse, error) {
+	var resolved ResolvePathResponse
+	if err := c.call(ctx, http.MethodPost, "/resources/definitions/"+url.PathEscape(name)+"/resolve", nil, req, &resolved); err != nil {
+		return nil, err
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Error is a failed API call. Code is the code of the API error response,
+// e.g. DEFINITION_NOT_FOUND or VALIDATION_ERROR, and is empty when the
+// server did not answer with the API envelope.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("resource server: %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	return msg
+}
+
+// ErrorCode returns the API error code of err, or an empty string when err
+// is not an *Error
+func ErrorCode(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an API error with status 404
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// decodeError builds the Error of a response with an error status
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	var env envelope
+	if json.Unmarshal(body, &env) != nil || env.Error == nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		apiErr.Details = string(body)
+		return apiErr
+	}
+
+	apiErr.Code = env.Error.Code
+	apiErr.Message = env.Message
+	apiErr.Details = env.Error.Details
+	return apiErr
+}
@@ -0,0 +1,495 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Scopes of resource paths
+const (
+	ScopeGlobal    = "G"
+	ScopeApp       = "A"
+	ScopeClientApp = "CA"
+)
+
+// FileList is a page of files
+type FileList struct {
+	Files             []FileInfo `json:"files"`
+	CommonPrefixes    []string   `json:"commonPrefixes,omitempty"`
+	ContinuationToken string     `json:"continuationToken,omitempty"`
+	IsTruncated       bool       `json:"isTruncated"`
+	MaxKeys           int        `json:"maxKeys"`
+}
+
+// FileInfo is a listed file
+type FileInfo struct {
+	Key          string         `json:"key"`
+	Size         int64          `json:"size"`
+	ContentType  string         `json:"contentType,omitempty"`
+	ETag         string         `json:"etag,omitempty"`
+	LastModified time.Time      `json:"lastModified"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
+// ListFilesOptions refines a file listing. Scope and Parameters resolve the
+// definition's path so Prefix is relative to it.
+type ListFilesOptions struct {
+	MaxKeys           int
+	ContinuationToken string
+	Prefix            string
+	Delimiter         string
+	Scope             string
+	ScopeValue        int16
+	Parameters        map[string]string
+	ContentType       string
+	Sort              string
+	Order             string
+	MinSize           *int64
+	MaxSize           *int64
+	ModifiedAfter     *time.Time
+	ModifiedBefore    *time.Time
+	IncludeMetadata   bool
+}
+
+func (o *ListFilesOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+
+	if o.MaxKeys > 0 {
+		query.Set("max_keys", strconv.Itoa(o.MaxKeys))
+	}
+	values := map[string]string{
+		"continuation_token": o.ContinuationToken,
+		"prefix":             o.Prefix,
+		"delimiter":          o.Delimiter,
+		"scope":              o.Scope,
+		"content_type":       o.ContentType,
+		"sort":               o.Sort,
+		"order":              o.Order,
+	}
+	for name, value := range values {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if o.ScopeValue > 0 {
+		query.Set("scope_value", strconv.Itoa(int(o.ScopeValue)))
+	}
+	for name, value := range o.Parameters {
+		query.Set("param."+name, value)
+	}
+	if o.MinSize != nil {
+		query.Set("min_size", strconv.FormatInt(*o.MinSize, 10))
+	}
+	if o.MaxSize != nil {
+		query.Set("max_size", strconv.FormatInt(*o.MaxSize, 10))
+	}
+	if o.ModifiedAfter != nil {
+		query.Set("modified_after", o.ModifiedAfter.Format(time.RFC3339))
+	}
+	if o.ModifiedBefore != nil {
+		query.Set("modified_before", o.ModifiedBefore.Format(time.RFC3339))
+	}
+	if o.IncludeMetadata {
+		query.Set("include_metadata", "true")
+	}
+	return query
+}
+
+// UploadRequest asks for a signed upload URL
+type UploadRequest struct {
+	Parameters    map[string]string `json:"parameters"`
+	Scope         string            `json:"scope,omitempty"`
+	ScopeValue    int16             `json:"scopeValue,omitempty"`
+	Expiry        string            `json:"expiry,omitempty"`
+	Metadata      *UploadMetadata   `json:"metadata,omitempty"`
+	ContentLength int64             `json:"contentLength,omitempty"`
+	Checksum      *ChecksumInfo     `json:"checksum,omitempty"`
+}
+
+// UploadMetadata is the storage metadata of an upload
+type UploadMetadata struct {
+	ContentType        string            `json:"contentType,omitempty"`
+	ContentEncoding    string            `json:"contentEncoding,omitempty"`
+	ContentLanguage    string            `json:"contentLanguage,omitempty"`
+	ContentDisposition string            `json:"contentDisposition,omitempty"`
+	CacheControl       string            `json:"cacheControl,omitempty"`
+	StorageClass       string            `json:"storageClass,omitempty"`
+	ACL                string            `json:"acl,omitempty"`
+	CustomHeaders      map[string]string `json:"customHeaders,omitempty"`
+}
+
+// ChecksumInfo is a checksum of a file or part, e.g. a base64 SHA256
+type ChecksumInfo struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// SignedURL is a signed URL and the method and headers to call it with
+type SignedURL struct {
+	URL                string             `json:"url"`
+	Method             string             `json:"method"`
+	Headers            map[string]string  `json:"headers,omitempty"`
+	ExpiresAt          time.Time          `json:"expiresAt"`
+	ResolvedPath       string             `json:"resolvedPath,omitempty"`
+	ResolvedParameters map[string]string  `json:"resolvedParameters,omitempty"`
+	Constraints        *UploadConstraints `json:"constraints,omitempty"`
+}
+
+// UploadConstraints are the upload policy conditions signed into a URL
+type UploadConstraints struct {
+	MaxSize     int64         `json:"maxSize,omitempty"`
+	ContentType string        `json:"contentType,omitempty"`
+	Checksum    *ChecksumInfo `json:"checksum,omitempty"`
+	ClientIP    string        `json:"clientIp,omitempty"`
+}
+
+// DownloadRequest asks for a signed download URL
+type DownloadRequest struct {
+	Expiry          string            `json:"expiry,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+}
+
+// ContentUploadOptions resolve the path of content uploaded through the server
+type ContentUploadOptions struct {
+	Scope       string
+	ScopeValue  int16
+	ResourceID  string
+	Parameters  map[string]string
+	ContentType string
+	// ContentLength is sent when positive, letting the server choose between
+	// a simple and a multipart upload up front
+	ContentLength int64
+}
+
+// ContentUploadResponse describes a completed upload through the server
+type ContentUploadResponse struct {
+	UploadID    string         `json:"uploadId"`
+	Provider    string         `json:"provider"`
+	Path        string         `json:"path"`
+	Size        int64          `json:"size"`
+	ContentType string         `json:"contentType,omitempty"`
+	UploadType  string         `json:"uploadType"`
+	Parts       int            `json:"parts"`
+	Checksums   []ChecksumInfo `json:"checksums"`
+	BlobKey     string         `json:"blobKey,omitempty"`
+}
+
+// ContentDownloadOptions shape a download through the server. Range is an
+// HTTP Range header value, e.g. bytes=0-1023.
+type ContentDownloadOptions struct {
+	Disposition string
+	FileName    string
+	Range       string
+}
+
+// Content is a file streamed from the server. The caller closes Body.
+type Content struct {
+	Body          io.ReadCloser
+	ContentType   string
+	ContentLength int64
+	ContentRange  string
+	ETag          string
+}
+
+// FileMetadata is the stored metadata of a file
+type FileMetadata struct {
+	Key                string            `json:"key"`
+	Size               int64             `json:"size"`
+	ContentType        string            `json:"contentType,omitempty"`
+	ETag               string            `json:"etag,omitempty"`
+	Created            *time.Time        `json:"created,omitempty"`
+	LastModified       *time.Time        `json:"lastModified,omitempty"`
+	StorageClass       string            `json:"storageClass,omitempty"`
+	CacheControl       string            `json:"cacheControl,omitempty"`
+	ContentEncoding    string            `json:"contentEncoding,omitempty"`
+	ContentDisposition string            `json:"contentDisposition,omitempty"`
+	ContentLanguage    string            `json:"contentLanguage,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	Checksums          []ChecksumInfo    `json:"checksums,omitempty"`
+	ACL                string            `json:"acl,omitempty"`
+	ExpirationTime     *time.Time        `json:"expirationTime,omitempty"`
+}
+
+// MetadataUpdateRequest changes the metadata of a file
+type MetadataUpdateRequest struct {
+	ContentType        string            `json:"contentType,omitempty"`
+	ContentEncoding    string            `json:"contentEncoding,omitempty"`
+	ContentLanguage    string            `json:"contentLanguage,omitempty"`
+	ContentDisposition string            `json:"contentDisposition,omitempty"`
+	CacheControl       string            `json:"cacheControl,omitempty"`
+	ACL                string            `json:"acl,omitempty"`
+	CustomHeaders      map[string]string `json:"customHeaders,omitempty"`
+}
+
+// CopyRequest copies or moves a file to either a raw path or a path
+// resolved from a definition
+type CopyRequest struct {
+	DestinationPath string              `json:"destinationPath,omitempty"`
+	Destination     *DestinationRequest `json:"destination,omitempty"`
+	Overwrite       bool                `json:"overwrite,omitempty"`
+}
+
+// DestinationRequest identifies a destination by path definition
+type DestinationRequest struct {
+	Definition string            `json:"definition"`
+	Parameters map[string]string `json:"parameters"`
+	Scope      string            `json:"scope,omitempty"`
+	ScopeValue int16             `json:"scopeValue,omitempty"`
+}
+
+// CopyFileResponse is the result of a copy or move
+type CopyFileResponse struct {
+	Provider          string        `json:"provider"`
+	SourcePath        string        `json:"sourcePath"`
+	DestinationPath   string        `json:"destinationPath"`
+	Method            string        `json:"method"`
+	Moved             bool          `json:"moved"`
+	UpdatedReferences int64         `json:"updatedReferences"`
+	Metadata          *FileMetadata `json:"metadata,omitempty"`
+}
+
+// BatchTargetRequest selects files for a batch operation, either as explicit
+// paths or as every file under a prefix within a definition
+type BatchTargetRequest struct {
+	Paths      []string `json:"paths,omitempty"`
+	Definition string   `json:"definition,omitempty"`
+	Prefix     string   `json:"prefix,omitempty"`
+}
+
+// BatchMetadataRequest updates the metadata of many files
+type BatchMetadataRequest struct {
+	BatchTargetRequest
+	Metadata *MetadataUpdateRequest `json:"metadata"`
+}
+
+// BatchResponse reports the outcome for every file in a batch
+type BatchResponse struct {
+	Provider  string            `json:"provider"`
+	DryRun    bool              `json:"dryRun"`
+	Truncated bool              `json:"truncated"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult is the outcome for a single file
+type BatchItemResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BundleRequest selects files to download as one archive
+type BundleRequest struct {
+	Paths      []string          `json:"paths,omitempty"`
+	Scope      string            `json:"scope,omitempty"`
+	ScopeValue int16             `json:"scopeValue,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Prefix     string            `json:"prefix,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Cache      bool              `json:"cache,omitempty"`
+}
+
+func resourcePath(provider, definition string) string {
+	return "/resources/" + url.PathEscape(provider) + "/" + url.PathEscape(definition)
+}
+
+func filePath(provider, path string) string {
+	return "/resources/" + url.PathEscape(provider) + "/" + pathEscape(path)
+}
+
+// ListFiles lists the files of a definition
+func (c *Client) ListFiles(ctx context.Context, provider, definition string, opts *ListFilesOptions) (*FileList, error) {
+	var files FileList
+	if err := c.call(ctx, http.MethodGet, resourcePath(provider, definition), opts.query(), nil, &files); err != nil {
+		return nil, err
+	}
+	return &files, nil
+}
+
+// GenerateUploadURL returns a signed URL to upload a file of a definition
+func (c *Client) GenerateUploadURL(ctx context.Context, provider, definition string, req *UploadRequest) (*SignedURL, error) {
+	var signed SignedURL
+	if err := c.call(ctx, http.MethodPost, resourcePath(provider, definition)+"/upload", nil, req, &signed); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
+
+// UploadContent streams a file through the server to the provider. The body
+// is sent once; the call is not retried.
+func (c *Client) UploadContent(ctx context.Context, provider, definition string, body io.Reader, opts *ContentUploadOptions) (*ContentUploadResponse, error) {
+	req := &request{
+		method:      http.MethodPut,
+		path:        resourcePath(provider, definition) + "/content",
+		query:       url.Values{},
+		contentType: "application/octet-stream",
+		stream:      body,
+	}
+	if opts != nil {
+		if opts.Scope != "" {
+			req.query.Set("scope", opts.Scope)
+		}
+		if opts.ScopeValue > 0 {
+			req.query.Set("scope_value", strconv.Itoa(int(opts.ScopeValue)))
+		}
+		if opts.ResourceID != "" {
+			req.query.Set("resource_id", opts.ResourceID)
+		}
+		for name, value := range opts.Parameters {
+			req.query.Set("param."+name, value)
+		}
+		if opts.ContentType != "" {
+			req.contentType = opts.ContentType
+		}
+		req.contentLength = opts.ContentLength
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ContentUploadResponse
+	if err := decodeData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadBundle downloads the selected files of a definition as a zip or
+// tar.gz archive. The caller closes the returned body.
+func (c *Client) DownloadBundle(ctx context.Context, provider, definition string, req *BundleRequest) (*Content, error) {
+	resp, err := c.callRaw(ctx, http.MethodPost, resourcePath(provider, definition)+"/bundle", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	return newContent(resp), nil
+}
+
+// GenerateDownloadURL returns a signed URL to download a file
+func (c *Client) GenerateDownloadURL(ctx context.Context, provider, path string, req *DownloadRequest) (*SignedURL, error) {
+	if req == nil {
+		req = &DownloadRequest{}
+	}
+
+	var signed SignedURL
+	if err := c.call(ctx, http.MethodPost, filePath(provider, path)+"/download", nil, req, &signed); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
+
+// DownloadContent streams a file through the server. The caller closes the
+// returned body.
+func (c *Client) DownloadContent(ctx context.Context, provider, path string, opts *ContentDownloadOptions) (*Content, error) {
+	req := &request{
+		method: http.MethodGet,
+		path:   filePath(provider, path) + "/content",
+		query:  url.Values{},
+		header: http.Header{"Accept": {"*/*"}},
+	}
+	if opts != nil {
+		if opts.Disposition != "" {
+			req.query.Set("disposition", opts.Disposition)
+		}
+		if opts.FileName != "" {
+			req.query.Set("filename", opts.FileName)
+		}
+		if opts.Range != "" {
+			req.header.Set("Range", opts.Range)
+		}
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return newContent(resp), nil
+}
+
+func newContent(resp *http.Response) *Content {
+	return &Content{
+		Body:          resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+		ContentRange:  resp.Header.Get("Content-Range"),
+		ETag:          resp.Header.Get("ETag"),
+	}
+}
+
+// CopyFile copies a file
+func (c *Client) CopyFile(ctx context.Context, provider, path string, req *CopyRequest) (*CopyFileResponse, error) {
+	var result CopyFileResponse
+	if err := c.call(ctx, http.MethodPost, filePath(provider, path)+"/copy", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MoveFile moves a file, updating the references to it
+func (c *Client) MoveFile(ctx context.Context, provider, path string, req *CopyRequest) (*CopyFileResponse, error) {
+	var result CopyFileResponse
+	if err := c.call(ctx, http.MethodPost, filePath(provider, path)+"/move", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFileMetadata returns the stored metadata of a file
+func (c *Client) GetFileMetadata(ctx context.Context, provider, path string) (*FileMetadata, error) {
+	var metadata FileMetadata
+	if err := c.call(ctx, http.MethodGet, filePath(provider, path)+"/metadata", nil, nil, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// UpdateFileMetadata changes the metadata of a file
+func (c *Client) UpdateFileMetadata(ctx context.Context, provider, path string, req *MetadataUpdateRequest) (*FileMetadata, error) {
+	var metadata FileMetadata
+	if err := c.call(ctx, http.MethodPut, filePath(provider, path)+"/metadata", nil, req, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// DeleteFile deletes a file
+func (c *Client) DeleteFile(ctx context.Context, provider, path string) error {
+	return c.call(ctx, http.MethodDelete, filePath(provider, path), nil, nil, nil)
+}
+
+// BatchDelete deletes many files. With dryRun the matched files are
+// reported without being deleted.
+func (c *Client) BatchDelete(ctx context.Context, provider string, req *BatchTargetRequest, dryRun bool) (*BatchResponse, error) {
+	var result BatchResponse
+	if err := c.call(ctx, http.MethodPost, "/resources/"+url.PathEscape(provider)+"/batch/delete", dryRunQuery(dryRun), req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BatchUpdateMetadata updates the metadata of many files
+func (c *Client) BatchUpdateMetadata(ctx context.Context, provider string, req *BatchMetadataRequest, dryRun bool) (*BatchResponse, error) {
+	var result BatchResponse
+	if err := c.call(ctx, http.MethodPost, "/resources/"+url.PathEscape(provider)+"/batch/metadata", dryRunQuery(dryRun), req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func dryRunQuery(dryRun bool) url.Values {
+	if !dryRun {
+		return nil
+	}
+	return url.Values{"dry_run": {"true"}}
+}
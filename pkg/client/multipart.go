@@ -0,0 +1,373 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// MultipartInitRequest starts a multipart upload to a definition's path
+type MultipartInitRequest struct {
+	DefinitionName string            `json:"definitionName"`
+	Provider       string            `json:"provider"`
+	Scope          string            `json:"scope,omitempty"`
+	ScopeValue     int16             `json:"scopeValue,omitempty"`
+	ParamResolver  map[string]string `json:"paramResolver"`
+	Metadata       *UploadMetadata   `json:"metadata,omitempty"`
+}
+
+// MultipartInitResponse identifies a started multipart upload
+type MultipartInitResponse struct {
+	UploadID    string `json:"uploadId"`
+	Path        string `json:"path"`
+	Provider    string `json:"provider"`
+	MaxPartSize int64  `json:"maxPartSize,omitempty"`
+	MinPartSize int64  `json:"minPartSize,omitempty"`
+	MaxParts    int    `json:"maxParts,omitempty"`
+}
+
+// MultipartURLsRequest asks for signed URLs of upload parts
+type MultipartURLsRequest struct {
+	Path       string         `json:"path"`
+	UploadID   string         `json:"uploadId"`
+	Provider   string         `json:"provider"`
+	URLOptions []*PartRequest `json:"urlOptions"`
+}
+
+// PartRequest identifies a part to sign by number and checksum
+type PartRequest struct {
+	PartNumber int          `json:"partNumber"`
+	Checksum   ChecksumInfo `json:"checksum"`
+}
+
+// MultipartURLsResponse holds the signed URLs of the requested parts and of
+// completing or aborting the upload
+type MultipartURLsResponse struct {
+	PartURLs    []MultipartPartURL `json:"partUrls"`
+	CompleteURL SignedURL          `json:"completeUrl"`
+	AbortURL    SignedURL          `json:"abortUrl"`
+}
+
+// MultipartPartURL is the signed URL of one part
+type MultipartPartURL struct {
+	SignedURL
+
+	PartNumber int `json:"partNumber"`
+}
+
+// InitMultipartUpload starts a multipart upload
+func (c *Client) InitMultipartUpload(ctx context.Context, req *MultipartInitRequest) (*MultipartInitResponse, error) {
+	var result MultipartInitResponse
+	if err := c.call(ctx, http.MethodPost, "/resources/multipart/init", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMultipartURLs returns signed URLs for parts of a multipart upload
+func (c *Client) GetMultipartURLs(ctx context.Context, req *MultipartURLsRequest) (*MultipartURLsResponse, error) {
+	var result MultipartURLsResponse
+	if err := c.call(ctx, http.MethodPost, "/resources/multipart/urls", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Defaults of UploadMultipart
+const (
+	DefaultPartSize    = 8 << 20
+	DefaultConcurrency = 4
+)
+
+// MultipartOptions tune UploadMultipart. Zero values use the defaults.
+type MultipartOptions struct {
+	// Size is the expected total size, used to keep the part count within
+	// the provider's limit
+	Size int64
+	// PartSize is raised to the provider's minimum part size when smaller
+	PartSize int64
+	// Concurrency is the number of parts uploaded, and held in memory, at once
+	Concurrency int
+	// Confirm runs after the upload completes, e.g. to confirm the upload
+	// with the API that requested it. A failure is returned as is.
+	Confirm func(ctx context.Context, result *MultipartResult) error
+}
+
+// CompletedPart is an uploaded part
+type CompletedPart struct {
+	PartNumber int
+	ETag       string
+	Size       int64
+	Checksum   ChecksumInfo
+}
+
+// MultipartResult describes a completed multipart upload
+type MultipartResult struct {
+	UploadID string
+	Path     string
+	Provider string
+	Size     int64
+	Parts    []CompletedPart
+}
+
+// UploadMultipart uploads body as a multipart upload: it starts the upload,
+// signs the parts with their SHA256 checksums, PUTs them concurrently to
+// the provider, completes the upload and runs the Confirm hook. The upload
+// is aborted when any step before completion fails.
+func (c *Client) UploadMultipart(ctx context.Context, req *MultipartInitRequest, body io.Reader, opts *MultipartOptions) (*MultipartResult, error) {
+	if opts == nil {
+		opts = &MultipartOptions{}
+	}
+
+	init, err := c.InitMultipartUpload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MultipartResult{
+		UploadID: init.UploadID,
+		Path:     init.Path,
+		Provider: init.Provider,
+	}
+
+	complete, abort, err := c.uploadParts(ctx, init, body, opts, result)
+	if err != nil {
+		if abort != nil {
+			// Abort on a fresh context so a cancelled upload is still cleaned up
+			c.sendSigned(context.WithoutCancel(ctx), abort, http.MethodDelete, nil)
+		}
+		return nil, err
+	}
+
+	if err := c.completeMultipart(ctx, complete, result.Parts); err != nil {
+		return nil, err
+	}
+
+	if opts.Confirm != nil {
+		if err := opts.Confirm(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// partSize picks the size of every part but the last
+func partSize(init *MultipartInitResponse, opts *MultipartOptions) int64 {
+	size := opts.PartSize
+	if size <= 0 {
+		size = DefaultPartSize
+	}
+	if init.MaxParts > 0 && opts.Size > 0 {
+		size = max(size, (opts.Size+int64(init.MaxParts)-1)/int64(init.MaxParts))
+	}
+	if init.MinPartSize > 0 {
+		size = max(size, init.MinPartSize)
+	}
+	if init.MaxPartSize > 0 {
+		size = min(size, init.MaxPartSize)
+	}
+	return size
+}
+
+// pendingPart is a part read from the body, waiting to be uploaded
+type pendingPart struct {
+	data []byte
+	part CompletedPart
+}
+
+// uploadParts reads the body a window of parts at a time, signs each window
+// in one call and uploads its parts concurrently. It returns the complete
+// and abort URLs of the last signing call.
+func (c *Client) uploadParts(ctx context.Context, init *MultipartInitResponse, body io.Reader, opts *MultipartOptions, result *MultipartResult) (*SignedURL, *SignedURL, error) {
+	size := partSize(init, opts)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var complete, abort *SignedURL
+	next := 1
+	for done := false; !done; {
+		var window []*pendingPart
+		for len(window) < concurrency {
+			data := make([]byte, size)
+			n, err := io.ReadFull(body, data)
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, abort, fmt.Errorf("failed to read part %d: %w", next, err)
+			}
+			// Providers need at least one part, even for an empty body
+			if n == 0 && (next > 1 || len(window) > 0) {
+				done = true
+				break
+			}
+
+			sum := sha256.Sum256(data[:n])
+			window = append(window, &pendingPart{
+				data: data[:n],
+				part: CompletedPart{
+					PartNumber: next,
+					Size:       int64(n),
+					Checksum: ChecksumInfo{
+						Algorithm: "SHA256",
+						Value:     base64.StdEncoding.EncodeToString(sum[:]),
+					},
+				},
+			})
+			next++
+
+			if n < len(data) {
+				done = true
+				break
+			}
+		}
+		if len(window) == 0 {
+			break
+		}
+
+		urlsReq := &MultipartURLsRequest{
+			Path:     init.Path,
+			UploadID: init.UploadID,
+			Provider: init.Provider,
+		}
+		for _, pending := range window {
+			urlsReq.URLOptions = append(urlsReq.URLOptions, &PartRequest{
+				PartNumber: pending.part.PartNumber,
+				Checksum:   pending.part.Checksum,
+			})
+		}
+		urls, err := c.GetMultipartURLs(ctx, urlsReq)
+		if err != nil {
+			return nil, abort, err
+		}
+		complete, abort = &urls.CompleteURL, &urls.AbortURL
+
+		if err := c.putParts(ctx, window, urls.PartURLs); err != nil {
+			return nil, abort, err
+		}
+		for _, pending := range window {
+			result.Parts = append(result.Parts, pending.part)
+			result.Size += pending.part.Size
+		}
+	}
+
+	return complete, abort, nil
+}
+
+// putParts uploads a window of parts concurrently, recording their ETags
+func (c *Client) putParts(ctx context.Context, window []*pendingPart, urls []MultipartPartURL) error {
+	signed := make(map[int]*SignedURL, len(urls))
+	for i := range urls {
+		signed[urls[i].PartNumber] = &urls[i].SignedURL
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(window))
+	for i, pending := range window {
+		target, ok := signed[pending.part.PartNumber]
+		if !ok {
+			return fmt.Errorf("no signed URL for part %d", pending.part.PartNumber)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			header, _, err := c.sendSigned(ctx, target, http.MethodPut, pending.data)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to upload part %d: %w", pending.part.PartNumber, err)
+				cancel()
+				return
+			}
+			pending.part.ETag = header.Get("ETag")
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// completeMultipartUpload is the S3 request body completing an upload
+type completeMultipartUpload struct {
+	XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+	Parts   []completePart `xml:"Part"`
+}
+
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeMultipart completes the upload with the ETags of its parts
+func (c *Client) completeMultipart(ctx context.Context, complete *SignedURL, parts []CompletedPart) error {
+	if complete == nil {
+		return errors.New("no signed URL to complete the upload")
+	}
+
+	var body completeMultipartUpload
+	for _, part := range parts {
+		body.Parts = append(body.Parts, completePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode complete request: %w", err)
+	}
+
+	_, respBody, err := c.sendSigned(ctx, complete, http.MethodPost, data)
+	if err != nil {
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	// S3-compatible providers report some failures with a 200 status
+	if bytes.Contains(respBody, []byte("<Error>")) {
+		return fmt.Errorf("failed to complete upload: %s", respBody)
+	}
+	return nil
+}
+
+// sendSigned calls a signed provider URL with its signed headers and
+// returns the response headers and body. Failures carry the provider's
+// status and body.
+func (c *Client) sendSigned(ctx context.Context, signed *SignedURL, defaultMethod string, body []byte) (http.Header, []byte, error) {
+	method := signed.Method
+	if method == "" {
+		method = defaultMethod
+	}
+
+	resp, err := c.doWithRetry(ctx, true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, signed.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range signed.Headers {
+			req.Header.Set(key, value)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+			Details:    string(data),
+		}
+	}
+	return resp.Header, data, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Provider is a storage provider
+type Provider struct {
+	Name         string               `json:"name"`
+	Capabilities ProviderCapabilities `json:"capabilities"`
+}
+
+// ProviderCapabilities describes what a storage provider supports
+type ProviderCapabilities struct {
+	SupportsRead               bool                   `json:"supportsRead"`
+	SupportsWrite              bool                   `json:"supportsWrite"`
+	SupportsDelete             bool                   `json:"supportsDelete"`
+	SupportsListing            bool                   `json:"supportsListing"`
+	SupportsMetadata           bool                   `json:"supportsMetadata"`
+	SupportsMultipart          bool                   `json:"supportsMultipart"`
+	SupportsResumableUploads   bool                   `json:"supportsResumableUploads"`
+	SupportsSignedURLs         bool                   `json:"supportsSignedUrls"`
+	SupportsChecksumAlgorithms []string               `json:"supportsChecksumAlgorithms"`
+	MaxUploadSize              int64                  `json:"maxUploadSize"`
+	MaxExpiry                  time.Duration          `json:"maxExpiry"`
+	MinExpiry                  time.Duration          `json:"minExpiry"`
+	Multipart                  *MultipartCapabilities `json:"multipart,omitempty"`
+}
+
+// MultipartCapabilities are the part limits of a provider's multipart uploads
+type MultipartCapabilities struct {
+	MinPartSize int64 `json:"minPartSize"`
+	MaxPartSize int64 `json:"maxPartSize"`
+	MaxParts    int   `json:"maxParts"`
+}
+
+// ListProviders returns every storage provider
+func (c *Client) ListProviders(ctx context.Context) ([]Provider, error) {
+	var providers []Provider
+	if err := c.call(ctx, http.MethodGet, "/resources/providers", nil, nil, &providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// GetProvider returns a storage provider
+func (c *Client) GetProvider(ctx context.Context, name string) (*Provider, error) {
+	var provider Provider
+	if err := c.call(ctx, http.MethodGet, "/resources/providers/"+url.PathEscape(name), nil, nil, &provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Upload statuses of tracked uploads
+const (
+	UploadStatusInitializing = "initializing"
+	UploadStatusPending      = "pending"
+	UploadStatusUploading    = "uploading"
+	UploadStatusProcessing   = "processing"
+	UploadStatusCompleting   = "completing"
+	UploadStatusCompleted    = "completed"
+	UploadStatusFailed       = "failed"
+	UploadStatusAborted      = "aborted"
+)
+
+// Upload is an upload tracked by the server's upload manager
+type Upload struct {
+	ID             string         `json:"id"`
+	ResourceType   string         `json:"resourceType"`
+	ResourceID     string         `json:"resourceId"`
+	ResourceField  string         `json:"resourceField,omitempty"`
+	UploadType     string         `json:"uploadType"`
+	Status         string         `json:"status"`
+	Error          string         `json:"error,omitempty"`
+	PathDefinition string         `json:"pathDefinition"`
+	PathParameters map[string]any `json:"pathParameters,omitempty"`
+	Provider       string         `json:"provider"`
+	StorageKey     string         `json:"storageKey"`
+	Size           *int64         `json:"size,omitempty"`
+	MultipartID    string         `json:"multipartId,omitempty"`
+	TotalParts     *int           `json:"totalParts,omitempty"`
+	UploadedParts  *int           `json:"uploadedParts,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	CompletedAt    *time.Time     `json:"completedAt,omitempty"`
+	ExpiresAt      time.Time      `json:"expiresAt"`
+}
+
+// UploadList is a page of tracked uploads
+type UploadList struct {
+	Uploads  []*Upload `json:"uploads"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+	Total    int       `json:"total"`
+}
+
+// ListUploadsOptions filter a listing of tracked uploads. Empty fields match
+// every upload.
+type ListUploadsOptions struct {
+	PageOptions
+
+	Status       string
+	ResourceType string
+	Provider     string
+}
+
+func (o *ListUploadsOptions) query() url.Values {
+	if o == nil {
+		return url.Values{}
+	}
+
+	query := o.PageOptions.query()
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.ResourceType != "" {
+		query.Set("resourceType", o.ResourceType)
+	}
+	if o.Provider != "" {
+		query.Set("provider", o.Provider)
+	}
+	return query
+}
+
+// AbortUploadResponse is an aborted upload. ProviderAborted reports whether
+// the provider's multipart upload was aborted too.
+type AbortUploadResponse struct {
+	Upload          *Upload `json:"upload"`
+	ProviderAborted bool    `json:"providerAborted"`
+}
+
+// RunJanitorRequest limits a janitor run. With DryRun the expired uploads are
+// reported without being aborted.
+type RunJanitorRequest struct {
+	Limit  int  `json:"limit,omitempty"`
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// JanitorResult reports a janitor run
+type JanitorResult struct {
+	DryRun  bool                    `json:"dryRun"`
+	Scanned int                     `json:"scanned"`
+	Aborted int                     `json:"aborted"`
+	Failed  int                     `json:"failed"`
+	Uploads []*JanitorUploadOutcome `json:"uploads"`
+}
+
+// JanitorUploadOutcome is the outcome of a janitor run for one upload
+type JanitorUploadOutcome struct {
+	ID              string    `json:"id"`
+	Provider        string    `json:"provider"`
+	StorageKey      string    `json:"storageKey"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	Aborted         bool      `json:"aborted"`
+	ProviderAborted bool      `json:"providerAborted"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func uploadPath(id string) string {
+	return "/admin/uploads/" + url.PathEscape(id)
+}
+
+// ListUploads returns a page of tracked uploads, newest first
+func (c *Client) ListUploads(ctx context.Context, opts *ListUploadsOptions) (*UploadList, error) {
+	var result UploadList
+	if err := c.call(ctx, http.MethodGet, "/admin/uploads", opts.query(), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetUpload returns a tracked upload
+func (c *Client) GetUpload(ctx context.Context, id string) (*Upload, error) {
+	var result Upload
+	if err := c.call(ctx, http.MethodGet, uploadPath(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AbortUpload aborts an unfinished upload. An empty reason records the
+// client's principal as the actor.
+func (c *Client) AbortUpload(ctx context.Context, id, reason string) (*AbortUploadResponse, error) {
+	var result AbortUploadResponse
+	req := map[string]string{"reason": reason}
+	if err := c.call(ctx, http.MethodPost, uploadPath(id)+"/abort", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RunUploadJanitor aborts the uploads that expired before completing, once
+func (c *Client) RunUploadJanitor(ctx context.Context, req *RunJanitorRequest) (*JanitorResult, error) {
+	if req == nil {
+		req = &RunJanitorRequest{}
+	}
+
+	var result JanitorResult
+	if err := c.call(ctx, http.MethodPost, "/admin/uploads/janitor", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -227,7 +227,7 @@ build_application() {
 start_server() {
     log_info "Starting server on port $SERVER_PORT..."
     
-    ./resource-server &
+    ./resource-server -config config.test.yaml &
     local server_pid=$!
     echo $server_pid > server.pid
     